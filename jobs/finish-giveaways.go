@@ -87,6 +87,24 @@ func main() {
 }
 
 func entrypoint(ctx context.Context, webhookUrl string) {
+	scheduledGiveaways, err := welcomer.Queries.GetScheduledGiveaways(ctx)
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		welcomer.Logger.Error().Err(err).Msg("Failed to fetch scheduled giveaways")
+
+		panic(err)
+	}
+
+	for _, giveaway := range scheduledGiveaways {
+		data, _ := json.Marshal(welcomer.CustomEventInvokeStartGiveawayStructure{
+			GiveawayUUID: giveaway.GiveawayUuid,
+			GuildID:      discord.Snowflake(giveaway.GuildID),
+		})
+
+		if relayGiveawayEvent(ctx, giveaway.GuildID, welcomer.CustomEventInvokeStartGiveaway, data) {
+			welcomer.Logger.Info().Int64("guild_id", giveaway.GuildID).Msg("Started giveaway")
+		}
+	}
+
 	expiredGiveaways, err := welcomer.Queries.GetExpiredGiveaways(ctx)
 	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
 		welcomer.Logger.Error().Err(err).Msg("Failed to fetch expired giveaways")
@@ -95,44 +113,85 @@ func entrypoint(ctx context.Context, webhookUrl string) {
 	}
 
 	for _, giveaway := range expiredGiveaways {
-		locationsPb, err := welcomer.SandwichClient.WhereIsGuild(ctx, &sandwich_protobuf.WhereIsGuildRequest{
-			GuildId: giveaway.GuildID,
+		data, _ := json.Marshal(welcomer.CustomEventInvokeEndGiveawayStructure{
+			GiveawayUUID: giveaway.GiveawayUuid,
+			GuildID:      discord.Snowflake(giveaway.GuildID),
 		})
-		if err != nil {
-			welcomer.Logger.Warn().Err(err).Int64("guild_id", giveaway.GuildID).Msg("Failed to do guild lookup for giveaway")
 
-			continue
+		if relayGiveawayEvent(ctx, giveaway.GuildID, welcomer.CustomEventInvokeEndGiveaway, data) {
+			welcomer.Logger.Info().Int64("guild_id", giveaway.GuildID).Msg("Finished giveaway")
 		}
+	}
 
-		locations := locationsPb.GetLocations()
-		if len(locations) == 0 {
-			welcomer.Logger.Warn().Int64("guild_id", giveaway.GuildID).Msg("No applications found for guild in giveaway")
+	expiredClaims, err := welcomer.Queries.GetGiveawaysWithExpiredClaims(ctx)
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		welcomer.Logger.Error().Err(err).Msg("Failed to fetch giveaways with expired claims")
 
-			continue
+		panic(err)
+	}
+
+	for _, giveaway := range expiredClaims {
+		data, _ := json.Marshal(welcomer.CustomEventInvokeRerollGiveawayStructure{
+			GiveawayUUID: giveaway.GiveawayUuid,
+			GuildID:      discord.Snowflake(giveaway.GuildID),
+		})
+
+		if relayGiveawayEvent(ctx, giveaway.GuildID, welcomer.CustomEventInvokeRerollGiveaway, data) {
+			welcomer.Logger.Info().Int64("guild_id", giveaway.GuildID).Msg("Rerolled unclaimed giveaway prizes")
 		}
+	}
 
-		data, _ := json.Marshal(welcomer.CustomEventInvokeEndGiveawayStructure{
+	expiredPrizeRoles, err := welcomer.Queries.GetGiveawaysWithExpiredPrizeRoles(ctx)
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		welcomer.Logger.Error().Err(err).Msg("Failed to fetch giveaways with expired prize roles")
+
+		panic(err)
+	}
+
+	for _, giveaway := range expiredPrizeRoles {
+		data, _ := json.Marshal(welcomer.CustomEventInvokeExpireGiveawayRolesStructure{
 			GiveawayUUID: giveaway.GiveawayUuid,
 			GuildID:      discord.Snowflake(giveaway.GuildID),
 		})
 
-		for _, location := range locations {
-			_, err = welcomer.SandwichClient.RelayMessage(ctx, &sandwich_protobuf.RelayMessageRequest{
-				Identifier: location.GetIdentifier(),
-				Type:       welcomer.CustomEventInvokeEndGiveaway,
-				Data:       data,
-			})
-			if err != nil {
-				welcomer.Logger.Warn().Err(err).Int64("guild_id", giveaway.GuildID).Str("identifier", location.GetIdentifier()).Msg("Failed to relay end giveaway message")
+		if relayGiveawayEvent(ctx, giveaway.GuildID, welcomer.CustomEventInvokeExpireGiveawayRoles, data) {
+			welcomer.Logger.Info().Int64("guild_id", giveaway.GuildID).Msg("Removed expired giveaway prize roles")
+		}
+	}
+}
 
-				continue
-			}
+// relayGiveawayEvent relays a custom event to the first application that is in the guild.
+func relayGiveawayEvent(ctx context.Context, guildID int64, eventType string, data []byte) bool {
+	locationsPb, err := welcomer.SandwichClient.WhereIsGuild(ctx, &sandwich_protobuf.WhereIsGuildRequest{
+		GuildId: guildID,
+	})
+	if err != nil {
+		welcomer.Logger.Warn().Err(err).Int64("guild_id", guildID).Msg("Failed to do guild lookup for giveaway")
 
-			if err == nil {
-				break
-			}
+		return false
+	}
+
+	locations := locationsPb.GetLocations()
+	if len(locations) == 0 {
+		welcomer.Logger.Warn().Int64("guild_id", guildID).Msg("No applications found for guild in giveaway")
+
+		return false
+	}
+
+	for _, location := range locations {
+		_, err = welcomer.SandwichClient.RelayMessage(ctx, &sandwich_protobuf.RelayMessageRequest{
+			Identifier: location.GetIdentifier(),
+			Type:       eventType,
+			Data:       data,
+		})
+		if err != nil {
+			welcomer.Logger.Warn().Err(err).Int64("guild_id", guildID).Str("identifier", location.GetIdentifier()).Str("type", eventType).Msg("Failed to relay giveaway message")
+
+			continue
 		}
 
-		welcomer.Logger.Info().Int64("guild_id", giveaway.GuildID).Msg("Finished giveaway")
+		return true
 	}
+
+	return false
 }
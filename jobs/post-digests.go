@@ -0,0 +1,303 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"runtime/debug"
+	"time"
+
+	"github.com/WelcomerTeam/Discord/discord"
+	sandwich_protobuf "github.com/WelcomerTeam/Sandwich-Daemon/proto"
+	"github.com/WelcomerTeam/Welcomer/welcomer-core"
+	"github.com/WelcomerTeam/Welcomer/welcomer-core/database"
+	"github.com/jackc/pgx/v4"
+	_ "github.com/joho/godotenv/autoload"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+// This job is expected to run once a day with -frequency daily, and once a week with -frequency weekly.
+func main() {
+	var err error
+
+	loggingLevel := flag.String("level", os.Getenv("LOGGING_LEVEL"), "Logging level")
+
+	postgresURL := flag.String("postgresURL", os.Getenv("POSTGRES_URL"), "Postgres connection URL")
+	sandwichGRPCHost := flag.String("sandwichGRPCHost", os.Getenv("SANDWICH_GRPC_HOST"), "GRPC Address for the Sandwich Daemon service")
+
+	proxyAddress := flag.String("proxyAddress", os.Getenv("PROXY_ADDRESS"), "Address to proxy requests through. This can be 'https://discord.com', if one is not setup.")
+	proxyDebug := flag.Bool("proxyDebug", false, "Enable debugging requests to the proxy")
+
+	webhookUrl := flag.String("webhookUrl", os.Getenv("JOB_POST_DIGESTS_WEBHOOK_URL"), "Webhook URL for logging")
+
+	frequency := flag.String("frequency", welcomer.DigestFrequencyWeekly, "Which digests to post, daily or weekly")
+	dryRun := flag.Bool("dryRun", false, "Log digests without sending them")
+
+	flag.Parse()
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	defer func() {
+		if r := recover(); r != nil {
+			fmt.Println("Recovered from panic:", r)
+			println(string(debug.Stack()))
+
+			err = welcomer.SendWebhookMessage(ctx, *webhookUrl, discord.WebhookMessageParams{
+				Content: "<@143090142360371200>",
+				Embeds: []discord.Embed{
+					{
+						Title:       "Post Digests Job",
+						Description: fmt.Sprintf("Recovered from panic: %v", r),
+						Color:       int32(16760839),
+						Timestamp:   new(time.Now()),
+					},
+				},
+			})
+			if err != nil {
+				welcomer.Logger.Warn().Err(err).Msg("Failed to send webhook message")
+			}
+		}
+	}()
+
+	welcomer.SetupLogger(*loggingLevel)
+
+	if !welcomer.IsValidDigestFrequency(*frequency) {
+		panic(fmt.Sprintf("invalid frequency: %s", *frequency))
+	}
+
+	welcomer.SetupGRPCConnection(*sandwichGRPCHost,
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithDefaultCallOptions(grpc.MaxCallRecvMsgSize(1024*1024*1024)), // Set max message size to 1GB
+	)
+
+	restInterface := welcomer.NewTwilightProxy(*proxyAddress)
+	restInterface.SetDebug(*proxyDebug)
+	welcomer.SetupRESTInterface(restInterface)
+
+	welcomer.SetupSandwichClient()
+	welcomer.SetupDatabase(ctx, *postgresURL)
+
+	entrypoint(ctx, *frequency, *dryRun)
+
+	if !*dryRun {
+		if err := welcomer.Queries.UpsertJobCheckpoint(ctx, database.UpsertJobCheckpointParams{
+			JobName:         "post-digests-" + *frequency,
+			LastProcessedTs: time.Now().UTC(),
+		}); err != nil {
+			welcomer.Logger.Error().Err(err).Msg("Failed to upsert job checkpoint")
+		}
+	}
+
+	cancel()
+}
+
+func entrypoint(ctx context.Context, frequency string, dryRun bool) {
+	guildSettings, err := welcomer.Queries.GetEnabledDigestGuildSettings(ctx, frequency)
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		welcomer.Logger.Error().Err(err).Msg("Failed to fetch guilds with digests enabled")
+
+		panic(err)
+	}
+
+	now := time.Now().UTC()
+
+	var totalPosted int
+
+	for _, guildSetting := range guildSettings {
+		err := postDigest(ctx, guildSetting, now, dryRun)
+		if err != nil {
+			welcomer.Logger.Error().Err(err).
+				Int64("guild_id", guildSetting.GuildID).
+				Int64("channel_id", guildSetting.ChannelDigest).
+				Msg("Failed to post digest")
+
+			continue
+		}
+
+		totalPosted++
+	}
+
+	welcomer.Logger.Info().
+		Str("frequency", frequency).
+		Int("guilds", len(guildSettings)).
+		Int("posted", totalPosted).
+		Msg("Completed posting digests")
+}
+
+func postDigest(ctx context.Context, guildSetting *database.GuildSettingsDigest, now time.Time, dryRun bool) error {
+	guildID := discord.Snowflake(guildSetting.GuildID)
+
+	digest, err := buildGuildDigest(ctx, guildID, guildSetting.Frequency, now)
+	if err != nil {
+		return err
+	}
+
+	if dryRun {
+		welcomer.Logger.Info().
+			Int64("guild_id", int64(guildID)).
+			Int64("channel_id", guildSetting.ChannelDigest).
+			Interface("digest", digest).
+			Msg("Would post digest")
+
+		return nil
+	}
+
+	session, err := acquireGuildSession(ctx, guildID)
+	if err != nil {
+		return err
+	}
+
+	channel := discord.Channel{ID: discord.Snowflake(guildSetting.ChannelDigest), GuildID: &guildID}
+
+	_, err = channel.Send(ctx, session, welcomer.GetDigestMessage(guildID, digest))
+	if err != nil {
+		return fmt.Errorf("failed to send digest: %w", err)
+	}
+
+	return nil
+}
+
+// buildGuildDigest gathers the science and ingest tables of a guild, for the period of the digest.
+func buildGuildDigest(ctx context.Context, guildID discord.Snowflake, frequency string, now time.Time) (welcomer.GuildDigest, error) {
+	digest := welcomer.GuildDigest{
+		Frequency: frequency,
+		Since:     welcomer.GetDigestSince(frequency, now),
+		Until:     now,
+	}
+
+	eventCounts, err := welcomer.Queries.GetGuildEventCounts(ctx, database.GetGuildEventCountsParams{
+		GuildID: int64(guildID),
+		EventTypes: []int32{
+			int32(database.ScienceGuildEventTypeUserJoin),
+			int32(database.ScienceGuildEventTypeUserLeave),
+			int32(database.ScienceGuildEventTypeBorderwallChallenge),
+			int32(database.ScienceGuildEventTypeBorderwallCompleted),
+		},
+		Since: digest.Since,
+		Until: digest.Until,
+	})
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		return digest, fmt.Errorf("failed to get guild event counts: %w", err)
+	}
+
+	for _, eventCount := range eventCounts {
+		switch database.ScienceGuildEventType(eventCount.EventType) {
+		case database.ScienceGuildEventTypeUserJoin:
+			digest.Joins = eventCount.EventCount
+		case database.ScienceGuildEventTypeUserLeave:
+			digest.Leaves = eventCount.EventCount
+		case database.ScienceGuildEventTypeBorderwallChallenge:
+			digest.VerificationsStarted = eventCount.EventCount
+		case database.ScienceGuildEventTypeBorderwallCompleted:
+			digest.VerificationsCompleted = eventCount.EventCount
+		}
+	}
+
+	inviters, err := welcomer.Queries.GetGuildTopInviters(ctx, database.GetGuildTopInvitersParams{
+		GuildID:                       int64(guildID),
+		ScienceGuildEventTypeUserJoin: int32(database.ScienceGuildEventTypeUserJoin),
+		Since:                         digest.Since,
+		Until:                         digest.Until,
+		EntryLimit:                    welcomer.DigestMaximumEntries,
+	})
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		return digest, fmt.Errorf("failed to get guild top inviters: %w", err)
+	}
+
+	for _, inviter := range inviters {
+		digest.TopInviters = append(digest.TopInviters, welcomer.DigestEntry{ID: discord.Snowflake(inviter.UserID), Count: inviter.InviteCount})
+	}
+
+	granularity := welcomer.GetMessageCountGranularity(digest.Since, now)
+
+	channels, err := welcomer.Queries.GetGuildMessageChannelLeaderboard(ctx, database.GetGuildMessageChannelLeaderboardParams{
+		Granularity: granularity,
+		GuildID:     int64(guildID),
+		Since:       digest.Since,
+		EntryLimit:  welcomer.DigestMaximumEntries,
+	})
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		return digest, fmt.Errorf("failed to get guild channel leaderboard: %w", err)
+	}
+
+	for _, channel := range channels {
+		digest.ActiveChannels = append(digest.ActiveChannels, welcomer.DigestEntry{ID: discord.Snowflake(channel.ChannelID), Count: channel.MessageCount})
+	}
+
+	members, err := welcomer.Queries.GetGuildMessageLeaderboard(ctx, database.GetGuildMessageLeaderboardParams{
+		Granularity:      granularity,
+		GuildID:          int64(guildID),
+		Since:            digest.Since,
+		ExcludedChannels: []int64{},
+		EntryLimit:       welcomer.DigestMaximumEntries,
+	})
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		return digest, fmt.Errorf("failed to get guild message leaderboard: %w", err)
+	}
+
+	for _, member := range members {
+		digest.ActiveMembers = append(digest.ActiveMembers, welcomer.DigestEntry{ID: discord.Snowflake(member.UserID), Count: member.MessageCount})
+	}
+
+	digest.Giveaways, err = welcomer.Queries.GetGuildEndedGiveaways(ctx, database.GetGuildEndedGiveawaysParams{
+		GuildID:    int64(guildID),
+		Since:      digest.Since,
+		Until:      digest.Until,
+		EntryLimit: welcomer.DigestMaximumEntries,
+	})
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		return digest, fmt.Errorf("failed to get guild ended giveaways: %w", err)
+	}
+
+	digest.CommandErrors, err = welcomer.Queries.GetGuildCommandErrorCounts(ctx, database.GetGuildCommandErrorCountsParams{
+		GuildID:    int64(guildID),
+		Since:      digest.Since,
+		Until:      digest.Until,
+		EntryLimit: welcomer.DigestMaximumEntries,
+	})
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		return digest, fmt.Errorf("failed to get guild command errors: %w", err)
+	}
+
+	digest.FailedMessageRemovals, err = welcomer.Queries.GetGuildFailedMessageRemovalCount(ctx, database.GetGuildFailedMessageRemovalCountParams{
+		GuildID: int64(guildID),
+		ScienceGuildEventTypeWelcomeMessageRemoved: int32(database.ScienceGuildEventTypeWelcomeMessageRemoved),
+		ScienceGuildEventTypeLeaverMessageRemoved:  int32(database.ScienceGuildEventTypeLeaverMessageRemoved),
+		Since: digest.Since,
+		Until: digest.Until,
+	})
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		return digest, fmt.Errorf("failed to get guild failed message removals: %w", err)
+	}
+
+	return digest, nil
+}
+
+// acquireGuildSession returns a session for the first application that is in the guild.
+func acquireGuildSession(ctx context.Context, guildID discord.Snowflake) (*discord.Session, error) {
+	locations, err := welcomer.SandwichClient.WhereIsGuild(ctx, &sandwich_protobuf.WhereIsGuildRequest{
+		GuildId: int64(guildID),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to find guild location: %w", err)
+	}
+
+	for _, location := range locations.GetLocations() {
+		session, err := welcomer.AcquireSession(ctx, location.GetIdentifier())
+		if err != nil {
+			welcomer.Logger.Warn().Err(err).
+				Int64("guild_id", int64(guildID)).
+				Str("identifier", location.GetIdentifier()).
+				Msg("Failed to acquire session for guild location")
+
+			continue
+		}
+
+		return session, nil
+	}
+
+	return nil, fmt.Errorf("no locations found for guild %d", guildID)
+}
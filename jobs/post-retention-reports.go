@@ -0,0 +1,191 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"runtime/debug"
+	"time"
+
+	"github.com/WelcomerTeam/Discord/discord"
+	sandwich_protobuf "github.com/WelcomerTeam/Sandwich-Daemon/proto"
+	"github.com/WelcomerTeam/Welcomer/welcomer-core"
+	"github.com/WelcomerTeam/Welcomer/welcomer-core/database"
+	"github.com/jackc/pgx/v4"
+	_ "github.com/joho/godotenv/autoload"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+// This job is expected to run once a week.
+func main() {
+	var err error
+
+	loggingLevel := flag.String("level", os.Getenv("LOGGING_LEVEL"), "Logging level")
+
+	postgresURL := flag.String("postgresURL", os.Getenv("POSTGRES_URL"), "Postgres connection URL")
+	sandwichGRPCHost := flag.String("sandwichGRPCHost", os.Getenv("SANDWICH_GRPC_HOST"), "GRPC Address for the Sandwich Daemon service")
+
+	proxyAddress := flag.String("proxyAddress", os.Getenv("PROXY_ADDRESS"), "Address to proxy requests through. This can be 'https://discord.com', if one is not setup.")
+	proxyDebug := flag.Bool("proxyDebug", false, "Enable debugging requests to the proxy")
+
+	webhookUrl := flag.String("webhookUrl", os.Getenv("JOB_POST_RETENTION_REPORTS_WEBHOOK_URL"), "Webhook URL for logging")
+
+	dryRun := flag.Bool("dryRun", false, "Log reports without sending them")
+
+	flag.Parse()
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	defer func() {
+		if r := recover(); r != nil {
+			fmt.Println("Recovered from panic:", r)
+			println(string(debug.Stack()))
+
+			err = welcomer.SendWebhookMessage(ctx, *webhookUrl, discord.WebhookMessageParams{
+				Content: "<@143090142360371200>",
+				Embeds: []discord.Embed{
+					{
+						Title:       "Post Retention Reports Job",
+						Description: fmt.Sprintf("Recovered from panic: %v", r),
+						Color:       int32(16760839),
+						Timestamp:   new(time.Now()),
+					},
+				},
+			})
+			if err != nil {
+				welcomer.Logger.Warn().Err(err).Msg("Failed to send webhook message")
+			}
+		}
+	}()
+
+	welcomer.SetupLogger(*loggingLevel)
+	welcomer.SetupGRPCConnection(*sandwichGRPCHost,
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithDefaultCallOptions(grpc.MaxCallRecvMsgSize(1024*1024*1024)), // Set max message size to 1GB
+	)
+
+	restInterface := welcomer.NewTwilightProxy(*proxyAddress)
+	restInterface.SetDebug(*proxyDebug)
+	welcomer.SetupRESTInterface(restInterface)
+
+	welcomer.SetupSandwichClient()
+	welcomer.SetupDatabase(ctx, *postgresURL)
+
+	entrypoint(ctx, *dryRun)
+
+	if !*dryRun {
+		if err := welcomer.Queries.UpsertJobCheckpoint(ctx, database.UpsertJobCheckpointParams{
+			JobName:         "post-retention-reports",
+			LastProcessedTs: time.Now().UTC(),
+		}); err != nil {
+			welcomer.Logger.Error().Err(err).Msg("Failed to upsert job checkpoint")
+		}
+	}
+
+	cancel()
+}
+
+func entrypoint(ctx context.Context, dryRun bool) {
+	guildSettings, err := welcomer.Queries.GetEnabledRetentionGuildSettings(ctx)
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		welcomer.Logger.Error().Err(err).Msg("Failed to fetch guilds with retention reports enabled")
+
+		panic(err)
+	}
+
+	now := time.Now().UTC()
+
+	var totalPosted int
+
+	for _, guildSetting := range guildSettings {
+		err := postRetentionReport(ctx, guildSetting, now, dryRun)
+		if err != nil {
+			welcomer.Logger.Error().Err(err).
+				Int64("guild_id", guildSetting.GuildID).
+				Int64("channel_id", guildSetting.ChannelReport).
+				Msg("Failed to post retention report")
+
+			continue
+		}
+
+		totalPosted++
+	}
+
+	welcomer.Logger.Info().
+		Int("guilds", len(guildSettings)).
+		Int("posted", totalPosted).
+		Msg("Completed posting retention reports")
+}
+
+func postRetentionReport(ctx context.Context, guildSetting *database.GuildSettingsRetention, now time.Time, dryRun bool) error {
+	guildID := discord.Snowflake(guildSetting.GuildID)
+
+	cohorts, err := welcomer.Queries.GetGuildCohortRetention(ctx, database.GetGuildCohortRetentionParams{
+		GuildID:                                  int64(guildID),
+		ScienceGuildEventTypeUserJoin:            int32(database.ScienceGuildEventTypeUserJoin),
+		Since:                                    welcomer.GetRetentionReportSince(now),
+		Until:                                    now,
+		ScienceGuildEventTypeUserLeave:           int32(database.ScienceGuildEventTypeUserLeave),
+		ScienceGuildEventTypeUserWelcomed:        int32(database.ScienceGuildEventTypeUserWelcomed),
+		ScienceGuildEventTypeBorderwallCompleted: int32(database.ScienceGuildEventTypeBorderwallCompleted),
+		Now:                                      now,
+	})
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		return fmt.Errorf("failed to get guild cohort retention: %w", err)
+	}
+
+	report := welcomer.BuildRetentionReport(cohorts)
+
+	if dryRun {
+		welcomer.Logger.Info().
+			Int64("guild_id", int64(guildID)).
+			Int64("channel_id", guildSetting.ChannelReport).
+			Interface("report", report).
+			Msg("Would post retention report")
+
+		return nil
+	}
+
+	session, err := acquireGuildSession(ctx, guildID)
+	if err != nil {
+		return err
+	}
+
+	channel := discord.Channel{ID: discord.Snowflake(guildSetting.ChannelReport), GuildID: &guildID}
+
+	_, err = channel.Send(ctx, session, welcomer.GetRetentionReportMessage(guildID, report))
+	if err != nil {
+		return fmt.Errorf("failed to send retention report: %w", err)
+	}
+
+	return nil
+}
+
+// acquireGuildSession returns a session for the first application that is in the guild.
+func acquireGuildSession(ctx context.Context, guildID discord.Snowflake) (*discord.Session, error) {
+	locations, err := welcomer.SandwichClient.WhereIsGuild(ctx, &sandwich_protobuf.WhereIsGuildRequest{
+		GuildId: int64(guildID),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to find guild location: %w", err)
+	}
+
+	for _, location := range locations.GetLocations() {
+		session, err := welcomer.AcquireSession(ctx, location.GetIdentifier())
+		if err != nil {
+			welcomer.Logger.Warn().Err(err).
+				Int64("guild_id", int64(guildID)).
+				Str("identifier", location.GetIdentifier()).
+				Msg("Failed to acquire session for guild location")
+
+			continue
+		}
+
+		return session, nil
+	}
+
+	return nil, fmt.Errorf("no locations found for guild %d", guildID)
+}
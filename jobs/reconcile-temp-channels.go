@@ -0,0 +1,480 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"runtime/debug"
+	"slices"
+	"time"
+
+	"github.com/WelcomerTeam/Discord/discord"
+	sandwich_protobuf "github.com/WelcomerTeam/Sandwich-Daemon/proto"
+	"github.com/WelcomerTeam/Welcomer/welcomer-core"
+	"github.com/WelcomerTeam/Welcomer/welcomer-core/database"
+	"github.com/jackc/pgx/v4"
+	_ "github.com/joho/godotenv/autoload"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+func main() {
+	var err error
+
+	loggingLevel := flag.String("level", os.Getenv("LOGGING_LEVEL"), "Logging level")
+
+	postgresURL := flag.String("postgresURL", os.Getenv("POSTGRES_URL"), "Postgres connection URL")
+	sandwichGRPCHost := flag.String("sandwichGRPCHost", os.Getenv("SANDWICH_GRPC_HOST"), "GRPC Address for the Sandwich Daemon service")
+
+	proxyAddress := flag.String("proxyAddress", os.Getenv("PROXY_ADDRESS"), "Address to proxy requests through. This can be 'https://discord.com', if one is not setup.")
+	proxyDebug := flag.Bool("proxyDebug", false, "Enable debugging requests to the proxy")
+
+	webhookUrl := flag.String("webhookUrl", os.Getenv("JOB_RECONCILE_TEMP_CHANNELS_WEBHOOK_URL"), "Webhook URL for logging")
+
+	gracePeriod := flag.Duration("gracePeriod", time.Minute*10, "How long an empty temp channel is kept before it is deleted")
+	dryRun := flag.Bool("dryRun", false, "Log changes without deleting channels or updating owners")
+
+	flag.Parse()
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	defer func() {
+		if r := recover(); r != nil {
+			fmt.Println("Recovered from panic:", r)
+			println(string(debug.Stack()))
+
+			err = welcomer.SendWebhookMessage(ctx, *webhookUrl, discord.WebhookMessageParams{
+				Content: "<@143090142360371200>",
+				Embeds: []discord.Embed{
+					{
+						Title:       "Reconcile Temp Channels Job",
+						Description: fmt.Sprintf("Recovered from panic: %v", r),
+						Color:       int32(16760839),
+						Timestamp:   new(time.Now()),
+					},
+				},
+			})
+			if err != nil {
+				welcomer.Logger.Warn().Err(err).Msg("Failed to send webhook message")
+			}
+		}
+	}()
+
+	welcomer.SetupLogger(*loggingLevel)
+	welcomer.SetupGRPCConnection(*sandwichGRPCHost,
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithDefaultCallOptions(grpc.MaxCallRecvMsgSize(1024*1024*1024)), // Set max message size to 1GB
+	)
+
+	restInterface := welcomer.NewTwilightProxy(*proxyAddress)
+	restInterface.SetDebug(*proxyDebug)
+	welcomer.SetupRESTInterface(restInterface)
+
+	welcomer.SetupSandwichClient()
+	welcomer.SetupDatabase(ctx, *postgresURL)
+
+	entrypoint(ctx, *gracePeriod, *dryRun)
+
+	if !*dryRun {
+		if err := welcomer.Queries.UpsertJobCheckpoint(ctx, database.UpsertJobCheckpointParams{
+			JobName:         "reconcile-temp-channels",
+			LastProcessedTs: time.Now().UTC(),
+		}); err != nil {
+			welcomer.Logger.Error().Err(err).Msg("Failed to upsert job checkpoint")
+		}
+	}
+
+	cancel()
+}
+
+type reconcileResult struct {
+	Deleted  int
+	Repaired int
+	Forgot   int
+}
+
+func entrypoint(ctx context.Context, gracePeriod time.Duration, dryRun bool) {
+	guildSettings, err := welcomer.Queries.GetEnabledTempChannelsGuildSettings(ctx)
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		welcomer.Logger.Error().Err(err).Msg("Failed to fetch guilds with temp channels enabled")
+
+		panic(err)
+	}
+
+	voiceStates, err := welcomer.SandwichClient.FetchVoiceStates(ctx, &sandwich_protobuf.FetchVoiceStatesRequest{})
+	if err != nil {
+		welcomer.Logger.Error().Err(err).Msg("Failed to fetch voice states")
+
+		panic(err)
+	}
+
+	// Users currently connected to each voice channel, in the order sandwich returned them.
+	occupants := make(map[int64][]int64)
+
+	for _, vs := range voiceStates.VoiceStates {
+		if vs.GetGuildID() == 0 || vs.GetUserID() == 0 || vs.GetChannelID() == 0 {
+			continue
+		}
+
+		occupants[vs.GetChannelID()] = append(occupants[vs.GetChannelID()], vs.GetUserID())
+	}
+
+	// An empty response means sandwich has no voice state data, not that every channel is empty.
+	allowDelete := len(occupants) > 0
+	if !allowDelete {
+		welcomer.Logger.Warn().Msg("No voice states were returned, empty temp channels will not be deleted")
+	}
+
+	var total reconcileResult
+
+	for _, settings := range guildSettings {
+		result, err := reconcileGuild(ctx, settings, occupants, allowDelete, gracePeriod, dryRun)
+		if err != nil {
+			welcomer.Logger.Error().Err(err).Int64("guild_id", settings.GuildID).Msg("Failed to reconcile temp channels for guild")
+
+			continue
+		}
+
+		total.Deleted += result.Deleted
+		total.Repaired += result.Repaired
+		total.Forgot += result.Forgot
+	}
+
+	welcomer.Logger.Info().
+		Bool("dry_run", dryRun).
+		Int("guilds", len(guildSettings)).
+		Int("deleted", total.Deleted).
+		Int("repaired", total.Repaired).
+		Int("forgot", total.Forgot).
+		Msg("Completed reconciliation of temp channels")
+}
+
+func reconcileGuild(ctx context.Context, settings *database.GuildSettingsTempchannels, occupants map[int64][]int64, allowDelete bool, gracePeriod time.Duration, dryRun bool) (result reconcileResult, err error) {
+	guildID := discord.Snowflake(settings.GuildID)
+
+	lobbies := welcomer.GetTempChannelLobbies(settings)
+	if len(lobbies) == 0 {
+		return result, nil
+	}
+
+	lobbyIDs := make(map[discord.Snowflake]bool, len(lobbies))
+	categoryIDs := make(map[discord.Snowflake]bool, len(lobbies))
+
+	for _, lobby := range lobbies {
+		lobbyIDs[lobby.ChannelID] = true
+
+		if !lobby.CategoryID.IsNil() {
+			categoryIDs[lobby.CategoryID] = true
+		}
+	}
+
+	channels, err := welcomer.FetchGuildChannels(ctx, guildID)
+	if err != nil {
+		return result, fmt.Errorf("failed to fetch guild channels: %w", err)
+	}
+
+	// Sandwich may not have the guild cached. Without channels we cannot tell which have been deleted.
+	if len(channels) == 0 {
+		return result, nil
+	}
+
+	tempChannels, err := welcomer.Queries.GetTempChannelsByGuild(ctx, int64(guildID))
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		return result, fmt.Errorf("failed to fetch temp channels: %w", err)
+	}
+
+	tempChannelsByID := make(map[discord.Snowflake]*database.GuildTempChannels, len(tempChannels))
+	for _, tempChannel := range tempChannels {
+		tempChannelsByID[discord.Snowflake(tempChannel.ChannelID)] = tempChannel
+	}
+
+	channelsByID := make(map[discord.Snowflake]*discord.Channel, len(channels))
+	for _, channel := range channels {
+		channelsByID[channel.ID] = channel
+	}
+
+	// Forget temp channels that were deleted while nobody was listening.
+	for channelID, tempChannel := range tempChannelsByID {
+		if _, ok := channelsByID[channelID]; ok {
+			continue
+		}
+
+		welcomer.Logger.Info().Bool("dry_run", dryRun).
+			Int64("guild_id", tempChannel.GuildID).
+			Int64("channel_id", tempChannel.ChannelID).
+			Msg("Forgetting temp channel that no longer exists")
+
+		if !dryRun {
+			forgetTempChannel(ctx, tempChannel.GuildID, tempChannel.ChannelID)
+		}
+
+		result.Forgot++
+	}
+
+	var session *discord.Session
+
+	for _, channel := range channels {
+		if channel.Type != discord.ChannelTypeGuildVoice || lobbyIDs[channel.ID] {
+			continue
+		}
+
+		tempChannel, isStored := tempChannelsByID[channel.ID]
+
+		// Only channels we have stored, or legacy channels inside a lobby category, are temp channels.
+		if !isStored && (channel.ParentID == nil || !categoryIDs[*channel.ParentID] || !isLegacyTempChannel(channel)) {
+			continue
+		}
+
+		channelOccupants := occupants[int64(channel.ID)]
+
+		if len(channelOccupants) == 0 {
+			if !settings.ToggleAutopurge || !allowDelete {
+				continue
+			}
+
+			// Voice states may be partial, only delete channels that every source agrees are empty.
+			if channel.MemberCount > 0 {
+				continue
+			}
+
+			sessions, err := welcomer.Queries.GetGuildVoiceChannelOpenSessionsByChannel(ctx, database.GetGuildVoiceChannelOpenSessionsByChannelParams{
+				GuildID:   int64(guildID),
+				ChannelID: int64(channel.ID),
+			})
+			if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+				welcomer.Logger.Warn().Err(err).
+					Int64("guild_id", int64(guildID)).
+					Int64("channel_id", int64(channel.ID)).
+					Msg("Failed to fetch open voice sessions for temp channel")
+
+				continue
+			}
+
+			if len(sessions) > 0 {
+				continue
+			}
+
+			createdAt := channel.ID.Time()
+			if isStored {
+				createdAt = tempChannel.CreatedAt
+			}
+
+			if time.Since(createdAt) < gracePeriod {
+				continue
+			}
+
+			welcomer.Logger.Info().Bool("dry_run", dryRun).
+				Int64("guild_id", int64(guildID)).
+				Int64("channel_id", int64(channel.ID)).
+				Str("channel_name", channel.Name).
+				Msg("Deleting empty temp channel")
+
+			if !dryRun {
+				if session == nil {
+					session, err = acquireGuildSession(ctx, guildID)
+					if err != nil {
+						return result, err
+					}
+				}
+
+				err = channel.Delete(ctx, session, new("Automatically deleted by TempChannels"))
+				if err != nil {
+					welcomer.Logger.Warn().Err(err).
+						Int64("guild_id", int64(guildID)).
+						Int64("channel_id", int64(channel.ID)).
+						Msg("Failed to delete empty temp channel")
+
+					continue
+				}
+
+				if isStored && tempChannel.TextChannelID != 0 {
+					textChannel := &discord.Channel{ID: discord.Snowflake(tempChannel.TextChannelID), GuildID: &guildID}
+
+					err = textChannel.Delete(ctx, session, new("Automatically deleted by TempChannels"))
+					if err != nil {
+						welcomer.Logger.Warn().Err(err).
+							Int64("guild_id", int64(guildID)).
+							Int64("channel_id", tempChannel.TextChannelID).
+							Msg("Failed to delete temp text channel")
+					}
+				}
+
+				if isStored {
+					forgetTempChannel(ctx, int64(guildID), int64(channel.ID))
+				}
+			}
+
+			result.Deleted++
+
+			continue
+		}
+
+		if isStored {
+			if slices.Contains(channelOccupants, tempChannel.OwnerID) {
+				continue
+			}
+
+			ownerID := earliestOccupant(ctx, guildID, channel.ID, channelOccupants)
+			if ownerID == 0 {
+				continue
+			}
+
+			welcomer.Logger.Info().Bool("dry_run", dryRun).
+				Int64("guild_id", int64(guildID)).
+				Int64("channel_id", int64(channel.ID)).
+				Int64("previous_owner_id", tempChannel.OwnerID).
+				Int64("owner_id", ownerID).
+				Msg("Passing on temp channel from owner that has left")
+
+			if !dryRun {
+				if session == nil {
+					session, err = acquireGuildSession(ctx, guildID)
+					if err != nil {
+						return result, err
+					}
+				}
+
+				err = welcomer.TransferTempChannel(ctx, session, tempChannel, discord.Snowflake(ownerID))
+				if err != nil {
+					welcomer.Logger.Warn().Err(err).
+						Int64("guild_id", int64(guildID)).
+						Int64("channel_id", int64(channel.ID)).
+						Msg("Failed to pass on temp channel")
+
+					continue
+				}
+			}
+
+			result.Repaired++
+
+			continue
+		}
+
+		// Legacy channels are stored so owner controls work on them. The owner is taken from
+		// the channel name when they are still connected, otherwise whoever joined first.
+		nameOwnerID, _ := welcomer.ParseLegacyTempChannelName(channel.Name)
+
+		ownerID := int64(nameOwnerID)
+		if !slices.Contains(channelOccupants, ownerID) {
+			if earliestID := earliestOccupant(ctx, guildID, channel.ID, channelOccupants); earliestID != 0 {
+				ownerID = earliestID
+			}
+		}
+
+		var lobbyID discord.Snowflake
+
+		for _, lobby := range lobbies {
+			if channel.ParentID != nil && lobby.CategoryID == *channel.ParentID {
+				lobbyID = lobby.ChannelID
+
+				break
+			}
+		}
+
+		welcomer.Logger.Info().Bool("dry_run", dryRun).
+			Int64("guild_id", int64(guildID)).
+			Int64("channel_id", int64(channel.ID)).
+			Int64("owner_id", ownerID).
+			Msg("Storing legacy temp channel")
+
+		if !dryRun {
+			_, err = welcomer.Queries.CreateOrUpdateTempChannel(ctx, database.CreateOrUpdateTempChannelParams{
+				ChannelID: int64(channel.ID),
+				GuildID:   int64(guildID),
+				OwnerID:   ownerID,
+				LobbyID:   int64(lobbyID),
+			})
+			if err != nil {
+				welcomer.Logger.Warn().Err(err).
+					Int64("guild_id", int64(guildID)).
+					Int64("channel_id", int64(channel.ID)).
+					Msg("Failed to store legacy temp channel")
+
+				continue
+			}
+		}
+
+		result.Repaired++
+	}
+
+	return result, nil
+}
+
+func isLegacyTempChannel(channel *discord.Channel) bool {
+	_, ok := welcomer.ParseLegacyTempChannelName(channel.Name)
+
+	return ok
+}
+
+// earliestOccupant returns the connected user who has been in the channel the longest,
+// ignoring bots. Returns 0 if no open session matches a connected user.
+func earliestOccupant(ctx context.Context, guildID, channelID discord.Snowflake, occupants []int64) int64 {
+	sessions, err := welcomer.Queries.GetGuildVoiceChannelOpenSessionsByChannel(ctx, database.GetGuildVoiceChannelOpenSessionsByChannelParams{
+		GuildID:   int64(guildID),
+		ChannelID: int64(channelID),
+	})
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		welcomer.Logger.Warn().Err(err).
+			Int64("guild_id", int64(guildID)).
+			Int64("channel_id", int64(channelID)).
+			Msg("Failed to fetch open voice sessions for temp channel")
+
+		return 0
+	}
+
+	// Sessions are ordered by when the user joined.
+	for _, voiceSession := range sessions {
+		if !slices.Contains(occupants, voiceSession.UserID) {
+			continue
+		}
+
+		if err := welcomer.CanOwnTempChannel(ctx, guildID, channelID, discord.Snowflake(voiceSession.UserID)); err != nil {
+			continue
+		}
+
+		return voiceSession.UserID
+	}
+
+	return 0
+}
+
+func forgetTempChannel(ctx context.Context, guildID, channelID int64) {
+	_, err := welcomer.Queries.DeleteTempChannel(ctx, database.DeleteTempChannelParams{
+		GuildID:   guildID,
+		ChannelID: channelID,
+	})
+	if err != nil {
+		welcomer.Logger.Warn().Err(err).
+			Int64("guild_id", guildID).
+			Int64("channel_id", channelID).
+			Msg("Failed to delete temp channel")
+	}
+}
+
+// acquireGuildSession returns a session for the first application that is in the guild.
+func acquireGuildSession(ctx context.Context, guildID discord.Snowflake) (*discord.Session, error) {
+	locations, err := welcomer.SandwichClient.WhereIsGuild(ctx, &sandwich_protobuf.WhereIsGuildRequest{
+		GuildId: int64(guildID),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to find guild location: %w", err)
+	}
+
+	for _, location := range locations.GetLocations() {
+		session, err := welcomer.AcquireSession(ctx, location.GetIdentifier())
+		if err != nil {
+			welcomer.Logger.Warn().Err(err).
+				Int64("guild_id", int64(guildID)).
+				Str("identifier", location.GetIdentifier()).
+				Msg("Failed to acquire session for guild location")
+
+			continue
+		}
+
+		return session, nil
+	}
+
+	return nil, fmt.Errorf("no locations found for guild %d", guildID)
+}
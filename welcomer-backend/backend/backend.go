@@ -217,15 +217,22 @@ func (b *Backend) PrepareGin() *gin.Engine {
 	registerPatreonRoutes(router)
 
 	registerBorderwallRoutes(router)
+	registerGiveawayRoutes(router)
 
 	registerGuildRoutes(router)
+	registerGuildAnalyticsRoutes(router)
 	registerGuildSettingsRoutes(router)
 
+	registerGuildSettingsActivityRolesRoutes(router)
 	registerGuildSettingsAutoRolesRoutes(router)
 	registerGuildSettingsBorderwallRoutes(router)
 	registerGuildSettingsCustomisationRoutes(router)
+	registerGuildSettingsDigestRoutes(router)
 	registerGuildSettingsFreeRolesRoutes(router)
+	registerGuildSettingsInactiveRoutes(router)
+	registerGuildSettingsLeaderboardRoutes(router)
 	registerGuildSettingsLeaverRoutes(router)
+	registerGuildSettingsRetentionRoutes(router)
 	registerGuildSettingsRulesRoutes(router)
 	registerGuildSettingsTempChannelsRoutes(router)
 	registerGuildSettingsTimeRolesRoutes(router)
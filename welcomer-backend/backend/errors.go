@@ -115,3 +115,15 @@ var (
 	ErrInvalidToken          = NewErrorWithCode(13100, "invalid token")
 	ErrCustomBotLimitReached = NewErrorWithCode(13101, "custom bot limit reached")
 )
+
+// Giveaway errors.
+var (
+	ErrGiveawayNotFound        = NewErrorWithCode(13200, "giveaway not found")
+	ErrGiveawayNotProvablyFair = NewErrorWithCode(13201, "giveaway is not provably fair")
+	ErrGiveawayAlreadyStarted  = NewErrorWithCode(13202, "giveaway has already started")
+	ErrGiveawayAlreadyEnded    = NewErrorWithCode(13203, "giveaway has already ended")
+	ErrGiveawayNotStarted      = NewErrorWithCode(13204, "giveaway has not started")
+	ErrGiveawayNotEnded        = NewErrorWithCode(13205, "giveaway has not ended")
+	ErrGiveawayWinnerNotFound  = NewErrorWithCode(13206, "giveaway winner not found")
+	ErrGiveawayInvalidPrize    = NewErrorWithCode(13207, "giveaway prize is not valid")
+)
@@ -0,0 +1,854 @@
+package backend
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	discord "github.com/WelcomerTeam/Discord/discord"
+	sandwich_protobuf "github.com/WelcomerTeam/Sandwich-Daemon/proto"
+	"github.com/WelcomerTeam/Welcomer/welcomer-core"
+	"github.com/WelcomerTeam/Welcomer/welcomer-core/database"
+	"github.com/gin-gonic/gin"
+	"github.com/gofrs/uuid"
+	"github.com/jackc/pgx/v4"
+)
+
+// Route GET /api/giveaways/:giveawayUUID/verify.
+func getGiveawayVerification(ctx *gin.Context) {
+	giveawayUUID, err := uuid.FromString(ctx.Param("giveawayUUID"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, NewBaseResponse(NewInvalidParameterError("giveawayUUID"), nil))
+
+		return
+	}
+
+	draw, err := welcomer.Queries.GetGiveawayDraw(ctx, giveawayUUID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			ctx.JSON(http.StatusNotFound, NewBaseResponse(ErrGiveawayNotProvablyFair, nil))
+
+			return
+		}
+
+		welcomer.Logger.Error().Err(err).
+			Str("giveaway_uuid", giveawayUUID.String()).
+			Msg("Failed to get giveaway draw")
+
+		ctx.JSON(http.StatusInternalServerError, NewBaseResponse(NewGenericErrorWithLineNumber(), nil))
+
+		return
+	}
+
+	winners, err := welcomer.Queries.GetGiveawayWinners(ctx, giveawayUUID)
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		welcomer.Logger.Error().Err(err).
+			Str("giveaway_uuid", giveawayUUID.String()).
+			Msg("Failed to get giveaway winners")
+
+		ctx.JSON(http.StatusInternalServerError, NewBaseResponse(NewGenericErrorWithLineNumber(), nil))
+
+		return
+	}
+
+	verification, err := welcomer.VerifyGiveawayDraw(draw, winners)
+	if err != nil {
+		welcomer.Logger.Error().Err(err).
+			Str("giveaway_uuid", giveawayUUID.String()).
+			Msg("Failed to verify giveaway draw")
+
+		ctx.JSON(http.StatusInternalServerError, NewBaseResponse(NewGenericErrorWithLineNumber(), nil))
+
+		return
+	}
+
+	ctx.JSON(http.StatusOK, NewBaseResponse(nil, verification))
+}
+
+// Route GET /api/guild/:guildID/giveaways.
+func getGuildGiveaways(ctx *gin.Context) {
+	requireOAuthAuthorization(ctx, func(ctx *gin.Context) {
+		requireGuildElevation(ctx, func(ctx *gin.Context) {
+			guildID := tryGetGuildID(ctx)
+
+			giveaways, err := welcomer.Queries.GetGuildGiveaways(ctx, int64(guildID))
+			if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+				welcomer.Logger.Warn().Err(err).Int64("guild_id", int64(guildID)).Msg("Failed to get guild giveaways")
+
+				ctx.JSON(http.StatusInternalServerError, NewBaseResponse(NewGenericErrorWithLineNumber(), nil))
+
+				return
+			}
+
+			partials := make([]*GuildGiveaway, 0, len(giveaways))
+
+			for _, giveaway := range giveaways {
+				partials = append(partials, GuildGiveawayToPartial(&giveaway.GuildGiveaways, giveaway.Entries))
+			}
+
+			ctx.JSON(http.StatusOK, NewBaseResponse(nil, partials))
+		})
+	})
+}
+
+// Route POST /api/guild/:guildID/giveaways.
+func createGuildGiveaway(ctx *gin.Context) {
+	requireOAuthAuthorization(ctx, func(ctx *gin.Context) {
+		requireGuildElevation(ctx, func(ctx *gin.Context) {
+			partial := &GuildGiveawayPayload{}
+
+			var err error
+
+			err = ctx.BindJSON(partial)
+			if err != nil {
+				ctx.JSON(http.StatusBadRequest, BaseResponse{
+					Ok:    false,
+					Error: err.Error(),
+				})
+
+				return
+			}
+
+			guildID := tryGetGuildID(ctx)
+			user := tryGetUser(ctx)
+
+			err = doValidateGiveaway(ctx, guildID, user, partial)
+			if err != nil {
+				ctx.JSON(http.StatusBadRequest, BaseResponse{
+					Ok:    false,
+					Error: err.Error(),
+				})
+
+				return
+			}
+
+			var giveaway *database.GuildGiveaways
+
+			err = welcomer.RetryWithFallback(
+				func() error {
+					giveaway, err = welcomer.Queries.CreateGiveaway(ctx, database.CreateGiveawayParams{
+						GuildID:     int64(guildID),
+						CreatedBy:   int64(user.ID),
+						Title:       partial.Title,
+						Description: partial.Description,
+						EndTime:     time.Unix(partial.Duration, 0),
+					})
+
+					return err
+				},
+				func() error {
+					return welcomer.EnsureGuild(ctx, guildID)
+				},
+				nil,
+			)
+			if err != nil {
+				welcomer.Logger.Warn().Err(err).Int64("guild_id", int64(guildID)).Msg("Failed to create giveaway")
+
+				ctx.JSON(http.StatusInternalServerError, NewBaseResponse(NewGenericErrorWithLineNumber(), nil))
+
+				return
+			}
+
+			params := PartialToUpdateGiveawayParams(giveaway, partial)
+
+			giveaway, err = welcomer.UpdateGiveawayGuildSettingsWithAudit(ctx, params, user.ID, guildID)
+			if err != nil {
+				welcomer.Logger.Warn().Err(err).Str("giveaway_uuid", params.GiveawayUuid.String()).Msg("Failed to update giveaway")
+
+				ctx.JSON(http.StatusInternalServerError, NewBaseResponse(NewGenericErrorWithLineNumber(), nil))
+
+				return
+			}
+
+			ctx.JSON(http.StatusOK, NewBaseResponse(nil, GuildGiveawayToPartial(giveaway, 0)))
+		})
+	})
+}
+
+// Route GET /api/guild/:guildID/giveaways/:giveawayUUID.
+func getGuildGiveaway(ctx *gin.Context) {
+	requireOAuthAuthorization(ctx, func(ctx *gin.Context) {
+		requireGuildElevation(ctx, func(ctx *gin.Context) {
+			giveaway, ok := tryGetGuildGiveaway(ctx)
+			if !ok {
+				return
+			}
+
+			entries, err := welcomer.Queries.CountGiveawayEntries(ctx, giveaway.GiveawayUuid)
+			if err != nil {
+				welcomer.Logger.Warn().Err(err).Str("giveaway_uuid", giveaway.GiveawayUuid.String()).Msg("Failed to count giveaway entries")
+			}
+
+			ctx.JSON(http.StatusOK, NewBaseResponse(nil, GuildGiveawayToPartial(giveaway, entries)))
+		})
+	})
+}
+
+// Route POST /api/guild/:guildID/giveaways/:giveawayUUID.
+func editGuildGiveaway(ctx *gin.Context) {
+	requireOAuthAuthorization(ctx, func(ctx *gin.Context) {
+		requireGuildElevation(ctx, func(ctx *gin.Context) {
+			partial := &GuildGiveawayEditPayload{}
+
+			var err error
+
+			err = ctx.BindJSON(partial)
+			if err != nil {
+				ctx.JSON(http.StatusBadRequest, BaseResponse{
+					Ok:    false,
+					Error: err.Error(),
+				})
+
+				return
+			}
+
+			giveaway, ok := tryGetGuildGiveaway(ctx)
+			if !ok {
+				return
+			}
+
+			if giveaway.HasEnded {
+				ctx.JSON(http.StatusBadRequest, NewBaseResponse(ErrGiveawayAlreadyEnded, nil))
+
+				return
+			}
+
+			guildID := tryGetGuildID(ctx)
+			user := tryGetUser(ctx)
+
+			var params database.UpdateGiveawayParams
+
+			if giveaway.IsSetup {
+				err = doValidateGiveaway(ctx, guildID, user, &partial.GuildGiveawayPayload)
+				if err != nil {
+					ctx.JSON(http.StatusBadRequest, BaseResponse{
+						Ok:    false,
+						Error: err.Error(),
+					})
+
+					return
+				}
+
+				params = PartialToUpdateGiveawayParams(giveaway, &partial.GuildGiveawayPayload)
+			} else {
+				// Once a giveaway has started, only entries and the end time can be changed.
+				params = GuildGiveawayToUpdateGiveawayParams(giveaway)
+
+				if partial.AllowEntries != nil {
+					params.AllowEntries = *partial.AllowEntries
+				}
+
+				switch {
+				case partial.ClearEndTime:
+					params.EndTime = time.Unix(0, 0)
+				case partial.EndTime != nil:
+					if partial.EndTime.Before(time.Now()) {
+						ctx.JSON(http.StatusBadRequest, NewBaseResponse(NewInvalidParameterError("end_time"), nil))
+
+						return
+					}
+
+					params.EndTime = *partial.EndTime
+				}
+			}
+
+			giveaway, err = welcomer.UpdateGiveawayGuildSettingsWithAudit(ctx, params, user.ID, guildID)
+			if err != nil {
+				welcomer.Logger.Warn().Err(err).Str("giveaway_uuid", params.GiveawayUuid.String()).Msg("Failed to update giveaway")
+
+				ctx.JSON(http.StatusInternalServerError, NewBaseResponse(NewGenericErrorWithLineNumber(), nil))
+
+				return
+			}
+
+			entries, err := welcomer.Queries.CountGiveawayEntries(ctx, giveaway.GiveawayUuid)
+			if err != nil {
+				welcomer.Logger.Warn().Err(err).Str("giveaway_uuid", giveaway.GiveawayUuid.String()).Msg("Failed to count giveaway entries")
+			}
+
+			ctx.JSON(http.StatusOK, NewBaseResponse(nil, GuildGiveawayToPartial(giveaway, entries)))
+		})
+	})
+}
+
+// Route DELETE /api/guild/:guildID/giveaways/:giveawayUUID.
+func deleteGuildGiveaway(ctx *gin.Context) {
+	requireOAuthAuthorization(ctx, func(ctx *gin.Context) {
+		requireGuildElevation(ctx, func(ctx *gin.Context) {
+			giveaway, ok := tryGetGuildGiveaway(ctx)
+			if !ok {
+				return
+			}
+
+			guildID := tryGetGuildID(ctx)
+			user := tryGetUser(ctx)
+
+			_, err := welcomer.Queries.DeleteGiveaway(ctx, database.DeleteGiveawayParams{
+				GuildID:      int64(guildID),
+				GiveawayUuid: giveaway.GiveawayUuid,
+			})
+			if err != nil {
+				welcomer.Logger.Warn().Err(err).Str("giveaway_uuid", giveaway.GiveawayUuid.String()).Msg("Failed to delete giveaway")
+
+				ctx.JSON(http.StatusInternalServerError, NewBaseResponse(NewGenericErrorWithLineNumber(), nil))
+
+				return
+			}
+
+			welcomer.AuditChange(ctx, guildID, user.ID, *giveaway, database.GuildGiveaways{}, database.AuditTypeGiveaways, giveaway.GiveawayUuid.String())
+
+			ctx.JSON(http.StatusOK, NewBaseResponse(nil, nil))
+		})
+	})
+}
+
+// Route POST /api/guild/:guildID/giveaways/:giveawayUUID/start.
+func startGuildGiveaway(ctx *gin.Context) {
+	requireOAuthAuthorization(ctx, func(ctx *gin.Context) {
+		requireGuildElevation(ctx, func(ctx *gin.Context) {
+			partial := &GuildGiveawayStartPayload{}
+
+			var err error
+
+			err = ctx.BindJSON(partial)
+			if err != nil {
+				ctx.JSON(http.StatusBadRequest, BaseResponse{
+					Ok:    false,
+					Error: err.Error(),
+				})
+
+				return
+			}
+
+			giveaway, ok := tryGetGuildGiveaway(ctx)
+			if !ok {
+				return
+			}
+
+			if !giveaway.IsSetup {
+				ctx.JSON(http.StatusBadRequest, NewBaseResponse(ErrGiveawayAlreadyStarted, nil))
+
+				return
+			}
+
+			if partial.ChannelID == 0 {
+				ctx.JSON(http.StatusBadRequest, NewBaseResponse(NewMissingParameterError("channel_id"), nil))
+
+				return
+			}
+
+			if partial.StartDelay < 0 {
+				ctx.JSON(http.StatusBadRequest, NewBaseResponse(NewInvalidParameterError("start_delay"), nil))
+
+				return
+			}
+
+			err = doValidateGiveawayPingContent(partial.PingContent)
+			if err != nil {
+				ctx.JSON(http.StatusBadRequest, BaseResponse{
+					Ok:    false,
+					Error: err.Error(),
+				})
+
+				return
+			}
+
+			guildID := tryGetGuildID(ctx)
+			user := tryGetUser(ctx)
+
+			_, err = welcomer.Queries.UpdateGiveawayMessage(ctx, database.UpdateGiveawayMessageParams{
+				GiveawayUuid: giveaway.GiveawayUuid,
+				MessageID:    0,
+				ChannelID:    int64(partial.ChannelID),
+			})
+			if err != nil {
+				welcomer.Logger.Warn().Err(err).Str("giveaway_uuid", giveaway.GiveawayUuid.String()).Msg("Failed to update giveaway channel")
+
+				ctx.JSON(http.StatusInternalServerError, NewBaseResponse(NewGenericErrorWithLineNumber(), nil))
+
+				return
+			}
+
+			params := GuildGiveawayToUpdateGiveawayParams(giveaway)
+			params.IsSetup = false
+			params.PingContent = partial.PingContent
+			params.StartTime = time.Now().Add(time.Duration(partial.StartDelay) * time.Second)
+
+			// While a giveaway is being setup, the end time holds its duration.
+			if giveaway.EndTime.Unix() > 0 {
+				params.EndTime = params.StartTime.Add(time.Duration(giveaway.EndTime.Unix()) * time.Second)
+			}
+
+			giveaway, err = welcomer.UpdateGiveawayGuildSettingsWithAudit(ctx, params, user.ID, guildID)
+			if err != nil {
+				welcomer.Logger.Warn().Err(err).Str("giveaway_uuid", params.GiveawayUuid.String()).Msg("Failed to start giveaway")
+
+				ctx.JSON(http.StatusInternalServerError, NewBaseResponse(NewGenericErrorWithLineNumber(), nil))
+
+				return
+			}
+
+			// Scheduled giveaways are started by the finish-giveaways job.
+			if partial.StartDelay == 0 {
+				err = relayGuildGiveawayEvent(ctx, guildID, welcomer.CustomEventInvokeStartGiveaway, welcomer.CustomEventInvokeStartGiveawayStructure{
+					GiveawayUUID: giveaway.GiveawayUuid,
+					GuildID:      guildID,
+				})
+				if err != nil {
+					welcomer.Logger.Warn().Err(err).Str("giveaway_uuid", giveaway.GiveawayUuid.String()).Msg("Failed to relay giveaway start")
+				}
+			}
+
+			entries, err := welcomer.Queries.CountGiveawayEntries(ctx, giveaway.GiveawayUuid)
+			if err != nil {
+				welcomer.Logger.Warn().Err(err).Str("giveaway_uuid", giveaway.GiveawayUuid.String()).Msg("Failed to count giveaway entries")
+			}
+
+			ctx.JSON(http.StatusOK, NewBaseResponse(nil, GuildGiveawayToPartial(giveaway, entries)))
+		})
+	})
+}
+
+// Route POST /api/guild/:guildID/giveaways/:giveawayUUID/end.
+func endGuildGiveaway(ctx *gin.Context) {
+	requireOAuthAuthorization(ctx, func(ctx *gin.Context) {
+		requireGuildElevation(ctx, func(ctx *gin.Context) {
+			giveaway, ok := tryGetGuildGiveaway(ctx)
+			if !ok {
+				return
+			}
+
+			if giveaway.IsSetup || giveaway.MessageID == 0 {
+				ctx.JSON(http.StatusBadRequest, NewBaseResponse(ErrGiveawayNotStarted, nil))
+
+				return
+			}
+
+			if giveaway.HasEnded {
+				ctx.JSON(http.StatusBadRequest, NewBaseResponse(ErrGiveawayAlreadyEnded, nil))
+
+				return
+			}
+
+			guildID := tryGetGuildID(ctx)
+			user := tryGetUser(ctx)
+
+			params := GuildGiveawayToUpdateGiveawayParams(giveaway)
+			params.EndTime = time.Now()
+
+			_, err := welcomer.UpdateGiveawayGuildSettingsWithAudit(ctx, params, user.ID, guildID)
+			if err != nil {
+				welcomer.Logger.Warn().Err(err).Str("giveaway_uuid", giveaway.GiveawayUuid.String()).Msg("Failed to update giveaway end time")
+
+				ctx.JSON(http.StatusInternalServerError, NewBaseResponse(NewGenericErrorWithLineNumber(), nil))
+
+				return
+			}
+
+			err = relayGuildGiveawayEvent(ctx, guildID, welcomer.CustomEventInvokeEndGiveaway, welcomer.CustomEventInvokeEndGiveawayStructure{
+				GiveawayUUID: giveaway.GiveawayUuid,
+				GuildID:      guildID,
+			})
+			if err != nil {
+				welcomer.Logger.Warn().Err(err).Str("giveaway_uuid", giveaway.GiveawayUuid.String()).Msg("Failed to relay giveaway end")
+
+				ctx.JSON(http.StatusInternalServerError, NewBaseResponse(NewGenericErrorWithLineNumber(), nil))
+
+				return
+			}
+
+			ctx.JSON(http.StatusOK, NewBaseResponse(nil, nil))
+		})
+	})
+}
+
+// Route POST /api/guild/:guildID/giveaways/:giveawayUUID/reroll.
+func rerollGuildGiveaway(ctx *gin.Context) {
+	requireOAuthAuthorization(ctx, func(ctx *gin.Context) {
+		requireGuildElevation(ctx, func(ctx *gin.Context) {
+			partial := &GuildGiveawayRerollPayload{}
+
+			var err error
+
+			err = ctx.BindJSON(partial)
+			if err != nil {
+				ctx.JSON(http.StatusBadRequest, BaseResponse{
+					Ok:    false,
+					Error: err.Error(),
+				})
+
+				return
+			}
+
+			giveaway, ok := tryGetGuildGiveaway(ctx)
+			if !ok {
+				return
+			}
+
+			if !giveaway.HasEnded {
+				ctx.JSON(http.StatusBadRequest, NewBaseResponse(ErrGiveawayNotEnded, nil))
+
+				return
+			}
+
+			winner, err := welcomer.Queries.GetGiveawayWinner(ctx, partial.GiveawayWinnerUUID)
+			if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+				welcomer.Logger.Warn().Err(err).Str("giveaway_winner_uuid", partial.GiveawayWinnerUUID.String()).Msg("Failed to get giveaway winner")
+
+				ctx.JSON(http.StatusInternalServerError, NewBaseResponse(NewGenericErrorWithLineNumber(), nil))
+
+				return
+			}
+
+			if errors.Is(err, pgx.ErrNoRows) || winner.GiveawayUuid != giveaway.GiveawayUuid || winner.IsExpired {
+				ctx.JSON(http.StatusNotFound, NewBaseResponse(ErrGiveawayWinnerNotFound, nil))
+
+				return
+			}
+
+			guildID := tryGetGuildID(ctx)
+			user := tryGetUser(ctx)
+
+			welcomer.Logger.Info().Int64("guild_id", int64(guildID)).Str("giveaway_uuid", giveaway.GiveawayUuid.String()).Int64("winner_id", winner.UserID).Int64("user_id", int64(user.ID)).Msg("Rerolling giveaway winner")
+
+			welcomer.AuditChange(ctx, guildID, user.ID, GuildGiveawayWinnerToPartial(winner), GuildGiveawayWinner{}, database.AuditTypeGiveaways, giveaway.GiveawayUuid.String())
+
+			err = relayGuildGiveawayEvent(ctx, guildID, welcomer.CustomEventInvokeRerollGiveaway, welcomer.CustomEventInvokeRerollGiveawayStructure{
+				GiveawayUUID:       giveaway.GiveawayUuid,
+				GuildID:            guildID,
+				GiveawayWinnerUUID: winner.GiveawayWinnerUuid,
+			})
+			if err != nil {
+				welcomer.Logger.Warn().Err(err).Str("giveaway_uuid", giveaway.GiveawayUuid.String()).Msg("Failed to relay giveaway reroll")
+
+				ctx.JSON(http.StatusInternalServerError, NewBaseResponse(NewGenericErrorWithLineNumber(), nil))
+
+				return
+			}
+
+			ctx.JSON(http.StatusOK, NewBaseResponse(nil, nil))
+		})
+	})
+}
+
+// Route GET /api/guild/:guildID/giveaways/:giveawayUUID/entries.
+func getGuildGiveawayEntries(ctx *gin.Context) {
+	requireOAuthAuthorization(ctx, func(ctx *gin.Context) {
+		requireGuildElevation(ctx, func(ctx *gin.Context) {
+			giveaway, ok := tryGetGuildGiveaway(ctx)
+			if !ok {
+				return
+			}
+
+			page, err := strconv.Atoi(ctx.DefaultQuery("page", "1"))
+			if err != nil || page < 1 {
+				ctx.JSON(http.StatusBadRequest, NewBaseResponse(NewInvalidParameterError("page"), nil))
+
+				return
+			}
+
+			limit, err := strconv.Atoi(ctx.DefaultQuery("limit", strconv.Itoa(GiveawayEntriesDefaultLimit)))
+			if err != nil || limit < 1 || limit > GiveawayEntriesMaximumLimit {
+				ctx.JSON(http.StatusBadRequest, NewBaseResponse(NewInvalidParameterError("limit"), nil))
+
+				return
+			}
+
+			total, err := welcomer.Queries.CountGiveawayEntries(ctx, giveaway.GiveawayUuid)
+			if err != nil {
+				welcomer.Logger.Warn().Err(err).Str("giveaway_uuid", giveaway.GiveawayUuid.String()).Msg("Failed to count giveaway entries")
+
+				ctx.JSON(http.StatusInternalServerError, NewBaseResponse(NewGenericErrorWithLineNumber(), nil))
+
+				return
+			}
+
+			entries, err := welcomer.Queries.GetGiveawayEntriesPaginated(ctx, database.GetGiveawayEntriesPaginatedParams{
+				GiveawayUuid: giveaway.GiveawayUuid,
+				Limit:        int32(limit),
+				Offset:       int32((page - 1) * limit),
+			})
+			if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+				welcomer.Logger.Warn().Err(err).Str("giveaway_uuid", giveaway.GiveawayUuid.String()).Msg("Failed to get giveaway entries")
+
+				ctx.JSON(http.StatusInternalServerError, NewBaseResponse(NewGenericErrorWithLineNumber(), nil))
+
+				return
+			}
+
+			response := GuildGiveawayEntriesResponse{
+				Entries: make([]GuildGiveawayEntry, 0, len(entries)),
+				Total:   total,
+				Page:    page,
+				Limit:   limit,
+			}
+
+			for _, entry := range entries {
+				response.Entries = append(response.Entries, GuildGiveawayEntry{
+					UserID:    discord.Snowflake(entry.UserID),
+					CreatedAt: entry.CreatedAt,
+				})
+			}
+
+			ctx.JSON(http.StatusOK, NewBaseResponse(nil, response))
+		})
+	})
+}
+
+// Route GET /api/guild/:guildID/giveaways/:giveawayUUID/entries/export.
+func exportGuildGiveawayEntries(ctx *gin.Context) {
+	requireOAuthAuthorization(ctx, func(ctx *gin.Context) {
+		requireGuildElevation(ctx, func(ctx *gin.Context) {
+			giveaway, ok := tryGetGuildGiveaway(ctx)
+			if !ok {
+				return
+			}
+
+			entries, err := welcomer.Queries.GetGiveawayEntries(ctx, giveaway.GiveawayUuid)
+			if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+				welcomer.Logger.Warn().Err(err).Str("giveaway_uuid", giveaway.GiveawayUuid.String()).Msg("Failed to get giveaway entries")
+
+				ctx.JSON(http.StatusInternalServerError, NewBaseResponse(NewGenericErrorWithLineNumber(), nil))
+
+				return
+			}
+
+			ctx.Header("Content-Type", "text/csv")
+			ctx.Header("Content-Disposition", fmt.Sprintf("attachment; filename=\"giveaway_entries_%s.csv\"", giveaway.GiveawayUuid.String()))
+
+			ctx.Status(http.StatusOK)
+
+			writer := csv.NewWriter(ctx.Writer)
+
+			_ = writer.Write([]string{"user_id", "entered_at"})
+
+			for _, entry := range entries {
+				_ = writer.Write([]string{
+					welcomer.Itoa(entry.UserID),
+					entry.CreatedAt.Format("2006-01-02 15:04:05"),
+				})
+			}
+
+			writer.Flush()
+
+			// The response has already been sent, so an error can only be logged.
+			if err := writer.Error(); err != nil {
+				welcomer.Logger.Warn().Err(err).Str("giveaway_uuid", giveaway.GiveawayUuid.String()).Msg("Failed to write giveaway entries to csv")
+			}
+		})
+	})
+}
+
+// Route GET /api/guild/:guildID/giveaways/:giveawayUUID/winners.
+func getGuildGiveawayWinners(ctx *gin.Context) {
+	requireOAuthAuthorization(ctx, func(ctx *gin.Context) {
+		requireGuildElevation(ctx, func(ctx *gin.Context) {
+			giveaway, ok := tryGetGuildGiveaway(ctx)
+			if !ok {
+				return
+			}
+
+			winners, err := welcomer.Queries.GetGiveawayWinners(ctx, giveaway.GiveawayUuid)
+			if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+				welcomer.Logger.Warn().Err(err).Str("giveaway_uuid", giveaway.GiveawayUuid.String()).Msg("Failed to get giveaway winners")
+
+				ctx.JSON(http.StatusInternalServerError, NewBaseResponse(NewGenericErrorWithLineNumber(), nil))
+
+				return
+			}
+
+			// Rerolled and expired winners are included so the full history is shown.
+			partials := make([]GuildGiveawayWinner, 0, len(winners))
+
+			for _, winner := range winners {
+				partials = append(partials, GuildGiveawayWinnerToPartial(winner))
+			}
+
+			ctx.JSON(http.StatusOK, NewBaseResponse(nil, partials))
+		})
+	})
+}
+
+// tryGetGuildGiveaway returns the giveaway in the route. If the giveaway
+// cannot be found, an error response is sent and false is returned.
+func tryGetGuildGiveaway(ctx *gin.Context) (*database.GuildGiveaways, bool) {
+	guildID := tryGetGuildID(ctx)
+
+	giveawayUUID, err := uuid.FromString(ctx.Param("giveawayUUID"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, NewBaseResponse(NewInvalidParameterError("giveawayUUID"), nil))
+
+		return nil, false
+	}
+
+	giveaway, err := welcomer.Queries.GetGiveaway(ctx, database.GetGiveawayParams{
+		GuildID:      int64(guildID),
+		GiveawayUuid: giveawayUUID,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			ctx.JSON(http.StatusNotFound, NewBaseResponse(ErrGiveawayNotFound, nil))
+
+			return nil, false
+		}
+
+		welcomer.Logger.Warn().Err(err).Int64("guild_id", int64(guildID)).Str("giveaway_uuid", giveawayUUID.String()).Msg("Failed to get giveaway")
+
+		ctx.JSON(http.StatusInternalServerError, NewBaseResponse(NewGenericErrorWithLineNumber(), nil))
+
+		return nil, false
+	}
+
+	return giveaway, true
+}
+
+// relayGuildGiveawayEvent relays a giveaway event to the first application in the guild.
+func relayGuildGiveawayEvent(ctx context.Context, guildID discord.Snowflake, eventType string, event any) error {
+	managers, err := fetchApplicationsForGuild(ctx, guildID)
+	if err != nil {
+		return err
+	}
+
+	if len(managers) == 0 {
+		return ErrWelcomerMissing
+	}
+
+	data, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to marshal giveaway event: %w", err)
+	}
+
+	_, err = welcomer.SandwichClient.RelayMessage(ctx, &sandwich_protobuf.RelayMessageRequest{
+		Identifier: managers[0],
+		Type:       eventType,
+		Data:       data,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to relay giveaway event: %w", err)
+	}
+
+	return nil
+}
+
+// Validates giveaway settings.
+func doValidateGiveaway(ctx context.Context, guildID discord.Snowflake, user SessionUser, giveaway *GuildGiveawayPayload) error {
+	if len(giveaway.Title) > 256 {
+		return fmt.Errorf("title is invalid: %w", ErrStringTooLong)
+	}
+
+	if len(giveaway.Description) > 4000 {
+		return fmt.Errorf("description is invalid: %w", ErrStringTooLong)
+	}
+
+	if giveaway.AccentColour < -1 || giveaway.AccentColour > 0xFFFFFF {
+		return fmt.Errorf("accent colour is invalid: %w", ErrInvalidColour)
+	}
+
+	if giveaway.ImageURL != "" {
+		if _, ok := welcomer.IsValidURL(giveaway.ImageURL); !ok {
+			return fmt.Errorf("image url is invalid: %w", ErrInvalidParameter)
+		}
+	}
+
+	if giveaway.Duration < 0 || giveaway.ClaimWindow < 0 || giveaway.MinimumJoinDate < 0 || giveaway.MinimumAccountAge < 0 {
+		return fmt.Errorf("duration is invalid: %w", ErrInvalidParameter)
+	}
+
+	if giveaway.MinimumMessageCount < 0 || giveaway.MinimumVoiceMinutes < 0 || giveaway.ActivityPeriodDays < 0 {
+		return fmt.Errorf("activity requirements are invalid: %w", ErrInvalidParameter)
+	}
+
+	if len(giveaway.RolesAllowed) > 25 || len(giveaway.RolesExcluded) > 25 {
+		return fmt.Errorf("roles are invalid: %w", ErrListTooLong)
+	}
+
+	if _, err := welcomer.ParseGiveawayRecurrence(giveaway.RecurrenceRule); err != nil {
+		return fmt.Errorf("recurrence rule is invalid: %w", ErrInvalidParameter)
+	}
+
+	if len(giveaway.Prizes) > 25 {
+		return fmt.Errorf("prizes are invalid: %w", ErrListTooLong)
+	}
+
+	for _, prize := range giveaway.Prizes {
+		if prize.Title == "" || prize.Count < 1 || prize.Duration < 0 {
+			return fmt.Errorf("prize %q is invalid: %w", prize.Title, ErrGiveawayInvalidPrize)
+		}
+
+		switch prize.Type {
+		case welcomer.GiveawayPrizeTypeManual, welcomer.GiveawayPrizeTypeCode:
+		case welcomer.GiveawayPrizeTypeRole:
+			if prize.RoleID == 0 {
+				return fmt.Errorf("prize %q role is invalid: %w", prize.Title, ErrRequired)
+			}
+
+			canAssignRoles, isRoleAssignable, isRoleElevated, err := welcomer.Accelerator_CanAssignRole(ctx, guildID, &discord.Role{ID: prize.RoleID})
+			if err != nil {
+				return fmt.Errorf("failed to check prize role: %w", err)
+			}
+
+			if !canAssignRoles || !isRoleAssignable || isRoleElevated {
+				return fmt.Errorf("prize %q role is not assignable: %w", prize.Title, ErrGiveawayInvalidPrize)
+			}
+		case welcomer.GiveawayPrizeTypeMembership:
+			// Memberships are paid for, so only Welcomer staff can give them away.
+			if !slices.Contains(welcomer.ElevatedUsers, user.ID) {
+				return fmt.Errorf("prize %q is invalid: %w", prize.Title, ErrInvalidPermissions)
+			}
+		default:
+			return fmt.Errorf("prize %q type is invalid: %w", prize.Title, ErrGiveawayInvalidPrize)
+		}
+	}
+
+	return nil
+}
+
+// doValidateGiveawayPingContent only allows the mentions that can be picked
+// when starting a giveaway from Discord.
+func doValidateGiveawayPingContent(pingContent string) error {
+	if len(pingContent) > 2000 {
+		return fmt.Errorf("ping content is invalid: %w", ErrStringTooLong)
+	}
+
+	for mention := range strings.FieldsSeq(pingContent) {
+		if mention == "@everyone" || mention == "@here" {
+			continue
+		}
+
+		roleID, isRoleMention := strings.CutPrefix(mention, "<@&")
+		roleID, hasSuffix := strings.CutSuffix(roleID, ">")
+
+		if !isRoleMention || !hasSuffix {
+			return fmt.Errorf("ping content is invalid: %w", ErrInvalidParameter)
+		}
+
+		if _, err := strconv.ParseUint(roleID, 10, 64); err != nil {
+			return fmt.Errorf("ping content is invalid: %w", ErrInvalidParameter)
+		}
+	}
+
+	return nil
+}
+
+func registerGiveawayRoutes(g *gin.Engine) {
+	g.GET("/api/giveaways/:giveawayUUID/verify", getGiveawayVerification)
+
+	g.GET("/api/guild/:guildID/giveaways", getGuildGiveaways)
+	g.POST("/api/guild/:guildID/giveaways", createGuildGiveaway)
+	g.GET("/api/guild/:guildID/giveaways/:giveawayUUID", getGuildGiveaway)
+	g.POST("/api/guild/:guildID/giveaways/:giveawayUUID", editGuildGiveaway)
+	g.DELETE("/api/guild/:guildID/giveaways/:giveawayUUID", deleteGuildGiveaway)
+	g.POST("/api/guild/:guildID/giveaways/:giveawayUUID/start", startGuildGiveaway)
+	g.POST("/api/guild/:guildID/giveaways/:giveawayUUID/end", endGuildGiveaway)
+	g.POST("/api/guild/:guildID/giveaways/:giveawayUUID/reroll", rerollGuildGiveaway)
+	g.GET("/api/guild/:guildID/giveaways/:giveawayUUID/entries", getGuildGiveawayEntries)
+	g.GET("/api/guild/:guildID/giveaways/:giveawayUUID/entries/export", exportGuildGiveawayEntries)
+	g.GET("/api/guild/:guildID/giveaways/:giveawayUUID/winners", getGuildGiveawayWinners)
+}
@@ -0,0 +1,455 @@
+package backend
+
+import (
+	"cmp"
+	"errors"
+	"net/http"
+	"slices"
+	"time"
+
+	discord "github.com/WelcomerTeam/Discord/discord"
+	"github.com/WelcomerTeam/Welcomer/welcomer-core"
+	"github.com/WelcomerTeam/Welcomer/welcomer-core/database"
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v4"
+)
+
+// getGuildAnalyticsRange reads the granularity, from and to query parameters.
+// If the parameters are invalid, a response is written and ok is false.
+func getGuildAnalyticsRange(ctx *gin.Context) (analyticsRange GuildAnalyticsRange, ok bool) {
+	analyticsRange.Granularity = ctx.DefaultQuery("granularity", AnalyticsGranularityDay)
+
+	maximumRange, ok := AnalyticsMaximumRange[analyticsRange.Granularity]
+	if !ok {
+		ctx.JSON(http.StatusBadRequest, NewBaseResponse(NewInvalidParameterError("granularity"), nil))
+
+		return analyticsRange, false
+	}
+
+	analyticsRange.To = time.Now().UTC()
+
+	if to := ctx.Query("to"); to != "" {
+		parsed, err := time.Parse(time.RFC3339, to)
+		if err != nil {
+			ctx.JSON(http.StatusBadRequest, NewBaseResponse(NewInvalidParameterError("to"), nil))
+
+			return analyticsRange, false
+		}
+
+		analyticsRange.To = parsed.UTC()
+	}
+
+	analyticsRange.From = analyticsRange.To.Add(-min(AnalyticsDefaultDays*24*time.Hour, maximumRange))
+
+	if from := ctx.Query("from"); from != "" {
+		parsed, err := time.Parse(time.RFC3339, from)
+		if err != nil {
+			ctx.JSON(http.StatusBadRequest, NewBaseResponse(NewInvalidParameterError("from"), nil))
+
+			return analyticsRange, false
+		}
+
+		analyticsRange.From = parsed.UTC()
+	}
+
+	if !analyticsRange.From.Before(analyticsRange.To) || analyticsRange.To.Sub(analyticsRange.From) > maximumRange {
+		ctx.JSON(http.StatusBadRequest, NewBaseResponse(NewInvalidParameterError("from"), nil))
+
+		return analyticsRange, false
+	}
+
+	return analyticsRange, true
+}
+
+// getGuildAnalyticsMessageCountGranularity returns the coarsest message count granularity that can be grouped
+// into the requested buckets. If the granularity no longer holds counts from the start of the range, a response
+// is written and ok is false.
+func getGuildAnalyticsMessageCountGranularity(ctx *gin.Context, analyticsRange GuildAnalyticsRange) (granularity string, ok bool) {
+	granularity = welcomer.GetMessageCountBucketGranularity(analyticsRange.Granularity)
+
+	// Retention is checked against the end of the range, as the default range is derived from it
+	// and can be as long as the retention itself.
+	if !welcomer.IsMessageCountGranularityRetained(granularity, analyticsRange.From, analyticsRange.To) {
+		ctx.JSON(http.StatusBadRequest, NewBaseResponse(NewInvalidParameterError("from"), nil))
+
+		return granularity, false
+	}
+
+	return granularity, true
+}
+
+func getGuildEventCountSeries(ctx *gin.Context, guildID discord.Snowflake, analyticsRange GuildAnalyticsRange, eventTypes ...database.ScienceGuildEventType) ([]*database.GetGuildEventCountSeriesRow, error) {
+	eventTypesInt := make([]int32, len(eventTypes))
+	for i, eventType := range eventTypes {
+		eventTypesInt[i] = int32(eventType)
+	}
+
+	eventCounts, err := welcomer.Queries.GetGuildEventCountSeries(ctx, database.GetGuildEventCountSeriesParams{
+		Bucket:     analyticsRange.Granularity,
+		GuildID:    int64(guildID),
+		EventTypes: eventTypesInt,
+		Since:      analyticsRange.From,
+		Until:      analyticsRange.To,
+	})
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		return nil, err
+	}
+
+	return eventCounts, nil
+}
+
+// buildGuildAnalyticsFunnel returns a funnel with a step for each event type, in order.
+func buildGuildAnalyticsFunnel(eventCounts []*database.GetGuildEventCountSeriesRow, eventTypes ...database.ScienceGuildEventType) GuildAnalyticsFunnel {
+	funnel := GuildAnalyticsFunnel{
+		Steps: make([]GuildAnalyticsFunnelStep, len(eventTypes)),
+	}
+
+	for i, eventType := range eventTypes {
+		funnel.Steps[i] = GuildAnalyticsFunnelStep{
+			Name:   eventType.String(),
+			Series: make([]GuildAnalyticsCountPoint, 0),
+		}
+
+		for _, eventCount := range eventCounts {
+			if eventCount.EventType != int32(eventType) {
+				continue
+			}
+
+			funnel.Steps[i].Count += eventCount.EventCount
+			funnel.Steps[i].Series = append(funnel.Steps[i].Series, GuildAnalyticsCountPoint{
+				Timestamp: eventCount.BucketTs,
+				Count:     eventCount.EventCount,
+			})
+		}
+	}
+
+	for i := range funnel.Steps {
+		if funnel.Steps[0].Count > 0 {
+			funnel.Steps[i].Conversion = float64(funnel.Steps[i].Count) / float64(funnel.Steps[0].Count)
+		}
+	}
+
+	return funnel
+}
+
+// Route GET /api/guild/:guildID/analytics/messages.
+func getGuildAnalyticsMessages(ctx *gin.Context) {
+	requireOAuthAuthorization(ctx, func(ctx *gin.Context) {
+		requireGuildElevation(ctx, func(ctx *gin.Context) {
+			guildID := tryGetGuildID(ctx)
+
+			analyticsRange, ok := getGuildAnalyticsRange(ctx)
+			if !ok {
+				return
+			}
+
+			granularity, ok := getGuildAnalyticsMessageCountGranularity(ctx, analyticsRange)
+			if !ok {
+				return
+			}
+
+			messageCounts, err := welcomer.Queries.GetGuildMessageCountSeries(ctx, database.GetGuildMessageCountSeriesParams{
+				Granularity:  granularity,
+				GuildID:      int64(guildID),
+				Since:        analyticsRange.From,
+				Until:        analyticsRange.To,
+				ChannelLimit: AnalyticsMaximumChannels,
+				Bucket:       analyticsRange.Granularity,
+			})
+			if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+				welcomer.Logger.Warn().Err(err).Int64("guild_id", int64(guildID)).Msg("Failed to get guild message count series")
+
+				ctx.JSON(http.StatusInternalServerError, NewBaseResponse(NewGenericErrorWithLineNumber(), nil))
+
+				return
+			}
+
+			analytics := GuildAnalyticsMessages{
+				GuildAnalyticsRange: analyticsRange,
+				Channels:            make([]GuildAnalyticsChannelCount, 0),
+				Series:              make([]GuildAnalyticsMessagesPoint, 0, len(messageCounts)),
+			}
+
+			channelIndex := make(map[discord.Snowflake]int)
+
+			for _, messageCount := range messageCounts {
+				channelID := discord.Snowflake(messageCount.ChannelID)
+
+				i, ok := channelIndex[channelID]
+				if !ok {
+					i = len(analytics.Channels)
+					channelIndex[channelID] = i
+
+					analytics.Channels = append(analytics.Channels, GuildAnalyticsChannelCount{ChannelID: channelID})
+				}
+
+				analytics.Total += messageCount.MessageCount
+				analytics.Channels[i].Messages += messageCount.MessageCount
+
+				analytics.Series = append(analytics.Series, GuildAnalyticsMessagesPoint{
+					Timestamp: messageCount.BucketTs,
+					ChannelID: channelID,
+					Messages:  messageCount.MessageCount,
+				})
+			}
+
+			slices.SortFunc(analytics.Channels, func(a, b GuildAnalyticsChannelCount) int {
+				return cmp.Compare(b.Messages, a.Messages)
+			})
+
+			ctx.JSON(http.StatusOK, NewBaseResponse(nil, analytics))
+		})
+	})
+}
+
+// Route GET /api/guild/:guildID/analytics/active-users.
+func getGuildAnalyticsActiveUsers(ctx *gin.Context) {
+	requireOAuthAuthorization(ctx, func(ctx *gin.Context) {
+		requireGuildElevation(ctx, func(ctx *gin.Context) {
+			guildID := tryGetGuildID(ctx)
+
+			analyticsRange, ok := getGuildAnalyticsRange(ctx)
+			if !ok {
+				return
+			}
+
+			granularity, ok := getGuildAnalyticsMessageCountGranularity(ctx, analyticsRange)
+			if !ok {
+				return
+			}
+
+			activeUsers, err := welcomer.Queries.GetGuildActiveUserSeries(ctx, database.GetGuildActiveUserSeriesParams{
+				Bucket:      analyticsRange.Granularity,
+				Granularity: granularity,
+				GuildID:     int64(guildID),
+				Since:       analyticsRange.From,
+				Until:       analyticsRange.To,
+			})
+			if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+				welcomer.Logger.Warn().Err(err).Int64("guild_id", int64(guildID)).Msg("Failed to get guild active user series")
+
+				ctx.JSON(http.StatusInternalServerError, NewBaseResponse(NewGenericErrorWithLineNumber(), nil))
+
+				return
+			}
+
+			analytics := GuildAnalyticsActiveUsers{
+				GuildAnalyticsRange: analyticsRange,
+				Series:              make([]GuildAnalyticsActiveUsersPoint, len(activeUsers)),
+			}
+
+			for i, activeUser := range activeUsers {
+				analytics.Series[i] = GuildAnalyticsActiveUsersPoint{
+					Timestamp:    activeUser.BucketTs,
+					ActiveUsers:  activeUser.ActiveUsers,
+					MessageUsers: activeUser.MessageUsers,
+					VoiceUsers:   activeUser.VoiceUsers,
+				}
+			}
+
+			ctx.JSON(http.StatusOK, NewBaseResponse(nil, analytics))
+		})
+	})
+}
+
+// Route GET /api/guild/:guildID/analytics/voice.
+func getGuildAnalyticsVoice(ctx *gin.Context) {
+	requireOAuthAuthorization(ctx, func(ctx *gin.Context) {
+		requireGuildElevation(ctx, func(ctx *gin.Context) {
+			guildID := tryGetGuildID(ctx)
+
+			analyticsRange, ok := getGuildAnalyticsRange(ctx)
+			if !ok {
+				return
+			}
+
+			// Minutes come from the hourly rollup, so sessions that span buckets are split between them.
+			voiceSeries, err := welcomer.Queries.GetGuildVoiceSeries(ctx, database.GetGuildVoiceSeriesParams{
+				Bucket:  analyticsRange.Granularity,
+				GuildID: int64(guildID),
+				Since:   analyticsRange.From,
+				Until:   analyticsRange.To,
+			})
+			if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+				welcomer.Logger.Warn().Err(err).Int64("guild_id", int64(guildID)).Msg("Failed to get guild voice series")
+
+				ctx.JSON(http.StatusInternalServerError, NewBaseResponse(NewGenericErrorWithLineNumber(), nil))
+
+				return
+			}
+
+			analytics := GuildAnalyticsVoice{
+				GuildAnalyticsRange: analyticsRange,
+				Series:              make([]GuildAnalyticsVoicePoint, len(voiceSeries)),
+			}
+
+			for i, voice := range voiceSeries {
+				analytics.TotalMinutes += voice.VoiceMinutes
+				analytics.Series[i] = GuildAnalyticsVoicePoint{
+					Timestamp:  voice.BucketTs,
+					Minutes:    voice.VoiceMinutes,
+					VoiceUsers: voice.VoiceUsers,
+					Sessions:   voice.Sessions,
+				}
+			}
+
+			ctx.JSON(http.StatusOK, NewBaseResponse(nil, analytics))
+		})
+	})
+}
+
+// Route GET /api/guild/:guildID/analytics/growth.
+func getGuildAnalyticsGrowth(ctx *gin.Context) {
+	requireOAuthAuthorization(ctx, func(ctx *gin.Context) {
+		requireGuildElevation(ctx, func(ctx *gin.Context) {
+			guildID := tryGetGuildID(ctx)
+
+			analyticsRange, ok := getGuildAnalyticsRange(ctx)
+			if !ok {
+				return
+			}
+
+			eventCounts, err := getGuildEventCountSeries(ctx, guildID, analyticsRange,
+				database.ScienceGuildEventTypeUserJoin,
+				database.ScienceGuildEventTypeUserLeave,
+			)
+			if err != nil {
+				welcomer.Logger.Warn().Err(err).Int64("guild_id", int64(guildID)).Msg("Failed to get guild event count series")
+
+				ctx.JSON(http.StatusInternalServerError, NewBaseResponse(NewGenericErrorWithLineNumber(), nil))
+
+				return
+			}
+
+			analytics := GuildAnalyticsGrowth{
+				GuildAnalyticsRange: analyticsRange,
+				Series:              make([]GuildAnalyticsGrowthPoint, 0),
+			}
+
+			// Rows are ordered by bucket, so each bucket is a run of rows.
+			for _, eventCount := range eventCounts {
+				if len(analytics.Series) == 0 || !analytics.Series[len(analytics.Series)-1].Timestamp.Equal(eventCount.BucketTs) {
+					analytics.Series = append(analytics.Series, GuildAnalyticsGrowthPoint{Timestamp: eventCount.BucketTs})
+				}
+
+				point := &analytics.Series[len(analytics.Series)-1]
+
+				switch database.ScienceGuildEventType(eventCount.EventType) {
+				case database.ScienceGuildEventTypeUserJoin:
+					point.Joins += eventCount.EventCount
+					analytics.Joins += eventCount.EventCount
+				case database.ScienceGuildEventTypeUserLeave:
+					point.Leaves += eventCount.EventCount
+					analytics.Leaves += eventCount.EventCount
+				}
+
+				point.Net = point.Joins - point.Leaves
+			}
+
+			analytics.Net = analytics.Joins - analytics.Leaves
+
+			ctx.JSON(http.StatusOK, NewBaseResponse(nil, analytics))
+		})
+	})
+}
+
+// Route GET /api/guild/:guildID/analytics/funnels.
+func getGuildAnalyticsFunnels(ctx *gin.Context) {
+	requireOAuthAuthorization(ctx, func(ctx *gin.Context) {
+		requireGuildElevation(ctx, func(ctx *gin.Context) {
+			guildID := tryGetGuildID(ctx)
+
+			analyticsRange, ok := getGuildAnalyticsRange(ctx)
+			if !ok {
+				return
+			}
+
+			eventCounts, err := getGuildEventCountSeries(ctx, guildID, analyticsRange,
+				database.ScienceGuildEventTypeUserJoin,
+				database.ScienceGuildEventTypeUserWelcomed,
+				database.ScienceGuildEventTypeBorderwallChallenge,
+				database.ScienceGuildEventTypeBorderwallCompleted,
+				database.ScienceGuildEventTypeGiveawayCreated,
+				database.ScienceGuildEventTypeGiveawayStarted,
+				database.ScienceGuildEventTypeGiveawayEnded,
+			)
+			if err != nil {
+				welcomer.Logger.Warn().Err(err).Int64("guild_id", int64(guildID)).Msg("Failed to get guild event count series")
+
+				ctx.JSON(http.StatusInternalServerError, NewBaseResponse(NewGenericErrorWithLineNumber(), nil))
+
+				return
+			}
+
+			analytics := GuildAnalyticsFunnels{
+				GuildAnalyticsRange: analyticsRange,
+				Welcome: buildGuildAnalyticsFunnel(eventCounts,
+					database.ScienceGuildEventTypeUserJoin,
+					database.ScienceGuildEventTypeUserWelcomed,
+				),
+				Borderwall: buildGuildAnalyticsFunnel(eventCounts,
+					database.ScienceGuildEventTypeBorderwallChallenge,
+					database.ScienceGuildEventTypeBorderwallCompleted,
+				),
+				Giveaways: buildGuildAnalyticsFunnel(eventCounts,
+					database.ScienceGuildEventTypeGiveawayCreated,
+					database.ScienceGuildEventTypeGiveawayStarted,
+					database.ScienceGuildEventTypeGiveawayEnded,
+				),
+			}
+
+			ctx.JSON(http.StatusOK, NewBaseResponse(nil, analytics))
+		})
+	})
+}
+
+// Route GET /api/guild/:guildID/analytics/retention.
+// Members are always grouped by the week they joined, the granularity only limits the range that can be requested.
+func getGuildAnalyticsRetention(ctx *gin.Context) {
+	requireOAuthAuthorization(ctx, func(ctx *gin.Context) {
+		requireGuildElevation(ctx, func(ctx *gin.Context) {
+			guildID := tryGetGuildID(ctx)
+
+			analyticsRange, ok := getGuildAnalyticsRange(ctx)
+			if !ok {
+				return
+			}
+
+			cohorts, err := welcomer.Queries.GetGuildCohortRetention(ctx, database.GetGuildCohortRetentionParams{
+				GuildID:                                  int64(guildID),
+				ScienceGuildEventTypeUserJoin:            int32(database.ScienceGuildEventTypeUserJoin),
+				Since:                                    analyticsRange.From,
+				Until:                                    analyticsRange.To,
+				ScienceGuildEventTypeUserLeave:           int32(database.ScienceGuildEventTypeUserLeave),
+				ScienceGuildEventTypeUserWelcomed:        int32(database.ScienceGuildEventTypeUserWelcomed),
+				ScienceGuildEventTypeBorderwallCompleted: int32(database.ScienceGuildEventTypeBorderwallCompleted),
+				Now:                                      time.Now().UTC(),
+			})
+			if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+				welcomer.Logger.Warn().Err(err).Int64("guild_id", int64(guildID)).Msg("Failed to get guild cohort retention")
+
+				ctx.JSON(http.StatusInternalServerError, NewBaseResponse(NewGenericErrorWithLineNumber(), nil))
+
+				return
+			}
+
+			analyticsRange.Granularity = AnalyticsGranularityWeek
+
+			analytics := GuildAnalyticsRetention{
+				GuildAnalyticsRange: analyticsRange,
+				RetentionReport:     welcomer.BuildRetentionReport(cohorts),
+			}
+
+			ctx.JSON(http.StatusOK, NewBaseResponse(nil, analytics))
+		})
+	})
+}
+
+func registerGuildAnalyticsRoutes(g *gin.Engine) {
+	g.GET("/api/guild/:guildID/analytics/messages", getGuildAnalyticsMessages)
+	g.GET("/api/guild/:guildID/analytics/active-users", getGuildAnalyticsActiveUsers)
+	g.GET("/api/guild/:guildID/analytics/voice", getGuildAnalyticsVoice)
+	g.GET("/api/guild/:guildID/analytics/growth", getGuildAnalyticsGrowth)
+	g.GET("/api/guild/:guildID/analytics/funnels", getGuildAnalyticsFunnels)
+	g.GET("/api/guild/:guildID/analytics/retention", getGuildAnalyticsRetention)
+}
@@ -0,0 +1,158 @@
+package backend
+
+import (
+	_ "embed"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+
+	discord "github.com/WelcomerTeam/Discord/discord"
+	"github.com/WelcomerTeam/Welcomer/welcomer-core"
+	"github.com/WelcomerTeam/Welcomer/welcomer-core/database"
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v4"
+)
+
+// Route GET /api/guild/:guildID/activityroles.
+func getGuildSettingsActivityRoles(ctx *gin.Context) {
+	requireOAuthAuthorization(ctx, func(ctx *gin.Context) {
+		requireGuildElevation(ctx, func(ctx *gin.Context) {
+			guildID := tryGetGuildID(ctx)
+
+			activityroles, err := welcomer.Queries.GetActivityRolesGuildSettings(ctx, int64(guildID))
+			if err != nil {
+				if errors.Is(err, pgx.ErrNoRows) {
+					activityroles = &database.GuildSettingsActivityroles{
+						GuildID:       int64(guildID),
+						ToggleEnabled: welcomer.DefaultActivityRoles.ToggleEnabled,
+						ToggleDecay:   welcomer.DefaultActivityRoles.ToggleDecay,
+						Activityroles: welcomer.DefaultActivityRoles.Activityroles,
+					}
+				} else {
+					welcomer.Logger.Warn().Err(err).Int64("guild_id", int64(guildID)).Msg("Failed to get guild activityroles settings")
+
+					ctx.JSON(http.StatusInternalServerError, NewBaseResponse(NewGenericErrorWithLineNumber(), nil))
+
+					return
+				}
+			}
+
+			partial := GuildSettingsActivityRolesSettingsToPartial(activityroles)
+
+			ctx.JSON(http.StatusOK, BaseResponse{
+				Ok:   true,
+				Data: partial,
+			})
+		})
+	})
+}
+
+// Route POST /api/guild/:guildID/activityroles.
+func setGuildSettingsActivityRoles(ctx *gin.Context) {
+	requireOAuthAuthorization(ctx, func(ctx *gin.Context) {
+		requireGuildElevation(ctx, func(ctx *gin.Context) {
+			partial := &GuildSettingsActivityRoles{}
+
+			var err error
+
+			err = ctx.BindJSON(partial)
+			if err != nil {
+				ctx.JSON(http.StatusBadRequest, BaseResponse{
+					Ok:    false,
+					Error: err.Error(),
+				})
+
+				return
+			}
+
+			err = doValidateActivityRoles(partial)
+			if err != nil {
+				ctx.JSON(http.StatusBadRequest, BaseResponse{
+					Ok:    false,
+					Error: err.Error(),
+				})
+
+				return
+			}
+
+			guildID := tryGetGuildID(ctx)
+
+			activityroles := PartialToGuildSettingsActivityRolesSettings(int64(guildID), partial)
+
+			databaseActivityRolesGuildSettings := database.CreateOrUpdateActivityRolesGuildSettingsParams(*activityroles)
+
+			user := tryGetUser(ctx)
+			welcomer.Logger.Info().Int64("guild_id", int64(guildID)).Interface("obj", *activityroles).Int64("user_id", int64(user.ID)).Msg("Creating or updating guild activityroles settings")
+
+			oldActivityRoles := &database.GuildSettingsActivityroles{}
+			if existing, err := welcomer.Queries.GetActivityRolesGuildSettings(ctx, int64(guildID)); err == nil {
+				oldActivityRoles = existing
+			}
+
+			var newActivityRoles *database.GuildSettingsActivityroles
+
+			err = welcomer.RetryWithFallback(
+				func() error {
+					newActivityRoles, err = welcomer.CreateOrUpdateActivityRolesGuildSettingsWithAudit(ctx, databaseActivityRolesGuildSettings, user.ID)
+
+					return err
+				},
+				func() error {
+					return welcomer.EnsureGuild(ctx, discord.Snowflake(guildID))
+				},
+				nil,
+			)
+			if err != nil {
+				welcomer.Logger.Warn().Err(err).Int64("guild_id", int64(guildID)).Msg("Failed to create or update guild activityroles settings")
+
+				ctx.JSON(http.StatusInternalServerError, NewBaseResponse(NewGenericErrorWithLineNumber(), nil))
+
+				return
+			}
+
+			welcomer.SyncActivityRolesGuildSettings(ctx, oldActivityRoles, newActivityRoles)
+
+			getGuildSettingsActivityRoles(ctx)
+		})
+	})
+}
+
+// Validates activity role settings.
+func doValidateActivityRoles(guildSettings *GuildSettingsActivityRoles) error {
+	if len(guildSettings.Roles) > ActivityRolesMaximum {
+		return NewInvalidParameterError("roles")
+	}
+
+	for i, activityRole := range guildSettings.Roles {
+		if activityRole.Role.IsNil() {
+			return fmt.Errorf("role %d: %w", i+1, NewMissingParameterError("role_id"))
+		}
+
+		// Each role can only be given by one threshold.
+		if slices.ContainsFunc(guildSettings.Roles[:i], func(existing welcomer.GuildSettingsActivityRolesRole) bool {
+			return existing.Role == activityRole.Role
+		}) {
+			return fmt.Errorf("role %d: %w", i+1, NewInvalidParameterError("role_id"))
+		}
+
+		if activityRole.Type != welcomer.ActivityRoleTypeMessages && activityRole.Type != welcomer.ActivityRoleTypeVoice {
+			return fmt.Errorf("role %d: %w", i+1, NewInvalidParameterError("type"))
+		}
+
+		if activityRole.Threshold <= 0 {
+			return fmt.Errorf("role %d: %w", i+1, NewInvalidParameterError("threshold"))
+		}
+
+		if activityRole.Days <= 0 || activityRole.Days > welcomer.MaximumActivityRoleDays {
+			return fmt.Errorf("role %d: %w", i+1, NewInvalidParameterError("days"))
+		}
+	}
+
+	return nil
+}
+
+func registerGuildSettingsActivityRolesRoutes(g *gin.Engine) {
+	g.GET("/api/guild/:guildID/activityroles", getGuildSettingsActivityRoles)
+	g.POST("/api/guild/:guildID/activityroles", setGuildSettingsActivityRoles)
+}
@@ -0,0 +1,125 @@
+package backend
+
+import (
+	"errors"
+	"net/http"
+
+	discord "github.com/WelcomerTeam/Discord/discord"
+	"github.com/WelcomerTeam/Welcomer/welcomer-core"
+	"github.com/WelcomerTeam/Welcomer/welcomer-core/database"
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v4"
+)
+
+// Route GET /api/guild/:guildID/digest.
+func getGuildSettingsDigest(ctx *gin.Context) {
+	requireOAuthAuthorization(ctx, func(ctx *gin.Context) {
+		requireGuildElevation(ctx, func(ctx *gin.Context) {
+			guildID := tryGetGuildID(ctx)
+
+			digest, err := welcomer.Queries.GetDigestGuildSettings(ctx, int64(guildID))
+			if err != nil {
+				if errors.Is(err, pgx.ErrNoRows) {
+					digest = &database.GuildSettingsDigest{
+						GuildID:       int64(guildID),
+						ToggleEnabled: welcomer.DefaultDigest.ToggleEnabled,
+						ChannelDigest: welcomer.DefaultDigest.ChannelDigest,
+						Frequency:     welcomer.DefaultDigest.Frequency,
+					}
+				} else {
+					welcomer.Logger.Warn().Err(err).Int64("guild_id", int64(guildID)).Msg("Failed to get guild digest settings")
+
+					ctx.JSON(http.StatusInternalServerError, NewBaseResponse(NewGenericErrorWithLineNumber(), nil))
+
+					return
+				}
+			}
+
+			partial := GuildSettingsDigestSettingsToPartial(digest)
+
+			ctx.JSON(http.StatusOK, BaseResponse{
+				Ok:   true,
+				Data: partial,
+			})
+		})
+	})
+}
+
+// Route POST /api/guild/:guildID/digest.
+func setGuildSettingsDigest(ctx *gin.Context) {
+	requireOAuthAuthorization(ctx, func(ctx *gin.Context) {
+		requireGuildElevation(ctx, func(ctx *gin.Context) {
+			partial := &GuildSettingsDigest{}
+
+			var err error
+
+			err = ctx.BindJSON(partial)
+			if err != nil {
+				ctx.JSON(http.StatusBadRequest, BaseResponse{
+					Ok:    false,
+					Error: err.Error(),
+				})
+
+				return
+			}
+
+			err = doValidateDigest(partial)
+			if err != nil {
+				ctx.JSON(http.StatusBadRequest, BaseResponse{
+					Ok:    false,
+					Error: err.Error(),
+				})
+
+				return
+			}
+
+			guildID := tryGetGuildID(ctx)
+
+			digest := PartialToGuildSettingsDigestSettings(int64(guildID), partial)
+
+			databaseDigestGuildSettings := database.CreateOrUpdateDigestGuildSettingsParams(*digest)
+
+			user := tryGetUser(ctx)
+			welcomer.Logger.Info().Int64("guild_id", int64(guildID)).Interface("obj", *digest).Int64("user_id", int64(user.ID)).Msg("Creating or updating guild digest settings")
+
+			err = welcomer.RetryWithFallback(
+				func() error {
+					_, err = welcomer.CreateOrUpdateDigestGuildSettingsWithAudit(ctx, databaseDigestGuildSettings, user.ID)
+
+					return err
+				},
+				func() error {
+					return welcomer.EnsureGuild(ctx, discord.Snowflake(guildID))
+				},
+				nil,
+			)
+			if err != nil {
+				welcomer.Logger.Warn().Err(err).Int64("guild_id", int64(guildID)).Msg("Failed to create or update guild digest settings")
+
+				ctx.JSON(http.StatusInternalServerError, NewBaseResponse(NewGenericErrorWithLineNumber(), nil))
+
+				return
+			}
+
+			getGuildSettingsDigest(ctx)
+		})
+	})
+}
+
+// Validates digest settings.
+func doValidateDigest(guildSettings *GuildSettingsDigest) error {
+	if !welcomer.IsValidDigestFrequency(guildSettings.Frequency) {
+		return NewInvalidParameterError("frequency")
+	}
+
+	if guildSettings.ToggleEnabled && welcomer.StringPointerToInt64(guildSettings.ChannelDigest) == 0 {
+		return NewMissingParameterError("channel")
+	}
+
+	return nil
+}
+
+func registerGuildSettingsDigestRoutes(g *gin.Engine) {
+	g.GET("/api/guild/:guildID/digest", getGuildSettingsDigest)
+	g.POST("/api/guild/:guildID/digest", setGuildSettingsDigest)
+}
@@ -3,7 +3,9 @@ package backend
 import (
 	_ "embed"
 	"errors"
+	"fmt"
 	"net/http"
+	"slices"
 
 	discord "github.com/WelcomerTeam/Discord/discord"
 	"github.com/WelcomerTeam/Welcomer/welcomer-core"
@@ -25,6 +27,7 @@ func getGuildSettingsFreeRoles(ctx *gin.Context) {
 						GuildID:       int64(guildID),
 						ToggleEnabled: welcomer.DefaultFreeRoles.ToggleEnabled,
 						Roles:         welcomer.DefaultFreeRoles.Roles,
+						RoleDurations: welcomer.DefaultFreeRoles.RoleDurations,
 					}
 				} else {
 					welcomer.Logger.Warn().Err(err).Int64("guild_id", int64(guildID)).Msg("Failed to get guild freeroles settings")
@@ -108,7 +111,15 @@ func setGuildSettingsFreeRoles(ctx *gin.Context) {
 
 // Validates freerole settings.
 func doValidateFreeRoles(guildSettings *GuildSettingsFreeRoles) error {
-	// TODO: validate freeroles
+	for _, roleDuration := range guildSettings.RoleDurations {
+		if roleDuration.Duration < 0 {
+			return fmt.Errorf("duration for role %d is invalid: %w", roleDuration.RoleID, ErrInvalidParameter)
+		}
+
+		if !slices.Contains(guildSettings.Roles, roleDuration.RoleID.String()) {
+			return fmt.Errorf("role %d is not a freerole: %w", roleDuration.RoleID, ErrInvalidParameter)
+		}
+	}
 
 	return nil
 }
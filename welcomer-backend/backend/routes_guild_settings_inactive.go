@@ -0,0 +1,119 @@
+package backend
+
+import (
+	"errors"
+	"net/http"
+
+	discord "github.com/WelcomerTeam/Discord/discord"
+	"github.com/WelcomerTeam/Welcomer/welcomer-core"
+	"github.com/WelcomerTeam/Welcomer/welcomer-core/database"
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v4"
+)
+
+// Route GET /api/guild/:guildID/inactive.
+func getGuildSettingsInactive(ctx *gin.Context) {
+	requireOAuthAuthorization(ctx, func(ctx *gin.Context) {
+		requireGuildElevation(ctx, func(ctx *gin.Context) {
+			guildID := tryGetGuildID(ctx)
+
+			inactive, err := welcomer.Queries.GetInactiveGuildSettings(ctx, int64(guildID))
+			if err != nil {
+				if errors.Is(err, pgx.ErrNoRows) {
+					inactive = &database.GuildSettingsInactive{
+						GuildID:       int64(guildID),
+						ExcludedRoles: welcomer.DefaultInactive.ExcludedRoles,
+					}
+				} else {
+					welcomer.Logger.Warn().Err(err).Int64("guild_id", int64(guildID)).Msg("Failed to get guild inactive settings")
+
+					ctx.JSON(http.StatusInternalServerError, NewBaseResponse(NewGenericErrorWithLineNumber(), nil))
+
+					return
+				}
+			}
+
+			partial := GuildSettingsInactiveSettingsToPartial(inactive)
+
+			ctx.JSON(http.StatusOK, BaseResponse{
+				Ok:   true,
+				Data: partial,
+			})
+		})
+	})
+}
+
+// Route POST /api/guild/:guildID/inactive.
+func setGuildSettingsInactive(ctx *gin.Context) {
+	requireOAuthAuthorization(ctx, func(ctx *gin.Context) {
+		requireGuildElevation(ctx, func(ctx *gin.Context) {
+			partial := &GuildSettingsInactive{}
+
+			var err error
+
+			err = ctx.BindJSON(partial)
+			if err != nil {
+				ctx.JSON(http.StatusBadRequest, BaseResponse{
+					Ok:    false,
+					Error: err.Error(),
+				})
+
+				return
+			}
+
+			err = doValidateInactive(partial)
+			if err != nil {
+				ctx.JSON(http.StatusBadRequest, BaseResponse{
+					Ok:    false,
+					Error: err.Error(),
+				})
+
+				return
+			}
+
+			guildID := tryGetGuildID(ctx)
+
+			inactive := PartialToGuildSettingsInactiveSettings(int64(guildID), partial)
+
+			databaseInactiveGuildSettings := database.CreateOrUpdateInactiveGuildSettingsParams(*inactive)
+
+			user := tryGetUser(ctx)
+			welcomer.Logger.Info().Int64("guild_id", int64(guildID)).Interface("obj", *inactive).Int64("user_id", int64(user.ID)).Msg("Creating or updating guild inactive settings")
+
+			err = welcomer.RetryWithFallback(
+				func() error {
+					_, err = welcomer.CreateOrUpdateInactiveGuildSettingsWithAudit(ctx, databaseInactiveGuildSettings, user.ID)
+
+					return err
+				},
+				func() error {
+					return welcomer.EnsureGuild(ctx, discord.Snowflake(guildID))
+				},
+				nil,
+			)
+			if err != nil {
+				welcomer.Logger.Warn().Err(err).Int64("guild_id", int64(guildID)).Msg("Failed to create or update guild inactive settings")
+
+				ctx.JSON(http.StatusInternalServerError, NewBaseResponse(NewGenericErrorWithLineNumber(), nil))
+
+				return
+			}
+
+			getGuildSettingsInactive(ctx)
+		})
+	})
+}
+
+// Validates inactive settings.
+func doValidateInactive(guildSettings *GuildSettingsInactive) error {
+	if len(guildSettings.ExcludedRoles) > welcomer.InactiveMaximumExcludedRoles {
+		return NewInvalidParameterError("excluded_roles")
+	}
+
+	return nil
+}
+
+func registerGuildSettingsInactiveRoutes(g *gin.Engine) {
+	g.GET("/api/guild/:guildID/inactive", getGuildSettingsInactive)
+	g.POST("/api/guild/:guildID/inactive", setGuildSettingsInactive)
+}
@@ -0,0 +1,120 @@
+package backend
+
+import (
+	"errors"
+	"net/http"
+
+	discord "github.com/WelcomerTeam/Discord/discord"
+	"github.com/WelcomerTeam/Welcomer/welcomer-core"
+	"github.com/WelcomerTeam/Welcomer/welcomer-core/database"
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v4"
+)
+
+// Route GET /api/guild/:guildID/leaderboard.
+func getGuildSettingsLeaderboard(ctx *gin.Context) {
+	requireOAuthAuthorization(ctx, func(ctx *gin.Context) {
+		requireGuildElevation(ctx, func(ctx *gin.Context) {
+			guildID := tryGetGuildID(ctx)
+
+			leaderboard, err := welcomer.Queries.GetLeaderboardGuildSettings(ctx, int64(guildID))
+			if err != nil {
+				if errors.Is(err, pgx.ErrNoRows) {
+					leaderboard = &database.GuildSettingsLeaderboard{
+						GuildID:           int64(guildID),
+						ToggleIncludeBots: welcomer.DefaultLeaderboard.ToggleIncludeBots,
+						ExcludedChannels:  welcomer.DefaultLeaderboard.ExcludedChannels,
+					}
+				} else {
+					welcomer.Logger.Warn().Err(err).Int64("guild_id", int64(guildID)).Msg("Failed to get guild leaderboard settings")
+
+					ctx.JSON(http.StatusInternalServerError, NewBaseResponse(NewGenericErrorWithLineNumber(), nil))
+
+					return
+				}
+			}
+
+			partial := GuildSettingsLeaderboardSettingsToPartial(leaderboard)
+
+			ctx.JSON(http.StatusOK, BaseResponse{
+				Ok:   true,
+				Data: partial,
+			})
+		})
+	})
+}
+
+// Route POST /api/guild/:guildID/leaderboard.
+func setGuildSettingsLeaderboard(ctx *gin.Context) {
+	requireOAuthAuthorization(ctx, func(ctx *gin.Context) {
+		requireGuildElevation(ctx, func(ctx *gin.Context) {
+			partial := &GuildSettingsLeaderboard{}
+
+			var err error
+
+			err = ctx.BindJSON(partial)
+			if err != nil {
+				ctx.JSON(http.StatusBadRequest, BaseResponse{
+					Ok:    false,
+					Error: err.Error(),
+				})
+
+				return
+			}
+
+			err = doValidateLeaderboard(partial)
+			if err != nil {
+				ctx.JSON(http.StatusBadRequest, BaseResponse{
+					Ok:    false,
+					Error: err.Error(),
+				})
+
+				return
+			}
+
+			guildID := tryGetGuildID(ctx)
+
+			leaderboard := PartialToGuildSettingsLeaderboardSettings(int64(guildID), partial)
+
+			databaseLeaderboardGuildSettings := database.CreateOrUpdateLeaderboardGuildSettingsParams(*leaderboard)
+
+			user := tryGetUser(ctx)
+			welcomer.Logger.Info().Int64("guild_id", int64(guildID)).Interface("obj", *leaderboard).Int64("user_id", int64(user.ID)).Msg("Creating or updating guild leaderboard settings")
+
+			err = welcomer.RetryWithFallback(
+				func() error {
+					_, err = welcomer.CreateOrUpdateLeaderboardGuildSettingsWithAudit(ctx, databaseLeaderboardGuildSettings, user.ID)
+
+					return err
+				},
+				func() error {
+					return welcomer.EnsureGuild(ctx, discord.Snowflake(guildID))
+				},
+				nil,
+			)
+			if err != nil {
+				welcomer.Logger.Warn().Err(err).Int64("guild_id", int64(guildID)).Msg("Failed to create or update guild leaderboard settings")
+
+				ctx.JSON(http.StatusInternalServerError, NewBaseResponse(NewGenericErrorWithLineNumber(), nil))
+
+				return
+			}
+
+			getGuildSettingsLeaderboard(ctx)
+		})
+	})
+}
+
+// Validates leaderboard settings.
+func doValidateLeaderboard(guildSettings *GuildSettingsLeaderboard) error {
+	if len(guildSettings.ExcludedChannels) > welcomer.LeaderboardMaximumExcludedChannels {
+		return NewInvalidParameterError("excluded_channels")
+	}
+
+	return nil
+}
+
+func registerGuildSettingsLeaderboardRoutes(g *gin.Engine) {
+	g.GET("/api/guild/:guildID/leaderboard", getGuildSettingsLeaderboard)
+	g.POST("/api/guild/:guildID/leaderboard", setGuildSettingsLeaderboard)
+}
@@ -7,6 +7,9 @@ import (
 	"fmt"
 	"net/http"
 	"slices"
+	"strconv"
+	"strings"
+	"time"
 
 	discord "github.com/WelcomerTeam/Discord/discord"
 	sandwich "github.com/WelcomerTeam/Sandwich-Daemon/proto"
@@ -148,6 +151,132 @@ type reactionRoleConfigurations struct {
 	NewIndex int
 }
 
+// Route GET /api/guild/:guildID/reactionroles/:reactionRoleID/stats.
+func getGuildSettingsReactionRoleStats(ctx *gin.Context) {
+	requireOAuthAuthorization(ctx, func(ctx *gin.Context) {
+		requireGuildElevation(ctx, func(ctx *gin.Context) {
+			guildID := tryGetGuildID(ctx)
+
+			reactionRoleID, err := uuid.FromString(ctx.Param("reactionRoleID"))
+			if err != nil {
+				ctx.JSON(http.StatusBadRequest, NewBaseResponse(NewInvalidParameterError("reactionRoleID"), nil))
+
+				return
+			}
+
+			days, err := strconv.Atoi(ctx.DefaultQuery("days", strconv.Itoa(ReactionRoleStatsDefaultDays)))
+			if err != nil || days < 1 || days > ReactionRoleStatsMaximumDays {
+				ctx.JSON(http.StatusBadRequest, NewBaseResponse(NewInvalidParameterError("days"), nil))
+
+				return
+			}
+
+			bucket := ctx.DefaultQuery("bucket", "day")
+			if bucket != "hour" && bucket != "day" && bucket != "week" {
+				ctx.JSON(http.StatusBadRequest, NewBaseResponse(NewInvalidParameterError("bucket"), nil))
+
+				return
+			}
+
+			reactionRole, err := welcomer.Queries.GetReactionRoleSettingById(ctx, database.GetReactionRoleSettingByIdParams{
+				ReactionRoleID: reactionRoleID,
+				GuildID:        int64(guildID),
+			})
+			if err != nil {
+				if errors.Is(err, pgx.ErrNoRows) {
+					ctx.JSON(http.StatusNotFound, NewBaseResponse(NewInvalidParameterError("reactionRoleID"), nil))
+
+					return
+				}
+
+				welcomer.Logger.Warn().Err(err).Int64("guild_id", int64(guildID)).Str("reaction_role_id", reactionRoleID.String()).Msg("Failed to get reaction role setting")
+
+				ctx.JSON(http.StatusInternalServerError, NewBaseResponse(NewGenericErrorWithLineNumber(), nil))
+
+				return
+			}
+
+			// Holders are counted from the roles members currently have, so members
+			// who left or had the role removed by other means are not included.
+			_, err = welcomer.SandwichClient.RequestGuildChunk(ctx, &sandwich.RequestGuildChunkRequest{
+				GuildId: int64(guildID),
+			})
+			if err != nil {
+				welcomer.Logger.Warn().Err(err).Int64("guild_id", int64(guildID)).Msg("Failed to request guild chunk for reaction role stats")
+			}
+
+			guildMembers, err := welcomer.SandwichClient.FetchGuildMember(ctx, &sandwich.FetchGuildMemberRequest{
+				GuildId: int64(guildID),
+			})
+			if err != nil {
+				welcomer.Logger.Warn().Err(err).Int64("guild_id", int64(guildID)).Msg("Failed to fetch guild members for reaction role stats")
+
+				ctx.JSON(http.StatusInternalServerError, NewBaseResponse(NewGenericErrorWithLineNumber(), nil))
+
+				return
+			}
+
+			since := time.Now().UTC().AddDate(0, 0, -days)
+
+			eventCounts, err := welcomer.Queries.GetReactionRoleEventCounts(ctx, database.GetReactionRoleEventCountsParams{
+				Bucket:                                   bucket,
+				ScienceGuildEventTypeReactionRoleGiven:   int32(database.ScienceGuildEventTypeReactionRoleGiven),
+				ScienceGuildEventTypeReactionRoleRemoved: int32(database.ScienceGuildEventTypeReactionRoleRemoved),
+				GuildID:                                  int64(guildID),
+				ReactionRoleUuid:                         reactionRoleID.String(),
+				Since:                                    since,
+			})
+			if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+				welcomer.Logger.Warn().Err(err).Int64("guild_id", int64(guildID)).Str("reaction_role_id", reactionRoleID.String()).Msg("Failed to get reaction role event counts")
+
+				ctx.JSON(http.StatusInternalServerError, NewBaseResponse(NewGenericErrorWithLineNumber(), nil))
+
+				return
+			}
+
+			options := welcomer.UnmarshalReactionRolesJSON(welcomer.JSONBToBytes(reactionRole.Roles))
+
+			stats := GuildReactionRoleStats{
+				Bucket:  bucket,
+				Since:   since,
+				Options: make([]GuildReactionRoleOptionStats, len(options)),
+				Series:  make([]GuildReactionRoleStatsPoint, 0, len(eventCounts)),
+			}
+
+			optionIndex := make(map[discord.Snowflake]int, len(options))
+
+			for i, option := range options {
+				stats.Options[i].RoleID = option.RoleID
+				optionIndex[option.RoleID] = i
+			}
+
+			for _, guildMember := range guildMembers.GetGuildMembers() {
+				for _, roleID := range guildMember.GetRoles() {
+					if i, ok := optionIndex[discord.Snowflake(roleID)]; ok {
+						stats.Options[i].Holders++
+					}
+				}
+			}
+
+			for _, eventCount := range eventCounts {
+				if i, ok := optionIndex[discord.Snowflake(eventCount.RoleID)]; ok {
+					stats.Options[i].Adds += eventCount.Adds
+					stats.Options[i].Removes += eventCount.Removes
+				}
+
+				stats.Series = append(stats.Series, GuildReactionRoleStatsPoint{
+					Timestamp: eventCount.BucketTs,
+					RoleID:    discord.Snowflake(eventCount.RoleID),
+					Adds:      eventCount.Adds,
+					Removes:   eventCount.Removes,
+				})
+			}
+
+			ctx.JSON(http.StatusOK, NewBaseResponse(nil, stats))
+		})
+	})
+}
+
 func processReactionRolesSettingsChange(ctx *gin.Context, old, new *GuildSettingsReactionRoles) *welcomer.ErrorGroup {
 	eg := welcomer.NewErrorGroup()
 
@@ -623,64 +752,338 @@ func checkGuildSettingsReactionRolesMessage(ctx *gin.Context) {
 			channelID := tryGetSnowflakeFromCtx(ctx, "channelID")
 			messageID := tryGetSnowflakeFromCtx(ctx, "messageID")
 
-			channel, err := welcomer.SandwichClient.FetchGuildChannel(ctx, &sandwich.FetchGuildChannelRequest{
-				GuildId:    int64(guildID),
-				ChannelIds: []int64{int64(channelID)},
-			})
+			_, err := checkReactionRoleMessage(ctx, guildID, channelID, messageID)
 			if err != nil {
-				welcomer.Logger.Warn().Err(err).Int64("guild_id", int64(guildID)).Int64("channel_id", int64(channelID)).Msg("Failed to fetch channel for reaction roles")
+				if isReactionRoleMessageCheckError(err) {
+					ctx.JSON(http.StatusBadRequest, BaseResponse{
+						Ok:    false,
+						Error: err.Error(),
+					})
+
+					return
+				}
 
 				ctx.JSON(http.StatusInternalServerError, NewGenericErrorWithLineNumber())
 
 				return
 			}
 
-			if len(channel.Channels) == 0 {
-				welcomer.Logger.Warn().Int64("guild_id", int64(guildID)).Int64("channel_id", int64(channelID)).Msg("Channel not found for reaction roles")
+			ctx.JSON(http.StatusOK, BaseResponse{
+				Ok: true,
+			})
+		})
+	})
+}
+
+var (
+	ErrReactionRoleChannelNotFound   = errors.New("The channel specified was not found in this server.")
+	ErrReactionRoleMessageNotFound   = errors.New("Failed to get message. Make sure the message exists and the bot has access to it.")
+	ErrReactionRoleMissingReactPerms = errors.New("Failed to add reaction to message. Make sure the bot has permission to add reactions in the specified channel.")
+)
+
+func isReactionRoleMessageCheckError(err error) bool {
+	return errors.Is(err, ErrReactionRoleChannelNotFound) || errors.Is(err, ErrReactionRoleMessageNotFound) || errors.Is(err, ErrReactionRoleMissingReactPerms)
+}
+
+// checkReactionRoleMessage checks a message exists in the guild and that the bot can react to it.
+func checkReactionRoleMessage(ctx context.Context, guildID, channelID, messageID discord.Snowflake) (*discord.Message, error) {
+	message, err := fetchReactionRoleMessage(ctx, guildID, channelID, messageID)
+	if err != nil {
+		return nil, err
+	}
+
+	emoji := "🎉"
+
+	err = message.AddReaction(ctx, backend.BotSession, emoji)
+	if err != nil {
+		welcomer.Logger.Warn().Err(err).Int64("guild_id", int64(guildID)).Int64("channel_id", int64(channelID)).Int64("message_id", int64(messageID)).Msg("Failed to add reaction to message for reaction roles")
+
+		return nil, ErrReactionRoleMissingReactPerms
+	}
+
+	_ = discord.DeleteOwnReaction(ctx, backend.BotSession, message.ChannelID, message.ID, emoji)
+
+	return message, nil
+}
+
+// fetchReactionRoleMessage fetches a message in a channel of the guild.
+func fetchReactionRoleMessage(ctx context.Context, guildID, channelID, messageID discord.Snowflake) (*discord.Message, error) {
+	channel, err := welcomer.SandwichClient.FetchGuildChannel(ctx, &sandwich.FetchGuildChannelRequest{
+		GuildId:    int64(guildID),
+		ChannelIds: []int64{int64(channelID)},
+	})
+	if err != nil {
+		welcomer.Logger.Warn().Err(err).Int64("guild_id", int64(guildID)).Int64("channel_id", int64(channelID)).Msg("Failed to fetch channel for reaction roles")
+
+		return nil, err
+	}
 
+	if len(channel.Channels) == 0 {
+		welcomer.Logger.Warn().Int64("guild_id", int64(guildID)).Int64("channel_id", int64(channelID)).Msg("Channel not found for reaction roles")
+
+		return nil, ErrReactionRoleChannelNotFound
+	}
+
+	message, err := discord.GetChannelMessage(ctx, backend.BotSession, channelID, messageID)
+	if err != nil {
+		welcomer.Logger.Warn().Err(err).Int64("guild_id", int64(guildID)).Int64("channel_id", int64(channelID)).Int64("message_id", int64(messageID)).Msg("Failed to get message for reaction roles")
+
+		return nil, ErrReactionRoleMessageNotFound
+	}
+
+	return message, nil
+}
+
+// Route GET /api/guild/:guildID/reactionroles/export.
+func exportGuildSettingsReactionRoles(ctx *gin.Context) {
+	requireOAuthAuthorization(ctx, func(ctx *gin.Context) {
+		requireGuildElevation(ctx, func(ctx *gin.Context) {
+			guildID := tryGetGuildID(ctx)
+
+			reactionroles, err := welcomer.Queries.GetReactionRoleSettingByGuildId(ctx, int64(guildID))
+			if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+				welcomer.Logger.Warn().Err(err).Int64("guild_id", int64(guildID)).Msg("Failed to get guild reaction roles settings for export")
+
+				ctx.JSON(http.StatusInternalServerError, NewBaseResponse(NewGenericErrorWithLineNumber(), nil))
+
+				return
+			}
+
+			ctx.Header("Content-Disposition", fmt.Sprintf("attachment; filename=\"reaction_roles_%d.json\"", guildID))
+
+			ctx.JSON(http.StatusOK, GuildSettingsReactionRolesSettingsToPartial(reactionroles))
+		})
+	})
+}
+
+// Route POST /api/guild/:guildID/reactionroles/import.
+func importGuildSettingsReactionRoles(ctx *gin.Context) {
+	requireOAuthAuthorization(ctx, func(ctx *gin.Context) {
+		requireGuildElevation(ctx, func(ctx *gin.Context) {
+			payload := &GuildSettingsReactionRolesImport{}
+
+			err := ctx.BindJSON(payload)
+			if err != nil {
 				ctx.JSON(http.StatusBadRequest, BaseResponse{
 					Ok:    false,
-					Error: "The channel specified was not found in this server.",
+					Error: err.Error(),
 				})
 
 				return
 			}
 
-			message, err := discord.GetChannelMessage(ctx, backend.BotSession, channelID, messageID)
-			if err != nil {
-				welcomer.Logger.Warn().Err(err).Int64("guild_id", int64(guildID)).Int64("channel_id", int64(channelID)).Int64("message_id", int64(messageID)).Msg("Failed to get message for reaction roles")
+			guildID := tryGetGuildID(ctx)
 
+			errGroup := doValidateReactionRolesAssignable(ctx, guildID, payload.ReactionRoles)
+			if errGroup != nil && !errGroup.Empty() {
 				ctx.JSON(http.StatusBadRequest, BaseResponse{
 					Ok:    false,
-					Error: "Failed to get message. Make sure the message exists and the bot has access to it.",
+					Error: errGroup.ErrorWithDelimiter("\n"),
 				})
 
 				return
 			}
 
-			emoji := "🎉"
+			result := GuildSettingsReactionRolesImportResult{}
 
-			err = message.AddReaction(ctx, backend.BotSession, emoji)
-			if err != nil {
-				welcomer.Logger.Warn().Err(err).Int64("guild_id", int64(guildID)).Int64("channel_id", int64(channelID)).Int64("message_id", int64(messageID)).Msg("Failed to add reaction to message for reaction roles")
+			imported := make([]welcomer.GuildSettingsReactionRole, 0, len(payload.ReactionRoles))
+
+			for i, reactionRole := range payload.ReactionRoles {
+				// Imported configurations always get new IDs so they cannot overwrite existing ones.
+				reactionRole.ReactionRoleID = uuid.Must(gen.NewV7())
+
+				if payload.AdoptExistingMessages && canAdoptReactionRoleMessage(ctx, guildID, &reactionRole) {
+					reactionRole.IsSystemMessage = false
+
+					result.Adopted++
+
+					imported = append(imported, reactionRole)
+
+					continue
+				}
+
+				// Any message that cannot be adopted is posted again by Welcomer,
+				// which needs the message to post.
+				if reactionRole.Message == "" {
+					result.Skipped++
+					result.Errors = append(result.Errors, fmt.Sprintf("Reaction role %d was skipped as its message could not be adopted and it has no message to post.", i+1))
+
+					continue
+				}
+
+				reactionRole.IsSystemMessage = true
+				reactionRole.MessageID = 0
+
+				if reactionRole.Enabled {
+					result.Reposted++
+				}
+
+				imported = append(imported, reactionRole)
+			}
+
+			payload.ReactionRoles = imported
+
+			oldReactionRoleSettings, err := welcomer.Queries.GetReactionRoleSettingByGuildId(ctx, int64(guildID))
+			if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+				welcomer.Logger.Warn().Err(err).Int64("guild_id", int64(guildID)).Msg("Failed to get existing guild reaction roles settings for import")
+
+				ctx.JSON(http.StatusInternalServerError, NewBaseResponse(NewGenericErrorWithLineNumber(), nil))
+
+				return
+			}
+
+			old := GuildSettingsReactionRolesSettingsToPartial(oldReactionRoleSettings)
+
+			partial := &GuildSettingsReactionRoles{}
+
+			if !payload.ReplaceExisting {
+				partial.ReactionRoles = append(partial.ReactionRoles, old.ReactionRoles...)
+			}
+
+			partial.ReactionRoles = append(partial.ReactionRoles, payload.ReactionRoles...)
 
+			errGroup = doValidateReactionRoles(ctx, guildID, partial)
+			if errGroup != nil && !errGroup.Empty() {
 				ctx.JSON(http.StatusBadRequest, BaseResponse{
 					Ok:    false,
-					Error: "Failed to add reaction to message. Make sure the bot has permission to add reactions in the specified channel.",
+					Error: errGroup.ErrorWithDelimiter("\n"),
 				})
 
 				return
 			}
 
-			_ = discord.DeleteOwnReaction(ctx, backend.BotSession, message.ChannelID, message.ID, emoji)
+			user := tryGetUser(ctx)
 
-			ctx.JSON(http.StatusOK, BaseResponse{
-				Ok: true,
-			})
+			reactionroles := PartialToGuildSettingsReactionRolesSettings(int64(guildID), partial)
+
+			err = welcomer.RetryWithFallback(
+				func() error {
+					eg := welcomer.CreateOrUpdateReactionRolesGuildSettingsWithAudit(ctx, guildID, reactionroles, user.ID)
+
+					if eg != nil {
+						return eg.AsStandardError()
+					}
+
+					return nil
+				},
+				func() error {
+					return welcomer.EnsureGuild(ctx, guildID)
+				},
+				nil,
+			)
+			if err != nil {
+				welcomer.Logger.Warn().Err(err).Int64("guild_id", int64(guildID)).Int64("user_id", int64(user.ID)).Msg("Failed to import guild reaction roles settings")
+
+				ctx.JSON(http.StatusInternalServerError, NewBaseResponse(NewGenericErrorWithLineNumber(), nil))
+
+				return
+			}
+
+			eg := processReactionRolesSettingsChange(ctx, old, partial)
+			if eg != nil && !eg.Empty() {
+				welcomer.Logger.Warn().Err(eg).Int64("guild_id", int64(guildID)).Int64("user_id", int64(user.ID)).Msg("Failed to process imported reaction roles settings")
+
+				result.Errors = append(result.Errors, strings.Split(eg.ErrorWithDelimiter("\n"), "\n")...)
+			}
+
+			welcomer.Logger.Info().Int64("guild_id", int64(guildID)).Int("adopted", result.Adopted).Int("reposted", result.Reposted).Int("skipped", result.Skipped).Int64("user_id", int64(user.ID)).Msg("Imported reaction roles guild settings")
+
+			reactionRoleSettings, err := welcomer.Queries.GetReactionRoleSettingByGuildId(ctx, int64(guildID))
+			if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+				welcomer.Logger.Warn().Err(err).Int64("guild_id", int64(guildID)).Msg("Failed to get guild reaction roles settings after import")
+
+				ctx.JSON(http.StatusInternalServerError, NewBaseResponse(NewGenericErrorWithLineNumber(), nil))
+
+				return
+			}
+
+			result.ReactionRoles = GuildSettingsReactionRolesSettingsToPartial(reactionRoleSettings).ReactionRoles
+
+			ctx.JSON(http.StatusOK, NewBaseResponse(nil, result))
 		})
 	})
 }
 
+// canAdoptReactionRoleMessage returns true if an imported emoji reaction role points at an
+// existing message that already has a reaction for every option.
+func canAdoptReactionRoleMessage(ctx context.Context, guildID discord.Snowflake, reactionRole *welcomer.GuildSettingsReactionRole) bool {
+	if reactionRole.Type != welcomer.ReactionRoleTypeEmoji || reactionRole.ChannelID.IsNil() || reactionRole.MessageID.IsNil() {
+		return false
+	}
+
+	// Adopting only needs the message and the reactions already on it, so it
+	// is fetched rather than checked by reacting to it.
+	message, err := fetchReactionRoleMessage(ctx, guildID, reactionRole.ChannelID, reactionRole.MessageID)
+	if err != nil {
+		return false
+	}
+
+	for _, option := range reactionRole.Roles {
+		found := false
+
+		for _, reaction := range message.Reactions {
+			if option.Emoji == reaction.Emoji.Name || option.Emoji == reaction.Emoji.ID.String() {
+				found = true
+
+				break
+			}
+		}
+
+		if !found {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Validate that Welcomer can assign every role used by the reaction roles.
+func doValidateReactionRolesAssignable(ctx context.Context, guildID discord.Snowflake, reactionRoles []welcomer.GuildSettingsReactionRole) *welcomer.ErrorGroup {
+	errorGroup := welcomer.NewErrorGroup()
+
+	var roleIDs []int64
+
+	for _, reactionRole := range reactionRoles {
+		for _, option := range reactionRole.Roles {
+			roleIDs = append(roleIDs, int64(option.RoleID))
+		}
+	}
+
+	if len(roleIDs) == 0 {
+		return nil
+	}
+
+	botID, err := getBotID(ctx)
+	if err != nil {
+		errorGroup.Add(fmt.Errorf("failed to get bot: %v", err))
+
+		return errorGroup
+	}
+
+	assignableRoles, err := welcomer.FilterAssignableRoles(ctx, welcomer.SandwichClient, int64(guildID), int64(botID), roleIDs)
+	if err != nil {
+		errorGroup.Add(fmt.Errorf("failed to check role assignability: %v", err))
+
+		return errorGroup
+	}
+
+	for reactionRoleIndex, reactionRole := range reactionRoles {
+		for j, option := range reactionRole.Roles {
+			if !slices.ContainsFunc(assignableRoles, func(role discord.Role) bool {
+				return role.ID == option.RoleID
+			}) {
+				errorGroup.Add(fmt.Errorf("reaction role %d: option %d: role is not assignable by Welcomer", reactionRoleIndex+1, j+1))
+			}
+		}
+	}
+
+	if errorGroup.Empty() {
+		return nil
+	}
+
+	return errorGroup
+}
+
 // Validates if string is a valid emoji. This can be a snowflake or a unicode emoji.
 func isValidEmoji(emoji string) bool {
 	if _, err := welcomer.Atoi(emoji); err == nil {
@@ -845,6 +1248,35 @@ func doValidateReactionRoles(ctx context.Context, guildID discord.Snowflake, par
 				}
 			}
 		}
+
+		for j, option := range reactionRole.Roles {
+			if option.Duration < 0 {
+				errorGroup.Add(fmt.Errorf("reaction role %d: option %d: duration cannot be negative", reactionRoleIndex+1, j+1))
+			}
+		}
+
+		// Check constraints
+		if reactionRole.MaxSelections < 0 {
+			errorGroup.Add(fmt.Errorf("reaction role %d: maximum selections cannot be negative", reactionRoleIndex+1))
+		} else if int(reactionRole.MaxSelections) > len(reactionRole.Roles) {
+			errorGroup.Add(fmt.Errorf("reaction role %d: maximum selections cannot be more than the number of options", reactionRoleIndex+1))
+		}
+
+		if reactionRole.UniqueSelection {
+			if reactionRole.MaxSelections > 1 {
+				errorGroup.Add(fmt.Errorf("reaction role %d: maximum selections cannot be set when unique mode is enabled", reactionRoleIndex+1))
+			}
+
+			if reactionRole.VerifyOnly {
+				errorGroup.Add(fmt.Errorf("reaction role %d: unique mode cannot be used with verify-only mode as roles are never removed", reactionRoleIndex+1))
+			}
+		}
+
+		if reactionRole.RequiredRoleID != 0 && slices.ContainsFunc(reactionRole.Roles, func(option welcomer.ReactionRoleOption) bool {
+			return option.RoleID == reactionRole.RequiredRoleID
+		}) {
+			errorGroup.Add(fmt.Errorf("reaction role %d: required role cannot also be an option", reactionRoleIndex+1))
+		}
 	}
 
 	if errorGroup.Empty() {
@@ -857,6 +1289,9 @@ func doValidateReactionRoles(ctx context.Context, guildID discord.Snowflake, par
 func registerGuildSettingsReactionRolesRoutes(g *gin.Engine) {
 	g.GET("/api/guild/:guildID/reactionroles", getGuildSettingsReactionRoles)
 	g.POST("/api/guild/:guildID/reactionroles", setGuildSettingsReactionRoles)
+	g.GET("/api/guild/:guildID/reactionroles/export", exportGuildSettingsReactionRoles)
+	g.POST("/api/guild/:guildID/reactionroles/import", importGuildSettingsReactionRoles)
+	g.GET("/api/guild/:guildID/reactionroles/:reactionRoleID/stats", getGuildSettingsReactionRoleStats)
 
 	g.POST("/api/guild/:guildID/checkmessage/:channelID/:messageID", checkGuildSettingsReactionRolesMessage)
 }
@@ -0,0 +1,120 @@
+package backend
+
+import (
+	"errors"
+	"net/http"
+
+	discord "github.com/WelcomerTeam/Discord/discord"
+	"github.com/WelcomerTeam/Welcomer/welcomer-core"
+	"github.com/WelcomerTeam/Welcomer/welcomer-core/database"
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v4"
+)
+
+// Route GET /api/guild/:guildID/retention.
+func getGuildSettingsRetention(ctx *gin.Context) {
+	requireOAuthAuthorization(ctx, func(ctx *gin.Context) {
+		requireGuildElevation(ctx, func(ctx *gin.Context) {
+			guildID := tryGetGuildID(ctx)
+
+			retention, err := welcomer.Queries.GetRetentionGuildSettings(ctx, int64(guildID))
+			if err != nil {
+				if errors.Is(err, pgx.ErrNoRows) {
+					retention = &database.GuildSettingsRetention{
+						GuildID:            int64(guildID),
+						ToggleWeeklyReport: welcomer.DefaultRetention.ToggleWeeklyReport,
+						ChannelReport:      welcomer.DefaultRetention.ChannelReport,
+					}
+				} else {
+					welcomer.Logger.Warn().Err(err).Int64("guild_id", int64(guildID)).Msg("Failed to get guild retention settings")
+
+					ctx.JSON(http.StatusInternalServerError, NewBaseResponse(NewGenericErrorWithLineNumber(), nil))
+
+					return
+				}
+			}
+
+			partial := GuildSettingsRetentionSettingsToPartial(retention)
+
+			ctx.JSON(http.StatusOK, BaseResponse{
+				Ok:   true,
+				Data: partial,
+			})
+		})
+	})
+}
+
+// Route POST /api/guild/:guildID/retention.
+func setGuildSettingsRetention(ctx *gin.Context) {
+	requireOAuthAuthorization(ctx, func(ctx *gin.Context) {
+		requireGuildElevation(ctx, func(ctx *gin.Context) {
+			partial := &GuildSettingsRetention{}
+
+			var err error
+
+			err = ctx.BindJSON(partial)
+			if err != nil {
+				ctx.JSON(http.StatusBadRequest, BaseResponse{
+					Ok:    false,
+					Error: err.Error(),
+				})
+
+				return
+			}
+
+			err = doValidateRetention(partial)
+			if err != nil {
+				ctx.JSON(http.StatusBadRequest, BaseResponse{
+					Ok:    false,
+					Error: err.Error(),
+				})
+
+				return
+			}
+
+			guildID := tryGetGuildID(ctx)
+
+			retention := PartialToGuildSettingsRetentionSettings(int64(guildID), partial)
+
+			databaseRetentionGuildSettings := database.CreateOrUpdateRetentionGuildSettingsParams(*retention)
+
+			user := tryGetUser(ctx)
+			welcomer.Logger.Info().Int64("guild_id", int64(guildID)).Interface("obj", *retention).Int64("user_id", int64(user.ID)).Msg("Creating or updating guild retention settings")
+
+			err = welcomer.RetryWithFallback(
+				func() error {
+					_, err = welcomer.CreateOrUpdateRetentionGuildSettingsWithAudit(ctx, databaseRetentionGuildSettings, user.ID)
+
+					return err
+				},
+				func() error {
+					return welcomer.EnsureGuild(ctx, discord.Snowflake(guildID))
+				},
+				nil,
+			)
+			if err != nil {
+				welcomer.Logger.Warn().Err(err).Int64("guild_id", int64(guildID)).Msg("Failed to create or update guild retention settings")
+
+				ctx.JSON(http.StatusInternalServerError, NewBaseResponse(NewGenericErrorWithLineNumber(), nil))
+
+				return
+			}
+
+			getGuildSettingsRetention(ctx)
+		})
+	})
+}
+
+// Validates retention settings.
+func doValidateRetention(guildSettings *GuildSettingsRetention) error {
+	if guildSettings.ToggleWeeklyReport && welcomer.StringPointerToInt64(guildSettings.ChannelReport) == 0 {
+		return NewMissingParameterError("channel")
+	}
+
+	return nil
+}
+
+func registerGuildSettingsRetentionRoutes(g *gin.Engine) {
+	g.GET("/api/guild/:guildID/retention", getGuildSettingsRetention)
+	g.POST("/api/guild/:guildID/retention", setGuildSettingsRetention)
+}
@@ -3,8 +3,10 @@ package backend
 import (
 	_ "embed"
 	"errors"
+	"fmt"
 	"net/http"
 
+	"github.com/WelcomerTeam/Discord/discord"
 	"github.com/WelcomerTeam/Welcomer/welcomer-core"
 	"github.com/WelcomerTeam/Welcomer/welcomer-core/database"
 	"github.com/gin-gonic/gin"
@@ -27,6 +29,7 @@ func getGuildSettingsTempChannels(ctx *gin.Context) {
 						ChannelLobby:     welcomer.DefaultTempChannels.ChannelLobby,
 						ChannelCategory:  welcomer.DefaultTempChannels.ChannelCategory,
 						DefaultUserCount: welcomer.DefaultTempChannels.DefaultUserCount,
+						Lobbies:          welcomer.DefaultTempChannels.Lobbies,
 					}
 				} else {
 					welcomer.Logger.Warn().Err(err).Int64("guild_id", int64(guildID)).Msg("Failed to get guild tempchannels settings")
@@ -110,7 +113,35 @@ func setGuildSettingsTempChannels(ctx *gin.Context) {
 
 // Validates tempchannel settings.
 func doValidateTempChannels(guildSettings *GuildSettingsTempChannels) error {
-	// TODO: validate tempchannels
+	lobbyIDs := make(map[discord.Snowflake]bool, len(guildSettings.Lobbies))
+
+	for i, lobby := range guildSettings.Lobbies {
+		if lobby.ChannelID.IsNil() {
+			return fmt.Errorf("lobby %d: %w", i+1, NewMissingParameterError("channel_id"))
+		}
+
+		if lobbyIDs[lobby.ChannelID] {
+			return fmt.Errorf("lobby %d: %w", i+1, NewInvalidParameterError("channel_id"))
+		}
+
+		lobbyIDs[lobby.ChannelID] = true
+
+		if lobby.CategoryID.IsNil() {
+			return fmt.Errorf("lobby %d: %w", i+1, NewMissingParameterError("category_id"))
+		}
+
+		if len(lobby.NameTemplate) > TempChannelNameTemplateMaxLength {
+			return fmt.Errorf("lobby %d: %w", i+1, NewInvalidParameterError("name_template"))
+		}
+
+		if lobby.UserLimit < 0 || lobby.UserLimit > TempChannelUserLimitMaximum {
+			return fmt.Errorf("lobby %d: %w", i+1, NewInvalidParameterError("user_limit"))
+		}
+
+		if lobby.Bitrate != 0 && (lobby.Bitrate < TempChannelBitrateMinimum || lobby.Bitrate > TempChannelBitrateMaximum) {
+			return fmt.Errorf("lobby %d: %w", i+1, NewInvalidParameterError("bitrate"))
+		}
+	}
 
 	return nil
 }
@@ -25,6 +25,7 @@ func getGuildSettingsTimeRoles(ctx *gin.Context) {
 						GuildID:       int64(guildID),
 						ToggleEnabled: welcomer.DefaultTimeRoles.ToggleEnabled,
 						Timeroles:     welcomer.DefaultTimeRoles.Timeroles,
+						ToggleLadder:  welcomer.DefaultTimeRoles.ToggleLadder,
 					}
 				} else {
 					welcomer.Logger.Warn().Err(err).Int64("guild_id", int64(guildID)).Msg("Failed to get guild timeroles settings")
@@ -82,9 +83,16 @@ func setGuildSettingsTimeRoles(ctx *gin.Context) {
 			user := tryGetUser(ctx)
 			welcomer.Logger.Info().Int64("guild_id", int64(guildID)).Interface("obj", *timeroles).Int64("user_id", int64(user.ID)).Msg("Creating or updating guild timeroles settings")
 
+			oldTimeRoles := &database.GuildSettingsTimeroles{}
+			if existing, err := welcomer.Queries.GetTimeRolesGuildSettings(ctx, int64(guildID)); err == nil {
+				oldTimeRoles = existing
+			}
+
+			var newTimeRoles *database.GuildSettingsTimeroles
+
 			err = welcomer.RetryWithFallback(
 				func() error {
-					_, err = welcomer.CreateOrUpdateTimeRolesGuildSettingsWithAudit(ctx, databaseTimeRolesGuildSettings, user.ID)
+					newTimeRoles, err = welcomer.CreateOrUpdateTimeRolesGuildSettingsWithAudit(ctx, databaseTimeRolesGuildSettings, user.ID)
 
 					return err
 				},
@@ -101,6 +109,8 @@ func setGuildSettingsTimeRoles(ctx *gin.Context) {
 				return
 			}
 
+			welcomer.SyncTimeRolesGuildSettings(ctx, oldTimeRoles, newTimeRoles)
+
 			getGuildSettingsTimeRoles(ctx)
 		})
 	})
@@ -0,0 +1,266 @@
+package backend
+
+import (
+	"time"
+
+	discord "github.com/WelcomerTeam/Discord/discord"
+	"github.com/WelcomerTeam/Welcomer/welcomer-core"
+	"github.com/WelcomerTeam/Welcomer/welcomer-core/database"
+	"github.com/gofrs/uuid"
+	"github.com/jackc/pgtype"
+)
+
+const (
+	GiveawayEntriesDefaultLimit = 50
+	GiveawayEntriesMaximumLimit = 500
+)
+
+type GuildGiveaway struct {
+	GiveawayUUID uuid.UUID         `json:"giveaway_uuid"`
+	CreatedAt    time.Time         `json:"created_at"`
+	CreatedBy    discord.Snowflake `json:"created_by"`
+
+	IsSetup      bool `json:"is_setup"`
+	HasEnded     bool `json:"has_ended"`
+	AllowEntries bool `json:"allow_entries"`
+
+	ChannelID discord.Snowflake `json:"channel_id"`
+	MessageID discord.Snowflake `json:"message_id"`
+
+	// StartTime and EndTime are only set once the giveaway has started.
+	StartTime *time.Time `json:"start_time,omitempty"`
+	EndTime   *time.Time `json:"end_time,omitempty"`
+
+	Entries int32 `json:"entries"`
+
+	GuildGiveawayPayload
+}
+
+type GuildGiveawayPayload struct {
+	Title        string                   `json:"title"`
+	Description  string                   `json:"description"`
+	AccentColour int64                    `json:"accent_colour"`
+	ImageURL     string                   `json:"image_url"`
+	ShowPrizes   bool                     `json:"show_prizes"`
+	ShowEntries  bool                     `json:"show_entries"`
+	Prizes       []welcomer.GiveawayPrize `json:"prizes"`
+
+	// Duration is how long the giveaway runs for in seconds. The giveaway
+	// runs until it is ended manually when this is 0.
+	Duration int64 `json:"duration"`
+
+	AnnounceWinners bool  `json:"announce_winners"`
+	ProvablyFair    bool  `json:"provably_fair"`
+	ClaimWindow     int64 `json:"claim_window"`
+
+	RolesAllowed        []discord.Snowflake `json:"roles_allowed"`
+	RolesExcluded       []discord.Snowflake `json:"roles_excluded"`
+	MinimumJoinDate     int64               `json:"minimum_join_date"`
+	MinimumAccountAge   int64               `json:"minimum_account_age"`
+	MinimumMessageCount int32               `json:"minimum_message_count"`
+	MinimumVoiceMinutes int32               `json:"minimum_voice_minutes"`
+	ActivityPeriodDays  int32               `json:"activity_period_days"`
+
+	RecurrenceRule string `json:"recurrence_rule"`
+}
+
+type GuildGiveawayStartPayload struct {
+	ChannelID   discord.Snowflake `json:"channel_id"`
+	StartDelay  int64             `json:"start_delay"`
+	PingContent string            `json:"ping_content"`
+}
+
+type GuildGiveawayEditPayload struct {
+	GuildGiveawayPayload
+
+	// AllowEntries and EndTime can be changed after a giveaway has started.
+	// They are left unchanged when they are not provided. ClearEndTime removes
+	// the end time, so the giveaway runs until it is ended manually.
+	AllowEntries *bool      `json:"allow_entries,omitempty"`
+	EndTime      *time.Time `json:"end_time,omitempty"`
+	ClearEndTime bool       `json:"clear_end_time,omitempty"`
+}
+
+type GuildGiveawayRerollPayload struct {
+	GiveawayWinnerUUID uuid.UUID `json:"giveaway_winner_uuid"`
+}
+
+type GuildGiveawayEntry struct {
+	UserID    discord.Snowflake `json:"user_id"`
+	CreatedAt time.Time         `json:"created_at"`
+}
+
+type GuildGiveawayEntriesResponse struct {
+	Entries []GuildGiveawayEntry `json:"entries"`
+	Total   int32                `json:"total"`
+	Page    int                  `json:"page"`
+	Limit   int                  `json:"limit"`
+}
+
+type GuildGiveawayWinner struct {
+	GiveawayWinnerUUID uuid.UUID         `json:"giveaway_winner_uuid"`
+	UserID             discord.Snowflake `json:"user_id"`
+	Prize              string            `json:"prize"`
+	MessageID          discord.Snowflake `json:"message_id"`
+
+	ClaimExpiresAt *time.Time `json:"claim_expires_at,omitempty"`
+	IsClaimed      bool       `json:"is_claimed"`
+	ClaimedAt      *time.Time `json:"claimed_at,omitempty"`
+	IsExpired      bool       `json:"is_expired"`
+
+	IsDelivered   bool   `json:"is_delivered"`
+	DeliveryError string `json:"delivery_error,omitempty"`
+}
+
+func GuildGiveawayToPartial(giveaway *database.GuildGiveaways, entries int32) *GuildGiveaway {
+	partial := &GuildGiveaway{
+		GiveawayUUID: giveaway.GiveawayUuid,
+		CreatedAt:    giveaway.CreatedAt,
+		CreatedBy:    discord.Snowflake(giveaway.CreatedBy),
+		IsSetup:      giveaway.IsSetup,
+		HasEnded:     giveaway.HasEnded,
+		AllowEntries: giveaway.AllowEntries,
+		ChannelID:    discord.Snowflake(giveaway.ChannelID),
+		MessageID:    discord.Snowflake(giveaway.MessageID),
+		Entries:      entries,
+		GuildGiveawayPayload: GuildGiveawayPayload{
+			Title:               giveaway.Title,
+			Description:         giveaway.Description,
+			AccentColour:        giveaway.AccentColour,
+			ImageURL:            giveaway.ImageUrl,
+			ShowPrizes:          giveaway.ShowPrizes,
+			ShowEntries:         giveaway.ShowEntries,
+			Prizes:              welcomer.UnmarshalGiveawayPrizeJSON(giveaway.GiveawayPrizes.Bytes),
+			AnnounceWinners:     giveaway.AnnounceWinners,
+			ProvablyFair:        giveaway.ProvablyFair,
+			ClaimWindow:         giveaway.ClaimWindow,
+			RolesAllowed:        welcomer.UnmarshalRolesListJSON(giveaway.RolesAllowed.Bytes),
+			RolesExcluded:       welcomer.UnmarshalRolesListJSON(giveaway.RolesExcluded.Bytes),
+			MinimumJoinDate:     giveaway.MinimumJoinDate.Unix(),
+			MinimumAccountAge:   giveaway.MinimumAccountAge,
+			MinimumMessageCount: giveaway.MinimumMessageCount,
+			MinimumVoiceMinutes: giveaway.MinimumVoiceMinutes,
+			ActivityPeriodDays:  giveaway.ActivityPeriodDays,
+			RecurrenceRule:      giveaway.RecurrenceRule,
+		},
+	}
+
+	// While a giveaway is being setup, the end time holds its duration.
+	if giveaway.IsSetup {
+		partial.Duration = max(giveaway.EndTime.Unix(), 0)
+	} else {
+		partial.StartTime = &giveaway.StartTime
+
+		if giveaway.EndTime.Unix() > 0 {
+			partial.EndTime = &giveaway.EndTime
+		}
+	}
+
+	if partial.Prizes == nil {
+		partial.Prizes = make([]welcomer.GiveawayPrize, 0)
+	}
+
+	if partial.RolesAllowed == nil {
+		partial.RolesAllowed = make([]discord.Snowflake, 0)
+	}
+
+	if partial.RolesExcluded == nil {
+		partial.RolesExcluded = make([]discord.Snowflake, 0)
+	}
+
+	return partial
+}
+
+func GuildGiveawayWinnerToPartial(winner *database.GuildGiveawaysWinners) GuildGiveawayWinner {
+	partial := GuildGiveawayWinner{
+		GiveawayWinnerUUID: winner.GiveawayWinnerUuid,
+		UserID:             discord.Snowflake(winner.UserID),
+		Prize:              winner.Prize,
+		MessageID:          discord.Snowflake(winner.MessageID),
+		IsClaimed:          winner.IsClaimed,
+		IsExpired:          winner.IsExpired,
+		IsDelivered:        winner.IsDelivered,
+		DeliveryError:      winner.DeliveryError,
+	}
+
+	if winner.ClaimExpiresAt.Unix() > 0 {
+		partial.ClaimExpiresAt = &winner.ClaimExpiresAt
+	}
+
+	if winner.IsClaimed {
+		partial.ClaimedAt = &winner.ClaimedAt
+	}
+
+	return partial
+}
+
+// PartialToUpdateGiveawayParams applies the payload to the giveaway settings.
+func PartialToUpdateGiveawayParams(giveaway *database.GuildGiveaways, partial *GuildGiveawayPayload) database.UpdateGiveawayParams {
+	return database.UpdateGiveawayParams{
+		GiveawayUuid:    giveaway.GiveawayUuid,
+		IsSetup:         giveaway.IsSetup,
+		AllowEntries:    giveaway.AllowEntries,
+		HasEnded:        giveaway.HasEnded,
+		Title:           partial.Title,
+		Description:     partial.Description,
+		StartTime:       giveaway.StartTime,
+		EndTime:         time.Unix(partial.Duration, 0),
+		AnnounceWinners: partial.AnnounceWinners,
+		GiveawayPrizes: pgtype.JSONB{
+			Bytes:  welcomer.MarshalGiveawayPrizeJSON(partial.Prizes),
+			Status: pgtype.Present,
+		},
+		RolesAllowed: pgtype.JSONB{
+			Bytes:  welcomer.MarshalRolesListJSON(partial.RolesAllowed),
+			Status: pgtype.Present,
+		},
+		RolesExcluded: pgtype.JSONB{
+			Bytes:  welcomer.MarshalRolesListJSON(partial.RolesExcluded),
+			Status: pgtype.Present,
+		},
+		MinimumJoinDate:     time.Unix(partial.MinimumJoinDate, 0),
+		AccentColour:        partial.AccentColour,
+		ImageUrl:            partial.ImageURL,
+		ShowPrizes:          partial.ShowPrizes,
+		ShowEntries:         partial.ShowEntries,
+		MinimumAccountAge:   partial.MinimumAccountAge,
+		MinimumMessageCount: partial.MinimumMessageCount,
+		MinimumVoiceMinutes: partial.MinimumVoiceMinutes,
+		ActivityPeriodDays:  partial.ActivityPeriodDays,
+		RecurrenceRule:      partial.RecurrenceRule,
+		PingContent:         giveaway.PingContent,
+		ProvablyFair:        partial.ProvablyFair,
+		ClaimWindow:         partial.ClaimWindow,
+	}
+}
+
+// GuildGiveawayToUpdateGiveawayParams returns the update params which leave the giveaway unchanged.
+func GuildGiveawayToUpdateGiveawayParams(giveaway *database.GuildGiveaways) database.UpdateGiveawayParams {
+	return database.UpdateGiveawayParams{
+		GiveawayUuid:        giveaway.GiveawayUuid,
+		IsSetup:             giveaway.IsSetup,
+		AllowEntries:        giveaway.AllowEntries,
+		HasEnded:            giveaway.HasEnded,
+		Title:               giveaway.Title,
+		Description:         giveaway.Description,
+		StartTime:           giveaway.StartTime,
+		EndTime:             giveaway.EndTime,
+		AnnounceWinners:     giveaway.AnnounceWinners,
+		GiveawayPrizes:      giveaway.GiveawayPrizes,
+		RolesAllowed:        giveaway.RolesAllowed,
+		RolesExcluded:       giveaway.RolesExcluded,
+		MinimumJoinDate:     giveaway.MinimumJoinDate,
+		AccentColour:        giveaway.AccentColour,
+		ImageUrl:            giveaway.ImageUrl,
+		ShowPrizes:          giveaway.ShowPrizes,
+		ShowEntries:         giveaway.ShowEntries,
+		MinimumAccountAge:   giveaway.MinimumAccountAge,
+		MinimumMessageCount: giveaway.MinimumMessageCount,
+		MinimumVoiceMinutes: giveaway.MinimumVoiceMinutes,
+		ActivityPeriodDays:  giveaway.ActivityPeriodDays,
+		RecurrenceRule:      giveaway.RecurrenceRule,
+		PingContent:         giveaway.PingContent,
+		ProvablyFair:        giveaway.ProvablyFair,
+		ClaimWindow:         giveaway.ClaimWindow,
+	}
+}
@@ -0,0 +1,128 @@
+package backend
+
+import (
+	"time"
+
+	discord "github.com/WelcomerTeam/Discord/discord"
+	"github.com/WelcomerTeam/Welcomer/welcomer-core"
+)
+
+const (
+	AnalyticsGranularityHour  = "hour"
+	AnalyticsGranularityDay   = "day"
+	AnalyticsGranularityWeek  = "week"
+	AnalyticsGranularityMonth = "month"
+
+	AnalyticsDefaultDays = 30
+
+	// AnalyticsMaximumChannels is the most channels broken down in the message series,
+	// the remaining channels are grouped under channel 0.
+	AnalyticsMaximumChannels = 25
+)
+
+// AnalyticsMaximumRange is the longest time range that can be requested for each granularity.
+var AnalyticsMaximumRange = map[string]time.Duration{
+	AnalyticsGranularityHour:  14 * 24 * time.Hour,
+	AnalyticsGranularityDay:   180 * 24 * time.Hour,
+	AnalyticsGranularityWeek:  730 * 24 * time.Hour,
+	AnalyticsGranularityMonth: 3650 * 24 * time.Hour,
+}
+
+type GuildAnalyticsRange struct {
+	Granularity string    `json:"granularity"`
+	From        time.Time `json:"from"`
+	To          time.Time `json:"to"`
+}
+
+type GuildAnalyticsMessages struct {
+	GuildAnalyticsRange
+
+	Total    int64                         `json:"total"`
+	Channels []GuildAnalyticsChannelCount  `json:"channels"`
+	Series   []GuildAnalyticsMessagesPoint `json:"series"`
+}
+
+type GuildAnalyticsChannelCount struct {
+	ChannelID discord.Snowflake `json:"channel_id"`
+	Messages  int64             `json:"messages"`
+}
+
+type GuildAnalyticsMessagesPoint struct {
+	Timestamp time.Time         `json:"timestamp"`
+	ChannelID discord.Snowflake `json:"channel_id"`
+	Messages  int64             `json:"messages"`
+}
+
+type GuildAnalyticsActiveUsers struct {
+	GuildAnalyticsRange
+
+	Series []GuildAnalyticsActiveUsersPoint `json:"series"`
+}
+
+type GuildAnalyticsActiveUsersPoint struct {
+	Timestamp    time.Time `json:"timestamp"`
+	ActiveUsers  int64     `json:"active_users"`
+	MessageUsers int64     `json:"message_users"`
+	VoiceUsers   int64     `json:"voice_users"`
+}
+
+type GuildAnalyticsVoice struct {
+	GuildAnalyticsRange
+
+	TotalMinutes int64                      `json:"total_minutes"`
+	Series       []GuildAnalyticsVoicePoint `json:"series"`
+}
+
+type GuildAnalyticsVoicePoint struct {
+	Timestamp  time.Time `json:"timestamp"`
+	Minutes    int64     `json:"minutes"`
+	VoiceUsers int64     `json:"voice_users"`
+	Sessions   int64     `json:"sessions"`
+}
+
+type GuildAnalyticsGrowth struct {
+	GuildAnalyticsRange
+
+	Joins  int64                       `json:"joins"`
+	Leaves int64                       `json:"leaves"`
+	Net    int64                       `json:"net"`
+	Series []GuildAnalyticsGrowthPoint `json:"series"`
+}
+
+type GuildAnalyticsGrowthPoint struct {
+	Timestamp time.Time `json:"timestamp"`
+	Joins     int64     `json:"joins"`
+	Leaves    int64     `json:"leaves"`
+	Net       int64     `json:"net"`
+}
+
+type GuildAnalyticsFunnels struct {
+	GuildAnalyticsRange
+
+	Welcome    GuildAnalyticsFunnel `json:"welcome"`
+	Borderwall GuildAnalyticsFunnel `json:"borderwall"`
+	Giveaways  GuildAnalyticsFunnel `json:"giveaways"`
+}
+
+type GuildAnalyticsFunnel struct {
+	Steps []GuildAnalyticsFunnelStep `json:"steps"`
+}
+
+type GuildAnalyticsFunnelStep struct {
+	Name  string `json:"name"`
+	Count int64  `json:"count"`
+
+	// Conversion is the count relative to the first step of the funnel.
+	Conversion float64                    `json:"conversion"`
+	Series     []GuildAnalyticsCountPoint `json:"series"`
+}
+
+type GuildAnalyticsCountPoint struct {
+	Timestamp time.Time `json:"timestamp"`
+	Count     int64     `json:"count"`
+}
+
+type GuildAnalyticsRetention struct {
+	GuildAnalyticsRange
+	welcomer.RetentionReport
+}
@@ -0,0 +1,41 @@
+package backend
+
+import (
+	"github.com/WelcomerTeam/Welcomer/welcomer-core"
+	"github.com/WelcomerTeam/Welcomer/welcomer-core/database"
+)
+
+const (
+	ActivityRolesMaximum = 50
+)
+
+type GuildSettingsActivityRoles struct {
+	Roles         []welcomer.GuildSettingsActivityRolesRole `json:"roles"`
+	ToggleEnabled bool                                      `json:"enabled"`
+	ToggleDecay   bool                                      `json:"decay"`
+}
+
+func GuildSettingsActivityRolesSettingsToPartial(
+	activityRoles *database.GuildSettingsActivityroles,
+) *GuildSettingsActivityRoles {
+	partial := &GuildSettingsActivityRoles{
+		ToggleEnabled: activityRoles.ToggleEnabled,
+		ToggleDecay:   activityRoles.ToggleDecay,
+		Roles:         welcomer.UnmarshalActivityRolesJSON(welcomer.JSONBToBytes(activityRoles.Activityroles)),
+	}
+
+	if len(partial.Roles) == 0 {
+		partial.Roles = make([]welcomer.GuildSettingsActivityRolesRole, 0)
+	}
+
+	return partial
+}
+
+func PartialToGuildSettingsActivityRolesSettings(guildID int64, guildSettings *GuildSettingsActivityRoles) *database.GuildSettingsActivityroles {
+	return &database.GuildSettingsActivityroles{
+		GuildID:       guildID,
+		ToggleEnabled: guildSettings.ToggleEnabled,
+		ToggleDecay:   guildSettings.ToggleDecay,
+		Activityroles: welcomer.BytesToJSONB(welcomer.MarshalActivityRolesJSON(guildSettings.Roles)),
+	}
+}
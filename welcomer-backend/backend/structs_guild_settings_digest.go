@@ -0,0 +1,31 @@
+package backend
+
+import (
+	"github.com/WelcomerTeam/Welcomer/welcomer-core"
+	"github.com/WelcomerTeam/Welcomer/welcomer-core/database"
+)
+
+type GuildSettingsDigest struct {
+	ChannelDigest *string `json:"channel"`
+	Frequency     string  `json:"frequency"`
+	ToggleEnabled bool    `json:"enabled"`
+}
+
+func GuildSettingsDigestSettingsToPartial(
+	digest *database.GuildSettingsDigest,
+) *GuildSettingsDigest {
+	return &GuildSettingsDigest{
+		ToggleEnabled: digest.ToggleEnabled,
+		ChannelDigest: welcomer.Int64ToStringPointer(digest.ChannelDigest),
+		Frequency:     digest.Frequency,
+	}
+}
+
+func PartialToGuildSettingsDigestSettings(guildID int64, guildSettings *GuildSettingsDigest) *database.GuildSettingsDigest {
+	return &database.GuildSettingsDigest{
+		GuildID:       guildID,
+		ToggleEnabled: guildSettings.ToggleEnabled,
+		ChannelDigest: welcomer.StringPointerToInt64(guildSettings.ChannelDigest),
+		Frequency:     guildSettings.Frequency,
+	}
+}
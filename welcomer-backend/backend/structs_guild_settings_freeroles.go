@@ -6,8 +6,9 @@ import (
 )
 
 type GuildSettingsFreeRoles struct {
-	Roles         []string `json:"roles"`
-	ToggleEnabled bool     `json:"enabled"`
+	Roles         []string                    `json:"roles"`
+	ToggleEnabled bool                        `json:"enabled"`
+	RoleDurations []welcomer.TempRoleDuration `json:"role_durations"`
 }
 
 func GuildSettingsFreeRolesSettingsToPartial(
@@ -16,12 +17,17 @@ func GuildSettingsFreeRolesSettingsToPartial(
 	partial := &GuildSettingsFreeRoles{
 		ToggleEnabled: freeRoles.ToggleEnabled,
 		Roles:         welcomer.Int64SliceToString(freeRoles.Roles),
+		RoleDurations: welcomer.UnmarshalTempRoleDurationsJSON(freeRoles.RoleDurations.Bytes),
 	}
 
 	if len(partial.Roles) == 0 {
 		partial.Roles = make([]string, 0)
 	}
 
+	if len(partial.RoleDurations) == 0 {
+		partial.RoleDurations = make([]welcomer.TempRoleDuration, 0)
+	}
+
 	return partial
 }
 
@@ -30,5 +36,6 @@ func PartialToGuildSettingsFreeRolesSettings(guildID int64, guildSettings *Guild
 		GuildID:       guildID,
 		ToggleEnabled: guildSettings.ToggleEnabled,
 		Roles:         welcomer.StringSliceToInt64(guildSettings.Roles),
+		RoleDurations: welcomer.BytesToJSONB(welcomer.MarshalTempRoleDurationsJSON(guildSettings.RoleDurations)),
 	}
 }
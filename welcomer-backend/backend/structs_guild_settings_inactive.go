@@ -0,0 +1,31 @@
+package backend
+
+import (
+	"github.com/WelcomerTeam/Welcomer/welcomer-core"
+	"github.com/WelcomerTeam/Welcomer/welcomer-core/database"
+)
+
+type GuildSettingsInactive struct {
+	ExcludedRoles []string `json:"excluded_roles"`
+}
+
+func GuildSettingsInactiveSettingsToPartial(
+	inactive *database.GuildSettingsInactive,
+) *GuildSettingsInactive {
+	partial := &GuildSettingsInactive{
+		ExcludedRoles: welcomer.Int64SliceToString(inactive.ExcludedRoles),
+	}
+
+	if len(partial.ExcludedRoles) == 0 {
+		partial.ExcludedRoles = make([]string, 0)
+	}
+
+	return partial
+}
+
+func PartialToGuildSettingsInactiveSettings(guildID int64, guildSettings *GuildSettingsInactive) *database.GuildSettingsInactive {
+	return &database.GuildSettingsInactive{
+		GuildID:       guildID,
+		ExcludedRoles: welcomer.StringSliceToInt64(guildSettings.ExcludedRoles),
+	}
+}
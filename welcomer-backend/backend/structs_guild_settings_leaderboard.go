@@ -0,0 +1,34 @@
+package backend
+
+import (
+	"github.com/WelcomerTeam/Welcomer/welcomer-core"
+	"github.com/WelcomerTeam/Welcomer/welcomer-core/database"
+)
+
+type GuildSettingsLeaderboard struct {
+	ExcludedChannels  []string `json:"excluded_channels"`
+	ToggleIncludeBots bool     `json:"include_bots"`
+}
+
+func GuildSettingsLeaderboardSettingsToPartial(
+	leaderboard *database.GuildSettingsLeaderboard,
+) *GuildSettingsLeaderboard {
+	partial := &GuildSettingsLeaderboard{
+		ToggleIncludeBots: leaderboard.ToggleIncludeBots,
+		ExcludedChannels:  welcomer.Int64SliceToString(leaderboard.ExcludedChannels),
+	}
+
+	if len(partial.ExcludedChannels) == 0 {
+		partial.ExcludedChannels = make([]string, 0)
+	}
+
+	return partial
+}
+
+func PartialToGuildSettingsLeaderboardSettings(guildID int64, guildSettings *GuildSettingsLeaderboard) *database.GuildSettingsLeaderboard {
+	return &database.GuildSettingsLeaderboard{
+		GuildID:           guildID,
+		ToggleIncludeBots: guildSettings.ToggleIncludeBots,
+		ExcludedChannels:  welcomer.StringSliceToInt64(guildSettings.ExcludedChannels),
+	}
+}
@@ -1,15 +1,62 @@
 package backend
 
 import (
+	"time"
+
 	discord "github.com/WelcomerTeam/Discord/discord"
 	"github.com/WelcomerTeam/Welcomer/welcomer-core"
 	"github.com/WelcomerTeam/Welcomer/welcomer-core/database"
 )
 
+const (
+	ReactionRoleStatsDefaultDays = 30
+	ReactionRoleStatsMaximumDays = 90
+)
+
 type GuildSettingsReactionRoles struct {
 	ReactionRoles []welcomer.GuildSettingsReactionRole `json:"reaction_roles"`
 }
 
+type GuildSettingsReactionRolesImport struct {
+	ReactionRoles []welcomer.GuildSettingsReactionRole `json:"reaction_roles"`
+
+	// AdoptExistingMessages keeps emoji reaction roles on their existing message
+	// when it already has every option's reaction, instead of posting a new one.
+	AdoptExistingMessages bool `json:"adopt_existing_messages"`
+
+	// ReplaceExisting removes the current reaction roles instead of adding to them.
+	ReplaceExisting bool `json:"replace_existing"`
+}
+
+type GuildSettingsReactionRolesImportResult struct {
+	ReactionRoles []welcomer.GuildSettingsReactionRole `json:"reaction_roles"`
+	Adopted       int                                  `json:"adopted"`
+	Reposted      int                                  `json:"reposted"`
+	Skipped       int                                  `json:"skipped"`
+	Errors        []string                             `json:"errors,omitempty"`
+}
+
+type GuildReactionRoleStats struct {
+	Bucket  string                         `json:"bucket"`
+	Since   time.Time                      `json:"since"`
+	Options []GuildReactionRoleOptionStats `json:"options"`
+	Series  []GuildReactionRoleStatsPoint  `json:"series"`
+}
+
+type GuildReactionRoleOptionStats struct {
+	RoleID  discord.Snowflake `json:"role_id"`
+	Holders int64             `json:"holders"`
+	Adds    int64             `json:"adds"`
+	Removes int64             `json:"removes"`
+}
+
+type GuildReactionRoleStatsPoint struct {
+	Timestamp time.Time         `json:"timestamp"`
+	RoleID    discord.Snowflake `json:"role_id"`
+	Adds      int64             `json:"adds"`
+	Removes   int64             `json:"removes"`
+}
+
 func GuildSettingsReactionRolesSettingsToPartial(reactionRoles []*database.GuildSettingsReactionRoles) *GuildSettingsReactionRoles {
 	partial := &GuildSettingsReactionRoles{
 		ReactionRoles: make([]welcomer.GuildSettingsReactionRole, len(reactionRoles)),
@@ -34,14 +81,19 @@ func PartialToGuildSettingsReactionRolesSettings(guildID int64, guildSettings *G
 
 func GuildSettingsReactionRoleToPartial(reactionRole *database.GuildSettingsReactionRoles) welcomer.GuildSettingsReactionRole {
 	return welcomer.GuildSettingsReactionRole{
-		ReactionRoleID:  reactionRole.ReactionRoleID,
-		Enabled:         reactionRole.ToggleEnabled,
-		ChannelID:       discord.Snowflake(reactionRole.ChannelID),
-		MessageID:       discord.Snowflake(reactionRole.MessageID),
-		IsSystemMessage: reactionRole.IsSystemMessage,
-		Message:         welcomer.JSONBToString(reactionRole.SystemMessageFormat),
-		Type:            welcomer.ReactionRoleType(reactionRole.ReactionRoleType),
-		Roles:           welcomer.UnmarshalReactionRolesJSON(welcomer.JSONBToBytes(reactionRole.Roles)),
+		ReactionRoleID:   reactionRole.ReactionRoleID,
+		Enabled:          reactionRole.ToggleEnabled,
+		ChannelID:        discord.Snowflake(reactionRole.ChannelID),
+		MessageID:        discord.Snowflake(reactionRole.MessageID),
+		IsSystemMessage:  reactionRole.IsSystemMessage,
+		Message:          welcomer.JSONBToString(reactionRole.SystemMessageFormat),
+		Type:             welcomer.ReactionRoleType(reactionRole.ReactionRoleType),
+		Roles:            welcomer.UnmarshalReactionRolesJSON(welcomer.JSONBToBytes(reactionRole.Roles)),
+		UniqueSelection:  reactionRole.UniqueSelection,
+		MaxSelections:    reactionRole.MaxSelections,
+		RequiredRoleID:   discord.Snowflake(reactionRole.RequiredRoleID),
+		VerifyOnly:       reactionRole.VerifyOnly,
+		DisableToggleOff: reactionRole.DisableToggleOff,
 	}
 }
 
@@ -56,5 +108,10 @@ func PartialToGuildSettingsReactionRole(guildID int64, reactionRole *welcomer.Gu
 		SystemMessageFormat: welcomer.StringToJSONB(reactionRole.Message),
 		ReactionRoleType:    int32(reactionRole.Type),
 		Roles:               welcomer.BytesToJSONB(welcomer.MarshalReactionRolesJSON(reactionRole.Roles)),
+		UniqueSelection:     reactionRole.UniqueSelection,
+		MaxSelections:       reactionRole.MaxSelections,
+		RequiredRoleID:      int64(reactionRole.RequiredRoleID),
+		VerifyOnly:          reactionRole.VerifyOnly,
+		DisableToggleOff:    reactionRole.DisableToggleOff,
 	}
 }
@@ -0,0 +1,28 @@
+package backend
+
+import (
+	"github.com/WelcomerTeam/Welcomer/welcomer-core"
+	"github.com/WelcomerTeam/Welcomer/welcomer-core/database"
+)
+
+type GuildSettingsRetention struct {
+	ChannelReport      *string `json:"channel"`
+	ToggleWeeklyReport bool    `json:"weekly_report"`
+}
+
+func GuildSettingsRetentionSettingsToPartial(
+	retention *database.GuildSettingsRetention,
+) *GuildSettingsRetention {
+	return &GuildSettingsRetention{
+		ToggleWeeklyReport: retention.ToggleWeeklyReport,
+		ChannelReport:      welcomer.Int64ToStringPointer(retention.ChannelReport),
+	}
+}
+
+func PartialToGuildSettingsRetentionSettings(guildID int64, guildSettings *GuildSettingsRetention) *database.GuildSettingsRetention {
+	return &database.GuildSettingsRetention{
+		GuildID:            guildID,
+		ToggleWeeklyReport: guildSettings.ToggleWeeklyReport,
+		ChannelReport:      welcomer.StringPointerToInt64(guildSettings.ChannelReport),
+	}
+}
@@ -5,12 +5,21 @@ import (
 	"github.com/WelcomerTeam/Welcomer/welcomer-core/database"
 )
 
+const (
+	TempChannelNameTemplateMaxLength = 256
+	TempChannelUserLimitMaximum      = 99
+	TempChannelBitrateMinimum        = 8000
+	TempChannelBitrateMaximum        = 384000
+)
+
 type GuildSettingsTempChannels struct {
 	ChannelLobby     *string `json:"channel_lobby"`
 	ChannelCategory  *string `json:"channel_category"`
 	DefaultUserCount int32   `json:"default_user_count"`
 	ToggleEnabled    bool    `json:"enabled"`
 	ToggleAutopurge  bool    `json:"autopurge"`
+
+	Lobbies []welcomer.TempChannelLobby `json:"lobbies"`
 }
 
 func GuildSettingsTempChannelsSettingsToPartial(
@@ -22,18 +31,34 @@ func GuildSettingsTempChannelsSettingsToPartial(
 		ChannelLobby:     welcomer.Int64ToStringPointer(tempChannels.ChannelLobby),
 		ChannelCategory:  welcomer.Int64ToStringPointer(tempChannels.ChannelCategory),
 		DefaultUserCount: tempChannels.DefaultUserCount,
+		Lobbies:          welcomer.GetTempChannelLobbies(tempChannels),
+	}
+
+	if partial.Lobbies == nil {
+		partial.Lobbies = make([]welcomer.TempChannelLobby, 0)
 	}
 
 	return partial
 }
 
 func PartialToGuildSettingsTempChannelsSettings(guildID int64, guildSettings *GuildSettingsTempChannels) *database.GuildSettingsTempchannels {
-	return &database.GuildSettingsTempchannels{
+	tempChannels := &database.GuildSettingsTempchannels{
 		GuildID:          guildID,
 		ToggleEnabled:    guildSettings.ToggleEnabled,
 		ToggleAutopurge:  guildSettings.ToggleAutopurge,
 		ChannelLobby:     welcomer.StringPointerToInt64(guildSettings.ChannelLobby),
 		ChannelCategory:  welcomer.StringPointerToInt64(guildSettings.ChannelCategory),
 		DefaultUserCount: guildSettings.DefaultUserCount,
+		Lobbies:          welcomer.MustConvertToJSONB(guildSettings.Lobbies),
+	}
+
+	// Settings sent without lobbies still use the single lobby fields. When lobbies are sent, even
+	// if empty, they replace the single lobby fields, which only mirror the first lobby.
+	if guildSettings.Lobbies != nil {
+		welcomer.SetTempChannelLobbies(tempChannels, guildSettings.Lobbies)
+	} else {
+		welcomer.SetTempChannelLobbies(tempChannels, welcomer.GetTempChannelLobbies(tempChannels))
 	}
+
+	return tempChannels
 }
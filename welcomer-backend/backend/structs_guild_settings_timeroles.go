@@ -8,6 +8,7 @@ import (
 type GuildSettingsTimeRoles struct {
 	Roles         []welcomer.GuildSettingsTimeRolesRole `json:"roles"`
 	ToggleEnabled bool                                  `json:"enabled"`
+	ToggleLadder  bool                                  `json:"ladder"`
 }
 
 func GuildSettingsTimeRolesSettingsToPartial(
@@ -15,6 +16,7 @@ func GuildSettingsTimeRolesSettingsToPartial(
 ) *GuildSettingsTimeRoles {
 	partial := &GuildSettingsTimeRoles{
 		ToggleEnabled: timeRoles.ToggleEnabled,
+		ToggleLadder:  timeRoles.ToggleLadder,
 		Roles:         welcomer.UnmarshalTimeRolesJSON(welcomer.JSONBToBytes(timeRoles.Timeroles)),
 	}
 
@@ -30,5 +32,6 @@ func PartialToGuildSettingsTimeRolesSettings(guildID int64, guildSettings *Guild
 		GuildID:       guildID,
 		ToggleEnabled: guildSettings.ToggleEnabled,
 		Timeroles:     welcomer.BytesToJSONB(welcomer.MarshalTimeRolesJSON(guildSettings.Roles)),
+		ToggleLadder:  guildSettings.ToggleLadder,
 	}
 }
@@ -45,6 +45,24 @@ func FetchGuildChannels(ctx context.Context, guildID discord.Snowflake) ([]*disc
 	return discordChannels, nil
 }
 
+func FetchGuildChannel(ctx context.Context, guildID, channelID discord.Snowflake) (*discord.Channel, error) {
+	channels, err := SandwichClient.FetchGuildChannel(ctx, &sandwich_protobuf.FetchGuildChannelRequest{
+		GuildId:    int64(guildID),
+		ChannelIds: []int64{int64(channelID)},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, channelPb := range channels.GetChannels() {
+		if channel := sandwich_protobuf.PBToChannel(channelPb); channel.ID == channelID {
+			return channel, nil
+		}
+	}
+
+	return nil, ErrMissingChannel
+}
+
 func FetchUser(ctx context.Context, userID discord.Snowflake) (*discord.User, error) {
 	users, err := SandwichClient.FetchUser(ctx, &sandwich_protobuf.FetchUserRequest{
 		UserIds: []int64{int64(userID)},
@@ -0,0 +1,248 @@
+package welcomer
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/WelcomerTeam/Discord/discord"
+	pb "github.com/WelcomerTeam/Sandwich-Daemon/proto"
+	"github.com/WelcomerTeam/Welcomer/welcomer-core/database"
+	"github.com/jackc/pgx/v4"
+)
+
+const (
+	ActivityRoleTypeMessages = "messages"
+	ActivityRoleTypeVoice    = "voice"
+
+	DefaultActivityRoleDays = 30
+	MaximumActivityRoleDays = 365
+
+	// ActivityRolesRelayBatchSize is the most users sent to the gateway in a single event.
+	ActivityRolesRelayBatchSize = 1000
+)
+
+type GuildSettingsActivityRolesRole struct {
+	Role discord.Snowflake `json:"role_id"`
+	Type string            `json:"type"`
+
+	// Threshold is the number of messages sent, or the seconds spent in voice channels.
+	Threshold int64 `json:"threshold"`
+
+	// Days is the window activity is counted over.
+	Days int `json:"days"`
+}
+
+// ActivityTotals is the activity of a member within a window.
+type ActivityTotals struct {
+	MessageCount int64
+	VoiceSeconds int64
+}
+
+func UnmarshalActivityRolesJSON(rolesJSON []byte) (roles []GuildSettingsActivityRolesRole) {
+	_ = json.Unmarshal(rolesJSON, &roles)
+
+	return
+}
+
+func MarshalActivityRolesJSON(roles []GuildSettingsActivityRolesRole) (rolesJSON []byte) {
+	rolesJSON, _ = json.Marshal(roles)
+
+	return
+}
+
+func FilterAssignableActivityRoles(ctx context.Context, sandwichClient pb.SandwichClient, guildID, applicationID int64, activityRoles []GuildSettingsActivityRolesRole) (out []GuildSettingsActivityRolesRole, err error) {
+	roleIDs := make([]int64, len(activityRoles))
+	for i, activityRole := range activityRoles {
+		roleIDs[i] = int64(activityRole.Role)
+	}
+
+	assignableRoleIDs, err := FilterAssignableRolesAsSnowflakes(ctx, sandwichClient, guildID, applicationID, roleIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, activityRole := range activityRoles {
+		if slices.Contains(assignableRoleIDs, activityRole.Role) {
+			out = append(out, activityRole)
+		}
+	}
+
+	return out, nil
+}
+
+// GetDays returns the window of the activity role, falling back to the default window.
+func (r GuildSettingsActivityRolesRole) GetDays() int {
+	if r.Days <= 0 {
+		return DefaultActivityRoleDays
+	}
+
+	return r.Days
+}
+
+// IsReached returns if the activity within the role's window meets the threshold.
+func (r GuildSettingsActivityRolesRole) IsReached(totals ActivityTotals) bool {
+	switch r.Type {
+	case ActivityRoleTypeMessages:
+		return totals.MessageCount >= r.Threshold
+	case ActivityRoleTypeVoice:
+		return totals.VoiceSeconds >= r.Threshold
+	default:
+		return false
+	}
+}
+
+// String returns a human readable description of the threshold, such as "500 messages in 30 days".
+func (r GuildSettingsActivityRolesRole) String() string {
+	switch r.Type {
+	case ActivityRoleTypeVoice:
+		return fmt.Sprintf("%s in voice in %d days", HumanizeDuration(int(r.Threshold), false), r.GetDays())
+	default:
+		return fmt.Sprintf("%d messages in %d days", r.Threshold, r.GetDays())
+	}
+}
+
+// GetActivityRoleWindows returns the distinct windows, in days, used by the activity roles.
+func GetActivityRoleWindows(activityRoles []GuildSettingsActivityRolesRole) (windows []int) {
+	for _, activityRole := range activityRoles {
+		if !slices.Contains(windows, activityRole.GetDays()) {
+			windows = append(windows, activityRole.GetDays())
+		}
+	}
+
+	return windows
+}
+
+// GetActivityRoleChanges returns the roles to give to and take from a member. totals is keyed by window
+// in days. Roles are only taken away when decay is enabled and the role was given by activity roles.
+func GetActivityRoleChanges(memberRoles, assignedRoles []discord.Snowflake, activityRoles []GuildSettingsActivityRolesRole, totals map[int]ActivityTotals, decay bool) (add, remove []discord.Snowflake) {
+	reached := make(map[discord.Snowflake]bool, len(activityRoles))
+
+	for _, activityRole := range activityRoles {
+		if activityRole.Role.IsNil() {
+			continue
+		}
+
+		reached[activityRole.Role] = reached[activityRole.Role] || activityRole.IsReached(totals[activityRole.GetDays()])
+	}
+
+	for roleID, isReached := range reached {
+		hasRole := slices.Contains(memberRoles, roleID)
+
+		switch {
+		case isReached && !hasRole:
+			add = append(add, roleID)
+		case !isReached && hasRole && decay && slices.Contains(assignedRoles, roleID):
+			remove = append(remove, roleID)
+		}
+	}
+
+	return add, remove
+}
+
+// BackfillActivityRoles asks the gateway to check every member that has been active within the
+// longest window, so members that were active before activity roles were enabled or changed are
+// given the roles they have earned. Returns the number of members that will be checked.
+func BackfillActivityRoles(ctx context.Context, guildID discord.Snowflake, activityRoles []GuildSettingsActivityRolesRole) (int, error) {
+	days := 0
+	for _, window := range GetActivityRoleWindows(activityRoles) {
+		days = max(days, window)
+	}
+
+	if days == 0 {
+		return 0, nil
+	}
+
+	userIDs, err := Queries.GetGuildActivityRoleCandidates(ctx, database.GetGuildActivityRoleCandidatesParams{
+		GuildID: int64(guildID),
+		Since:   time.Now().AddDate(0, 0, -days),
+	})
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		return 0, fmt.Errorf("failed to get activity role candidates: %w", err)
+	}
+
+	for start := 0; start < len(userIDs); start += ActivityRolesRelayBatchSize {
+		end := min(start+ActivityRolesRelayBatchSize, len(userIDs))
+
+		batch := make([]discord.Snowflake, 0, end-start)
+		for _, userID := range userIDs[start:end] {
+			batch = append(batch, discord.Snowflake(userID))
+		}
+
+		data, err := json.Marshal(CustomEventInvokeActivityRolesStructure{
+			GuildID: guildID,
+			UserIDs: batch,
+		})
+		if err != nil {
+			return 0, fmt.Errorf("failed to marshal activity roles event: %w", err)
+		}
+
+		if err := RelayActivityRolesEvent(ctx, int64(guildID), data); err != nil {
+			return 0, err
+		}
+	}
+
+	return len(userIDs), nil
+}
+
+// SyncActivityRolesGuildSettings checks members that have already been active against the new
+// thresholds in the background when activity roles are enabled or their roles change. The ingest
+// job only checks members as they are active.
+func SyncActivityRolesGuildSettings(ctx context.Context, old, newRow *database.GuildSettingsActivityroles) {
+	if !newRow.ToggleEnabled || (old.ToggleEnabled && bytes.Equal(old.Activityroles.Bytes, newRow.Activityroles.Bytes)) {
+		return
+	}
+
+	guildID := discord.Snowflake(newRow.GuildID)
+	activityRoles := UnmarshalActivityRolesJSON(newRow.Activityroles.Bytes)
+
+	go func(ctx context.Context) {
+		backfilled, err := BackfillActivityRoles(ctx, guildID, activityRoles)
+		if err != nil {
+			Logger.Warn().Err(err).Int64("guild_id", int64(guildID)).Msg("Failed to backfill activity roles")
+
+			return
+		}
+
+		Logger.Info().Int64("guild_id", int64(guildID)).Int("members", backfilled).Msg("Backfilled activity roles")
+	}(context.WithoutCancel(ctx))
+}
+
+// RelayActivityRolesEvent relays the event to the first application that is in the guild.
+// Guilds that no application is in are skipped, as there is nothing to give roles with.
+func RelayActivityRolesEvent(ctx context.Context, guildID int64, data []byte) error {
+	locationsPb, err := SandwichClient.WhereIsGuild(ctx, &pb.WhereIsGuildRequest{
+		GuildId: guildID,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to do guild lookup: %w", err)
+	}
+
+	locations := locationsPb.GetLocations()
+	if len(locations) == 0 {
+		Logger.Warn().Int64("guild_id", guildID).Msg("No applications found for guild with activity roles")
+
+		return nil
+	}
+
+	for _, location := range locations {
+		_, err = SandwichClient.RelayMessage(ctx, &pb.RelayMessageRequest{
+			Identifier: location.GetIdentifier(),
+			Type:       CustomEventInvokeActivityRoles,
+			Data:       data,
+		})
+		if err != nil {
+			Logger.Warn().Err(err).Int64("guild_id", guildID).Str("identifier", location.GetIdentifier()).Msg("Failed to relay activity roles message")
+
+			continue
+		}
+
+		return nil
+	}
+
+	return fmt.Errorf("failed to relay to any of %d applications: %w", len(locations), err)
+}
@@ -0,0 +1,53 @@
+package welcomer
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/WelcomerTeam/Discord/discord"
+)
+
+func TestGetActivityRoleChanges(t *testing.T) {
+	activityRoles := []GuildSettingsActivityRolesRole{
+		{Role: 1, Type: ActivityRoleTypeMessages, Threshold: 500, Days: 30},
+		{Role: 2, Type: ActivityRoleTypeVoice, Threshold: 36000, Days: 7},
+	}
+
+	tests := []struct {
+		name           string
+		memberRoles    []discord.Snowflake
+		assignedRoles  []discord.Snowflake
+		totals         map[int]ActivityTotals
+		decay          bool
+		expectedAdd    []discord.Snowflake
+		expectedRemove []discord.Snowflake
+	}{
+		{"inactive", nil, nil, map[int]ActivityTotals{}, false, nil, nil},
+		{"messages", nil, nil, map[int]ActivityTotals{30: {MessageCount: 500}}, false, []discord.Snowflake{1}, nil},
+		{"voice in wrong window", nil, nil, map[int]ActivityTotals{30: {VoiceSeconds: 36000}}, false, nil, nil},
+		{"both", nil, nil, map[int]ActivityTotals{30: {MessageCount: 600}, 7: {VoiceSeconds: 40000}}, false, []discord.Snowflake{1, 2}, nil},
+		{"already has role", []discord.Snowflake{1}, nil, map[int]ActivityTotals{30: {MessageCount: 600}}, false, nil, nil},
+		{"no decay", []discord.Snowflake{1}, []discord.Snowflake{1}, map[int]ActivityTotals{}, false, nil, nil},
+		{"decay", []discord.Snowflake{1}, []discord.Snowflake{1}, map[int]ActivityTotals{}, true, nil, []discord.Snowflake{1}},
+		{"decay ignores manual roles", []discord.Snowflake{1}, nil, map[int]ActivityTotals{}, true, nil, nil},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			add, remove := GetActivityRoleChanges(test.memberRoles, test.assignedRoles, activityRoles, test.totals, test.decay)
+
+			slices.Sort(add)
+			slices.Sort(remove)
+
+			if !slices.Equal(add, test.expectedAdd) {
+				t.Errorf("expected add: %v, got: %v", test.expectedAdd, add)
+			}
+
+			if !slices.Equal(remove, test.expectedRemove) {
+				t.Errorf("expected remove: %v, got: %v", test.expectedRemove, remove)
+			}
+		})
+	}
+}
@@ -2,5 +2,5 @@ package database
 
 //go:generate go-enum -f=$GOFILE --marshal
 
-// ENUM(unknown, borderwall_requests, custom_bots, guild_settings_autoroles, guild_settings_borderwall, guild_settings_freeroles, guild_settings_leaver, guild_settings_rules, guild_settings_tempchannels, guild_settings_timeroles, guild_settings_welcomer, guild_settings_welcomer_dms, guild_settings_welcomer_images, guild_settings_welcomer_text, guilds, users, welcomer_images, guild_features, bio, bot_customisation, guild_settings_reactionroles, giveaways)
+// ENUM(unknown, borderwall_requests, custom_bots, guild_settings_autoroles, guild_settings_borderwall, guild_settings_freeroles, guild_settings_leaver, guild_settings_rules, guild_settings_tempchannels, guild_settings_timeroles, guild_settings_welcomer, guild_settings_welcomer_dms, guild_settings_welcomer_images, guild_settings_welcomer_text, guilds, users, welcomer_images, guild_features, bio, bot_customisation, guild_settings_reactionroles, giveaways, guild_settings_activityroles, guild_settings_leaderboard, guild_settings_retention, guild_settings_digest, guild_settings_inactive)
 type AuditType int32
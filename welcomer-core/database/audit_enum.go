@@ -55,11 +55,21 @@ const (
 	AuditTypeGuildSettingsReactionroles
 	// AuditTypeGiveaways is a AuditType of type Giveaways.
 	AuditTypeGiveaways
+	// AuditTypeGuildSettingsActivityroles is a AuditType of type Guild_settings_activityroles.
+	AuditTypeGuildSettingsActivityroles
+	// AuditTypeGuildSettingsLeaderboard is a AuditType of type Guild_settings_leaderboard.
+	AuditTypeGuildSettingsLeaderboard
+	// AuditTypeGuildSettingsRetention is a AuditType of type Guild_settings_retention.
+	AuditTypeGuildSettingsRetention
+	// AuditTypeGuildSettingsDigest is a AuditType of type Guild_settings_digest.
+	AuditTypeGuildSettingsDigest
+	// AuditTypeGuildSettingsInactive is a AuditType of type Guild_settings_inactive.
+	AuditTypeGuildSettingsInactive
 )
 
 var ErrInvalidAuditType = errors.New("not a valid AuditType")
 
-const _AuditTypeName = "unknownborderwall_requestscustom_botsguild_settings_autorolesguild_settings_borderwallguild_settings_freerolesguild_settings_leaverguild_settings_rulesguild_settings_tempchannelsguild_settings_timerolesguild_settings_welcomerguild_settings_welcomer_dmsguild_settings_welcomer_imagesguild_settings_welcomer_textguildsuserswelcomer_imagesguild_featuresbiobot_customisationguild_settings_reactionrolesgiveaways"
+const _AuditTypeName = "unknownborderwall_requestscustom_botsguild_settings_autorolesguild_settings_borderwallguild_settings_freerolesguild_settings_leaverguild_settings_rulesguild_settings_tempchannelsguild_settings_timerolesguild_settings_welcomerguild_settings_welcomer_dmsguild_settings_welcomer_imagesguild_settings_welcomer_textguildsuserswelcomer_imagesguild_featuresbiobot_customisationguild_settings_reactionrolesgiveawaysguild_settings_activityrolesguild_settings_leaderboardguild_settings_retentionguild_settings_digestguild_settings_inactive"
 
 var _AuditTypeMap = map[AuditType]string{
 	AuditTypeUnknown:                     _AuditTypeName[0:7],
@@ -84,6 +94,11 @@ var _AuditTypeMap = map[AuditType]string{
 	AuditTypeBotCustomisation:            _AuditTypeName[353:370],
 	AuditTypeGuildSettingsReactionroles:  _AuditTypeName[370:398],
 	AuditTypeGiveaways:                   _AuditTypeName[398:407],
+	AuditTypeGuildSettingsActivityroles:  _AuditTypeName[407:435],
+	AuditTypeGuildSettingsLeaderboard:    _AuditTypeName[435:461],
+	AuditTypeGuildSettingsRetention:      _AuditTypeName[461:485],
+	AuditTypeGuildSettingsDigest:         _AuditTypeName[485:506],
+	AuditTypeGuildSettingsInactive:       _AuditTypeName[506:529],
 }
 
 // String implements the Stringer interface.
@@ -124,6 +139,11 @@ var _AuditTypeValue = map[string]AuditType{
 	_AuditTypeName[353:370]: AuditTypeBotCustomisation,
 	_AuditTypeName[370:398]: AuditTypeGuildSettingsReactionroles,
 	_AuditTypeName[398:407]: AuditTypeGiveaways,
+	_AuditTypeName[407:435]: AuditTypeGuildSettingsActivityroles,
+	_AuditTypeName[435:461]: AuditTypeGuildSettingsLeaderboard,
+	_AuditTypeName[461:485]: AuditTypeGuildSettingsRetention,
+	_AuditTypeName[485:506]: AuditTypeGuildSettingsDigest,
+	_AuditTypeName[506:529]: AuditTypeGuildSettingsInactive,
 }
 
 // ParseAuditType attempts to convert a string to a AuditType.
@@ -5,7 +5,7 @@ package database
 // ENUM(unknown)
 type ScienceEventType int32
 
-// ENUM(unknown, userJoin, userLeave, userWelcomed, timeRoleGiven, borderwallChallenge, borderwallCompleted, tempChannelCreated, membershipReceived, membershipRemoved, guildJoin, guildLeave, guildOnboarded, guildUserOnboarded, welcomeMessageRemoved, userLeftMessage, leaverMessageRemoved, reactionRoleGiven, reactionRoleRemoved, giveawayCreated, giveawayStarted, giveawayEnded)
+// ENUM(unknown, userJoin, userLeave, userWelcomed, timeRoleGiven, borderwallChallenge, borderwallCompleted, tempChannelCreated, membershipReceived, membershipRemoved, guildJoin, guildLeave, guildOnboarded, guildUserOnboarded, welcomeMessageRemoved, userLeftMessage, leaverMessageRemoved, reactionRoleGiven, reactionRoleRemoved, giveawayCreated, giveawayStarted, giveawayEnded, tempRoleRemoved, activityRoleGiven, activityRoleRemoved)
 type ScienceGuildEventType int32
 
 // ENUM(unknown, idle, active, expired, refunded, removed)
@@ -14,7 +14,7 @@ type MembershipStatus int32
 // ENUM(unknown, legacyCustomBackgrounds, legacyWelcomerPro, welcomerPro, customBackgrounds)
 type MembershipType int32
 
-// ENUM(unknown, paypal, patreon, stripe, paypal_subscription, discord)
+// ENUM(unknown, paypal, patreon, stripe, paypal_subscription, discord, giveaway)
 type PlatformType int32
 
 // ENUM(unknown, pending, completed, refunded)
@@ -22,3 +22,6 @@ type TransactionStatus int32
 
 // ENUM(default, commas, dots, indian, arabic)
 type NumberLocale int32
+
+// ENUM(unknown, command, freeRoles, reactionRoles)
+type TempRoleSource int32
@@ -267,11 +267,13 @@ const (
 	PlatformTypePaypalSubscription
 	// PlatformTypeDiscord is a PlatformType of type Discord.
 	PlatformTypeDiscord
+	// PlatformTypeGiveaway is a PlatformType of type Giveaway.
+	PlatformTypeGiveaway
 )
 
 var ErrInvalidPlatformType = errors.New("not a valid PlatformType")
 
-const _PlatformTypeName = "unknownpaypalpatreonstripepaypal_subscriptiondiscord"
+const _PlatformTypeName = "unknownpaypalpatreonstripepaypal_subscriptiondiscordgiveaway"
 
 var _PlatformTypeMap = map[PlatformType]string{
 	PlatformTypeUnknown:            _PlatformTypeName[0:7],
@@ -280,6 +282,7 @@ var _PlatformTypeMap = map[PlatformType]string{
 	PlatformTypeStripe:             _PlatformTypeName[20:26],
 	PlatformTypePaypalSubscription: _PlatformTypeName[26:45],
 	PlatformTypeDiscord:            _PlatformTypeName[45:52],
+	PlatformTypeGiveaway:           _PlatformTypeName[52:60],
 }
 
 // String implements the Stringer interface.
@@ -304,6 +307,7 @@ var _PlatformTypeValue = map[string]PlatformType{
 	_PlatformTypeName[20:26]: PlatformTypeStripe,
 	_PlatformTypeName[26:45]: PlatformTypePaypalSubscription,
 	_PlatformTypeName[45:52]: PlatformTypeDiscord,
+	_PlatformTypeName[52:60]: PlatformTypeGiveaway,
 }
 
 // ParsePlatformType attempts to convert a string to a PlatformType.
@@ -447,11 +451,17 @@ const (
 	ScienceGuildEventTypeGiveawayStarted
 	// ScienceGuildEventTypeGiveawayEnded is a ScienceGuildEventType of type GiveawayEnded.
 	ScienceGuildEventTypeGiveawayEnded
+	// ScienceGuildEventTypeTempRoleRemoved is a ScienceGuildEventType of type TempRoleRemoved.
+	ScienceGuildEventTypeTempRoleRemoved
+	// ScienceGuildEventTypeActivityRoleGiven is a ScienceGuildEventType of type ActivityRoleGiven.
+	ScienceGuildEventTypeActivityRoleGiven
+	// ScienceGuildEventTypeActivityRoleRemoved is a ScienceGuildEventType of type ActivityRoleRemoved.
+	ScienceGuildEventTypeActivityRoleRemoved
 )
 
 var ErrInvalidScienceGuildEventType = errors.New("not a valid ScienceGuildEventType")
 
-const _ScienceGuildEventTypeName = "unknownuserJoinuserLeaveuserWelcomedtimeRoleGivenborderwallChallengeborderwallCompletedtempChannelCreatedmembershipReceivedmembershipRemovedguildJoinguildLeaveguildOnboardedguildUserOnboardedwelcomeMessageRemoveduserLeftMessageleaverMessageRemovedreactionRoleGivenreactionRoleRemovedgiveawayCreatedgiveawayStartedgiveawayEnded"
+const _ScienceGuildEventTypeName = "unknownuserJoinuserLeaveuserWelcomedtimeRoleGivenborderwallChallengeborderwallCompletedtempChannelCreatedmembershipReceivedmembershipRemovedguildJoinguildLeaveguildOnboardedguildUserOnboardedwelcomeMessageRemoveduserLeftMessageleaverMessageRemovedreactionRoleGivenreactionRoleRemovedgiveawayCreatedgiveawayStartedgiveawayEndedtempRoleRemovedactivityRoleGivenactivityRoleRemoved"
 
 var _ScienceGuildEventTypeMap = map[ScienceGuildEventType]string{
 	ScienceGuildEventTypeUnknown:               _ScienceGuildEventTypeName[0:7],
@@ -476,6 +486,9 @@ var _ScienceGuildEventTypeMap = map[ScienceGuildEventType]string{
 	ScienceGuildEventTypeGiveawayCreated:       _ScienceGuildEventTypeName[283:298],
 	ScienceGuildEventTypeGiveawayStarted:       _ScienceGuildEventTypeName[298:313],
 	ScienceGuildEventTypeGiveawayEnded:         _ScienceGuildEventTypeName[313:326],
+	ScienceGuildEventTypeTempRoleRemoved:       _ScienceGuildEventTypeName[326:341],
+	ScienceGuildEventTypeActivityRoleGiven:     _ScienceGuildEventTypeName[341:358],
+	ScienceGuildEventTypeActivityRoleRemoved:   _ScienceGuildEventTypeName[358:377],
 }
 
 // String implements the Stringer interface.
@@ -516,6 +529,9 @@ var _ScienceGuildEventTypeValue = map[string]ScienceGuildEventType{
 	_ScienceGuildEventTypeName[283:298]: ScienceGuildEventTypeGiveawayCreated,
 	_ScienceGuildEventTypeName[298:313]: ScienceGuildEventTypeGiveawayStarted,
 	_ScienceGuildEventTypeName[313:326]: ScienceGuildEventTypeGiveawayEnded,
+	_ScienceGuildEventTypeName[326:341]: ScienceGuildEventTypeTempRoleRemoved,
+	_ScienceGuildEventTypeName[341:358]: ScienceGuildEventTypeActivityRoleGiven,
+	_ScienceGuildEventTypeName[358:377]: ScienceGuildEventTypeActivityRoleRemoved,
 }
 
 // ParseScienceGuildEventType attempts to convert a string to a ScienceGuildEventType.
@@ -550,6 +566,82 @@ func (x *ScienceGuildEventType) AppendText(b []byte) ([]byte, error) {
 	return append(b, x.String()...), nil
 }
 
+const (
+	// TempRoleSourceUnknown is a TempRoleSource of type Unknown.
+	TempRoleSourceUnknown TempRoleSource = iota
+	// TempRoleSourceCommand is a TempRoleSource of type Command.
+	TempRoleSourceCommand
+	// TempRoleSourceFreeRoles is a TempRoleSource of type FreeRoles.
+	TempRoleSourceFreeRoles
+	// TempRoleSourceReactionRoles is a TempRoleSource of type ReactionRoles.
+	TempRoleSourceReactionRoles
+)
+
+var ErrInvalidTempRoleSource = errors.New("not a valid TempRoleSource")
+
+const _TempRoleSourceName = "unknowncommandfreeRolesreactionRoles"
+
+var _TempRoleSourceMap = map[TempRoleSource]string{
+	TempRoleSourceUnknown:       _TempRoleSourceName[0:7],
+	TempRoleSourceCommand:       _TempRoleSourceName[7:14],
+	TempRoleSourceFreeRoles:     _TempRoleSourceName[14:23],
+	TempRoleSourceReactionRoles: _TempRoleSourceName[23:36],
+}
+
+// String implements the Stringer interface.
+func (x TempRoleSource) String() string {
+	if str, ok := _TempRoleSourceMap[x]; ok {
+		return str
+	}
+	return fmt.Sprintf("TempRoleSource(%d)", x)
+}
+
+// IsValid provides a quick way to determine if the typed value is
+// part of the allowed enumerated values
+func (x TempRoleSource) IsValid() bool {
+	_, ok := _TempRoleSourceMap[x]
+	return ok
+}
+
+var _TempRoleSourceValue = map[string]TempRoleSource{
+	_TempRoleSourceName[0:7]:   TempRoleSourceUnknown,
+	_TempRoleSourceName[7:14]:  TempRoleSourceCommand,
+	_TempRoleSourceName[14:23]: TempRoleSourceFreeRoles,
+	_TempRoleSourceName[23:36]: TempRoleSourceReactionRoles,
+}
+
+// ParseTempRoleSource attempts to convert a string to a TempRoleSource.
+func ParseTempRoleSource(name string) (TempRoleSource, error) {
+	if x, ok := _TempRoleSourceValue[name]; ok {
+		return x, nil
+	}
+	return TempRoleSource(0), fmt.Errorf("%s is %w", name, ErrInvalidTempRoleSource)
+}
+
+// MarshalText implements the text marshaller method.
+func (x TempRoleSource) MarshalText() ([]byte, error) {
+	return []byte(x.String()), nil
+}
+
+// UnmarshalText implements the text unmarshaller method.
+func (x *TempRoleSource) UnmarshalText(text []byte) error {
+	name := string(text)
+	tmp, err := ParseTempRoleSource(name)
+	if err != nil {
+		return err
+	}
+	*x = tmp
+	return nil
+}
+
+// AppendText appends the textual representation of itself to the end of b
+// (allocating a larger slice if necessary) and returns the updated slice.
+//
+// Implementations must not retain b, nor mutate any bytes within b[:len(b)].
+func (x *TempRoleSource) AppendText(b []byte) ([]byte, error) {
+	return append(b, x.String()...), nil
+}
+
 const (
 	// TransactionStatusUnknown is a TransactionStatus of type Unknown.
 	TransactionStatusUnknown TransactionStatus = iota
@@ -50,6 +50,8 @@ func (x PlatformType) Label() string {
 		return "Paypal Subscription"
 	case PlatformTypeDiscord:
 		return "Discord"
+	case PlatformTypeGiveaway:
+		return "Giveaway"
 	}
 
 	return "Unknown"
@@ -75,9 +75,47 @@ func (q *Queries) GetGiveawayEntries(ctx context.Context, giveawayUuid uuid.UUID
 	return items, nil
 }
 
+const GetGiveawayEntriesPaginated = `-- name: GetGiveawayEntriesPaginated :many
+SELECT guild_giveaway_entry_uuid, giveaway_uuid, user_id, created_at FROM guild_giveaways_entries
+WHERE giveaway_uuid = $1
+ORDER BY created_at DESC
+LIMIT $2 OFFSET $3
+`
+
+type GetGiveawayEntriesPaginatedParams struct {
+	GiveawayUuid uuid.UUID `json:"giveaway_uuid"`
+	Limit        int32     `json:"limit"`
+	Offset       int32     `json:"offset"`
+}
+
+func (q *Queries) GetGiveawayEntriesPaginated(ctx context.Context, arg GetGiveawayEntriesPaginatedParams) ([]*GuildGiveawaysEntries, error) {
+	rows, err := q.db.Query(ctx, GetGiveawayEntriesPaginated, arg.GiveawayUuid, arg.Limit, arg.Offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []*GuildGiveawaysEntries{}
+	for rows.Next() {
+		var i GuildGiveawaysEntries
+		if err := rows.Scan(
+			&i.GuildGiveawayEntryUuid,
+			&i.GiveawayUuid,
+			&i.UserID,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, &i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const GetGiveawayEntryFromMessageID = `-- name: GetGiveawayEntryFromMessageID :one
 SELECT
-    guild_giveaway_entry_uuid, guild_giveaways_entries.giveaway_uuid, user_id, guild_giveaways_entries.created_at, guild_giveaways.giveaway_uuid, guild_giveaways.created_at, guild_id, created_by, allow_entries, has_ended, is_setup, title, description, accent_colour, image_url, start_time, end_time, announce_winners, giveaway_prizes, roles_allowed, roles_excluded, minimum_join_date, message_id, channel_id, show_prizes, show_entries
+    guild_giveaway_entry_uuid, guild_giveaways_entries.giveaway_uuid, user_id, guild_giveaways_entries.created_at, guild_giveaways.giveaway_uuid, guild_giveaways.created_at, guild_id, created_by, allow_entries, has_ended, is_setup, title, description, accent_colour, image_url, start_time, end_time, announce_winners, giveaway_prizes, roles_allowed, roles_excluded, minimum_join_date, message_id, channel_id, show_prizes, show_entries, minimum_account_age, minimum_message_count, minimum_voice_minutes, activity_period_days, recurrence_rule, ping_content, provably_fair, seed_commitment, claim_window, recurrence_anchor, prize_code_pool_uuid
 FROM
     guild_giveaways_entries
     JOIN guild_giveaways ON guild_giveaways.giveaway_uuid = guild_giveaways_entries.giveaway_uuid
@@ -120,6 +158,17 @@ type GetGiveawayEntryFromMessageIDRow struct {
 	ChannelID              int64        `json:"channel_id"`
 	ShowPrizes             bool         `json:"show_prizes"`
 	ShowEntries            bool         `json:"show_entries"`
+	MinimumAccountAge      int64        `json:"minimum_account_age"`
+	MinimumMessageCount    int32        `json:"minimum_message_count"`
+	MinimumVoiceMinutes    int32        `json:"minimum_voice_minutes"`
+	ActivityPeriodDays     int32        `json:"activity_period_days"`
+	RecurrenceRule         string       `json:"recurrence_rule"`
+	PingContent            string       `json:"ping_content"`
+	ProvablyFair           bool         `json:"provably_fair"`
+	SeedCommitment         string       `json:"seed_commitment"`
+	ClaimWindow            int64        `json:"claim_window"`
+	RecurrenceAnchor       time.Time    `json:"recurrence_anchor"`
+	PrizeCodePoolUuid      uuid.UUID    `json:"prize_code_pool_uuid"`
 }
 
 func (q *Queries) GetGiveawayEntryFromMessageID(ctx context.Context, arg GetGiveawayEntryFromMessageIDParams) (*GetGiveawayEntryFromMessageIDRow, error) {
@@ -152,6 +201,17 @@ func (q *Queries) GetGiveawayEntryFromMessageID(ctx context.Context, arg GetGive
 		&i.ChannelID,
 		&i.ShowPrizes,
 		&i.ShowEntries,
+		&i.MinimumAccountAge,
+		&i.MinimumMessageCount,
+		&i.MinimumVoiceMinutes,
+		&i.ActivityPeriodDays,
+		&i.RecurrenceRule,
+		&i.PingContent,
+		&i.ProvablyFair,
+		&i.SeedCommitment,
+		&i.ClaimWindow,
+		&i.RecurrenceAnchor,
+		&i.PrizeCodePoolUuid,
 	)
 	return &i, err
 }
@@ -17,7 +17,7 @@ const CreateGiveaway = `-- name: CreateGiveaway :one
 INSERT INTO guild_giveaways (giveaway_uuid, created_at, guild_id, created_by, allow_entries, has_ended, is_setup, title, description, end_time, start_time, announce_winners, giveaway_prizes, roles_allowed, roles_excluded, minimum_join_date, message_id, channel_id, accent_colour, image_url, show_prizes, show_entries)
 VALUES (uuid_generate_v7(), NOW(), $1, $2, TRUE, FALSE, TRUE, $3, $4, $5, NOW(), TRUE, '[]', '[]', '[]', 'epoch', 0, 0, -1, '', TRUE, TRUE)
 RETURNING
-    giveaway_uuid, created_at, guild_id, created_by, allow_entries, has_ended, is_setup, title, description, accent_colour, image_url, start_time, end_time, announce_winners, giveaway_prizes, roles_allowed, roles_excluded, minimum_join_date, message_id, channel_id, show_prizes, show_entries
+    giveaway_uuid, created_at, guild_id, created_by, allow_entries, has_ended, is_setup, title, description, accent_colour, image_url, start_time, end_time, announce_winners, giveaway_prizes, roles_allowed, roles_excluded, minimum_join_date, message_id, channel_id, show_prizes, show_entries, minimum_account_age, minimum_message_count, minimum_voice_minutes, activity_period_days, recurrence_rule, ping_content, provably_fair, seed_commitment, claim_window, recurrence_anchor, prize_code_pool_uuid
 `
 
 type CreateGiveawayParams struct {
@@ -60,18 +60,50 @@ func (q *Queries) CreateGiveaway(ctx context.Context, arg CreateGiveawayParams)
 		&i.ChannelID,
 		&i.ShowPrizes,
 		&i.ShowEntries,
+		&i.MinimumAccountAge,
+		&i.MinimumMessageCount,
+		&i.MinimumVoiceMinutes,
+		&i.ActivityPeriodDays,
+		&i.RecurrenceRule,
+		&i.PingContent,
+		&i.ProvablyFair,
+		&i.SeedCommitment,
+		&i.ClaimWindow,
+		&i.RecurrenceAnchor,
+		&i.PrizeCodePoolUuid,
 	)
 	return &i, err
 }
 
+const DeleteGiveaway = `-- name: DeleteGiveaway :execrows
+DELETE FROM guild_giveaways
+WHERE
+    guild_id = $1
+    AND giveaway_uuid = $2
+`
+
+type DeleteGiveawayParams struct {
+	GuildID      int64     `json:"guild_id"`
+	GiveawayUuid uuid.UUID `json:"giveaway_uuid"`
+}
+
+func (q *Queries) DeleteGiveaway(ctx context.Context, arg DeleteGiveawayParams) (int64, error) {
+	result, err := q.db.Exec(ctx, DeleteGiveaway, arg.GuildID, arg.GiveawayUuid)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
 const GetExpiredGiveaways = `-- name: GetExpiredGiveaways :many
 SELECT
-    giveaway_uuid, created_at, guild_id, created_by, allow_entries, has_ended, is_setup, title, description, accent_colour, image_url, start_time, end_time, announce_winners, giveaway_prizes, roles_allowed, roles_excluded, minimum_join_date, message_id, channel_id, show_prizes, show_entries
+    giveaway_uuid, created_at, guild_id, created_by, allow_entries, has_ended, is_setup, title, description, accent_colour, image_url, start_time, end_time, announce_winners, giveaway_prizes, roles_allowed, roles_excluded, minimum_join_date, message_id, channel_id, show_prizes, show_entries, minimum_account_age, minimum_message_count, minimum_voice_minutes, activity_period_days, recurrence_rule, ping_content, provably_fair, seed_commitment, claim_window, recurrence_anchor, prize_code_pool_uuid
 FROM
     guild_giveaways
 WHERE
     has_ended = FALSE
     AND is_setup = FALSE
+    AND message_id != 0
     AND end_time <= NOW()
     AND end_time > 'epoch'
 `
@@ -108,6 +140,17 @@ func (q *Queries) GetExpiredGiveaways(ctx context.Context) ([]*GuildGiveaways, e
 			&i.ChannelID,
 			&i.ShowPrizes,
 			&i.ShowEntries,
+			&i.MinimumAccountAge,
+			&i.MinimumMessageCount,
+			&i.MinimumVoiceMinutes,
+			&i.ActivityPeriodDays,
+			&i.RecurrenceRule,
+			&i.PingContent,
+			&i.ProvablyFair,
+			&i.SeedCommitment,
+			&i.ClaimWindow,
+			&i.RecurrenceAnchor,
+			&i.PrizeCodePoolUuid,
 		); err != nil {
 			return nil, err
 		}
@@ -121,7 +164,7 @@ func (q *Queries) GetExpiredGiveaways(ctx context.Context) ([]*GuildGiveaways, e
 
 const GetGiveaway = `-- name: GetGiveaway :one
 SELECT
-    giveaway_uuid, created_at, guild_id, created_by, allow_entries, has_ended, is_setup, title, description, accent_colour, image_url, start_time, end_time, announce_winners, giveaway_prizes, roles_allowed, roles_excluded, minimum_join_date, message_id, channel_id, show_prizes, show_entries
+    giveaway_uuid, created_at, guild_id, created_by, allow_entries, has_ended, is_setup, title, description, accent_colour, image_url, start_time, end_time, announce_winners, giveaway_prizes, roles_allowed, roles_excluded, minimum_join_date, message_id, channel_id, show_prizes, show_entries, minimum_account_age, minimum_message_count, minimum_voice_minutes, activity_period_days, recurrence_rule, ping_content, provably_fair, seed_commitment, claim_window, recurrence_anchor, prize_code_pool_uuid
 FROM
     guild_giveaways
 WHERE
@@ -160,13 +203,24 @@ func (q *Queries) GetGiveaway(ctx context.Context, arg GetGiveawayParams) (*Guil
 		&i.ChannelID,
 		&i.ShowPrizes,
 		&i.ShowEntries,
+		&i.MinimumAccountAge,
+		&i.MinimumMessageCount,
+		&i.MinimumVoiceMinutes,
+		&i.ActivityPeriodDays,
+		&i.RecurrenceRule,
+		&i.PingContent,
+		&i.ProvablyFair,
+		&i.SeedCommitment,
+		&i.ClaimWindow,
+		&i.RecurrenceAnchor,
+		&i.PrizeCodePoolUuid,
 	)
 	return &i, err
 }
 
 const GetGiveawayFromMessageID = `-- name: GetGiveawayFromMessageID :one
 SELECT
-    giveaway_uuid, created_at, guild_id, created_by, allow_entries, has_ended, is_setup, title, description, accent_colour, image_url, start_time, end_time, announce_winners, giveaway_prizes, roles_allowed, roles_excluded, minimum_join_date, message_id, channel_id, show_prizes, show_entries
+    giveaway_uuid, created_at, guild_id, created_by, allow_entries, has_ended, is_setup, title, description, accent_colour, image_url, start_time, end_time, announce_winners, giveaway_prizes, roles_allowed, roles_excluded, minimum_join_date, message_id, channel_id, show_prizes, show_entries, minimum_account_age, minimum_message_count, minimum_voice_minutes, activity_period_days, recurrence_rule, ping_content, provably_fair, seed_commitment, claim_window, recurrence_anchor, prize_code_pool_uuid
 FROM
     guild_giveaways
 WHERE
@@ -207,10 +261,316 @@ func (q *Queries) GetGiveawayFromMessageID(ctx context.Context, arg GetGiveawayF
 		&i.ChannelID,
 		&i.ShowPrizes,
 		&i.ShowEntries,
+		&i.MinimumAccountAge,
+		&i.MinimumMessageCount,
+		&i.MinimumVoiceMinutes,
+		&i.ActivityPeriodDays,
+		&i.RecurrenceRule,
+		&i.PingContent,
+		&i.ProvablyFair,
+		&i.SeedCommitment,
+		&i.ClaimWindow,
+		&i.RecurrenceAnchor,
+		&i.PrizeCodePoolUuid,
 	)
 	return &i, err
 }
 
+const GetGuildEndedGiveaways = `-- name: GetGuildEndedGiveaways :many
+SELECT
+    guild_giveaways.giveaway_uuid,
+    guild_giveaways.title,
+    guild_giveaways.channel_id,
+    guild_giveaways.message_id,
+    guild_giveaways.end_time,
+    (
+        SELECT
+            COUNT(*)
+        FROM
+            guild_giveaways_entries
+        WHERE
+            guild_giveaways_entries.giveaway_uuid = guild_giveaways.giveaway_uuid) AS entries,
+    COUNT(guild_giveaways_winners.giveaway_winner_uuid) AS winners,
+    COUNT(guild_giveaways_winners.giveaway_winner_uuid) FILTER (WHERE guild_giveaways_winners.delivery_error != '') AS delivery_failures
+FROM
+    guild_giveaways
+    LEFT JOIN guild_giveaways_winners ON guild_giveaways_winners.giveaway_uuid = guild_giveaways.giveaway_uuid
+WHERE
+    guild_giveaways.guild_id = $1
+    AND guild_giveaways.has_ended = TRUE
+    AND guild_giveaways.end_time >= $2
+    AND guild_giveaways.end_time < $3
+GROUP BY
+    guild_giveaways.giveaway_uuid
+ORDER BY
+    guild_giveaways.end_time DESC
+LIMIT $4
+`
+
+type GetGuildEndedGiveawaysParams struct {
+	GuildID    int64     `json:"guild_id"`
+	Since      time.Time `json:"since"`
+	Until      time.Time `json:"until"`
+	EntryLimit int32     `json:"entry_limit"`
+}
+
+type GetGuildEndedGiveawaysRow struct {
+	GiveawayUuid     uuid.UUID `json:"giveaway_uuid"`
+	Title            string    `json:"title"`
+	ChannelID        int64     `json:"channel_id"`
+	MessageID        int64     `json:"message_id"`
+	EndTime          time.Time `json:"end_time"`
+	Entries          int64     `json:"entries"`
+	Winners          int64     `json:"winners"`
+	DeliveryFailures int64     `json:"delivery_failures"`
+}
+
+func (q *Queries) GetGuildEndedGiveaways(ctx context.Context, arg GetGuildEndedGiveawaysParams) ([]*GetGuildEndedGiveawaysRow, error) {
+	rows, err := q.db.Query(ctx, GetGuildEndedGiveaways,
+		arg.GuildID,
+		arg.Since,
+		arg.Until,
+		arg.EntryLimit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []*GetGuildEndedGiveawaysRow{}
+	for rows.Next() {
+		var i GetGuildEndedGiveawaysRow
+		if err := rows.Scan(
+			&i.GiveawayUuid,
+			&i.Title,
+			&i.ChannelID,
+			&i.MessageID,
+			&i.EndTime,
+			&i.Entries,
+			&i.Winners,
+			&i.DeliveryFailures,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, &i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const GetGuildGiveaways = `-- name: GetGuildGiveaways :many
+SELECT
+    guild_giveaways.giveaway_uuid, guild_giveaways.created_at, guild_giveaways.guild_id, guild_giveaways.created_by, guild_giveaways.allow_entries, guild_giveaways.has_ended, guild_giveaways.is_setup, guild_giveaways.title, guild_giveaways.description, guild_giveaways.accent_colour, guild_giveaways.image_url, guild_giveaways.start_time, guild_giveaways.end_time, guild_giveaways.announce_winners, guild_giveaways.giveaway_prizes, guild_giveaways.roles_allowed, guild_giveaways.roles_excluded, guild_giveaways.minimum_join_date, guild_giveaways.message_id, guild_giveaways.channel_id, guild_giveaways.show_prizes, guild_giveaways.show_entries, guild_giveaways.minimum_account_age, guild_giveaways.minimum_message_count, guild_giveaways.minimum_voice_minutes, guild_giveaways.activity_period_days, guild_giveaways.recurrence_rule, guild_giveaways.ping_content, guild_giveaways.provably_fair, guild_giveaways.seed_commitment, guild_giveaways.claim_window, guild_giveaways.recurrence_anchor, guild_giveaways.prize_code_pool_uuid,
+    (
+        SELECT
+            COUNT(*)
+        FROM
+            guild_giveaways_entries
+        WHERE
+            guild_giveaways_entries.giveaway_uuid = guild_giveaways.giveaway_uuid)::int AS entries
+FROM
+    guild_giveaways
+WHERE
+    guild_id = $1
+ORDER BY
+    created_at DESC
+`
+
+type GetGuildGiveawaysRow struct {
+	GuildGiveaways GuildGiveaways `json:"guild_giveaways"`
+	Entries        int32          `json:"entries"`
+}
+
+func (q *Queries) GetGuildGiveaways(ctx context.Context, guildID int64) ([]*GetGuildGiveawaysRow, error) {
+	rows, err := q.db.Query(ctx, GetGuildGiveaways, guildID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []*GetGuildGiveawaysRow{}
+	for rows.Next() {
+		var i GetGuildGiveawaysRow
+		if err := rows.Scan(
+			&i.GuildGiveaways.GiveawayUuid,
+			&i.GuildGiveaways.CreatedAt,
+			&i.GuildGiveaways.GuildID,
+			&i.GuildGiveaways.CreatedBy,
+			&i.GuildGiveaways.AllowEntries,
+			&i.GuildGiveaways.HasEnded,
+			&i.GuildGiveaways.IsSetup,
+			&i.GuildGiveaways.Title,
+			&i.GuildGiveaways.Description,
+			&i.GuildGiveaways.AccentColour,
+			&i.GuildGiveaways.ImageUrl,
+			&i.GuildGiveaways.StartTime,
+			&i.GuildGiveaways.EndTime,
+			&i.GuildGiveaways.AnnounceWinners,
+			&i.GuildGiveaways.GiveawayPrizes,
+			&i.GuildGiveaways.RolesAllowed,
+			&i.GuildGiveaways.RolesExcluded,
+			&i.GuildGiveaways.MinimumJoinDate,
+			&i.GuildGiveaways.MessageID,
+			&i.GuildGiveaways.ChannelID,
+			&i.GuildGiveaways.ShowPrizes,
+			&i.GuildGiveaways.ShowEntries,
+			&i.GuildGiveaways.MinimumAccountAge,
+			&i.GuildGiveaways.MinimumMessageCount,
+			&i.GuildGiveaways.MinimumVoiceMinutes,
+			&i.GuildGiveaways.ActivityPeriodDays,
+			&i.GuildGiveaways.RecurrenceRule,
+			&i.GuildGiveaways.PingContent,
+			&i.GuildGiveaways.ProvablyFair,
+			&i.GuildGiveaways.SeedCommitment,
+			&i.GuildGiveaways.ClaimWindow,
+			&i.GuildGiveaways.RecurrenceAnchor,
+			&i.GuildGiveaways.PrizeCodePoolUuid,
+			&i.Entries,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, &i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const GetGuildScheduledGiveaways = `-- name: GetGuildScheduledGiveaways :many
+SELECT
+    giveaway_uuid, created_at, guild_id, created_by, allow_entries, has_ended, is_setup, title, description, accent_colour, image_url, start_time, end_time, announce_winners, giveaway_prizes, roles_allowed, roles_excluded, minimum_join_date, message_id, channel_id, show_prizes, show_entries, minimum_account_age, minimum_message_count, minimum_voice_minutes, activity_period_days, recurrence_rule, ping_content, provably_fair, seed_commitment, claim_window, recurrence_anchor, prize_code_pool_uuid
+FROM
+    guild_giveaways
+WHERE
+    guild_id = $1
+    AND has_ended = FALSE
+    AND is_setup = FALSE
+    AND message_id = 0
+ORDER BY
+    start_time
+`
+
+func (q *Queries) GetGuildScheduledGiveaways(ctx context.Context, guildID int64) ([]*GuildGiveaways, error) {
+	rows, err := q.db.Query(ctx, GetGuildScheduledGiveaways, guildID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []*GuildGiveaways{}
+	for rows.Next() {
+		var i GuildGiveaways
+		if err := rows.Scan(
+			&i.GiveawayUuid,
+			&i.CreatedAt,
+			&i.GuildID,
+			&i.CreatedBy,
+			&i.AllowEntries,
+			&i.HasEnded,
+			&i.IsSetup,
+			&i.Title,
+			&i.Description,
+			&i.AccentColour,
+			&i.ImageUrl,
+			&i.StartTime,
+			&i.EndTime,
+			&i.AnnounceWinners,
+			&i.GiveawayPrizes,
+			&i.RolesAllowed,
+			&i.RolesExcluded,
+			&i.MinimumJoinDate,
+			&i.MessageID,
+			&i.ChannelID,
+			&i.ShowPrizes,
+			&i.ShowEntries,
+			&i.MinimumAccountAge,
+			&i.MinimumMessageCount,
+			&i.MinimumVoiceMinutes,
+			&i.ActivityPeriodDays,
+			&i.RecurrenceRule,
+			&i.PingContent,
+			&i.ProvablyFair,
+			&i.SeedCommitment,
+			&i.ClaimWindow,
+			&i.RecurrenceAnchor,
+			&i.PrizeCodePoolUuid,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, &i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const GetScheduledGiveaways = `-- name: GetScheduledGiveaways :many
+SELECT
+    giveaway_uuid, created_at, guild_id, created_by, allow_entries, has_ended, is_setup, title, description, accent_colour, image_url, start_time, end_time, announce_winners, giveaway_prizes, roles_allowed, roles_excluded, minimum_join_date, message_id, channel_id, show_prizes, show_entries, minimum_account_age, minimum_message_count, minimum_voice_minutes, activity_period_days, recurrence_rule, ping_content, provably_fair, seed_commitment, claim_window, recurrence_anchor, prize_code_pool_uuid
+FROM
+    guild_giveaways
+WHERE
+    has_ended = FALSE
+    AND is_setup = FALSE
+    AND message_id = 0
+    AND start_time <= NOW()
+`
+
+func (q *Queries) GetScheduledGiveaways(ctx context.Context) ([]*GuildGiveaways, error) {
+	rows, err := q.db.Query(ctx, GetScheduledGiveaways)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []*GuildGiveaways{}
+	for rows.Next() {
+		var i GuildGiveaways
+		if err := rows.Scan(
+			&i.GiveawayUuid,
+			&i.CreatedAt,
+			&i.GuildID,
+			&i.CreatedBy,
+			&i.AllowEntries,
+			&i.HasEnded,
+			&i.IsSetup,
+			&i.Title,
+			&i.Description,
+			&i.AccentColour,
+			&i.ImageUrl,
+			&i.StartTime,
+			&i.EndTime,
+			&i.AnnounceWinners,
+			&i.GiveawayPrizes,
+			&i.RolesAllowed,
+			&i.RolesExcluded,
+			&i.MinimumJoinDate,
+			&i.MessageID,
+			&i.ChannelID,
+			&i.ShowPrizes,
+			&i.ShowEntries,
+			&i.MinimumAccountAge,
+			&i.MinimumMessageCount,
+			&i.MinimumVoiceMinutes,
+			&i.ActivityPeriodDays,
+			&i.RecurrenceRule,
+			&i.PingContent,
+			&i.ProvablyFair,
+			&i.SeedCommitment,
+			&i.ClaimWindow,
+			&i.RecurrenceAnchor,
+			&i.PrizeCodePoolUuid,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, &i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const SetGiveawayEnded = `-- name: SetGiveawayEnded :one
 UPDATE
     guild_giveaways
@@ -219,7 +579,7 @@ SET
 WHERE
     giveaway_uuid = $1
 RETURNING
-    giveaway_uuid, created_at, guild_id, created_by, allow_entries, has_ended, is_setup, title, description, accent_colour, image_url, start_time, end_time, announce_winners, giveaway_prizes, roles_allowed, roles_excluded, minimum_join_date, message_id, channel_id, show_prizes, show_entries
+    giveaway_uuid, created_at, guild_id, created_by, allow_entries, has_ended, is_setup, title, description, accent_colour, image_url, start_time, end_time, announce_winners, giveaway_prizes, roles_allowed, roles_excluded, minimum_join_date, message_id, channel_id, show_prizes, show_entries, minimum_account_age, minimum_message_count, minimum_voice_minutes, activity_period_days, recurrence_rule, ping_content, provably_fair, seed_commitment, claim_window, recurrence_anchor, prize_code_pool_uuid
 `
 
 type SetGiveawayEndedParams struct {
@@ -253,6 +613,112 @@ func (q *Queries) SetGiveawayEnded(ctx context.Context, arg SetGiveawayEndedPara
 		&i.ChannelID,
 		&i.ShowPrizes,
 		&i.ShowEntries,
+		&i.MinimumAccountAge,
+		&i.MinimumMessageCount,
+		&i.MinimumVoiceMinutes,
+		&i.ActivityPeriodDays,
+		&i.RecurrenceRule,
+		&i.PingContent,
+		&i.ProvablyFair,
+		&i.SeedCommitment,
+		&i.ClaimWindow,
+		&i.RecurrenceAnchor,
+		&i.PrizeCodePoolUuid,
+	)
+	return &i, err
+}
+
+const SetGiveawayPrizeCodePool = `-- name: SetGiveawayPrizeCodePool :exec
+UPDATE
+    guild_giveaways
+SET
+    prize_code_pool_uuid = $2
+WHERE
+    giveaway_uuid = $1
+`
+
+type SetGiveawayPrizeCodePoolParams struct {
+	GiveawayUuid      uuid.UUID `json:"giveaway_uuid"`
+	PrizeCodePoolUuid uuid.UUID `json:"prize_code_pool_uuid"`
+}
+
+func (q *Queries) SetGiveawayPrizeCodePool(ctx context.Context, arg SetGiveawayPrizeCodePoolParams) error {
+	_, err := q.db.Exec(ctx, SetGiveawayPrizeCodePool, arg.GiveawayUuid, arg.PrizeCodePoolUuid)
+	return err
+}
+
+const SetGiveawayRecurrenceAnchor = `-- name: SetGiveawayRecurrenceAnchor :exec
+UPDATE
+    guild_giveaways
+SET
+    recurrence_anchor = $2
+WHERE
+    giveaway_uuid = $1
+`
+
+type SetGiveawayRecurrenceAnchorParams struct {
+	GiveawayUuid     uuid.UUID `json:"giveaway_uuid"`
+	RecurrenceAnchor time.Time `json:"recurrence_anchor"`
+}
+
+func (q *Queries) SetGiveawayRecurrenceAnchor(ctx context.Context, arg SetGiveawayRecurrenceAnchorParams) error {
+	_, err := q.db.Exec(ctx, SetGiveawayRecurrenceAnchor, arg.GiveawayUuid, arg.RecurrenceAnchor)
+	return err
+}
+
+const SetGiveawaySeedCommitment = `-- name: SetGiveawaySeedCommitment :one
+UPDATE
+    guild_giveaways
+SET
+    seed_commitment = $2
+WHERE
+    giveaway_uuid = $1
+RETURNING
+    giveaway_uuid, created_at, guild_id, created_by, allow_entries, has_ended, is_setup, title, description, accent_colour, image_url, start_time, end_time, announce_winners, giveaway_prizes, roles_allowed, roles_excluded, minimum_join_date, message_id, channel_id, show_prizes, show_entries, minimum_account_age, minimum_message_count, minimum_voice_minutes, activity_period_days, recurrence_rule, ping_content, provably_fair, seed_commitment, claim_window, recurrence_anchor, prize_code_pool_uuid
+`
+
+type SetGiveawaySeedCommitmentParams struct {
+	GiveawayUuid   uuid.UUID `json:"giveaway_uuid"`
+	SeedCommitment string    `json:"seed_commitment"`
+}
+
+func (q *Queries) SetGiveawaySeedCommitment(ctx context.Context, arg SetGiveawaySeedCommitmentParams) (*GuildGiveaways, error) {
+	row := q.db.QueryRow(ctx, SetGiveawaySeedCommitment, arg.GiveawayUuid, arg.SeedCommitment)
+	var i GuildGiveaways
+	err := row.Scan(
+		&i.GiveawayUuid,
+		&i.CreatedAt,
+		&i.GuildID,
+		&i.CreatedBy,
+		&i.AllowEntries,
+		&i.HasEnded,
+		&i.IsSetup,
+		&i.Title,
+		&i.Description,
+		&i.AccentColour,
+		&i.ImageUrl,
+		&i.StartTime,
+		&i.EndTime,
+		&i.AnnounceWinners,
+		&i.GiveawayPrizes,
+		&i.RolesAllowed,
+		&i.RolesExcluded,
+		&i.MinimumJoinDate,
+		&i.MessageID,
+		&i.ChannelID,
+		&i.ShowPrizes,
+		&i.ShowEntries,
+		&i.MinimumAccountAge,
+		&i.MinimumMessageCount,
+		&i.MinimumVoiceMinutes,
+		&i.ActivityPeriodDays,
+		&i.RecurrenceRule,
+		&i.PingContent,
+		&i.ProvablyFair,
+		&i.SeedCommitment,
+		&i.ClaimWindow,
+		&i.RecurrenceAnchor,
+		&i.PrizeCodePoolUuid,
 	)
 	return &i, err
 }
@@ -276,31 +742,47 @@ SET
     accent_colour = $14,
     image_url = $15,
     show_prizes = $16,
-    show_entries = $17
+    show_entries = $17,
+    minimum_account_age = $18,
+    minimum_message_count = $19,
+    minimum_voice_minutes = $20,
+    activity_period_days = $21,
+    recurrence_rule = $22,
+    ping_content = $23,
+    provably_fair = $24,
+    claim_window = $25
 WHERE
     giveaway_uuid = $1
 RETURNING
-    giveaway_uuid, created_at, guild_id, created_by, allow_entries, has_ended, is_setup, title, description, accent_colour, image_url, start_time, end_time, announce_winners, giveaway_prizes, roles_allowed, roles_excluded, minimum_join_date, message_id, channel_id, show_prizes, show_entries
+    giveaway_uuid, created_at, guild_id, created_by, allow_entries, has_ended, is_setup, title, description, accent_colour, image_url, start_time, end_time, announce_winners, giveaway_prizes, roles_allowed, roles_excluded, minimum_join_date, message_id, channel_id, show_prizes, show_entries, minimum_account_age, minimum_message_count, minimum_voice_minutes, activity_period_days, recurrence_rule, ping_content, provably_fair, seed_commitment, claim_window, recurrence_anchor, prize_code_pool_uuid
 `
 
 type UpdateGiveawayParams struct {
-	GiveawayUuid    uuid.UUID    `json:"giveaway_uuid"`
-	IsSetup         bool         `json:"is_setup"`
-	AllowEntries    bool         `json:"allow_entries"`
-	HasEnded        bool         `json:"has_ended"`
-	Title           string       `json:"title"`
-	Description     string       `json:"description"`
-	StartTime       time.Time    `json:"start_time"`
-	EndTime         time.Time    `json:"end_time"`
-	AnnounceWinners bool         `json:"announce_winners"`
-	GiveawayPrizes  pgtype.JSONB `json:"giveaway_prizes"`
-	RolesAllowed    pgtype.JSONB `json:"roles_allowed"`
-	RolesExcluded   pgtype.JSONB `json:"roles_excluded"`
-	MinimumJoinDate time.Time    `json:"minimum_join_date"`
-	AccentColour    int64        `json:"accent_colour"`
-	ImageUrl        string       `json:"image_url"`
-	ShowPrizes      bool         `json:"show_prizes"`
-	ShowEntries     bool         `json:"show_entries"`
+	GiveawayUuid        uuid.UUID    `json:"giveaway_uuid"`
+	IsSetup             bool         `json:"is_setup"`
+	AllowEntries        bool         `json:"allow_entries"`
+	HasEnded            bool         `json:"has_ended"`
+	Title               string       `json:"title"`
+	Description         string       `json:"description"`
+	StartTime           time.Time    `json:"start_time"`
+	EndTime             time.Time    `json:"end_time"`
+	AnnounceWinners     bool         `json:"announce_winners"`
+	GiveawayPrizes      pgtype.JSONB `json:"giveaway_prizes"`
+	RolesAllowed        pgtype.JSONB `json:"roles_allowed"`
+	RolesExcluded       pgtype.JSONB `json:"roles_excluded"`
+	MinimumJoinDate     time.Time    `json:"minimum_join_date"`
+	AccentColour        int64        `json:"accent_colour"`
+	ImageUrl            string       `json:"image_url"`
+	ShowPrizes          bool         `json:"show_prizes"`
+	ShowEntries         bool         `json:"show_entries"`
+	MinimumAccountAge   int64        `json:"minimum_account_age"`
+	MinimumMessageCount int32        `json:"minimum_message_count"`
+	MinimumVoiceMinutes int32        `json:"minimum_voice_minutes"`
+	ActivityPeriodDays  int32        `json:"activity_period_days"`
+	RecurrenceRule      string       `json:"recurrence_rule"`
+	PingContent         string       `json:"ping_content"`
+	ProvablyFair        bool         `json:"provably_fair"`
+	ClaimWindow         int64        `json:"claim_window"`
 }
 
 func (q *Queries) UpdateGiveaway(ctx context.Context, arg UpdateGiveawayParams) (*GuildGiveaways, error) {
@@ -322,6 +804,14 @@ func (q *Queries) UpdateGiveaway(ctx context.Context, arg UpdateGiveawayParams)
 		arg.ImageUrl,
 		arg.ShowPrizes,
 		arg.ShowEntries,
+		arg.MinimumAccountAge,
+		arg.MinimumMessageCount,
+		arg.MinimumVoiceMinutes,
+		arg.ActivityPeriodDays,
+		arg.RecurrenceRule,
+		arg.PingContent,
+		arg.ProvablyFair,
+		arg.ClaimWindow,
 	)
 	var i GuildGiveaways
 	err := row.Scan(
@@ -347,6 +837,17 @@ func (q *Queries) UpdateGiveaway(ctx context.Context, arg UpdateGiveawayParams)
 		&i.ChannelID,
 		&i.ShowPrizes,
 		&i.ShowEntries,
+		&i.MinimumAccountAge,
+		&i.MinimumMessageCount,
+		&i.MinimumVoiceMinutes,
+		&i.ActivityPeriodDays,
+		&i.RecurrenceRule,
+		&i.PingContent,
+		&i.ProvablyFair,
+		&i.SeedCommitment,
+		&i.ClaimWindow,
+		&i.RecurrenceAnchor,
+		&i.PrizeCodePoolUuid,
 	)
 	return &i, err
 }
@@ -360,7 +861,7 @@ SET
 WHERE
     giveaway_uuid = $1
 RETURNING
-    giveaway_uuid, created_at, guild_id, created_by, allow_entries, has_ended, is_setup, title, description, accent_colour, image_url, start_time, end_time, announce_winners, giveaway_prizes, roles_allowed, roles_excluded, minimum_join_date, message_id, channel_id, show_prizes, show_entries
+    giveaway_uuid, created_at, guild_id, created_by, allow_entries, has_ended, is_setup, title, description, accent_colour, image_url, start_time, end_time, announce_winners, giveaway_prizes, roles_allowed, roles_excluded, minimum_join_date, message_id, channel_id, show_prizes, show_entries, minimum_account_age, minimum_message_count, minimum_voice_minutes, activity_period_days, recurrence_rule, ping_content, provably_fair, seed_commitment, claim_window, recurrence_anchor, prize_code_pool_uuid
 `
 
 type UpdateGiveawayMessageParams struct {
@@ -395,6 +896,17 @@ func (q *Queries) UpdateGiveawayMessage(ctx context.Context, arg UpdateGiveawayM
 		&i.ChannelID,
 		&i.ShowPrizes,
 		&i.ShowEntries,
+		&i.MinimumAccountAge,
+		&i.MinimumMessageCount,
+		&i.MinimumVoiceMinutes,
+		&i.ActivityPeriodDays,
+		&i.RecurrenceRule,
+		&i.PingContent,
+		&i.ProvablyFair,
+		&i.SeedCommitment,
+		&i.ClaimWindow,
+		&i.RecurrenceAnchor,
+		&i.PrizeCodePoolUuid,
 	)
 	return &i, err
 }
@@ -7,22 +7,60 @@ package database
 
 import (
 	"context"
+	"time"
 
 	"github.com/gofrs/uuid"
 )
 
+const ClaimGiveawayWinner = `-- name: ClaimGiveawayWinner :one
+UPDATE
+    guild_giveaways_winners
+SET
+    is_claimed = TRUE,
+    claimed_at = NOW()
+WHERE
+    giveaway_winner_uuid = $1
+    AND is_claimed = FALSE
+    AND is_expired = FALSE
+RETURNING
+    giveaway_winner_uuid, giveaway_uuid, user_id, prize, message_id, claim_expires_at, is_claimed, claimed_at, is_expired, role_id, role_expires_at, is_role_removed, is_delivered, delivery_error
+`
+
+func (q *Queries) ClaimGiveawayWinner(ctx context.Context, giveawayWinnerUuid uuid.UUID) (*GuildGiveawaysWinners, error) {
+	row := q.db.QueryRow(ctx, ClaimGiveawayWinner, giveawayWinnerUuid)
+	var i GuildGiveawaysWinners
+	err := row.Scan(
+		&i.GiveawayWinnerUuid,
+		&i.GiveawayUuid,
+		&i.UserID,
+		&i.Prize,
+		&i.MessageID,
+		&i.ClaimExpiresAt,
+		&i.IsClaimed,
+		&i.ClaimedAt,
+		&i.IsExpired,
+		&i.RoleID,
+		&i.RoleExpiresAt,
+		&i.IsRoleRemoved,
+		&i.IsDelivered,
+		&i.DeliveryError,
+	)
+	return &i, err
+}
+
 const CreateGiveawayWinner = `-- name: CreateGiveawayWinner :one
-INSERT INTO guild_giveaways_winners (giveaway_winner_uuid, giveaway_uuid, user_id, prize, message_id)
-VALUES (uuid_generate_v7(), $1, $2, $3, $4)
+INSERT INTO guild_giveaways_winners (giveaway_winner_uuid, giveaway_uuid, user_id, prize, message_id, claim_expires_at)
+VALUES (uuid_generate_v7(), $1, $2, $3, $4, $5)
 RETURNING
-    giveaway_winner_uuid, giveaway_uuid, user_id, prize, message_id
+    giveaway_winner_uuid, giveaway_uuid, user_id, prize, message_id, claim_expires_at, is_claimed, claimed_at, is_expired, role_id, role_expires_at, is_role_removed, is_delivered, delivery_error
 `
 
 type CreateGiveawayWinnerParams struct {
-	GiveawayUuid uuid.UUID `json:"giveaway_uuid"`
-	UserID       int64     `json:"user_id"`
-	Prize        string    `json:"prize"`
-	MessageID    int64     `json:"message_id"`
+	GiveawayUuid   uuid.UUID `json:"giveaway_uuid"`
+	UserID         int64     `json:"user_id"`
+	Prize          string    `json:"prize"`
+	MessageID      int64     `json:"message_id"`
+	ClaimExpiresAt time.Time `json:"claim_expires_at"`
 }
 
 func (q *Queries) CreateGiveawayWinner(ctx context.Context, arg CreateGiveawayWinnerParams) (*GuildGiveawaysWinners, error) {
@@ -31,7 +69,122 @@ func (q *Queries) CreateGiveawayWinner(ctx context.Context, arg CreateGiveawayWi
 		arg.UserID,
 		arg.Prize,
 		arg.MessageID,
+		arg.ClaimExpiresAt,
+	)
+	var i GuildGiveawaysWinners
+	err := row.Scan(
+		&i.GiveawayWinnerUuid,
+		&i.GiveawayUuid,
+		&i.UserID,
+		&i.Prize,
+		&i.MessageID,
+		&i.ClaimExpiresAt,
+		&i.IsClaimed,
+		&i.ClaimedAt,
+		&i.IsExpired,
+		&i.RoleID,
+		&i.RoleExpiresAt,
+		&i.IsRoleRemoved,
+		&i.IsDelivered,
+		&i.DeliveryError,
+	)
+	return &i, err
+}
+
+const ExpireGiveawayWinner = `-- name: ExpireGiveawayWinner :one
+UPDATE
+    guild_giveaways_winners
+SET
+    is_expired = TRUE
+WHERE
+    giveaway_winner_uuid = $1
+    AND is_expired = FALSE
+RETURNING
+    giveaway_winner_uuid, giveaway_uuid, user_id, prize, message_id, claim_expires_at, is_claimed, claimed_at, is_expired, role_id, role_expires_at, is_role_removed, is_delivered, delivery_error
+`
+
+func (q *Queries) ExpireGiveawayWinner(ctx context.Context, giveawayWinnerUuid uuid.UUID) (*GuildGiveawaysWinners, error) {
+	row := q.db.QueryRow(ctx, ExpireGiveawayWinner, giveawayWinnerUuid)
+	var i GuildGiveawaysWinners
+	err := row.Scan(
+		&i.GiveawayWinnerUuid,
+		&i.GiveawayUuid,
+		&i.UserID,
+		&i.Prize,
+		&i.MessageID,
+		&i.ClaimExpiresAt,
+		&i.IsClaimed,
+		&i.ClaimedAt,
+		&i.IsExpired,
+		&i.RoleID,
+		&i.RoleExpiresAt,
+		&i.IsRoleRemoved,
+		&i.IsDelivered,
+		&i.DeliveryError,
 	)
+	return &i, err
+}
+
+const GetExpiredGiveawayPrizeRoles = `-- name: GetExpiredGiveawayPrizeRoles :many
+SELECT
+    giveaway_winner_uuid, giveaway_uuid, user_id, prize, message_id, claim_expires_at, is_claimed, claimed_at, is_expired, role_id, role_expires_at, is_role_removed, is_delivered, delivery_error
+FROM
+    guild_giveaways_winners
+WHERE
+    giveaway_uuid = $1
+    AND role_id != 0
+    AND is_role_removed = FALSE
+    AND (is_expired = TRUE
+        OR (role_expires_at > 'epoch'
+            AND role_expires_at <= NOW()))
+`
+
+func (q *Queries) GetExpiredGiveawayPrizeRoles(ctx context.Context, giveawayUuid uuid.UUID) ([]*GuildGiveawaysWinners, error) {
+	rows, err := q.db.Query(ctx, GetExpiredGiveawayPrizeRoles, giveawayUuid)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []*GuildGiveawaysWinners{}
+	for rows.Next() {
+		var i GuildGiveawaysWinners
+		if err := rows.Scan(
+			&i.GiveawayWinnerUuid,
+			&i.GiveawayUuid,
+			&i.UserID,
+			&i.Prize,
+			&i.MessageID,
+			&i.ClaimExpiresAt,
+			&i.IsClaimed,
+			&i.ClaimedAt,
+			&i.IsExpired,
+			&i.RoleID,
+			&i.RoleExpiresAt,
+			&i.IsRoleRemoved,
+			&i.IsDelivered,
+			&i.DeliveryError,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, &i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const GetGiveawayWinner = `-- name: GetGiveawayWinner :one
+SELECT
+    giveaway_winner_uuid, giveaway_uuid, user_id, prize, message_id, claim_expires_at, is_claimed, claimed_at, is_expired, role_id, role_expires_at, is_role_removed, is_delivered, delivery_error
+FROM
+    guild_giveaways_winners
+WHERE
+    giveaway_winner_uuid = $1
+`
+
+func (q *Queries) GetGiveawayWinner(ctx context.Context, giveawayWinnerUuid uuid.UUID) (*GuildGiveawaysWinners, error) {
+	row := q.db.QueryRow(ctx, GetGiveawayWinner, giveawayWinnerUuid)
 	var i GuildGiveawaysWinners
 	err := row.Scan(
 		&i.GiveawayWinnerUuid,
@@ -39,13 +192,22 @@ func (q *Queries) CreateGiveawayWinner(ctx context.Context, arg CreateGiveawayWi
 		&i.UserID,
 		&i.Prize,
 		&i.MessageID,
+		&i.ClaimExpiresAt,
+		&i.IsClaimed,
+		&i.ClaimedAt,
+		&i.IsExpired,
+		&i.RoleID,
+		&i.RoleExpiresAt,
+		&i.IsRoleRemoved,
+		&i.IsDelivered,
+		&i.DeliveryError,
 	)
 	return &i, err
 }
 
 const GetGiveawayWinners = `-- name: GetGiveawayWinners :many
 SELECT
-    giveaway_winner_uuid, giveaway_uuid, user_id, prize, message_id
+    giveaway_winner_uuid, giveaway_uuid, user_id, prize, message_id, claim_expires_at, is_claimed, claimed_at, is_expired, role_id, role_expires_at, is_role_removed, is_delivered, delivery_error
 FROM
     guild_giveaways_winners
 WHERE
@@ -67,6 +229,15 @@ func (q *Queries) GetGiveawayWinners(ctx context.Context, giveawayUuid uuid.UUID
 			&i.UserID,
 			&i.Prize,
 			&i.MessageID,
+			&i.ClaimExpiresAt,
+			&i.IsClaimed,
+			&i.ClaimedAt,
+			&i.IsExpired,
+			&i.RoleID,
+			&i.RoleExpiresAt,
+			&i.IsRoleRemoved,
+			&i.IsDelivered,
+			&i.DeliveryError,
 		); err != nil {
 			return nil, err
 		}
@@ -78,6 +249,168 @@ func (q *Queries) GetGiveawayWinners(ctx context.Context, giveawayUuid uuid.UUID
 	return items, nil
 }
 
+const GetGiveawaysWithExpiredClaims = `-- name: GetGiveawaysWithExpiredClaims :many
+SELECT DISTINCT
+    guild_giveaways.giveaway_uuid,
+    guild_giveaways.guild_id
+FROM
+    guild_giveaways_winners
+    JOIN guild_giveaways ON guild_giveaways.giveaway_uuid = guild_giveaways_winners.giveaway_uuid
+WHERE
+    guild_giveaways_winners.is_claimed = FALSE
+    AND guild_giveaways_winners.is_expired = FALSE
+    AND guild_giveaways_winners.claim_expires_at > 'epoch'
+    AND guild_giveaways_winners.claim_expires_at <= NOW()
+`
+
+type GetGiveawaysWithExpiredClaimsRow struct {
+	GiveawayUuid uuid.UUID `json:"giveaway_uuid"`
+	GuildID      int64     `json:"guild_id"`
+}
+
+func (q *Queries) GetGiveawaysWithExpiredClaims(ctx context.Context) ([]*GetGiveawaysWithExpiredClaimsRow, error) {
+	rows, err := q.db.Query(ctx, GetGiveawaysWithExpiredClaims)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []*GetGiveawaysWithExpiredClaimsRow{}
+	for rows.Next() {
+		var i GetGiveawaysWithExpiredClaimsRow
+		if err := rows.Scan(&i.GiveawayUuid, &i.GuildID); err != nil {
+			return nil, err
+		}
+		items = append(items, &i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const GetGiveawaysWithExpiredPrizeRoles = `-- name: GetGiveawaysWithExpiredPrizeRoles :many
+SELECT DISTINCT
+    guild_giveaways.giveaway_uuid,
+    guild_giveaways.guild_id
+FROM
+    guild_giveaways_winners
+    JOIN guild_giveaways ON guild_giveaways.giveaway_uuid = guild_giveaways_winners.giveaway_uuid
+WHERE
+    guild_giveaways_winners.role_id != 0
+    AND guild_giveaways_winners.is_role_removed = FALSE
+    AND (guild_giveaways_winners.is_expired = TRUE
+        OR (guild_giveaways_winners.role_expires_at > 'epoch'
+            AND guild_giveaways_winners.role_expires_at <= NOW()))
+`
+
+type GetGiveawaysWithExpiredPrizeRolesRow struct {
+	GiveawayUuid uuid.UUID `json:"giveaway_uuid"`
+	GuildID      int64     `json:"guild_id"`
+}
+
+func (q *Queries) GetGiveawaysWithExpiredPrizeRoles(ctx context.Context) ([]*GetGiveawaysWithExpiredPrizeRolesRow, error) {
+	rows, err := q.db.Query(ctx, GetGiveawaysWithExpiredPrizeRoles)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []*GetGiveawaysWithExpiredPrizeRolesRow{}
+	for rows.Next() {
+		var i GetGiveawaysWithExpiredPrizeRolesRow
+		if err := rows.Scan(&i.GiveawayUuid, &i.GuildID); err != nil {
+			return nil, err
+		}
+		items = append(items, &i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const SetGiveawayWinnerDelivery = `-- name: SetGiveawayWinnerDelivery :one
+UPDATE
+    guild_giveaways_winners
+SET
+    is_delivered = $2,
+    delivery_error = $3,
+    role_id = $4,
+    role_expires_at = $5
+WHERE
+    giveaway_winner_uuid = $1
+RETURNING
+    giveaway_winner_uuid, giveaway_uuid, user_id, prize, message_id, claim_expires_at, is_claimed, claimed_at, is_expired, role_id, role_expires_at, is_role_removed, is_delivered, delivery_error
+`
+
+type SetGiveawayWinnerDeliveryParams struct {
+	GiveawayWinnerUuid uuid.UUID `json:"giveaway_winner_uuid"`
+	IsDelivered        bool      `json:"is_delivered"`
+	DeliveryError      string    `json:"delivery_error"`
+	RoleID             int64     `json:"role_id"`
+	RoleExpiresAt      time.Time `json:"role_expires_at"`
+}
+
+func (q *Queries) SetGiveawayWinnerDelivery(ctx context.Context, arg SetGiveawayWinnerDeliveryParams) (*GuildGiveawaysWinners, error) {
+	row := q.db.QueryRow(ctx, SetGiveawayWinnerDelivery,
+		arg.GiveawayWinnerUuid,
+		arg.IsDelivered,
+		arg.DeliveryError,
+		arg.RoleID,
+		arg.RoleExpiresAt,
+	)
+	var i GuildGiveawaysWinners
+	err := row.Scan(
+		&i.GiveawayWinnerUuid,
+		&i.GiveawayUuid,
+		&i.UserID,
+		&i.Prize,
+		&i.MessageID,
+		&i.ClaimExpiresAt,
+		&i.IsClaimed,
+		&i.ClaimedAt,
+		&i.IsExpired,
+		&i.RoleID,
+		&i.RoleExpiresAt,
+		&i.IsRoleRemoved,
+		&i.IsDelivered,
+		&i.DeliveryError,
+	)
+	return &i, err
+}
+
+const SetGiveawayWinnerRoleRemoved = `-- name: SetGiveawayWinnerRoleRemoved :one
+UPDATE
+    guild_giveaways_winners
+SET
+    is_role_removed = TRUE
+WHERE
+    giveaway_winner_uuid = $1
+RETURNING
+    giveaway_winner_uuid, giveaway_uuid, user_id, prize, message_id, claim_expires_at, is_claimed, claimed_at, is_expired, role_id, role_expires_at, is_role_removed, is_delivered, delivery_error
+`
+
+func (q *Queries) SetGiveawayWinnerRoleRemoved(ctx context.Context, giveawayWinnerUuid uuid.UUID) (*GuildGiveawaysWinners, error) {
+	row := q.db.QueryRow(ctx, SetGiveawayWinnerRoleRemoved, giveawayWinnerUuid)
+	var i GuildGiveawaysWinners
+	err := row.Scan(
+		&i.GiveawayWinnerUuid,
+		&i.GiveawayUuid,
+		&i.UserID,
+		&i.Prize,
+		&i.MessageID,
+		&i.ClaimExpiresAt,
+		&i.IsClaimed,
+		&i.ClaimedAt,
+		&i.IsExpired,
+		&i.RoleID,
+		&i.RoleExpiresAt,
+		&i.IsRoleRemoved,
+		&i.IsDelivered,
+		&i.DeliveryError,
+	)
+	return &i, err
+}
+
 const UpdateGiveawayWinnerMessageID = `-- name: UpdateGiveawayWinnerMessageID :one
 UPDATE
     guild_giveaways_winners
@@ -86,7 +419,7 @@ SET
 WHERE
     giveaway_winner_uuid = $1
 RETURNING
-    giveaway_winner_uuid, giveaway_uuid, user_id, prize, message_id
+    giveaway_winner_uuid, giveaway_uuid, user_id, prize, message_id, claim_expires_at, is_claimed, claimed_at, is_expired, role_id, role_expires_at, is_role_removed, is_delivered, delivery_error
 `
 
 type UpdateGiveawayWinnerMessageIDParams struct {
@@ -103,6 +436,15 @@ func (q *Queries) UpdateGiveawayWinnerMessageID(ctx context.Context, arg UpdateG
 		&i.UserID,
 		&i.Prize,
 		&i.MessageID,
+		&i.ClaimExpiresAt,
+		&i.IsClaimed,
+		&i.ClaimedAt,
+		&i.IsExpired,
+		&i.RoleID,
+		&i.RoleExpiresAt,
+		&i.IsRoleRemoved,
+		&i.IsDelivered,
+		&i.DeliveryError,
 	)
 	return &i, err
 }
@@ -115,7 +457,7 @@ SET
 WHERE
     giveaway_winner_uuid = $1
 RETURNING
-    giveaway_winner_uuid, giveaway_uuid, user_id, prize, message_id
+    giveaway_winner_uuid, giveaway_uuid, user_id, prize, message_id, claim_expires_at, is_claimed, claimed_at, is_expired, role_id, role_expires_at, is_role_removed, is_delivered, delivery_error
 `
 
 type UpdateGiveawayWinnerUserIDParams struct {
@@ -132,6 +474,15 @@ func (q *Queries) UpdateGiveawayWinnerUserID(ctx context.Context, arg UpdateGive
 		&i.UserID,
 		&i.Prize,
 		&i.MessageID,
+		&i.ClaimExpiresAt,
+		&i.IsClaimed,
+		&i.ClaimedAt,
+		&i.IsExpired,
+		&i.RoleID,
+		&i.RoleExpiresAt,
+		&i.IsRoleRemoved,
+		&i.IsDelivered,
+		&i.DeliveryError,
 	)
 	return &i, err
 }
@@ -166,3 +166,64 @@ func (q *Queries) GetGuildInvites(ctx context.Context, guildID int64) ([]*GuildI
 	}
 	return items, nil
 }
+
+const GetGuildTopInviters = `-- name: GetGuildTopInviters :many
+SELECT
+    guild_invites.created_by AS user_id,
+    COUNT(*) AS invite_count
+FROM
+    science_guild_events
+    INNER JOIN guild_invites ON guild_invites.invite_code = science_guild_events.data ->> 'invite_code'
+        AND guild_invites.guild_id = science_guild_events.guild_id
+WHERE
+    science_guild_events.guild_id = $1
+    AND science_guild_events.event_type = $2
+    AND science_guild_events.created_at >= $3
+    AND science_guild_events.created_at < $4
+    AND guild_invites.created_by != 0
+GROUP BY
+    guild_invites.created_by
+ORDER BY
+    invite_count DESC,
+    guild_invites.created_by
+LIMIT $5
+`
+
+type GetGuildTopInvitersParams struct {
+	GuildID                       int64     `json:"guild_id"`
+	ScienceGuildEventTypeUserJoin int32     `json:"science_guild_event_type_user_join"`
+	Since                         time.Time `json:"since"`
+	Until                         time.Time `json:"until"`
+	EntryLimit                    int32     `json:"entry_limit"`
+}
+
+type GetGuildTopInvitersRow struct {
+	UserID      int64 `json:"user_id"`
+	InviteCount int64 `json:"invite_count"`
+}
+
+func (q *Queries) GetGuildTopInviters(ctx context.Context, arg GetGuildTopInvitersParams) ([]*GetGuildTopInvitersRow, error) {
+	rows, err := q.db.Query(ctx, GetGuildTopInviters,
+		arg.GuildID,
+		arg.ScienceGuildEventTypeUserJoin,
+		arg.Since,
+		arg.Until,
+		arg.EntryLimit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []*GetGuildTopInvitersRow{}
+	for rows.Next() {
+		var i GetGuildTopInvitersRow
+		if err := rows.Scan(&i.UserID, &i.InviteCount); err != nil {
+			return nil, err
+		}
+		items = append(items, &i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
@@ -7,54 +7,79 @@ package database
 
 import (
 	"context"
+
+	"github.com/jackc/pgtype"
 )
 
 const CreateFreeRolesGuildSettings = `-- name: CreateFreeRolesGuildSettings :one
-INSERT INTO guild_settings_freeroles (guild_id, toggle_enabled, roles)
-    VALUES ($1, $2, $3)
+INSERT INTO guild_settings_freeroles (guild_id, toggle_enabled, roles, role_durations)
+    VALUES ($1, $2, $3, $4)
 RETURNING
-    guild_id, toggle_enabled, roles
+    guild_id, toggle_enabled, roles, role_durations
 `
 
 type CreateFreeRolesGuildSettingsParams struct {
-	GuildID       int64   `json:"guild_id"`
-	ToggleEnabled bool    `json:"toggle_enabled"`
-	Roles         []int64 `json:"roles"`
+	GuildID       int64        `json:"guild_id"`
+	ToggleEnabled bool         `json:"toggle_enabled"`
+	Roles         []int64      `json:"roles"`
+	RoleDurations pgtype.JSONB `json:"role_durations"`
 }
 
 func (q *Queries) CreateFreeRolesGuildSettings(ctx context.Context, arg CreateFreeRolesGuildSettingsParams) (*GuildSettingsFreeroles, error) {
-	row := q.db.QueryRow(ctx, CreateFreeRolesGuildSettings, arg.GuildID, arg.ToggleEnabled, arg.Roles)
+	row := q.db.QueryRow(ctx, CreateFreeRolesGuildSettings,
+		arg.GuildID,
+		arg.ToggleEnabled,
+		arg.Roles,
+		arg.RoleDurations,
+	)
 	var i GuildSettingsFreeroles
-	err := row.Scan(&i.GuildID, &i.ToggleEnabled, &i.Roles)
+	err := row.Scan(
+		&i.GuildID,
+		&i.ToggleEnabled,
+		&i.Roles,
+		&i.RoleDurations,
+	)
 	return &i, err
 }
 
 const CreateOrUpdateFreeRolesGuildSettings = `-- name: CreateOrUpdateFreeRolesGuildSettings :one
-INSERT INTO guild_settings_freeroles (guild_id, toggle_enabled, roles)
-    VALUES ($1, $2, $3)
+INSERT INTO guild_settings_freeroles (guild_id, toggle_enabled, roles, role_durations)
+    VALUES ($1, $2, $3, $4)
 ON CONFLICT(guild_id) DO UPDATE
     SET toggle_enabled = EXCLUDED.toggle_enabled,
-        roles = EXCLUDED.roles
+        roles = EXCLUDED.roles,
+        role_durations = EXCLUDED.role_durations
 RETURNING
-    guild_id, toggle_enabled, roles
+    guild_id, toggle_enabled, roles, role_durations
 `
 
 type CreateOrUpdateFreeRolesGuildSettingsParams struct {
-	GuildID       int64   `json:"guild_id"`
-	ToggleEnabled bool    `json:"toggle_enabled"`
-	Roles         []int64 `json:"roles"`
+	GuildID       int64        `json:"guild_id"`
+	ToggleEnabled bool         `json:"toggle_enabled"`
+	Roles         []int64      `json:"roles"`
+	RoleDurations pgtype.JSONB `json:"role_durations"`
 }
 
 func (q *Queries) CreateOrUpdateFreeRolesGuildSettings(ctx context.Context, arg CreateOrUpdateFreeRolesGuildSettingsParams) (*GuildSettingsFreeroles, error) {
-	row := q.db.QueryRow(ctx, CreateOrUpdateFreeRolesGuildSettings, arg.GuildID, arg.ToggleEnabled, arg.Roles)
+	row := q.db.QueryRow(ctx, CreateOrUpdateFreeRolesGuildSettings,
+		arg.GuildID,
+		arg.ToggleEnabled,
+		arg.Roles,
+		arg.RoleDurations,
+	)
 	var i GuildSettingsFreeroles
-	err := row.Scan(&i.GuildID, &i.ToggleEnabled, &i.Roles)
+	err := row.Scan(
+		&i.GuildID,
+		&i.ToggleEnabled,
+		&i.Roles,
+		&i.RoleDurations,
+	)
 	return &i, err
 }
 
 const GetFreeRolesGuildSettings = `-- name: GetFreeRolesGuildSettings :one
 SELECT
-    guild_id, toggle_enabled, roles
+    guild_id, toggle_enabled, roles, role_durations
 FROM
     guild_settings_freeroles
 WHERE
@@ -64,7 +89,12 @@ WHERE
 func (q *Queries) GetFreeRolesGuildSettings(ctx context.Context, guildID int64) (*GuildSettingsFreeroles, error) {
 	row := q.db.QueryRow(ctx, GetFreeRolesGuildSettings, guildID)
 	var i GuildSettingsFreeroles
-	err := row.Scan(&i.GuildID, &i.ToggleEnabled, &i.Roles)
+	err := row.Scan(
+		&i.GuildID,
+		&i.ToggleEnabled,
+		&i.Roles,
+		&i.RoleDurations,
+	)
 	return &i, err
 }
 
@@ -73,19 +103,26 @@ UPDATE
     guild_settings_freeroles
 SET
     toggle_enabled = $2,
-    roles = $3
+    roles = $3,
+    role_durations = $4
 WHERE
     guild_id = $1
 `
 
 type UpdateFreeRolesGuildSettingsParams struct {
-	GuildID       int64   `json:"guild_id"`
-	ToggleEnabled bool    `json:"toggle_enabled"`
-	Roles         []int64 `json:"roles"`
+	GuildID       int64        `json:"guild_id"`
+	ToggleEnabled bool         `json:"toggle_enabled"`
+	Roles         []int64      `json:"roles"`
+	RoleDurations pgtype.JSONB `json:"role_durations"`
 }
 
 func (q *Queries) UpdateFreeRolesGuildSettings(ctx context.Context, arg UpdateFreeRolesGuildSettingsParams) (int64, error) {
-	result, err := q.db.Exec(ctx, UpdateFreeRolesGuildSettings, arg.GuildID, arg.ToggleEnabled, arg.Roles)
+	result, err := q.db.Exec(ctx, UpdateFreeRolesGuildSettings,
+		arg.GuildID,
+		arg.ToggleEnabled,
+		arg.Roles,
+		arg.RoleDurations,
+	)
 	if err != nil {
 		return 0, err
 	}
@@ -13,17 +13,22 @@ import (
 )
 
 const CreateOrUpdateReactionRoleSetting = `-- name: CreateOrUpdateReactionRoleSetting :one
-INSERT INTO guild_settings_reaction_roles (reaction_role_id, guild_id, toggle_enabled, channel_id, message_id, is_system_message, system_message_format, reaction_role_type, roles)
-    VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+INSERT INTO guild_settings_reaction_roles (reaction_role_id, guild_id, toggle_enabled, channel_id, message_id, is_system_message, system_message_format, reaction_role_type, roles, unique_selection, max_selections, required_role_id, verify_only, disable_toggle_off)
+    VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14)
 ON CONFLICT(reaction_role_id) DO UPDATE
     SET toggle_enabled = EXCLUDED.toggle_enabled,
         channel_id = EXCLUDED.channel_id,
         is_system_message = EXCLUDED.is_system_message,
         system_message_format = EXCLUDED.system_message_format,
         reaction_role_type = EXCLUDED.reaction_role_type,
-        roles = EXCLUDED.roles
+        roles = EXCLUDED.roles,
+        unique_selection = EXCLUDED.unique_selection,
+        max_selections = EXCLUDED.max_selections,
+        required_role_id = EXCLUDED.required_role_id,
+        verify_only = EXCLUDED.verify_only,
+        disable_toggle_off = EXCLUDED.disable_toggle_off
 RETURNING
-    reaction_role_id, guild_id, toggle_enabled, channel_id, message_id, is_system_message, system_message_format, reaction_role_type, roles
+    reaction_role_id, guild_id, toggle_enabled, channel_id, message_id, is_system_message, system_message_format, reaction_role_type, roles, unique_selection, max_selections, required_role_id, verify_only, disable_toggle_off
 `
 
 type CreateOrUpdateReactionRoleSettingParams struct {
@@ -36,6 +41,11 @@ type CreateOrUpdateReactionRoleSettingParams struct {
 	SystemMessageFormat pgtype.JSONB `json:"system_message_format"`
 	ReactionRoleType    int32        `json:"reaction_role_type"`
 	Roles               pgtype.JSONB `json:"roles"`
+	UniqueSelection     bool         `json:"unique_selection"`
+	MaxSelections       int32        `json:"max_selections"`
+	RequiredRoleID      int64        `json:"required_role_id"`
+	VerifyOnly          bool         `json:"verify_only"`
+	DisableToggleOff    bool         `json:"disable_toggle_off"`
 }
 
 func (q *Queries) CreateOrUpdateReactionRoleSetting(ctx context.Context, arg CreateOrUpdateReactionRoleSettingParams) (*GuildSettingsReactionRoles, error) {
@@ -49,6 +59,11 @@ func (q *Queries) CreateOrUpdateReactionRoleSetting(ctx context.Context, arg Cre
 		arg.SystemMessageFormat,
 		arg.ReactionRoleType,
 		arg.Roles,
+		arg.UniqueSelection,
+		arg.MaxSelections,
+		arg.RequiredRoleID,
+		arg.VerifyOnly,
+		arg.DisableToggleOff,
 	)
 	var i GuildSettingsReactionRoles
 	err := row.Scan(
@@ -61,6 +76,11 @@ func (q *Queries) CreateOrUpdateReactionRoleSetting(ctx context.Context, arg Cre
 		&i.SystemMessageFormat,
 		&i.ReactionRoleType,
 		&i.Roles,
+		&i.UniqueSelection,
+		&i.MaxSelections,
+		&i.RequiredRoleID,
+		&i.VerifyOnly,
+		&i.DisableToggleOff,
 	)
 	return &i, err
 }
@@ -109,7 +129,7 @@ func (q *Queries) DisableReactionRoleSettingByMessageId(ctx context.Context, arg
 
 const GetReactionRoleSettingByGuildId = `-- name: GetReactionRoleSettingByGuildId :many
 SELECT
-    reaction_role_id, guild_id, toggle_enabled, channel_id, message_id, is_system_message, system_message_format, reaction_role_type, roles
+    reaction_role_id, guild_id, toggle_enabled, channel_id, message_id, is_system_message, system_message_format, reaction_role_type, roles, unique_selection, max_selections, required_role_id, verify_only, disable_toggle_off
 FROM
     guild_settings_reaction_roles
 WHERE
@@ -135,6 +155,11 @@ func (q *Queries) GetReactionRoleSettingByGuildId(ctx context.Context, guildID i
 			&i.SystemMessageFormat,
 			&i.ReactionRoleType,
 			&i.Roles,
+			&i.UniqueSelection,
+			&i.MaxSelections,
+			&i.RequiredRoleID,
+			&i.VerifyOnly,
+			&i.DisableToggleOff,
 		); err != nil {
 			return nil, err
 		}
@@ -148,7 +173,7 @@ func (q *Queries) GetReactionRoleSettingByGuildId(ctx context.Context, guildID i
 
 const GetReactionRoleSettingById = `-- name: GetReactionRoleSettingById :one
 SELECT
-    reaction_role_id, guild_id, toggle_enabled, channel_id, message_id, is_system_message, system_message_format, reaction_role_type, roles
+    reaction_role_id, guild_id, toggle_enabled, channel_id, message_id, is_system_message, system_message_format, reaction_role_type, roles, unique_selection, max_selections, required_role_id, verify_only, disable_toggle_off
 FROM
     guild_settings_reaction_roles
 WHERE
@@ -174,13 +199,18 @@ func (q *Queries) GetReactionRoleSettingById(ctx context.Context, arg GetReactio
 		&i.SystemMessageFormat,
 		&i.ReactionRoleType,
 		&i.Roles,
+		&i.UniqueSelection,
+		&i.MaxSelections,
+		&i.RequiredRoleID,
+		&i.VerifyOnly,
+		&i.DisableToggleOff,
 	)
 	return &i, err
 }
 
 const GetReactionRoleSettingByMessageId = `-- name: GetReactionRoleSettingByMessageId :one
 SELECT
-    reaction_role_id, guild_id, toggle_enabled, channel_id, message_id, is_system_message, system_message_format, reaction_role_type, roles
+    reaction_role_id, guild_id, toggle_enabled, channel_id, message_id, is_system_message, system_message_format, reaction_role_type, roles, unique_selection, max_selections, required_role_id, verify_only, disable_toggle_off
 FROM
     guild_settings_reaction_roles
 WHERE
@@ -206,6 +236,11 @@ func (q *Queries) GetReactionRoleSettingByMessageId(ctx context.Context, arg Get
 		&i.SystemMessageFormat,
 		&i.ReactionRoleType,
 		&i.Roles,
+		&i.UniqueSelection,
+		&i.MaxSelections,
+		&i.RequiredRoleID,
+		&i.VerifyOnly,
+		&i.DisableToggleOff,
 	)
 	return &i, err
 }
@@ -7,28 +7,32 @@ package database
 
 import (
 	"context"
+
+	"github.com/jackc/pgtype"
 )
 
 const CreateOrUpdateTempChannelsGuildSettings = `-- name: CreateOrUpdateTempChannelsGuildSettings :one
-INSERT INTO guild_settings_tempchannels (guild_id, toggle_enabled, toggle_autopurge, channel_lobby, channel_category, default_user_count)
-    VALUES ($1, $2, $3, $4, $5, $6)
+INSERT INTO guild_settings_tempchannels (guild_id, toggle_enabled, toggle_autopurge, channel_lobby, channel_category, default_user_count, lobbies)
+    VALUES ($1, $2, $3, $4, $5, $6, $7)
 ON CONFLICT(guild_id) DO UPDATE
     SET toggle_enabled = EXCLUDED.toggle_enabled,
         toggle_autopurge = EXCLUDED.toggle_autopurge,
         channel_lobby = EXCLUDED.channel_lobby,
         channel_category = EXCLUDED.channel_category,
-        default_user_count = EXCLUDED.default_user_count
+        default_user_count = EXCLUDED.default_user_count,
+        lobbies = EXCLUDED.lobbies
 RETURNING
-    guild_id, toggle_enabled, toggle_autopurge, channel_lobby, channel_category, default_user_count
+    guild_id, toggle_enabled, toggle_autopurge, channel_lobby, channel_category, default_user_count, lobbies
 `
 
 type CreateOrUpdateTempChannelsGuildSettingsParams struct {
-	GuildID          int64 `json:"guild_id"`
-	ToggleEnabled    bool  `json:"toggle_enabled"`
-	ToggleAutopurge  bool  `json:"toggle_autopurge"`
-	ChannelLobby     int64 `json:"channel_lobby"`
-	ChannelCategory  int64 `json:"channel_category"`
-	DefaultUserCount int32 `json:"default_user_count"`
+	GuildID          int64        `json:"guild_id"`
+	ToggleEnabled    bool         `json:"toggle_enabled"`
+	ToggleAutopurge  bool         `json:"toggle_autopurge"`
+	ChannelLobby     int64        `json:"channel_lobby"`
+	ChannelCategory  int64        `json:"channel_category"`
+	DefaultUserCount int32        `json:"default_user_count"`
+	Lobbies          pgtype.JSONB `json:"lobbies"`
 }
 
 func (q *Queries) CreateOrUpdateTempChannelsGuildSettings(ctx context.Context, arg CreateOrUpdateTempChannelsGuildSettingsParams) (*GuildSettingsTempchannels, error) {
@@ -39,6 +43,7 @@ func (q *Queries) CreateOrUpdateTempChannelsGuildSettings(ctx context.Context, a
 		arg.ChannelLobby,
 		arg.ChannelCategory,
 		arg.DefaultUserCount,
+		arg.Lobbies,
 	)
 	var i GuildSettingsTempchannels
 	err := row.Scan(
@@ -48,24 +53,26 @@ func (q *Queries) CreateOrUpdateTempChannelsGuildSettings(ctx context.Context, a
 		&i.ChannelLobby,
 		&i.ChannelCategory,
 		&i.DefaultUserCount,
+		&i.Lobbies,
 	)
 	return &i, err
 }
 
 const CreateTempChannelsGuildSettings = `-- name: CreateTempChannelsGuildSettings :one
-INSERT INTO guild_settings_tempchannels (guild_id, toggle_enabled, toggle_autopurge, channel_lobby, channel_category, default_user_count)
-    VALUES ($1, $2, $3, $4, $5, $6)
+INSERT INTO guild_settings_tempchannels (guild_id, toggle_enabled, toggle_autopurge, channel_lobby, channel_category, default_user_count, lobbies)
+    VALUES ($1, $2, $3, $4, $5, $6, $7)
 RETURNING
-    guild_id, toggle_enabled, toggle_autopurge, channel_lobby, channel_category, default_user_count
+    guild_id, toggle_enabled, toggle_autopurge, channel_lobby, channel_category, default_user_count, lobbies
 `
 
 type CreateTempChannelsGuildSettingsParams struct {
-	GuildID          int64 `json:"guild_id"`
-	ToggleEnabled    bool  `json:"toggle_enabled"`
-	ToggleAutopurge  bool  `json:"toggle_autopurge"`
-	ChannelLobby     int64 `json:"channel_lobby"`
-	ChannelCategory  int64 `json:"channel_category"`
-	DefaultUserCount int32 `json:"default_user_count"`
+	GuildID          int64        `json:"guild_id"`
+	ToggleEnabled    bool         `json:"toggle_enabled"`
+	ToggleAutopurge  bool         `json:"toggle_autopurge"`
+	ChannelLobby     int64        `json:"channel_lobby"`
+	ChannelCategory  int64        `json:"channel_category"`
+	DefaultUserCount int32        `json:"default_user_count"`
+	Lobbies          pgtype.JSONB `json:"lobbies"`
 }
 
 func (q *Queries) CreateTempChannelsGuildSettings(ctx context.Context, arg CreateTempChannelsGuildSettingsParams) (*GuildSettingsTempchannels, error) {
@@ -76,6 +83,7 @@ func (q *Queries) CreateTempChannelsGuildSettings(ctx context.Context, arg Creat
 		arg.ChannelLobby,
 		arg.ChannelCategory,
 		arg.DefaultUserCount,
+		arg.Lobbies,
 	)
 	var i GuildSettingsTempchannels
 	err := row.Scan(
@@ -85,13 +93,51 @@ func (q *Queries) CreateTempChannelsGuildSettings(ctx context.Context, arg Creat
 		&i.ChannelLobby,
 		&i.ChannelCategory,
 		&i.DefaultUserCount,
+		&i.Lobbies,
 	)
 	return &i, err
 }
 
+const GetEnabledTempChannelsGuildSettings = `-- name: GetEnabledTempChannelsGuildSettings :many
+SELECT
+    guild_id, toggle_enabled, toggle_autopurge, channel_lobby, channel_category, default_user_count, lobbies
+FROM
+    guild_settings_tempchannels
+WHERE
+    toggle_enabled = TRUE
+`
+
+func (q *Queries) GetEnabledTempChannelsGuildSettings(ctx context.Context) ([]*GuildSettingsTempchannels, error) {
+	rows, err := q.db.Query(ctx, GetEnabledTempChannelsGuildSettings)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []*GuildSettingsTempchannels{}
+	for rows.Next() {
+		var i GuildSettingsTempchannels
+		if err := rows.Scan(
+			&i.GuildID,
+			&i.ToggleEnabled,
+			&i.ToggleAutopurge,
+			&i.ChannelLobby,
+			&i.ChannelCategory,
+			&i.DefaultUserCount,
+			&i.Lobbies,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, &i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const GetTempChannelsGuildSettings = `-- name: GetTempChannelsGuildSettings :one
 SELECT
-    guild_id, toggle_enabled, toggle_autopurge, channel_lobby, channel_category, default_user_count
+    guild_id, toggle_enabled, toggle_autopurge, channel_lobby, channel_category, default_user_count, lobbies
 FROM
     guild_settings_tempchannels
 WHERE
@@ -108,6 +154,7 @@ func (q *Queries) GetTempChannelsGuildSettings(ctx context.Context, guildID int6
 		&i.ChannelLobby,
 		&i.ChannelCategory,
 		&i.DefaultUserCount,
+		&i.Lobbies,
 	)
 	return &i, err
 }
@@ -120,18 +167,20 @@ SET
     toggle_autopurge = $3,
     channel_lobby = $4,
     channel_category = $5,
-    default_user_count = $6
+    default_user_count = $6,
+    lobbies = $7
 WHERE
     guild_id = $1
 `
 
 type UpdateTempChannelsGuildSettingsParams struct {
-	GuildID          int64 `json:"guild_id"`
-	ToggleEnabled    bool  `json:"toggle_enabled"`
-	ToggleAutopurge  bool  `json:"toggle_autopurge"`
-	ChannelLobby     int64 `json:"channel_lobby"`
-	ChannelCategory  int64 `json:"channel_category"`
-	DefaultUserCount int32 `json:"default_user_count"`
+	GuildID          int64        `json:"guild_id"`
+	ToggleEnabled    bool         `json:"toggle_enabled"`
+	ToggleAutopurge  bool         `json:"toggle_autopurge"`
+	ChannelLobby     int64        `json:"channel_lobby"`
+	ChannelCategory  int64        `json:"channel_category"`
+	DefaultUserCount int32        `json:"default_user_count"`
+	Lobbies          pgtype.JSONB `json:"lobbies"`
 }
 
 func (q *Queries) UpdateTempChannelsGuildSettings(ctx context.Context, arg UpdateTempChannelsGuildSettingsParams) (int64, error) {
@@ -142,6 +191,7 @@ func (q *Queries) UpdateTempChannelsGuildSettings(ctx context.Context, arg Updat
 		arg.ChannelLobby,
 		arg.ChannelCategory,
 		arg.DefaultUserCount,
+		arg.Lobbies,
 	)
 	if err != nil {
 		return 0, err
@@ -12,51 +12,74 @@ import (
 )
 
 const CreateOrUpdateTimeRolesGuildSettings = `-- name: CreateOrUpdateTimeRolesGuildSettings :one
-INSERT INTO guild_settings_timeroles (guild_id, toggle_enabled, timeroles)
-    VALUES ($1, $2, $3)
+INSERT INTO guild_settings_timeroles (guild_id, toggle_enabled, timeroles, toggle_ladder)
+    VALUES ($1, $2, $3, $4)
 ON CONFLICT(guild_id) DO UPDATE
     SET toggle_enabled = EXCLUDED.toggle_enabled,
-        timeroles = EXCLUDED.timeroles
+        timeroles = EXCLUDED.timeroles,
+        toggle_ladder = EXCLUDED.toggle_ladder
 RETURNING
-    guild_id, toggle_enabled, timeroles
+    guild_id, toggle_enabled, timeroles, toggle_ladder
 `
 
 type CreateOrUpdateTimeRolesGuildSettingsParams struct {
 	GuildID       int64        `json:"guild_id"`
 	ToggleEnabled bool         `json:"toggle_enabled"`
 	Timeroles     pgtype.JSONB `json:"timeroles"`
+	ToggleLadder  bool         `json:"toggle_ladder"`
 }
 
 func (q *Queries) CreateOrUpdateTimeRolesGuildSettings(ctx context.Context, arg CreateOrUpdateTimeRolesGuildSettingsParams) (*GuildSettingsTimeroles, error) {
-	row := q.db.QueryRow(ctx, CreateOrUpdateTimeRolesGuildSettings, arg.GuildID, arg.ToggleEnabled, arg.Timeroles)
+	row := q.db.QueryRow(ctx, CreateOrUpdateTimeRolesGuildSettings,
+		arg.GuildID,
+		arg.ToggleEnabled,
+		arg.Timeroles,
+		arg.ToggleLadder,
+	)
 	var i GuildSettingsTimeroles
-	err := row.Scan(&i.GuildID, &i.ToggleEnabled, &i.Timeroles)
+	err := row.Scan(
+		&i.GuildID,
+		&i.ToggleEnabled,
+		&i.Timeroles,
+		&i.ToggleLadder,
+	)
 	return &i, err
 }
 
 const CreateTimeRolesGuildSettings = `-- name: CreateTimeRolesGuildSettings :one
-INSERT INTO guild_settings_timeroles (guild_id, toggle_enabled, timeroles)
-    VALUES ($1, $2, $3)
+INSERT INTO guild_settings_timeroles (guild_id, toggle_enabled, timeroles, toggle_ladder)
+    VALUES ($1, $2, $3, $4)
 RETURNING
-    guild_id, toggle_enabled, timeroles
+    guild_id, toggle_enabled, timeroles, toggle_ladder
 `
 
 type CreateTimeRolesGuildSettingsParams struct {
 	GuildID       int64        `json:"guild_id"`
 	ToggleEnabled bool         `json:"toggle_enabled"`
 	Timeroles     pgtype.JSONB `json:"timeroles"`
+	ToggleLadder  bool         `json:"toggle_ladder"`
 }
 
 func (q *Queries) CreateTimeRolesGuildSettings(ctx context.Context, arg CreateTimeRolesGuildSettingsParams) (*GuildSettingsTimeroles, error) {
-	row := q.db.QueryRow(ctx, CreateTimeRolesGuildSettings, arg.GuildID, arg.ToggleEnabled, arg.Timeroles)
+	row := q.db.QueryRow(ctx, CreateTimeRolesGuildSettings,
+		arg.GuildID,
+		arg.ToggleEnabled,
+		arg.Timeroles,
+		arg.ToggleLadder,
+	)
 	var i GuildSettingsTimeroles
-	err := row.Scan(&i.GuildID, &i.ToggleEnabled, &i.Timeroles)
+	err := row.Scan(
+		&i.GuildID,
+		&i.ToggleEnabled,
+		&i.Timeroles,
+		&i.ToggleLadder,
+	)
 	return &i, err
 }
 
 const GetTimeRolesGuildSettings = `-- name: GetTimeRolesGuildSettings :one
 SELECT
-    guild_id, toggle_enabled, timeroles
+    guild_id, toggle_enabled, timeroles, toggle_ladder
 FROM
     guild_settings_timeroles
 WHERE
@@ -66,7 +89,12 @@ WHERE
 func (q *Queries) GetTimeRolesGuildSettings(ctx context.Context, guildID int64) (*GuildSettingsTimeroles, error) {
 	row := q.db.QueryRow(ctx, GetTimeRolesGuildSettings, guildID)
 	var i GuildSettingsTimeroles
-	err := row.Scan(&i.GuildID, &i.ToggleEnabled, &i.Timeroles)
+	err := row.Scan(
+		&i.GuildID,
+		&i.ToggleEnabled,
+		&i.Timeroles,
+		&i.ToggleLadder,
+	)
 	return &i, err
 }
 
@@ -75,7 +103,8 @@ UPDATE
     guild_settings_timeroles
 SET
     toggle_enabled = $2,
-    timeroles = $3
+    timeroles = $3,
+    toggle_ladder = $4
 WHERE
     guild_id = $1
 `
@@ -84,10 +113,16 @@ type UpdateTimeRolesGuildSettingsParams struct {
 	GuildID       int64        `json:"guild_id"`
 	ToggleEnabled bool         `json:"toggle_enabled"`
 	Timeroles     pgtype.JSONB `json:"timeroles"`
+	ToggleLadder  bool         `json:"toggle_ladder"`
 }
 
 func (q *Queries) UpdateTimeRolesGuildSettings(ctx context.Context, arg UpdateTimeRolesGuildSettingsParams) (int64, error) {
-	result, err := q.db.Exec(ctx, UpdateTimeRolesGuildSettings, arg.GuildID, arg.ToggleEnabled, arg.Timeroles)
+	result, err := q.db.Exec(ctx, UpdateTimeRolesGuildSettings,
+		arg.GuildID,
+		arg.ToggleEnabled,
+		arg.Timeroles,
+		arg.ToggleLadder,
+	)
 	if err != nil {
 		return 0, err
 	}
@@ -90,6 +90,49 @@ func (q *Queries) DeleteAndGetGuildVoiceChannelOpenSessionsBefore(ctx context.Co
 	return items, nil
 }
 
+const GetGuildVoiceChannelOpenSessionsByChannel = `-- name: GetGuildVoiceChannelOpenSessionsByChannel :many
+SELECT
+    guild_id, user_id, channel_id, start_ts, last_seen_ts
+FROM
+    guild_voice_channel_open_sessions
+WHERE
+    guild_id = $1
+    AND channel_id = $2
+ORDER BY
+    start_ts ASC
+`
+
+type GetGuildVoiceChannelOpenSessionsByChannelParams struct {
+	GuildID   int64 `json:"guild_id"`
+	ChannelID int64 `json:"channel_id"`
+}
+
+func (q *Queries) GetGuildVoiceChannelOpenSessionsByChannel(ctx context.Context, arg GetGuildVoiceChannelOpenSessionsByChannelParams) ([]*GuildVoiceChannelOpenSessions, error) {
+	rows, err := q.db.Query(ctx, GetGuildVoiceChannelOpenSessionsByChannel, arg.GuildID, arg.ChannelID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []*GuildVoiceChannelOpenSessions{}
+	for rows.Next() {
+		var i GuildVoiceChannelOpenSessions
+		if err := rows.Scan(
+			&i.GuildID,
+			&i.UserID,
+			&i.ChannelID,
+			&i.StartTs,
+			&i.LastSeenTs,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, &i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const UpdateGuildVoiceChannelOpenSessionLastSeen = `-- name: UpdateGuildVoiceChannelOpenSessionLastSeen :exec
 UPDATE guild_voice_channel_open_sessions
 SET last_seen_ts = $3
@@ -37,3 +37,241 @@ func (q *Queries) CreateVoiceChannelStat(ctx context.Context, arg CreateVoiceCha
 	)
 	return err
 }
+
+const GetGuildVoiceLeaderboard = `-- name: GetGuildVoiceLeaderboard :many
+SELECT
+    user_id,
+    SUM(LEAST(total_time_ms, (EXTRACT(EPOCH FROM (end_ts - $2)) * 1000)::bigint))::bigint AS total_time_ms
+FROM
+    guild_voice_channel_stats
+WHERE
+    guild_id = $1
+    AND end_ts >= $2
+    AND ($3::bigint = 0 OR channel_id = $3)
+    AND NOT (channel_id = ANY($4::bigint[]))
+    AND NOT (user_id = ANY($5::bigint[]))
+GROUP BY
+    user_id
+ORDER BY
+    total_time_ms DESC,
+    user_id
+LIMIT $6
+`
+
+type GetGuildVoiceLeaderboardParams struct {
+	GuildID          int64     `json:"guild_id"`
+	Since            time.Time `json:"since"`
+	ChannelID        int64     `json:"channel_id"`
+	ExcludedChannels []int64   `json:"excluded_channels"`
+	ExcludedUsers    []int64   `json:"excluded_users"`
+	EntryLimit       int32     `json:"entry_limit"`
+}
+
+type GetGuildVoiceLeaderboardRow struct {
+	UserID      int64 `json:"user_id"`
+	TotalTimeMs int64 `json:"total_time_ms"`
+}
+
+func (q *Queries) GetGuildVoiceLeaderboard(ctx context.Context, arg GetGuildVoiceLeaderboardParams) ([]*GetGuildVoiceLeaderboardRow, error) {
+	rows, err := q.db.Query(ctx, GetGuildVoiceLeaderboard,
+		arg.GuildID,
+		arg.Since,
+		arg.ChannelID,
+		arg.ExcludedChannels,
+		arg.ExcludedUsers,
+		arg.EntryLimit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []*GetGuildVoiceLeaderboardRow{}
+	for rows.Next() {
+		var i GetGuildVoiceLeaderboardRow
+		if err := rows.Scan(&i.UserID, &i.TotalTimeMs); err != nil {
+			return nil, err
+		}
+		items = append(items, &i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const GetGuildVoiceLeaderboardRank = `-- name: GetGuildVoiceLeaderboardRank :one
+WITH totals AS (
+    SELECT
+        user_id,
+        SUM(LEAST(total_time_ms, (EXTRACT(EPOCH FROM (end_ts - $2)) * 1000)::bigint))::bigint AS total_time_ms
+    FROM
+        guild_voice_channel_stats
+    WHERE
+        guild_id = $1
+        AND end_ts >= $2
+        AND ($3::bigint = 0 OR channel_id = $3)
+        AND NOT (channel_id = ANY($4::bigint[]))
+    GROUP BY
+        user_id
+),
+user_total AS (
+    SELECT
+        COALESCE(SUM(totals.total_time_ms), 0)::bigint AS total_time_ms
+    FROM
+        totals
+    WHERE
+        totals.user_id = $5
+)
+SELECT
+    user_total.total_time_ms,
+    (
+        SELECT
+            COUNT(*)
+        FROM
+            totals
+        WHERE
+            totals.total_time_ms > user_total.total_time_ms
+            AND NOT (totals.user_id = ANY($6::bigint[]))
+    ) + 1 AS rank
+FROM
+    user_total
+`
+
+type GetGuildVoiceLeaderboardRankParams struct {
+	GuildID          int64     `json:"guild_id"`
+	Since            time.Time `json:"since"`
+	ChannelID        int64     `json:"channel_id"`
+	ExcludedChannels []int64   `json:"excluded_channels"`
+	UserID           int64     `json:"user_id"`
+	ExcludedUsers    []int64   `json:"excluded_users"`
+}
+
+type GetGuildVoiceLeaderboardRankRow struct {
+	TotalTimeMs int64 `json:"total_time_ms"`
+	Rank        int64 `json:"rank"`
+}
+
+func (q *Queries) GetGuildVoiceLeaderboardRank(ctx context.Context, arg GetGuildVoiceLeaderboardRankParams) (*GetGuildVoiceLeaderboardRankRow, error) {
+	row := q.db.QueryRow(ctx, GetGuildVoiceLeaderboardRank,
+		arg.GuildID,
+		arg.Since,
+		arg.ChannelID,
+		arg.ExcludedChannels,
+		arg.UserID,
+		arg.ExcludedUsers,
+	)
+	var i GetGuildVoiceLeaderboardRankRow
+	err := row.Scan(
+		&i.TotalTimeMs,
+		&i.Rank,
+	)
+	return &i, err
+}
+
+const GetGuildVoiceSeries = `-- name: GetGuildVoiceSeries :many
+WITH minutes AS (
+    SELECT
+        date_trunc($1::text, hour_ts)::timestamp AS bucket_ts,
+        SUM(minutes) AS voice_minutes,
+        COUNT(DISTINCT user_id) AS voice_users
+    FROM
+        guild_voice_minutes_hour
+    WHERE
+        guild_id = $2
+        AND hour_ts >= $3
+        AND hour_ts < $4
+    GROUP BY
+        1
+),
+sessions AS (
+    SELECT
+        date_trunc($1::text, start_ts)::timestamp AS bucket_ts,
+        COUNT(*) AS sessions
+    FROM
+        guild_voice_channel_stats
+    WHERE
+        guild_id = $2
+        AND start_ts >= $3
+        AND start_ts < $4
+    GROUP BY
+        1
+)
+SELECT
+    COALESCE(minutes.bucket_ts, sessions.bucket_ts)::timestamp AS bucket_ts,
+    COALESCE(minutes.voice_minutes, 0)::bigint AS voice_minutes,
+    COALESCE(minutes.voice_users, 0)::bigint AS voice_users,
+    COALESCE(sessions.sessions, 0)::bigint AS sessions
+FROM
+    minutes
+    FULL OUTER JOIN sessions ON sessions.bucket_ts = minutes.bucket_ts
+ORDER BY
+    bucket_ts
+`
+
+type GetGuildVoiceSeriesParams struct {
+	Bucket  string    `json:"bucket"`
+	GuildID int64     `json:"guild_id"`
+	Since   time.Time `json:"since"`
+	Until   time.Time `json:"until"`
+}
+
+type GetGuildVoiceSeriesRow struct {
+	BucketTs     time.Time `json:"bucket_ts"`
+	VoiceMinutes int64     `json:"voice_minutes"`
+	VoiceUsers   int64     `json:"voice_users"`
+	Sessions     int64     `json:"sessions"`
+}
+
+func (q *Queries) GetGuildVoiceSeries(ctx context.Context, arg GetGuildVoiceSeriesParams) ([]*GetGuildVoiceSeriesRow, error) {
+	rows, err := q.db.Query(ctx, GetGuildVoiceSeries,
+		arg.Bucket,
+		arg.GuildID,
+		arg.Since,
+		arg.Until,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []*GetGuildVoiceSeriesRow{}
+	for rows.Next() {
+		var i GetGuildVoiceSeriesRow
+		if err := rows.Scan(
+			&i.BucketTs,
+			&i.VoiceMinutes,
+			&i.VoiceUsers,
+			&i.Sessions,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, &i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const GetUserVoiceTimeSince = `-- name: GetUserVoiceTimeSince :one
+SELECT
+    COALESCE(SUM(LEAST(total_time_ms, (EXTRACT(EPOCH FROM (end_ts - $3)) * 1000)::bigint)), 0)::bigint AS total_time_ms
+FROM
+    guild_voice_channel_stats
+WHERE
+    guild_id = $1
+    AND user_id = $2
+    AND end_ts >= $3
+`
+
+type GetUserVoiceTimeSinceParams struct {
+	GuildID int64     `json:"guild_id"`
+	UserID  int64     `json:"user_id"`
+	EndTs   time.Time `json:"end_ts"`
+}
+
+func (q *Queries) GetUserVoiceTimeSince(ctx context.Context, arg GetUserVoiceTimeSinceParams) (int64, error) {
+	row := q.db.QueryRow(ctx, GetUserVoiceTimeSince, arg.GuildID, arg.UserID, arg.EndTs)
+	var total_time_ms int64
+	err := row.Scan(&total_time_ms)
+	return total_time_ms, err
+}
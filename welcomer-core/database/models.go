@@ -80,6 +80,13 @@ type EasterEggs struct {
 	WmUserID      string    `json:"wm_user_id"`
 }
 
+type GuildActivityRoleMembers struct {
+	GuildID    int64     `json:"guild_id"`
+	UserID     int64     `json:"user_id"`
+	RoleID     int64     `json:"role_id"`
+	AssignedAt time.Time `json:"assigned_at"`
+}
+
 type GuildFeatures struct {
 	GuildID   int64     `json:"guild_id"`
 	CreatedAt time.Time `json:"created_at"`
@@ -87,28 +94,50 @@ type GuildFeatures struct {
 }
 
 type GuildGiveaways struct {
-	GiveawayUuid    uuid.UUID    `json:"giveaway_uuid"`
-	CreatedAt       time.Time    `json:"created_at"`
-	GuildID         int64        `json:"guild_id"`
-	CreatedBy       int64        `json:"created_by"`
-	AllowEntries    bool         `json:"allow_entries"`
-	HasEnded        bool         `json:"has_ended"`
-	IsSetup         bool         `json:"is_setup"`
-	Title           string       `json:"title"`
-	Description     string       `json:"description"`
-	AccentColour    int64        `json:"accent_colour"`
-	ImageUrl        string       `json:"image_url"`
-	StartTime       time.Time    `json:"start_time"`
-	EndTime         time.Time    `json:"end_time"`
-	AnnounceWinners bool         `json:"announce_winners"`
-	GiveawayPrizes  pgtype.JSONB `json:"giveaway_prizes"`
-	RolesAllowed    pgtype.JSONB `json:"roles_allowed"`
-	RolesExcluded   pgtype.JSONB `json:"roles_excluded"`
-	MinimumJoinDate time.Time    `json:"minimum_join_date"`
-	MessageID       int64        `json:"message_id"`
-	ChannelID       int64        `json:"channel_id"`
-	ShowPrizes      bool         `json:"show_prizes"`
-	ShowEntries     bool         `json:"show_entries"`
+	GiveawayUuid        uuid.UUID    `json:"giveaway_uuid"`
+	CreatedAt           time.Time    `json:"created_at"`
+	GuildID             int64        `json:"guild_id"`
+	CreatedBy           int64        `json:"created_by"`
+	AllowEntries        bool         `json:"allow_entries"`
+	HasEnded            bool         `json:"has_ended"`
+	IsSetup             bool         `json:"is_setup"`
+	Title               string       `json:"title"`
+	Description         string       `json:"description"`
+	AccentColour        int64        `json:"accent_colour"`
+	ImageUrl            string       `json:"image_url"`
+	StartTime           time.Time    `json:"start_time"`
+	EndTime             time.Time    `json:"end_time"`
+	AnnounceWinners     bool         `json:"announce_winners"`
+	GiveawayPrizes      pgtype.JSONB `json:"giveaway_prizes"`
+	RolesAllowed        pgtype.JSONB `json:"roles_allowed"`
+	RolesExcluded       pgtype.JSONB `json:"roles_excluded"`
+	MinimumJoinDate     time.Time    `json:"minimum_join_date"`
+	MessageID           int64        `json:"message_id"`
+	ChannelID           int64        `json:"channel_id"`
+	ShowPrizes          bool         `json:"show_prizes"`
+	ShowEntries         bool         `json:"show_entries"`
+	MinimumAccountAge   int64        `json:"minimum_account_age"`
+	MinimumMessageCount int32        `json:"minimum_message_count"`
+	MinimumVoiceMinutes int32        `json:"minimum_voice_minutes"`
+	ActivityPeriodDays  int32        `json:"activity_period_days"`
+	RecurrenceRule      string       `json:"recurrence_rule"`
+	PingContent         string       `json:"ping_content"`
+	ProvablyFair        bool         `json:"provably_fair"`
+	SeedCommitment      string       `json:"seed_commitment"`
+	ClaimWindow         int64        `json:"claim_window"`
+	RecurrenceAnchor    time.Time    `json:"recurrence_anchor"`
+	PrizeCodePoolUuid   uuid.UUID    `json:"prize_code_pool_uuid"`
+}
+
+type GuildGiveawaysDraws struct {
+	GiveawayUuid   uuid.UUID    `json:"giveaway_uuid"`
+	CreatedAt      time.Time    `json:"created_at"`
+	ServerSeed     string       `json:"server_seed"`
+	SeedCommitment string       `json:"seed_commitment"`
+	Entries        pgtype.JSONB `json:"entries"`
+	Prizes         pgtype.JSONB `json:"prizes"`
+	IsRevealed     bool         `json:"is_revealed"`
+	RevealedAt     time.Time    `json:"revealed_at"`
 }
 
 type GuildGiveawaysEntries struct {
@@ -118,12 +147,31 @@ type GuildGiveawaysEntries struct {
 	CreatedAt              time.Time `json:"created_at"`
 }
 
+type GuildGiveawaysPrizeCodes struct {
+	PrizeCodeUuid uuid.UUID `json:"prize_code_uuid"`
+	GiveawayUuid  uuid.UUID `json:"giveaway_uuid"`
+	CreatedAt     time.Time `json:"created_at"`
+	Prize         string    `json:"prize"`
+	Code          string    `json:"code"`
+	UserID        int64     `json:"user_id"`
+	AssignedAt    time.Time `json:"assigned_at"`
+}
+
 type GuildGiveawaysWinners struct {
 	GiveawayWinnerUuid uuid.UUID `json:"giveaway_winner_uuid"`
 	GiveawayUuid       uuid.UUID `json:"giveaway_uuid"`
 	UserID             int64     `json:"user_id"`
 	Prize              string    `json:"prize"`
 	MessageID          int64     `json:"message_id"`
+	ClaimExpiresAt     time.Time `json:"claim_expires_at"`
+	IsClaimed          bool      `json:"is_claimed"`
+	ClaimedAt          time.Time `json:"claimed_at"`
+	IsExpired          bool      `json:"is_expired"`
+	RoleID             int64     `json:"role_id"`
+	RoleExpiresAt      time.Time `json:"role_expires_at"`
+	IsRoleRemoved      bool      `json:"is_role_removed"`
+	IsDelivered        bool      `json:"is_delivered"`
+	DeliveryError      string    `json:"delivery_error"`
 }
 
 type GuildInvites struct {
@@ -134,6 +182,24 @@ type GuildInvites struct {
 	Uses       int64     `json:"uses"`
 }
 
+type GuildMessageCounts struct {
+	Granularity  string    `json:"granularity"`
+	BucketTs     time.Time `json:"bucket_ts"`
+	GuildID      int64     `json:"guild_id"`
+	ChannelID    int64     `json:"channel_id"`
+	UserID       int64     `json:"user_id"`
+	MessageCount int64     `json:"message_count"`
+}
+
+type GuildMessageCountsDay struct {
+	DayTs        time.Time `json:"day_ts"`
+	GuildID      int64     `json:"guild_id"`
+	ChannelID    int64     `json:"channel_id"`
+	UserID       int64     `json:"user_id"`
+	MessageCount int32     `json:"message_count"`
+	UpdatedAt    time.Time `json:"updated_at"`
+}
+
 type GuildMessageCountsHour struct {
 	HourTs       time.Time `json:"hour_ts"`
 	GuildID      int64     `json:"guild_id"`
@@ -141,6 +207,31 @@ type GuildMessageCountsHour struct {
 	UserID       int64     `json:"user_id"`
 	MessageCount int32     `json:"message_count"`
 	MinTs        time.Time `json:"min_ts"`
+	UpdatedAt    time.Time `json:"updated_at"`
+}
+
+type GuildMessageCountsMonth struct {
+	MonthTs      time.Time `json:"month_ts"`
+	GuildID      int64     `json:"guild_id"`
+	ChannelID    int64     `json:"channel_id"`
+	UserID       int64     `json:"user_id"`
+	MessageCount int64     `json:"message_count"`
+}
+
+type GuildReactionCountsHour struct {
+	HourTs        time.Time `json:"hour_ts"`
+	GuildID       int64     `json:"guild_id"`
+	ChannelID     int64     `json:"channel_id"`
+	UserID        int64     `json:"user_id"`
+	ReactionCount int32     `json:"reaction_count"`
+	MinTs         time.Time `json:"min_ts"`
+}
+
+type GuildSettingsActivityroles struct {
+	GuildID       int64        `json:"guild_id"`
+	ToggleEnabled bool         `json:"toggle_enabled"`
+	ToggleDecay   bool         `json:"toggle_decay"`
+	Activityroles pgtype.JSONB `json:"activityroles"`
 }
 
 type GuildSettingsAutoroles struct {
@@ -160,10 +251,29 @@ type GuildSettingsBorderwall struct {
 	RolesOnVerify   []int64      `json:"roles_on_verify"`
 }
 
+type GuildSettingsDigest struct {
+	GuildID       int64  `json:"guild_id"`
+	ToggleEnabled bool   `json:"toggle_enabled"`
+	ChannelDigest int64  `json:"channel_digest"`
+	Frequency     string `json:"frequency"`
+}
+
 type GuildSettingsFreeroles struct {
+	GuildID       int64        `json:"guild_id"`
+	ToggleEnabled bool         `json:"toggle_enabled"`
+	Roles         []int64      `json:"roles"`
+	RoleDurations pgtype.JSONB `json:"role_durations"`
+}
+
+type GuildSettingsInactive struct {
 	GuildID       int64   `json:"guild_id"`
-	ToggleEnabled bool    `json:"toggle_enabled"`
-	Roles         []int64 `json:"roles"`
+	ExcludedRoles []int64 `json:"excluded_roles"`
+}
+
+type GuildSettingsLeaderboard struct {
+	GuildID           int64   `json:"guild_id"`
+	ToggleIncludeBots bool    `json:"toggle_include_bots"`
+	ExcludedChannels  []int64 `json:"excluded_channels"`
 }
 
 type GuildSettingsLeaver struct {
@@ -185,6 +295,17 @@ type GuildSettingsReactionRoles struct {
 	SystemMessageFormat pgtype.JSONB `json:"system_message_format"`
 	ReactionRoleType    int32        `json:"reaction_role_type"`
 	Roles               pgtype.JSONB `json:"roles"`
+	UniqueSelection     bool         `json:"unique_selection"`
+	MaxSelections       int32        `json:"max_selections"`
+	RequiredRoleID      int64        `json:"required_role_id"`
+	VerifyOnly          bool         `json:"verify_only"`
+	DisableToggleOff    bool         `json:"disable_toggle_off"`
+}
+
+type GuildSettingsRetention struct {
+	GuildID            int64 `json:"guild_id"`
+	ToggleWeeklyReport bool  `json:"toggle_weekly_report"`
+	ChannelReport      int64 `json:"channel_report"`
 }
 
 type GuildSettingsRules struct {
@@ -195,18 +316,20 @@ type GuildSettingsRules struct {
 }
 
 type GuildSettingsTempchannels struct {
-	GuildID          int64 `json:"guild_id"`
-	ToggleEnabled    bool  `json:"toggle_enabled"`
-	ToggleAutopurge  bool  `json:"toggle_autopurge"`
-	ChannelLobby     int64 `json:"channel_lobby"`
-	ChannelCategory  int64 `json:"channel_category"`
-	DefaultUserCount int32 `json:"default_user_count"`
+	GuildID          int64        `json:"guild_id"`
+	ToggleEnabled    bool         `json:"toggle_enabled"`
+	ToggleAutopurge  bool         `json:"toggle_autopurge"`
+	ChannelLobby     int64        `json:"channel_lobby"`
+	ChannelCategory  int64        `json:"channel_category"`
+	DefaultUserCount int32        `json:"default_user_count"`
+	Lobbies          pgtype.JSONB `json:"lobbies"`
 }
 
 type GuildSettingsTimeroles struct {
 	GuildID       int64        `json:"guild_id"`
 	ToggleEnabled bool         `json:"toggle_enabled"`
 	Timeroles     pgtype.JSONB `json:"timeroles"`
+	ToggleLadder  bool         `json:"toggle_ladder"`
 }
 
 type GuildSettingsWelcomer struct {
@@ -249,6 +372,36 @@ type GuildSettingsWelcomerText struct {
 	MessageFormat pgtype.JSONB `json:"message_format"`
 }
 
+type GuildTempChannels struct {
+	ChannelID     int64     `json:"channel_id"`
+	GuildID       int64     `json:"guild_id"`
+	OwnerID       int64     `json:"owner_id"`
+	LobbyID       int64     `json:"lobby_id"`
+	TextChannelID int64     `json:"text_channel_id"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+type GuildTempRoles struct {
+	TempRoleUuid uuid.UUID `json:"temp_role_uuid"`
+	CreatedAt    time.Time `json:"created_at"`
+	GuildID      int64     `json:"guild_id"`
+	UserID       int64     `json:"user_id"`
+	RoleID       int64     `json:"role_id"`
+	ExpiresAt    time.Time `json:"expires_at"`
+	Source       int32     `json:"source"`
+	CreatedBy    int64     `json:"created_by"`
+	IsRemoved    bool      `json:"is_removed"`
+	SourceUuid   uuid.UUID `json:"source_uuid"`
+}
+
+type GuildTimeRoleSchedules struct {
+	GuildID        int64        `json:"guild_id"`
+	UserID         int64        `json:"user_id"`
+	JoinedAt       time.Time    `json:"joined_at"`
+	NextEligibleAt sql.NullTime `json:"next_eligible_at"`
+	UpdatedAt      time.Time    `json:"updated_at"`
+}
+
 type GuildVoiceChannelOpenSessions struct {
 	GuildID    int64     `json:"guild_id"`
 	UserID     int64     `json:"user_id"`
@@ -266,6 +419,16 @@ type GuildVoiceChannelStats struct {
 	EndTs       time.Time `json:"end_ts"`
 	TotalTimeMs int64     `json:"total_time_ms"`
 	Inferred    bool      `json:"inferred"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+type GuildVoiceMinutesHour struct {
+	HourTs          time.Time `json:"hour_ts"`
+	GuildID         int64     `json:"guild_id"`
+	ChannelID       int64     `json:"channel_id"`
+	UserID          int64     `json:"user_id"`
+	Minutes         float64   `json:"minutes"`
+	InferredMinutes float64   `json:"inferred_minutes"`
 }
 
 type Guilds struct {
@@ -16,38 +16,57 @@ import (
 type Querier interface {
 	AddGiveawayEntry(ctx context.Context, arg AddGiveawayEntryParams) (uuid.UUID, error)
 	AddGuildFeature(ctx context.Context, arg AddGuildFeatureParams) error
+	AssignGiveawayPrizeCode(ctx context.Context, arg AssignGiveawayPrizeCodeParams) (*GuildGiveawaysPrizeCodes, error)
+	BackfillTimeRoleSchedules(ctx context.Context, arg BackfillTimeRoleSchedulesParams) (int64, error)
+	ClaimGiveawayWinner(ctx context.Context, giveawayWinnerUuid uuid.UUID) (*GuildGiveawaysWinners, error)
+	ClearGuildTimeRoleSchedules(ctx context.Context, guildID int64) (int64, error)
 	ClearInteractionCommands(ctx context.Context, applicationID int64) (int64, error)
 	CountGiveawayEntries(ctx context.Context, giveawayUuid uuid.UUID) (int32, error)
+	CreateActivityRoleMember(ctx context.Context, arg CreateActivityRoleMemberParams) (int64, error)
+	CreateActivityRolesGuildSettings(ctx context.Context, arg CreateActivityRolesGuildSettingsParams) (*GuildSettingsActivityroles, error)
 	CreateAutoRolesGuildSettings(ctx context.Context, arg CreateAutoRolesGuildSettingsParams) (*GuildSettingsAutoroles, error)
 	CreateBorderwallGuildSettings(ctx context.Context, arg CreateBorderwallGuildSettingsParams) (*GuildSettingsBorderwall, error)
 	CreateBorderwallRequest(ctx context.Context, arg CreateBorderwallRequestParams) (*BorderwallRequests, error)
 	CreateCommandError(ctx context.Context, arg CreateCommandErrorParams) (*ScienceCommandErrors, error)
 	CreateCommandUsage(ctx context.Context, arg CreateCommandUsageParams) (*ScienceCommandUsages, error)
 	CreateCustomBot(ctx context.Context, arg CreateCustomBotParams) (*CustomBots, error)
+	CreateDigestGuildSettings(ctx context.Context, arg CreateDigestGuildSettingsParams) (*GuildSettingsDigest, error)
 	CreateFreeRolesGuildSettings(ctx context.Context, arg CreateFreeRolesGuildSettingsParams) (*GuildSettingsFreeroles, error)
 	CreateGiveaway(ctx context.Context, arg CreateGiveawayParams) (*GuildGiveaways, error)
+	CreateGiveawayDraw(ctx context.Context, arg CreateGiveawayDrawParams) (*GuildGiveawaysDraws, error)
+	CreateGiveawayPrizeCode(ctx context.Context, arg CreateGiveawayPrizeCodeParams) (*GuildGiveawaysPrizeCodes, error)
 	CreateGiveawayWinner(ctx context.Context, arg CreateGiveawayWinnerParams) (*GuildGiveawaysWinners, error)
 	CreateGuild(ctx context.Context, arg CreateGuildParams) (*Guilds, error)
 	CreateGuildInvites(ctx context.Context, arg CreateGuildInvitesParams) (*GuildInvites, error)
 	CreateGuildVoiceChannelOpenSession(ctx context.Context, arg CreateGuildVoiceChannelOpenSessionParams) error
+	CreateInactiveGuildSettings(ctx context.Context, arg CreateInactiveGuildSettingsParams) (*GuildSettingsInactive, error)
+	CreateLeaderboardGuildSettings(ctx context.Context, arg CreateLeaderboardGuildSettingsParams) (*GuildSettingsLeaderboard, error)
 	CreateLeaverGuildSettings(ctx context.Context, arg CreateLeaverGuildSettingsParams) (*GuildSettingsLeaver, error)
 	CreateManyIngestMessageEvents(ctx context.Context, arg []CreateManyIngestMessageEventsParams) (int64, error)
 	CreateManyInteractionCommands(ctx context.Context, arg []CreateManyInteractionCommandsParams) (int64, error)
 	CreateManyScienceGuildEvents(ctx context.Context, arg []CreateManyScienceGuildEventsParams) (int64, error)
 	CreateNewMembership(ctx context.Context, arg CreateNewMembershipParams) (*UserMemberships, error)
+	CreateOrUpdateActivityRolesGuildSettings(ctx context.Context, arg CreateOrUpdateActivityRolesGuildSettingsParams) (*GuildSettingsActivityroles, error)
 	CreateOrUpdateAutoRolesGuildSettings(ctx context.Context, arg CreateOrUpdateAutoRolesGuildSettingsParams) (*GuildSettingsAutoroles, error)
 	CreateOrUpdateBorderwallGuildSettings(ctx context.Context, arg CreateOrUpdateBorderwallGuildSettingsParams) (*GuildSettingsBorderwall, error)
+	CreateOrUpdateDigestGuildSettings(ctx context.Context, arg CreateOrUpdateDigestGuildSettingsParams) (*GuildSettingsDigest, error)
 	CreateOrUpdateDiscordSubscription(ctx context.Context, arg CreateOrUpdateDiscordSubscriptionParams) (*DiscordSubscriptions, error)
 	CreateOrUpdateFreeRolesGuildSettings(ctx context.Context, arg CreateOrUpdateFreeRolesGuildSettingsParams) (*GuildSettingsFreeroles, error)
 	CreateOrUpdateGuild(ctx context.Context, arg CreateOrUpdateGuildParams) (*Guilds, error)
 	CreateOrUpdateGuildInvites(ctx context.Context, arg CreateOrUpdateGuildInvitesParams) (*GuildInvites, error)
+	CreateOrUpdateInactiveGuildSettings(ctx context.Context, arg CreateOrUpdateInactiveGuildSettingsParams) (*GuildSettingsInactive, error)
+	CreateOrUpdateLeaderboardGuildSettings(ctx context.Context, arg CreateOrUpdateLeaderboardGuildSettingsParams) (*GuildSettingsLeaderboard, error)
 	CreateOrUpdateLeaverGuildSettings(ctx context.Context, arg CreateOrUpdateLeaverGuildSettingsParams) (*GuildSettingsLeaver, error)
 	CreateOrUpdateNewMembership(ctx context.Context, arg CreateOrUpdateNewMembershipParams) (*UserMemberships, error)
 	CreateOrUpdatePatreonUser(ctx context.Context, arg CreateOrUpdatePatreonUserParams) (*PatreonUsers, error)
 	CreateOrUpdatePaypalSubscription(ctx context.Context, arg CreateOrUpdatePaypalSubscriptionParams) (*PaypalSubscriptions, error)
 	CreateOrUpdateReactionRoleSetting(ctx context.Context, arg CreateOrUpdateReactionRoleSettingParams) (*GuildSettingsReactionRoles, error)
+	CreateOrUpdateRetentionGuildSettings(ctx context.Context, arg CreateOrUpdateRetentionGuildSettingsParams) (*GuildSettingsRetention, error)
 	CreateOrUpdateRulesGuildSettings(ctx context.Context, arg CreateOrUpdateRulesGuildSettingsParams) (*GuildSettingsRules, error)
+	CreateOrUpdateTempChannel(ctx context.Context, arg CreateOrUpdateTempChannelParams) (*GuildTempChannels, error)
 	CreateOrUpdateTempChannelsGuildSettings(ctx context.Context, arg CreateOrUpdateTempChannelsGuildSettingsParams) (*GuildSettingsTempchannels, error)
+	CreateOrUpdateTempRole(ctx context.Context, arg CreateOrUpdateTempRoleParams) (*GuildTempRoles, error)
+	CreateOrUpdateTimeRoleSchedule(ctx context.Context, arg CreateOrUpdateTimeRoleScheduleParams) (*GuildTimeRoleSchedules, error)
 	CreateOrUpdateTimeRolesGuildSettings(ctx context.Context, arg CreateOrUpdateTimeRolesGuildSettingsParams) (*GuildSettingsTimeroles, error)
 	CreateOrUpdateUser(ctx context.Context, arg CreateOrUpdateUserParams) (*Users, error)
 	CreateOrUpdateUserTransaction(ctx context.Context, arg CreateOrUpdateUserTransactionParams) (*UserTransactions, error)
@@ -56,10 +75,12 @@ type Querier interface {
 	CreateOrUpdateWelcomerImagesGuildSettings(ctx context.Context, arg CreateOrUpdateWelcomerImagesGuildSettingsParams) (*GuildSettingsWelcomerImages, error)
 	CreateOrUpdateWelcomerTextGuildSettings(ctx context.Context, arg CreateOrUpdateWelcomerTextGuildSettingsParams) (*GuildSettingsWelcomerText, error)
 	CreatePatreonUser(ctx context.Context, arg CreatePatreonUserParams) (*PatreonUsers, error)
+	CreateRetentionGuildSettings(ctx context.Context, arg CreateRetentionGuildSettingsParams) (*GuildSettingsRetention, error)
 	CreateRulesGuildSettings(ctx context.Context, arg CreateRulesGuildSettingsParams) (*GuildSettingsRules, error)
 	CreateScienceEvent(ctx context.Context, arg CreateScienceEventParams) (*ScienceEvents, error)
 	CreateScienceGuildEvent(ctx context.Context, arg CreateScienceGuildEventParams) (*ScienceGuildEvents, error)
 	CreateTempChannelsGuildSettings(ctx context.Context, arg CreateTempChannelsGuildSettingsParams) (*GuildSettingsTempchannels, error)
+	CreateTimeRoleSchedule(ctx context.Context, arg CreateTimeRoleScheduleParams) (int64, error)
 	CreateTimeRolesGuildSettings(ctx context.Context, arg CreateTimeRolesGuildSettingsParams) (*GuildSettingsTimeroles, error)
 	CreateUser(ctx context.Context, arg CreateUserParams) (*Users, error)
 	CreateUserTransaction(ctx context.Context, arg CreateUserTransactionParams) (*UserTransactions, error)
@@ -70,17 +91,28 @@ type Querier interface {
 	CreateWelcomerImages(ctx context.Context, arg CreateWelcomerImagesParams) (*WelcomerImages, error)
 	CreateWelcomerImagesGuildSettings(ctx context.Context, arg CreateWelcomerImagesGuildSettingsParams) (*GuildSettingsWelcomerImages, error)
 	CreateWelcomerTextGuildSettings(ctx context.Context, arg CreateWelcomerTextGuildSettingsParams) (*GuildSettingsWelcomerText, error)
+	DeleteActivityRoleMember(ctx context.Context, arg DeleteActivityRoleMemberParams) (int64, error)
+	DeleteActivityRoleMembersByUser(ctx context.Context, arg DeleteActivityRoleMembersByUserParams) (int64, error)
 	DeleteAndGetGuildVoiceChannelOpenSession(ctx context.Context, arg DeleteAndGetGuildVoiceChannelOpenSessionParams) (*GuildVoiceChannelOpenSessions, error)
 	DeleteAndGetGuildVoiceChannelOpenSessionsBefore(ctx context.Context, lastSeenTs time.Time) ([]*GuildVoiceChannelOpenSessions, error)
 	DeleteCustomBot(ctx context.Context, customBotUuid uuid.UUID) (int64, error)
+	DeleteGiveaway(ctx context.Context, arg DeleteGiveawayParams) (int64, error)
 	DeleteGuildInvites(ctx context.Context, arg DeleteGuildInvitesParams) (int64, error)
 	DeletePatreonUser(ctx context.Context, arg DeletePatreonUserParams) (int64, error)
 	DeleteReactionRoleSettings(ctx context.Context, arg DeleteReactionRoleSettingsParams) (int64, error)
+	DeleteTempChannel(ctx context.Context, arg DeleteTempChannelParams) (int64, error)
+	DeleteTimeRoleSchedule(ctx context.Context, arg DeleteTimeRoleScheduleParams) (int64, error)
+	DeleteUnassignedGiveawayPrizeCodes(ctx context.Context, arg DeleteUnassignedGiveawayPrizeCodesParams) (int64, error)
 	DeleteUserMembership(ctx context.Context, membershipUuid uuid.UUID) (int64, error)
 	DeleteUserTransaction(ctx context.Context, transactionUuid uuid.UUID) (int64, error)
 	DeleteWelcomerImage(ctx context.Context, imageUuid uuid.UUID) (int64, error)
 	DisableReactionRoleSettingByMessageId(ctx context.Context, arg DisableReactionRoleSettingByMessageIdParams) (int64, error)
+	ExpireGiveawayWinner(ctx context.Context, giveawayWinnerUuid uuid.UUID) (*GuildGiveawaysWinners, error)
+	GetActivityRoleCandidates(ctx context.Context, arg GetActivityRoleCandidatesParams) ([]*GetActivityRoleCandidatesRow, error)
+	GetActivityRoleMembersByUsers(ctx context.Context, arg GetActivityRoleMembersByUsersParams) ([]*GuildActivityRoleMembers, error)
+	GetActivityRolesGuildSettings(ctx context.Context, guildID int64) (*GuildSettingsActivityroles, error)
 	GetAllCustomBotsWithToken(ctx context.Context, environment string) ([]*CustomBots, error)
+	GetAssignedGiveawayPrizeCode(ctx context.Context, arg GetAssignedGiveawayPrizeCodeParams) (*GuildGiveawaysPrizeCodes, error)
 	GetAutoRolesGuildSettings(ctx context.Context, guildID int64) (*GuildSettingsAutoroles, error)
 	GetBorderwallGuildSettings(ctx context.Context, guildID int64) (*GuildSettingsBorderwall, error)
 	GetBorderwallRequest(ctx context.Context, requestUuid uuid.UUID) (*BorderwallRequests, error)
@@ -94,25 +126,64 @@ type Querier interface {
 	GetCustomBotById(ctx context.Context, arg GetCustomBotByIdParams) (*GetCustomBotByIdRow, error)
 	GetCustomBotByIdWithToken(ctx context.Context, arg GetCustomBotByIdWithTokenParams) (*CustomBots, error)
 	GetCustomBotsByGuildId(ctx context.Context, guildID int64) ([]*GetCustomBotsByGuildIdRow, error)
+	GetDigestGuildSettings(ctx context.Context, guildID int64) (*GuildSettingsDigest, error)
 	GetDiscordSubscriptionsByUserID(ctx context.Context, userID int64) ([]*DiscordSubscriptions, error)
+	GetDueTimeRoleSchedules(ctx context.Context, arg GetDueTimeRoleSchedulesParams) ([]*GuildTimeRoleSchedules, error)
 	GetEasterEggsByUserID(ctx context.Context, userID int64) ([]*GetEasterEggsByUserIDRow, error)
+	GetEnabledDigestGuildSettings(ctx context.Context, frequency string) ([]*GuildSettingsDigest, error)
+	GetEnabledRetentionGuildSettings(ctx context.Context) ([]*GuildSettingsRetention, error)
+	GetEnabledTempChannelsGuildSettings(ctx context.Context) ([]*GuildSettingsTempchannels, error)
+	GetExpiredGiveawayPrizeRoles(ctx context.Context, giveawayUuid uuid.UUID) ([]*GuildGiveawaysWinners, error)
 	GetExpiredGiveaways(ctx context.Context) ([]*GuildGiveaways, error)
+	GetExpiredTempRoles(ctx context.Context, guildID int64) ([]*GuildTempRoles, error)
 	GetExpiredWelcomeMessageEvents(ctx context.Context, arg GetExpiredWelcomeMessageEventsParams) ([]*GetExpiredWelcomeMessageEventsRow, error)
 	GetExpiringUserMemberships(ctx context.Context, status int32) ([]*UserMemberships, error)
 	GetFreeRolesGuildSettings(ctx context.Context, guildID int64) (*GuildSettingsFreeroles, error)
 	GetGiveaway(ctx context.Context, arg GetGiveawayParams) (*GuildGiveaways, error)
+	GetGiveawayDraw(ctx context.Context, giveawayUuid uuid.UUID) (*GuildGiveawaysDraws, error)
 	GetGiveawayEntries(ctx context.Context, giveawayUuid uuid.UUID) ([]*GuildGiveawaysEntries, error)
+	GetGiveawayEntriesPaginated(ctx context.Context, arg GetGiveawayEntriesPaginatedParams) ([]*GuildGiveawaysEntries, error)
 	GetGiveawayEntryFromMessageID(ctx context.Context, arg GetGiveawayEntryFromMessageIDParams) (*GetGiveawayEntryFromMessageIDRow, error)
 	GetGiveawayEntryUsers(ctx context.Context, giveawayUuid uuid.UUID) ([]int64, error)
 	GetGiveawayFromMessageID(ctx context.Context, arg GetGiveawayFromMessageIDParams) (*GuildGiveaways, error)
+	GetGiveawayPrizeCodeCounts(ctx context.Context, giveawayUuid uuid.UUID) ([]*GetGiveawayPrizeCodeCountsRow, error)
+	GetGiveawayWinner(ctx context.Context, giveawayWinnerUuid uuid.UUID) (*GuildGiveawaysWinners, error)
 	GetGiveawayWinners(ctx context.Context, giveawayUuid uuid.UUID) ([]*GuildGiveawaysWinners, error)
+	GetGiveawaysWithExpiredClaims(ctx context.Context) ([]*GetGiveawaysWithExpiredClaimsRow, error)
+	GetGiveawaysWithExpiredPrizeRoles(ctx context.Context) ([]*GetGiveawaysWithExpiredPrizeRolesRow, error)
 	GetGuild(ctx context.Context, guildID int64) (*Guilds, error)
+	GetGuildActiveUserIDs(ctx context.Context, arg GetGuildActiveUserIDsParams) ([]int64, error)
+	GetGuildActiveUserSeries(ctx context.Context, arg GetGuildActiveUserSeriesParams) ([]*GetGuildActiveUserSeriesRow, error)
+	GetGuildActivityRoleCandidates(ctx context.Context, arg GetGuildActivityRoleCandidatesParams) ([]int64, error)
+	GetGuildActivityTotals(ctx context.Context, arg GetGuildActivityTotalsParams) ([]*GetGuildActivityTotalsRow, error)
 	GetGuildAuditLogs(ctx context.Context, guildID sql.NullInt64) ([]*GetGuildAuditLogsRow, error)
+	GetGuildCohortRetention(ctx context.Context, arg GetGuildCohortRetentionParams) ([]*GetGuildCohortRetentionRow, error)
+	GetGuildCommandErrorCounts(ctx context.Context, arg GetGuildCommandErrorCountsParams) ([]*GetGuildCommandErrorCountsRow, error)
+	GetGuildEarliestActivity(ctx context.Context, guildID int64) (time.Time, error)
+	GetGuildEndedGiveaways(ctx context.Context, arg GetGuildEndedGiveawaysParams) ([]*GetGuildEndedGiveawaysRow, error)
+	GetGuildEventCountSeries(ctx context.Context, arg GetGuildEventCountSeriesParams) ([]*GetGuildEventCountSeriesRow, error)
+	GetGuildEventCounts(ctx context.Context, arg GetGuildEventCountsParams) ([]*GetGuildEventCountsRow, error)
+	GetGuildFailedMessageRemovalCount(ctx context.Context, arg GetGuildFailedMessageRemovalCountParams) (int64, error)
 	GetGuildFeatures(ctx context.Context, guildID int64) ([]string, error)
+	GetGuildGiveaways(ctx context.Context, guildID int64) ([]*GetGuildGiveawaysRow, error)
 	GetGuildInvite(ctx context.Context, arg GetGuildInviteParams) (*GuildInvites, error)
 	GetGuildInvites(ctx context.Context, guildID int64) ([]*GuildInvites, error)
+	GetGuildMessageChannelLeaderboard(ctx context.Context, arg GetGuildMessageChannelLeaderboardParams) ([]*GetGuildMessageChannelLeaderboardRow, error)
+	GetGuildMessageCountSeries(ctx context.Context, arg GetGuildMessageCountSeriesParams) ([]*GetGuildMessageCountSeriesRow, error)
+	GetGuildMessageLeaderboard(ctx context.Context, arg GetGuildMessageLeaderboardParams) ([]*GetGuildMessageLeaderboardRow, error)
+	GetGuildMessageLeaderboardRank(ctx context.Context, arg GetGuildMessageLeaderboardRankParams) (*GetGuildMessageLeaderboardRankRow, error)
+	GetGuildScheduledGiveaways(ctx context.Context, guildID int64) ([]*GuildGiveaways, error)
+	GetGuildTopInviters(ctx context.Context, arg GetGuildTopInvitersParams) ([]*GetGuildTopInvitersRow, error)
+	GetGuildVoiceChannelOpenSessionsByChannel(ctx context.Context, arg GetGuildVoiceChannelOpenSessionsByChannelParams) ([]*GuildVoiceChannelOpenSessions, error)
+	GetGuildVoiceLeaderboard(ctx context.Context, arg GetGuildVoiceLeaderboardParams) ([]*GetGuildVoiceLeaderboardRow, error)
+	GetGuildVoiceLeaderboardRank(ctx context.Context, arg GetGuildVoiceLeaderboardRankParams) (*GetGuildVoiceLeaderboardRankRow, error)
+	GetGuildVoiceSeries(ctx context.Context, arg GetGuildVoiceSeriesParams) ([]*GetGuildVoiceSeriesRow, error)
+	GetGuildsWithDueTimeRoleSchedules(ctx context.Context) ([]int64, error)
+	GetGuildsWithExpiredTempRoles(ctx context.Context) ([]int64, error)
+	GetInactiveGuildSettings(ctx context.Context, guildID int64) (*GuildSettingsInactive, error)
 	GetInteractionCommand(ctx context.Context, arg GetInteractionCommandParams) (*InteractionCommands, error)
 	GetJobCheckpointByName(ctx context.Context, jobName string) (*JobCheckpoints, error)
+	GetLeaderboardGuildSettings(ctx context.Context, guildID int64) (*GuildSettingsLeaderboard, error)
 	GetLeaverGuildSettings(ctx context.Context, guildID int64) (*GuildSettingsLeaver, error)
 	GetMinimalWelcomerBuilderArtifactByGuildId(ctx context.Context, guildID int64) ([]*GetMinimalWelcomerBuilderArtifactByGuildIdRow, error)
 	GetPatreonUser(ctx context.Context, patreonUserID int64) (*PatreonUsers, error)
@@ -120,23 +191,32 @@ type Querier interface {
 	GetPatreonUsersByUserID(ctx context.Context, userID int64) ([]*PatreonUsers, error)
 	GetPaypalSubscriptionBySubscriptionID(ctx context.Context, subscriptionID string) (*PaypalSubscriptions, error)
 	GetPaypalSubscriptionsByUserID(ctx context.Context, userID int64) ([]*PaypalSubscriptions, error)
+	GetReactionRoleEventCounts(ctx context.Context, arg GetReactionRoleEventCountsParams) ([]*GetReactionRoleEventCountsRow, error)
 	GetReactionRoleSettingByGuildId(ctx context.Context, guildID int64) ([]*GuildSettingsReactionRoles, error)
 	GetReactionRoleSettingById(ctx context.Context, arg GetReactionRoleSettingByIdParams) (*GuildSettingsReactionRoles, error)
 	GetReactionRoleSettingByMessageId(ctx context.Context, arg GetReactionRoleSettingByMessageIdParams) (*GuildSettingsReactionRoles, error)
+	GetRetentionGuildSettings(ctx context.Context, guildID int64) (*GuildSettingsRetention, error)
 	GetRulesGuildSettings(ctx context.Context, guildID int64) (*GuildSettingsRules, error)
+	GetScheduledGiveaways(ctx context.Context) ([]*GuildGiveaways, error)
 	GetScienceEvent(ctx context.Context, eventUuid uuid.UUID) (*ScienceEvents, error)
 	GetScienceGuildEvent(ctx context.Context, guildEventUuid uuid.UUID) (*ScienceGuildEvents, error)
 	GetScienceGuildJoinLeaveEventForUser(ctx context.Context, arg GetScienceGuildJoinLeaveEventForUserParams) (*GetScienceGuildJoinLeaveEventForUserRow, error)
+	GetTempChannel(ctx context.Context, arg GetTempChannelParams) (*GuildTempChannels, error)
+	GetTempChannelByOwner(ctx context.Context, arg GetTempChannelByOwnerParams) (*GuildTempChannels, error)
+	GetTempChannelsByGuild(ctx context.Context, guildID int64) ([]*GuildTempChannels, error)
 	GetTempChannelsGuildSettings(ctx context.Context, guildID int64) (*GuildSettingsTempchannels, error)
+	GetTempRole(ctx context.Context, arg GetTempRoleParams) (*GuildTempRoles, error)
 	GetTimeRolesGuildSettings(ctx context.Context, guildID int64) (*GuildSettingsTimeroles, error)
 	GetUser(ctx context.Context, userID int64) (*Users, error)
 	GetUserMembership(ctx context.Context, membershipUuid uuid.UUID) (*GetUserMembershipRow, error)
 	GetUserMembershipsByGuildID(ctx context.Context, guildID int64) ([]*GetUserMembershipsByGuildIDRow, error)
 	GetUserMembershipsByTransactionID(ctx context.Context, transactionID string) ([]*GetUserMembershipsByTransactionIDRow, error)
 	GetUserMembershipsByUserID(ctx context.Context, userID int64) ([]*GetUserMembershipsByUserIDRow, error)
+	GetUserMessageCountSince(ctx context.Context, arg GetUserMessageCountSinceParams) (int64, error)
 	GetUserTransaction(ctx context.Context, transactionUuid uuid.UUID) (*UserTransactions, error)
 	GetUserTransactionsByTransactionID(ctx context.Context, transactionID string) ([]*UserTransactions, error)
 	GetUserTransactionsByUserID(ctx context.Context, userID int64) ([]*UserTransactions, error)
+	GetUserVoiceTimeSince(ctx context.Context, arg GetUserVoiceTimeSinceParams) (int64, error)
 	GetWelcomerBuilderArtifactByArtifactUUID(ctx context.Context, artifactUuid uuid.UUID) (*WelcomerBuilderArtifacts, error)
 	GetWelcomerBuilderArtifactsByGuildId(ctx context.Context, guildID int64) ([]*WelcomerBuilderArtifacts, error)
 	GetWelcomerDMsGuildSettings(ctx context.Context, guildID int64) (*GuildSettingsWelcomerDms, error)
@@ -152,14 +232,24 @@ type Querier interface {
 	InsertEasterEgg(ctx context.Context, arg InsertEasterEggParams) (uuid.UUID, error)
 	RemoveGiveawayEntry(ctx context.Context, arg RemoveGiveawayEntryParams) error
 	RemoveGuildFeature(ctx context.Context, arg RemoveGuildFeatureParams) error
+	RemoveTempRole(ctx context.Context, arg RemoveTempRoleParams) (int64, error)
 	RemoveWelcomerArtifact(ctx context.Context, arg RemoveWelcomerArtifactParams) (int64, error)
+	RescheduleGuildTimeRoles(ctx context.Context, arg RescheduleGuildTimeRolesParams) (int64, error)
+	RevealGiveawayDraw(ctx context.Context, arg RevealGiveawayDrawParams) (*GuildGiveawaysDraws, error)
 	SetGiveawayEnded(ctx context.Context, arg SetGiveawayEndedParams) (*GuildGiveaways, error)
+	SetGiveawayPrizeCodePool(ctx context.Context, arg SetGiveawayPrizeCodePoolParams) error
+	SetGiveawayRecurrenceAnchor(ctx context.Context, arg SetGiveawayRecurrenceAnchorParams) error
+	SetGiveawaySeedCommitment(ctx context.Context, arg SetGiveawaySeedCommitmentParams) (*GuildGiveaways, error)
+	SetGiveawayWinnerDelivery(ctx context.Context, arg SetGiveawayWinnerDeliveryParams) (*GuildGiveawaysWinners, error)
+	SetGiveawayWinnerRoleRemoved(ctx context.Context, giveawayWinnerUuid uuid.UUID) (*GuildGiveawaysWinners, error)
 	SetGuildMemberCount(ctx context.Context, arg SetGuildMemberCountParams) (int64, error)
+	UpdateActivityRolesGuildSettings(ctx context.Context, arg UpdateActivityRolesGuildSettingsParams) (int64, error)
 	UpdateAutoRolesGuildSettings(ctx context.Context, arg UpdateAutoRolesGuildSettingsParams) (int64, error)
 	UpdateBorderwallGuildSettings(ctx context.Context, arg UpdateBorderwallGuildSettingsParams) (int64, error)
 	UpdateBorderwallRequest(ctx context.Context, arg UpdateBorderwallRequestParams) (int64, error)
 	UpdateCustomBot(ctx context.Context, arg UpdateCustomBotParams) (*CustomBots, error)
 	UpdateCustomBotToken(ctx context.Context, arg UpdateCustomBotTokenParams) (*CustomBots, error)
+	UpdateDigestGuildSettings(ctx context.Context, arg UpdateDigestGuildSettingsParams) (int64, error)
 	UpdateFreeRolesGuildSettings(ctx context.Context, arg UpdateFreeRolesGuildSettingsParams) (int64, error)
 	UpdateGiveaway(ctx context.Context, arg UpdateGiveawayParams) (*GuildGiveaways, error)
 	UpdateGiveawayMessage(ctx context.Context, arg UpdateGiveawayMessageParams) (*GuildGiveaways, error)
@@ -168,11 +258,16 @@ type Querier interface {
 	UpdateGuild(ctx context.Context, arg UpdateGuildParams) (*Guilds, error)
 	UpdateGuildBio(ctx context.Context, arg UpdateGuildBioParams) (*Guilds, error)
 	UpdateGuildVoiceChannelOpenSessionLastSeen(ctx context.Context, arg UpdateGuildVoiceChannelOpenSessionLastSeenParams) error
+	UpdateInactiveGuildSettings(ctx context.Context, arg UpdateInactiveGuildSettingsParams) (int64, error)
+	UpdateLeaderboardGuildSettings(ctx context.Context, arg UpdateLeaderboardGuildSettingsParams) (int64, error)
 	UpdateLeaverGuildSettings(ctx context.Context, arg UpdateLeaverGuildSettingsParams) (int64, error)
 	UpdatePatreonUser(ctx context.Context, arg UpdatePatreonUserParams) (int64, error)
 	UpdateReactionRoleSettingMessageId(ctx context.Context, arg UpdateReactionRoleSettingMessageIdParams) (int64, error)
+	UpdateRetentionGuildSettings(ctx context.Context, arg UpdateRetentionGuildSettingsParams) (int64, error)
 	UpdateRuleGuildSettings(ctx context.Context, arg UpdateRuleGuildSettingsParams) (int64, error)
+	UpdateTempChannelOwner(ctx context.Context, arg UpdateTempChannelOwnerParams) (int64, error)
 	UpdateTempChannelsGuildSettings(ctx context.Context, arg UpdateTempChannelsGuildSettingsParams) (int64, error)
+	UpdateTimeRoleScheduleNextEligibleAt(ctx context.Context, arg UpdateTimeRoleScheduleNextEligibleAtParams) (int64, error)
 	UpdateTimeRolesGuildSettings(ctx context.Context, arg UpdateTimeRolesGuildSettingsParams) (int64, error)
 	UpdateUser(ctx context.Context, arg UpdateUserParams) (int64, error)
 	UpdateUserMembership(ctx context.Context, arg UpdateUserMembershipParams) (int64, error)
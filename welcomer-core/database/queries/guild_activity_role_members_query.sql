@@ -0,0 +1,111 @@
+-- name: CreateActivityRoleMember :execrows
+INSERT INTO guild_activity_role_members (guild_id, user_id, role_id, assigned_at)
+    VALUES ($1, $2, $3, NOW())
+ON CONFLICT(guild_id, user_id, role_id) DO NOTHING;
+
+-- name: DeleteActivityRoleMember :execrows
+DELETE FROM guild_activity_role_members
+WHERE guild_id = $1
+    AND user_id = $2
+    AND role_id = $3;
+
+-- name: DeleteActivityRoleMembersByUser :execrows
+DELETE FROM guild_activity_role_members
+WHERE guild_id = $1
+    AND user_id = $2;
+
+-- name: GetActivityRoleMembersByUsers :many
+SELECT
+    *
+FROM
+    guild_activity_role_members
+WHERE
+    guild_id = @guild_id
+    AND user_id = ANY (@user_ids::bigint[]);
+
+-- name: GetGuildActivityTotals :many
+SELECT
+    member.user_id::bigint AS user_id,
+    COALESCE((
+        SELECT
+            SUM(message_count)
+        FROM guild_message_counts
+        WHERE
+            guild_message_counts.granularity = @granularity::text
+            AND guild_message_counts.guild_id = @guild_id
+            AND guild_message_counts.user_id = member.user_id
+            AND guild_message_counts.bucket_ts >= @since::timestamptz), 0)::bigint AS message_count,
+    COALESCE((
+        SELECT
+            SUM(LEAST(total_time_ms, (EXTRACT(EPOCH FROM (end_ts - @since)) * 1000)::bigint))
+        FROM guild_voice_channel_stats
+        WHERE
+            guild_voice_channel_stats.guild_id = @guild_id
+            AND guild_voice_channel_stats.user_id = member.user_id
+            AND end_ts >= @since), 0)::bigint AS total_time_ms
+FROM
+    unnest(@user_ids::bigint[]) AS member (user_id);
+
+-- name: GetActivityRoleCandidates :many
+SELECT
+    guild_id,
+    user_id
+FROM
+    guild_message_counts_hour
+WHERE
+    hour_ts >= date_trunc('hour', @since::timestamptz)
+    AND guild_id IN (
+        SELECT
+            guild_id
+        FROM
+            guild_settings_activityroles
+        WHERE
+            toggle_enabled = TRUE)
+UNION
+SELECT
+    guild_id,
+    user_id
+FROM
+    guild_voice_channel_stats
+WHERE
+    end_ts >= @since
+    AND guild_id IN (
+        SELECT
+            guild_id
+        FROM
+            guild_settings_activityroles
+        WHERE
+            toggle_enabled = TRUE)
+UNION
+SELECT
+    guild_id,
+    user_id
+FROM
+    guild_activity_role_members
+WHERE
+    @include_decay::boolean
+    AND guild_id IN (
+        SELECT
+            guild_id
+        FROM
+            guild_settings_activityroles
+        WHERE
+            toggle_enabled = TRUE
+            AND toggle_decay = TRUE);
+
+-- name: GetGuildActivityRoleCandidates :many
+SELECT
+    user_id
+FROM
+    guild_message_counts_hour
+WHERE
+    guild_id = @guild_id
+    AND hour_ts >= date_trunc('hour', @since::timestamptz)
+UNION
+SELECT
+    user_id
+FROM
+    guild_voice_channel_stats
+WHERE
+    guild_id = @guild_id
+    AND end_ts >= @since;
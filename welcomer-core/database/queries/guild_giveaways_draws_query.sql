@@ -0,0 +1,28 @@
+-- name: CreateGiveawayDraw :one
+INSERT INTO guild_giveaways_draws (giveaway_uuid, created_at, server_seed, seed_commitment, entries, prizes, is_revealed, revealed_at)
+VALUES ($1, NOW(), $2, $3, '[]', '[]', FALSE, 'epoch')
+ON CONFLICT (giveaway_uuid) DO UPDATE
+    SET giveaway_uuid = EXCLUDED.giveaway_uuid
+RETURNING
+    *;
+
+-- name: GetGiveawayDraw :one
+SELECT
+    *
+FROM
+    guild_giveaways_draws
+WHERE
+    giveaway_uuid = $1;
+
+-- name: RevealGiveawayDraw :one
+UPDATE
+    guild_giveaways_draws
+SET
+    entries = $2,
+    prizes = $3,
+    is_revealed = TRUE,
+    revealed_at = NOW()
+WHERE
+    giveaway_uuid = $1
+RETURNING
+    *;
\ No newline at end of file
@@ -30,4 +30,10 @@ FROM
 WHERE
     guild_giveaways.guild_id = $1
     AND guild_giveaways.channel_id = $2
-    AND guild_giveaways.message_id = $3;
\ No newline at end of file
+    AND guild_giveaways.message_id = $3;
+
+-- name: GetGiveawayEntriesPaginated :many
+SELECT * FROM guild_giveaways_entries
+WHERE giveaway_uuid = $1
+ORDER BY created_at DESC
+LIMIT $2 OFFSET $3;
\ No newline at end of file
@@ -0,0 +1,58 @@
+-- name: CreateGiveawayPrizeCode :one
+INSERT INTO guild_giveaways_prize_codes (prize_code_uuid, giveaway_uuid, created_at, prize, code)
+VALUES (uuid_generate_v7(), $1, NOW(), $2, $3)
+RETURNING
+    *;
+
+-- name: GetGiveawayPrizeCodeCounts :many
+SELECT
+    prize,
+    COUNT(*) AS total,
+    COUNT(*) FILTER (WHERE user_id = 0) AS remaining
+FROM
+    guild_giveaways_prize_codes
+WHERE
+    giveaway_uuid = $1
+GROUP BY
+    prize;
+
+-- name: GetAssignedGiveawayPrizeCode :one
+SELECT
+    *
+FROM
+    guild_giveaways_prize_codes
+WHERE
+    giveaway_uuid = $1
+    AND prize = $2
+    AND user_id = $3;
+
+-- name: AssignGiveawayPrizeCode :one
+UPDATE
+    guild_giveaways_prize_codes
+SET
+    giveaway_uuid = @giveaway_uuid,
+    user_id = @user_id,
+    assigned_at = NOW()
+WHERE
+    prize_code_uuid = (
+        SELECT
+            prize_code_uuid
+        FROM
+            guild_giveaways_prize_codes
+        WHERE
+            guild_giveaways_prize_codes.giveaway_uuid = @prize_code_pool_uuid
+            AND guild_giveaways_prize_codes.prize = @prize
+            AND guild_giveaways_prize_codes.user_id = 0
+        ORDER BY
+            created_at
+        LIMIT 1
+        FOR UPDATE
+            SKIP LOCKED)
+RETURNING
+    *;
+
+-- name: DeleteUnassignedGiveawayPrizeCodes :execrows
+DELETE FROM guild_giveaways_prize_codes
+WHERE giveaway_uuid = $1
+    AND prize = $2
+    AND user_id = 0;
\ No newline at end of file
@@ -23,7 +23,15 @@ SET
     accent_colour = $14,
     image_url = $15,
     show_prizes = $16,
-    show_entries = $17
+    show_entries = $17,
+    minimum_account_age = $18,
+    minimum_message_count = $19,
+    minimum_voice_minutes = $20,
+    activity_period_days = $21,
+    recurrence_rule = $22,
+    ping_content = $23,
+    provably_fair = $24,
+    claim_window = $25
 WHERE
     giveaway_uuid = $1
 RETURNING
@@ -77,5 +85,109 @@ FROM
 WHERE
     has_ended = FALSE
     AND is_setup = FALSE
+    AND message_id != 0
     AND end_time <= NOW()
-    AND end_time > 'epoch';
\ No newline at end of file
+    AND end_time > 'epoch';
+
+-- name: GetScheduledGiveaways :many
+SELECT
+    *
+FROM
+    guild_giveaways
+WHERE
+    has_ended = FALSE
+    AND is_setup = FALSE
+    AND message_id = 0
+    AND start_time <= NOW();
+
+-- name: SetGiveawayRecurrenceAnchor :exec
+UPDATE
+    guild_giveaways
+SET
+    recurrence_anchor = $2
+WHERE
+    giveaway_uuid = $1;
+
+-- name: SetGiveawayPrizeCodePool :exec
+UPDATE
+    guild_giveaways
+SET
+    prize_code_pool_uuid = $2
+WHERE
+    giveaway_uuid = $1;
+
+-- name: GetGuildScheduledGiveaways :many
+SELECT
+    *
+FROM
+    guild_giveaways
+WHERE
+    guild_id = $1
+    AND has_ended = FALSE
+    AND is_setup = FALSE
+    AND message_id = 0
+ORDER BY
+    start_time;
+
+-- name: SetGiveawaySeedCommitment :one
+UPDATE
+    guild_giveaways
+SET
+    seed_commitment = $2
+WHERE
+    giveaway_uuid = $1
+RETURNING
+    *;
+
+-- name: GetGuildGiveaways :many
+SELECT
+    sqlc.embed(guild_giveaways),
+    (
+        SELECT
+            COUNT(*)
+        FROM
+            guild_giveaways_entries
+        WHERE
+            guild_giveaways_entries.giveaway_uuid = guild_giveaways.giveaway_uuid)::int AS entries
+FROM
+    guild_giveaways
+WHERE
+    guild_id = $1
+ORDER BY
+    created_at DESC;
+
+-- name: GetGuildEndedGiveaways :many
+SELECT
+    guild_giveaways.giveaway_uuid,
+    guild_giveaways.title,
+    guild_giveaways.channel_id,
+    guild_giveaways.message_id,
+    guild_giveaways.end_time,
+    (
+        SELECT
+            COUNT(*)
+        FROM
+            guild_giveaways_entries
+        WHERE
+            guild_giveaways_entries.giveaway_uuid = guild_giveaways.giveaway_uuid) AS entries,
+    COUNT(guild_giveaways_winners.giveaway_winner_uuid) AS winners,
+    COUNT(guild_giveaways_winners.giveaway_winner_uuid) FILTER (WHERE guild_giveaways_winners.delivery_error != '') AS delivery_failures
+FROM
+    guild_giveaways
+    LEFT JOIN guild_giveaways_winners ON guild_giveaways_winners.giveaway_uuid = guild_giveaways.giveaway_uuid
+WHERE
+    guild_giveaways.guild_id = @guild_id
+    AND guild_giveaways.has_ended = TRUE
+    AND guild_giveaways.end_time >= @since
+    AND guild_giveaways.end_time < @until
+GROUP BY
+    guild_giveaways.giveaway_uuid
+ORDER BY
+    guild_giveaways.end_time DESC
+LIMIT @entry_limit;
+
+-- name: DeleteGiveaway :execrows
+DELETE FROM guild_giveaways
+WHERE
+    guild_id = $1
+    AND giveaway_uuid = $2;
\ No newline at end of file
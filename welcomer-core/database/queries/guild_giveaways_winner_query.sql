@@ -1,6 +1,6 @@
 -- name: CreateGiveawayWinner :one
-INSERT INTO guild_giveaways_winners (giveaway_winner_uuid, giveaway_uuid, user_id, prize, message_id)
-VALUES (uuid_generate_v7(), $1, $2, $3, $4)
+INSERT INTO guild_giveaways_winners (giveaway_winner_uuid, giveaway_uuid, user_id, prize, message_id, claim_expires_at)
+VALUES (uuid_generate_v7(), $1, $2, $3, $4, $5)
 RETURNING
     *;
 
@@ -12,6 +12,14 @@ FROM
 WHERE
     giveaway_uuid = $1;
 
+-- name: GetGiveawayWinner :one
+SELECT
+    *
+FROM
+    guild_giveaways_winners
+WHERE
+    giveaway_winner_uuid = $1;
+
 -- name: UpdateGiveawayWinnerUserID :one
 UPDATE
     guild_giveaways_winners
@@ -29,5 +37,92 @@ SET
     message_id = $2
 WHERE
     giveaway_winner_uuid = $1
+RETURNING
+    *;
+
+-- name: ClaimGiveawayWinner :one
+UPDATE
+    guild_giveaways_winners
+SET
+    is_claimed = TRUE,
+    claimed_at = NOW()
+WHERE
+    giveaway_winner_uuid = $1
+    AND is_claimed = FALSE
+    AND is_expired = FALSE
+RETURNING
+    *;
+
+-- name: GetGiveawaysWithExpiredClaims :many
+SELECT DISTINCT
+    guild_giveaways.giveaway_uuid,
+    guild_giveaways.guild_id
+FROM
+    guild_giveaways_winners
+    JOIN guild_giveaways ON guild_giveaways.giveaway_uuid = guild_giveaways_winners.giveaway_uuid
+WHERE
+    guild_giveaways_winners.is_claimed = FALSE
+    AND guild_giveaways_winners.is_expired = FALSE
+    AND guild_giveaways_winners.claim_expires_at > 'epoch'
+    AND guild_giveaways_winners.claim_expires_at <= NOW();
+
+-- name: SetGiveawayWinnerDelivery :one
+UPDATE
+    guild_giveaways_winners
+SET
+    is_delivered = $2,
+    delivery_error = $3,
+    role_id = $4,
+    role_expires_at = $5
+WHERE
+    giveaway_winner_uuid = $1
+RETURNING
+    *;
+
+-- name: GetGiveawaysWithExpiredPrizeRoles :many
+SELECT DISTINCT
+    guild_giveaways.giveaway_uuid,
+    guild_giveaways.guild_id
+FROM
+    guild_giveaways_winners
+    JOIN guild_giveaways ON guild_giveaways.giveaway_uuid = guild_giveaways_winners.giveaway_uuid
+WHERE
+    guild_giveaways_winners.role_id != 0
+    AND guild_giveaways_winners.is_role_removed = FALSE
+    AND (guild_giveaways_winners.is_expired = TRUE
+        OR (guild_giveaways_winners.role_expires_at > 'epoch'
+            AND guild_giveaways_winners.role_expires_at <= NOW()));
+
+-- name: GetExpiredGiveawayPrizeRoles :many
+SELECT
+    *
+FROM
+    guild_giveaways_winners
+WHERE
+    giveaway_uuid = $1
+    AND role_id != 0
+    AND is_role_removed = FALSE
+    AND (is_expired = TRUE
+        OR (role_expires_at > 'epoch'
+            AND role_expires_at <= NOW()));
+
+-- name: SetGiveawayWinnerRoleRemoved :one
+UPDATE
+    guild_giveaways_winners
+SET
+    is_role_removed = TRUE
+WHERE
+    giveaway_winner_uuid = $1
+RETURNING
+    *;
+
+-- name: ExpireGiveawayWinner :one
+UPDATE
+    guild_giveaways_winners
+SET
+    is_expired = TRUE
+WHERE
+    giveaway_winner_uuid = $1
+    AND is_expired = FALSE
 RETURNING
     *;
\ No newline at end of file
@@ -37,4 +37,25 @@ DELETE FROM
     guild_invites
 WHERE
     invite_code = $1
-    AND guild_id = $2;
\ No newline at end of file
+    AND guild_id = $2;
+
+-- name: GetGuildTopInviters :many
+SELECT
+    guild_invites.created_by AS user_id,
+    COUNT(*) AS invite_count
+FROM
+    science_guild_events
+    INNER JOIN guild_invites ON guild_invites.invite_code = science_guild_events.data ->> 'invite_code'
+        AND guild_invites.guild_id = science_guild_events.guild_id
+WHERE
+    science_guild_events.guild_id = @guild_id
+    AND science_guild_events.event_type = @science_guild_event_type_user_join
+    AND science_guild_events.created_at >= @since
+    AND science_guild_events.created_at < @until
+    AND guild_invites.created_by != 0
+GROUP BY
+    guild_invites.created_by
+ORDER BY
+    invite_count DESC,
+    guild_invites.created_by
+LIMIT @entry_limit;
\ No newline at end of file
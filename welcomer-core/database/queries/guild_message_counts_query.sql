@@ -0,0 +1,190 @@
+-- name: GetUserMessageCountSince :one
+SELECT
+    COALESCE(SUM(message_count), 0)::bigint AS message_count
+FROM
+    guild_message_counts
+WHERE
+    granularity = @granularity::text
+    AND guild_id = @guild_id
+    AND user_id = @user_id
+    AND bucket_ts >= @since::timestamptz;
+
+-- name: GetGuildMessageCountSeries :many
+WITH top_channels AS (
+    SELECT
+        guild_message_counts.channel_id
+    FROM
+        guild_message_counts
+    WHERE
+        guild_message_counts.granularity = @granularity::text
+        AND guild_message_counts.guild_id = @guild_id
+        AND guild_message_counts.bucket_ts >= date_trunc(@granularity::text, @since::timestamptz)
+        AND guild_message_counts.bucket_ts < @until
+    GROUP BY
+        guild_message_counts.channel_id
+    ORDER BY
+        SUM(guild_message_counts.message_count) DESC
+    LIMIT @channel_limit
+)
+SELECT
+    date_trunc(@bucket::text, guild_message_counts.bucket_ts)::timestamp AS bucket_ts,
+    COALESCE(top_channels.channel_id, 0)::bigint AS channel_id,
+    SUM(guild_message_counts.message_count)::bigint AS message_count
+FROM
+    guild_message_counts
+    LEFT JOIN top_channels ON top_channels.channel_id = guild_message_counts.channel_id
+WHERE
+    guild_message_counts.granularity = @granularity::text
+    AND guild_message_counts.guild_id = @guild_id
+    AND guild_message_counts.bucket_ts >= date_trunc(@granularity::text, @since::timestamptz)
+    AND guild_message_counts.bucket_ts < @until
+GROUP BY
+    bucket_ts,
+    top_channels.channel_id
+ORDER BY
+    bucket_ts;
+
+-- name: GetGuildActiveUserIDs :many
+SELECT
+    guild_message_counts.user_id
+FROM
+    guild_message_counts
+WHERE
+    guild_message_counts.granularity = @granularity::text
+    AND guild_message_counts.guild_id = @guild_id
+    AND guild_message_counts.bucket_ts >= @since::timestamptz
+UNION
+SELECT
+    guild_voice_channel_stats.user_id
+FROM
+    guild_voice_channel_stats
+WHERE
+    guild_voice_channel_stats.guild_id = @guild_id
+    AND guild_voice_channel_stats.end_ts >= @since;
+
+-- name: GetGuildEarliestActivity :one
+SELECT
+    COALESCE((
+        SELECT
+            MIN(bucket_ts)
+        FROM
+            guild_message_counts
+        WHERE
+            guild_id = @guild_id
+            AND granularity IN ('hour', 'day')), (
+        SELECT
+            MIN(start_ts)
+        FROM
+            guild_voice_channel_stats
+        WHERE
+            guild_id = @guild_id), now())::timestamptz AS earliest_ts;
+
+-- name: GetGuildActiveUserSeries :many
+SELECT
+    activity.bucket_ts,
+    COUNT(DISTINCT activity.user_id) AS active_users,
+    COUNT(DISTINCT activity.user_id) FILTER (WHERE NOT activity.is_voice) AS message_users,
+    COUNT(DISTINCT activity.user_id) FILTER (WHERE activity.is_voice) AS voice_users
+FROM (
+    SELECT
+        date_trunc(@bucket::text, guild_message_counts.bucket_ts)::timestamp AS bucket_ts,
+        guild_message_counts.user_id,
+        FALSE AS is_voice
+    FROM
+        guild_message_counts
+    WHERE
+        guild_message_counts.granularity = @granularity::text
+        AND guild_message_counts.guild_id = @guild_id
+        AND guild_message_counts.bucket_ts >= date_trunc(@granularity::text, @since::timestamptz)
+        AND guild_message_counts.bucket_ts < @until
+    UNION ALL
+    SELECT
+        date_trunc(@bucket::text, guild_voice_channel_stats.start_ts)::timestamp AS bucket_ts,
+        guild_voice_channel_stats.user_id,
+        TRUE AS is_voice
+    FROM
+        guild_voice_channel_stats
+    WHERE
+        guild_voice_channel_stats.guild_id = @guild_id
+        AND guild_voice_channel_stats.start_ts >= @since
+        AND guild_voice_channel_stats.start_ts < @until
+) AS activity
+GROUP BY
+    activity.bucket_ts
+ORDER BY
+    activity.bucket_ts;
+
+-- name: GetGuildMessageChannelLeaderboard :many
+SELECT
+    channel_id,
+    SUM(message_count)::bigint AS message_count
+FROM
+    guild_message_counts
+WHERE
+    granularity = @granularity::text
+    AND guild_id = @guild_id
+    AND bucket_ts >= @since::timestamptz
+GROUP BY
+    channel_id
+ORDER BY
+    message_count DESC,
+    channel_id
+LIMIT @entry_limit;
+
+-- name: GetGuildMessageLeaderboard :many
+SELECT
+    user_id,
+    SUM(message_count)::bigint AS message_count
+FROM
+    guild_message_counts
+WHERE
+    granularity = @granularity::text
+    AND guild_id = @guild_id
+    AND bucket_ts >= @since::timestamptz
+    AND (@channel_id::bigint = 0 OR channel_id = @channel_id)
+    AND NOT (channel_id = ANY(@excluded_channels::bigint[]))
+    AND NOT (user_id = ANY(@excluded_users::bigint[]))
+GROUP BY
+    user_id
+ORDER BY
+    message_count DESC,
+    user_id
+LIMIT @entry_limit;
+
+-- name: GetGuildMessageLeaderboardRank :one
+WITH totals AS (
+    SELECT
+        user_id,
+        SUM(message_count)::bigint AS message_count
+    FROM
+        guild_message_counts
+    WHERE
+        granularity = @granularity::text
+        AND guild_id = @guild_id
+        AND bucket_ts >= @since::timestamptz
+        AND (@channel_id::bigint = 0 OR channel_id = @channel_id)
+        AND NOT (channel_id = ANY(@excluded_channels::bigint[]))
+    GROUP BY
+        user_id
+),
+user_total AS (
+    SELECT
+        COALESCE(SUM(totals.message_count), 0)::bigint AS message_count
+    FROM
+        totals
+    WHERE
+        totals.user_id = @user_id
+)
+SELECT
+    user_total.message_count,
+    (
+        SELECT
+            COUNT(*)
+        FROM
+            totals
+        WHERE
+            totals.message_count > user_total.message_count
+            AND NOT (totals.user_id = ANY(@excluded_users::bigint[]))
+    ) + 1 AS rank
+FROM
+    user_total;
\ No newline at end of file
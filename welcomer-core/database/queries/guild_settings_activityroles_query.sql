@@ -0,0 +1,33 @@
+-- name: CreateActivityRolesGuildSettings :one
+INSERT INTO guild_settings_activityroles (guild_id, toggle_enabled, toggle_decay, activityroles)
+    VALUES ($1, $2, $3, $4)
+RETURNING
+    *;
+
+-- name: CreateOrUpdateActivityRolesGuildSettings :one
+INSERT INTO guild_settings_activityroles (guild_id, toggle_enabled, toggle_decay, activityroles)
+    VALUES ($1, $2, $3, $4)
+ON CONFLICT(guild_id) DO UPDATE
+    SET toggle_enabled = EXCLUDED.toggle_enabled,
+        toggle_decay = EXCLUDED.toggle_decay,
+        activityroles = EXCLUDED.activityroles
+RETURNING
+    *;
+
+-- name: GetActivityRolesGuildSettings :one
+SELECT
+    *
+FROM
+    guild_settings_activityroles
+WHERE
+    guild_id = $1;
+
+-- name: UpdateActivityRolesGuildSettings :execrows
+UPDATE
+    guild_settings_activityroles
+SET
+    toggle_enabled = $2,
+    toggle_decay = $3,
+    activityroles = $4
+WHERE
+    guild_id = $1;
\ No newline at end of file
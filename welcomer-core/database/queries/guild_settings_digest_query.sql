@@ -0,0 +1,43 @@
+-- name: CreateDigestGuildSettings :one
+INSERT INTO guild_settings_digest (guild_id, toggle_enabled, channel_digest, frequency)
+    VALUES ($1, $2, $3, $4)
+RETURNING
+    *;
+
+-- name: CreateOrUpdateDigestGuildSettings :one
+INSERT INTO guild_settings_digest (guild_id, toggle_enabled, channel_digest, frequency)
+    VALUES ($1, $2, $3, $4)
+ON CONFLICT(guild_id) DO UPDATE
+    SET toggle_enabled = EXCLUDED.toggle_enabled,
+        channel_digest = EXCLUDED.channel_digest,
+        frequency = EXCLUDED.frequency
+RETURNING
+    *;
+
+-- name: GetDigestGuildSettings :one
+SELECT
+    *
+FROM
+    guild_settings_digest
+WHERE
+    guild_id = $1;
+
+-- name: GetEnabledDigestGuildSettings :many
+SELECT
+    *
+FROM
+    guild_settings_digest
+WHERE
+    toggle_enabled = TRUE
+    AND channel_digest != 0
+    AND frequency = $1;
+
+-- name: UpdateDigestGuildSettings :execrows
+UPDATE
+    guild_settings_digest
+SET
+    toggle_enabled = $2,
+    channel_digest = $3,
+    frequency = $4
+WHERE
+    guild_id = $1;
\ No newline at end of file
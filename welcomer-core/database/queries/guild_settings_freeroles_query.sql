@@ -1,15 +1,16 @@
 -- name: CreateFreeRolesGuildSettings :one
-INSERT INTO guild_settings_freeroles (guild_id, toggle_enabled, roles)
-    VALUES ($1, $2, $3)
+INSERT INTO guild_settings_freeroles (guild_id, toggle_enabled, roles, role_durations)
+    VALUES ($1, $2, $3, $4)
 RETURNING
     *;
 
 -- name: CreateOrUpdateFreeRolesGuildSettings :one
-INSERT INTO guild_settings_freeroles (guild_id, toggle_enabled, roles)
-    VALUES ($1, $2, $3)
+INSERT INTO guild_settings_freeroles (guild_id, toggle_enabled, roles, role_durations)
+    VALUES ($1, $2, $3, $4)
 ON CONFLICT(guild_id) DO UPDATE
     SET toggle_enabled = EXCLUDED.toggle_enabled,
-        roles = EXCLUDED.roles
+        roles = EXCLUDED.roles,
+        role_durations = EXCLUDED.role_durations
 RETURNING
     *;
 
@@ -26,7 +27,8 @@ UPDATE
     guild_settings_freeroles
 SET
     toggle_enabled = $2,
-    roles = $3
+    roles = $3,
+    role_durations = $4
 WHERE
     guild_id = $1;
 
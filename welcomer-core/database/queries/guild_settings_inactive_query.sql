@@ -0,0 +1,29 @@
+-- name: CreateInactiveGuildSettings :one
+INSERT INTO guild_settings_inactive (guild_id, excluded_roles)
+    VALUES ($1, $2)
+RETURNING
+    *;
+
+-- name: CreateOrUpdateInactiveGuildSettings :one
+INSERT INTO guild_settings_inactive (guild_id, excluded_roles)
+    VALUES ($1, $2)
+ON CONFLICT(guild_id) DO UPDATE
+    SET excluded_roles = EXCLUDED.excluded_roles
+RETURNING
+    *;
+
+-- name: GetInactiveGuildSettings :one
+SELECT
+    *
+FROM
+    guild_settings_inactive
+WHERE
+    guild_id = $1;
+
+-- name: UpdateInactiveGuildSettings :execrows
+UPDATE
+    guild_settings_inactive
+SET
+    excluded_roles = $2
+WHERE
+    guild_id = $1;
\ No newline at end of file
@@ -0,0 +1,31 @@
+-- name: CreateLeaderboardGuildSettings :one
+INSERT INTO guild_settings_leaderboard (guild_id, toggle_include_bots, excluded_channels)
+    VALUES ($1, $2, $3)
+RETURNING
+    *;
+
+-- name: CreateOrUpdateLeaderboardGuildSettings :one
+INSERT INTO guild_settings_leaderboard (guild_id, toggle_include_bots, excluded_channels)
+    VALUES ($1, $2, $3)
+ON CONFLICT(guild_id) DO UPDATE
+    SET toggle_include_bots = EXCLUDED.toggle_include_bots,
+        excluded_channels = EXCLUDED.excluded_channels
+RETURNING
+    *;
+
+-- name: GetLeaderboardGuildSettings :one
+SELECT
+    *
+FROM
+    guild_settings_leaderboard
+WHERE
+    guild_id = $1;
+
+-- name: UpdateLeaderboardGuildSettings :execrows
+UPDATE
+    guild_settings_leaderboard
+SET
+    toggle_include_bots = $2,
+    excluded_channels = $3
+WHERE
+    guild_id = $1;
\ No newline at end of file
@@ -1,13 +1,18 @@
 -- name: CreateOrUpdateReactionRoleSetting :one
-INSERT INTO guild_settings_reaction_roles (reaction_role_id, guild_id, toggle_enabled, channel_id, message_id, is_system_message, system_message_format, reaction_role_type, roles)
-    VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+INSERT INTO guild_settings_reaction_roles (reaction_role_id, guild_id, toggle_enabled, channel_id, message_id, is_system_message, system_message_format, reaction_role_type, roles, unique_selection, max_selections, required_role_id, verify_only, disable_toggle_off)
+    VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14)
 ON CONFLICT(reaction_role_id) DO UPDATE
     SET toggle_enabled = EXCLUDED.toggle_enabled,
         channel_id = EXCLUDED.channel_id,
         is_system_message = EXCLUDED.is_system_message,
         system_message_format = EXCLUDED.system_message_format,
         reaction_role_type = EXCLUDED.reaction_role_type,
-        roles = EXCLUDED.roles
+        roles = EXCLUDED.roles,
+        unique_selection = EXCLUDED.unique_selection,
+        max_selections = EXCLUDED.max_selections,
+        required_role_id = EXCLUDED.required_role_id,
+        verify_only = EXCLUDED.verify_only,
+        disable_toggle_off = EXCLUDED.disable_toggle_off
 RETURNING
     *;
 
@@ -0,0 +1,40 @@
+-- name: CreateRetentionGuildSettings :one
+INSERT INTO guild_settings_retention (guild_id, toggle_weekly_report, channel_report)
+    VALUES ($1, $2, $3)
+RETURNING
+    *;
+
+-- name: CreateOrUpdateRetentionGuildSettings :one
+INSERT INTO guild_settings_retention (guild_id, toggle_weekly_report, channel_report)
+    VALUES ($1, $2, $3)
+ON CONFLICT(guild_id) DO UPDATE
+    SET toggle_weekly_report = EXCLUDED.toggle_weekly_report,
+        channel_report = EXCLUDED.channel_report
+RETURNING
+    *;
+
+-- name: GetRetentionGuildSettings :one
+SELECT
+    *
+FROM
+    guild_settings_retention
+WHERE
+    guild_id = $1;
+
+-- name: GetEnabledRetentionGuildSettings :many
+SELECT
+    *
+FROM
+    guild_settings_retention
+WHERE
+    toggle_weekly_report = TRUE
+    AND channel_report != 0;
+
+-- name: UpdateRetentionGuildSettings :execrows
+UPDATE
+    guild_settings_retention
+SET
+    toggle_weekly_report = $2,
+    channel_report = $3
+WHERE
+    guild_id = $1;
\ No newline at end of file
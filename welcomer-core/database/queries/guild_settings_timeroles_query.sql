@@ -1,15 +1,16 @@
 -- name: CreateTimeRolesGuildSettings :one
-INSERT INTO guild_settings_timeroles (guild_id, toggle_enabled, timeroles)
-    VALUES ($1, $2, $3)
+INSERT INTO guild_settings_timeroles (guild_id, toggle_enabled, timeroles, toggle_ladder)
+    VALUES ($1, $2, $3, $4)
 RETURNING
     *;
 
 -- name: CreateOrUpdateTimeRolesGuildSettings :one
-INSERT INTO guild_settings_timeroles (guild_id, toggle_enabled, timeroles)
-    VALUES ($1, $2, $3)
+INSERT INTO guild_settings_timeroles (guild_id, toggle_enabled, timeroles, toggle_ladder)
+    VALUES ($1, $2, $3, $4)
 ON CONFLICT(guild_id) DO UPDATE
     SET toggle_enabled = EXCLUDED.toggle_enabled,
-        timeroles = EXCLUDED.timeroles
+        timeroles = EXCLUDED.timeroles,
+        toggle_ladder = EXCLUDED.toggle_ladder
 RETURNING
     *;
 
@@ -26,7 +27,8 @@ UPDATE
     guild_settings_timeroles
 SET
     toggle_enabled = $2,
-    timeroles = $3
+    timeroles = $3,
+    toggle_ladder = $4
 WHERE
     guild_id = $1;
 
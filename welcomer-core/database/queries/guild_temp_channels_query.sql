@@ -0,0 +1,53 @@
+-- name: CreateOrUpdateTempChannel :one
+INSERT INTO guild_temp_channels (channel_id, guild_id, owner_id, lobby_id, text_channel_id, created_at)
+    VALUES ($1, $2, $3, $4, $5, NOW())
+ON CONFLICT(channel_id) DO UPDATE
+    SET owner_id = EXCLUDED.owner_id,
+        lobby_id = EXCLUDED.lobby_id,
+        text_channel_id = EXCLUDED.text_channel_id,
+        created_at = EXCLUDED.created_at
+RETURNING
+    *;
+
+-- name: GetTempChannel :one
+SELECT
+    *
+FROM
+    guild_temp_channels
+WHERE
+    guild_id = $1
+    AND channel_id = $2;
+
+-- name: GetTempChannelByOwner :one
+SELECT
+    *
+FROM
+    guild_temp_channels
+WHERE
+    guild_id = $1
+    AND owner_id = $2
+ORDER BY
+    created_at DESC
+LIMIT 1;
+
+-- name: UpdateTempChannelOwner :execrows
+UPDATE
+    guild_temp_channels
+SET
+    owner_id = $3
+WHERE
+    guild_id = $1
+    AND channel_id = $2;
+
+-- name: DeleteTempChannel :execrows
+DELETE FROM guild_temp_channels
+WHERE guild_id = $1
+    AND channel_id = $2;
+
+-- name: GetTempChannelsByGuild :many
+SELECT
+    *
+FROM
+    guild_temp_channels
+WHERE
+    guild_id = $1;
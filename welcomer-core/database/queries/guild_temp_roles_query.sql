@@ -0,0 +1,53 @@
+-- name: CreateOrUpdateTempRole :one
+INSERT INTO guild_temp_roles (temp_role_uuid, created_at, guild_id, user_id, role_id, expires_at, source, created_by, is_removed, source_uuid)
+    VALUES (uuid_generate_v7(), NOW(), $1, $2, $3, $4, $5, $6, FALSE, $7)
+ON CONFLICT(guild_id, user_id, role_id) DO UPDATE
+    SET created_at = EXCLUDED.created_at,
+        expires_at = EXCLUDED.expires_at,
+        source = EXCLUDED.source,
+        created_by = EXCLUDED.created_by,
+        is_removed = FALSE,
+        source_uuid = EXCLUDED.source_uuid
+RETURNING
+    *;
+
+-- name: GetExpiredTempRoles :many
+SELECT
+    *
+FROM
+    guild_temp_roles
+WHERE
+    guild_id = $1
+    AND is_removed = FALSE
+    AND expires_at <= NOW();
+
+-- name: GetTempRole :one
+SELECT
+    *
+FROM
+    guild_temp_roles
+WHERE
+    guild_id = $1
+    AND user_id = $2
+    AND role_id = $3
+    AND is_removed = FALSE;
+
+-- name: GetGuildsWithExpiredTempRoles :many
+SELECT DISTINCT
+    guild_id
+FROM
+    guild_temp_roles
+WHERE
+    is_removed = FALSE
+    AND expires_at <= NOW();
+
+-- name: RemoveTempRole :execrows
+UPDATE
+    guild_temp_roles
+SET
+    is_removed = TRUE
+WHERE
+    guild_id = $1
+    AND user_id = $2
+    AND role_id = $3
+    AND is_removed = FALSE;
\ No newline at end of file
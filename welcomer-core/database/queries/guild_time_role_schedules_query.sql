@@ -0,0 +1,79 @@
+-- name: CreateTimeRoleSchedule :execrows
+INSERT INTO guild_time_role_schedules (guild_id, user_id, joined_at, next_eligible_at, updated_at)
+    VALUES ($1, $2, $3, $4, NOW())
+ON CONFLICT(guild_id, user_id) DO NOTHING;
+
+-- name: CreateOrUpdateTimeRoleSchedule :one
+INSERT INTO guild_time_role_schedules (guild_id, user_id, joined_at, next_eligible_at, updated_at)
+    VALUES ($1, $2, $3, $4, NOW())
+ON CONFLICT(guild_id, user_id) DO UPDATE
+    SET joined_at = EXCLUDED.joined_at,
+        next_eligible_at = EXCLUDED.next_eligible_at,
+        updated_at = EXCLUDED.updated_at
+RETURNING
+    *;
+
+-- name: GetDueTimeRoleSchedules :many
+SELECT
+    *
+FROM
+    guild_time_role_schedules
+WHERE
+    guild_id = $1
+    AND next_eligible_at <= NOW()
+ORDER BY
+    next_eligible_at
+LIMIT $2;
+
+-- name: GetGuildsWithDueTimeRoleSchedules :many
+SELECT DISTINCT
+    guild_id
+FROM
+    guild_time_role_schedules
+WHERE
+    next_eligible_at <= NOW();
+
+-- name: UpdateTimeRoleScheduleNextEligibleAt :execrows
+UPDATE
+    guild_time_role_schedules
+SET
+    next_eligible_at = $3,
+    updated_at = NOW()
+WHERE
+    guild_id = $1
+    AND user_id = $2;
+
+-- name: RescheduleGuildTimeRoles :execrows
+UPDATE
+    guild_time_role_schedules
+SET
+    next_eligible_at = joined_at + make_interval(secs => @seconds::integer),
+    updated_at = NOW()
+WHERE
+    guild_id = @guild_id;
+
+-- name: ClearGuildTimeRoleSchedules :execrows
+UPDATE
+    guild_time_role_schedules
+SET
+    next_eligible_at = NULL,
+    updated_at = NOW()
+WHERE
+    guild_id = $1;
+
+-- name: DeleteTimeRoleSchedule :execrows
+DELETE FROM guild_time_role_schedules
+WHERE guild_id = $1
+    AND user_id = $2;
+
+-- name: BackfillTimeRoleSchedules :execrows
+INSERT INTO guild_time_role_schedules (guild_id, user_id, joined_at, next_eligible_at, updated_at)
+SELECT
+    @guild_id::bigint,
+    member.user_id,
+    member.joined_at,
+    member.joined_at + make_interval(secs => @seconds::integer),
+    NOW()
+FROM
+    unnest(@user_ids::bigint[], @joined_ats::timestamp[]) AS member (user_id, joined_at)
+ON CONFLICT(guild_id, user_id) DO NOTHING;
@@ -15,4 +15,15 @@ WHERE guild_id = $1 AND user_id = $2;
 -- name: DeleteAndGetGuildVoiceChannelOpenSessionsBefore :many
 DELETE FROM guild_voice_channel_open_sessions
 WHERE last_seen_ts < $1
-RETURNING guild_id, user_id, channel_id, start_ts, last_seen_ts;
\ No newline at end of file
+RETURNING guild_id, user_id, channel_id, start_ts, last_seen_ts;
+
+-- name: GetGuildVoiceChannelOpenSessionsByChannel :many
+SELECT
+    *
+FROM
+    guild_voice_channel_open_sessions
+WHERE
+    guild_id = $1
+    AND channel_id = $2
+ORDER BY
+    start_ts ASC;
\ No newline at end of file
@@ -1,3 +1,108 @@
 -- name: CreateVoiceChannelStat :exec
 INSERT INTO guild_voice_channel_stats (guild_id, channel_id, user_id, start_ts, end_ts, total_time_ms, inferred)
 VALUES ($1, $2, $3, $4, $5, $6, $7);
+
+-- name: GetUserVoiceTimeSince :one
+SELECT
+    COALESCE(SUM(LEAST(total_time_ms, (EXTRACT(EPOCH FROM (end_ts - $3)) * 1000)::bigint)), 0)::bigint AS total_time_ms
+FROM
+    guild_voice_channel_stats
+WHERE
+    guild_id = $1
+    AND user_id = $2
+    AND end_ts >= $3;
+
+-- name: GetGuildVoiceSeries :many
+WITH minutes AS (
+    SELECT
+        date_trunc(@bucket::text, hour_ts)::timestamp AS bucket_ts,
+        SUM(minutes) AS voice_minutes,
+        COUNT(DISTINCT user_id) AS voice_users
+    FROM
+        guild_voice_minutes_hour
+    WHERE
+        guild_id = @guild_id
+        AND hour_ts >= @since
+        AND hour_ts < @until
+    GROUP BY
+        1
+),
+sessions AS (
+    SELECT
+        date_trunc(@bucket::text, start_ts)::timestamp AS bucket_ts,
+        COUNT(*) AS sessions
+    FROM
+        guild_voice_channel_stats
+    WHERE
+        guild_id = @guild_id
+        AND start_ts >= @since
+        AND start_ts < @until
+    GROUP BY
+        1
+)
+SELECT
+    COALESCE(minutes.bucket_ts, sessions.bucket_ts)::timestamp AS bucket_ts,
+    COALESCE(minutes.voice_minutes, 0)::bigint AS voice_minutes,
+    COALESCE(minutes.voice_users, 0)::bigint AS voice_users,
+    COALESCE(sessions.sessions, 0)::bigint AS sessions
+FROM
+    minutes
+    FULL OUTER JOIN sessions ON sessions.bucket_ts = minutes.bucket_ts
+ORDER BY
+    bucket_ts;
+
+-- name: GetGuildVoiceLeaderboard :many
+SELECT
+    user_id,
+    SUM(LEAST(total_time_ms, (EXTRACT(EPOCH FROM (end_ts - @since)) * 1000)::bigint))::bigint AS total_time_ms
+FROM
+    guild_voice_channel_stats
+WHERE
+    guild_id = @guild_id
+    AND end_ts >= @since
+    AND (@channel_id::bigint = 0 OR channel_id = @channel_id)
+    AND NOT (channel_id = ANY(@excluded_channels::bigint[]))
+    AND NOT (user_id = ANY(@excluded_users::bigint[]))
+GROUP BY
+    user_id
+ORDER BY
+    total_time_ms DESC,
+    user_id
+LIMIT @entry_limit;
+
+-- name: GetGuildVoiceLeaderboardRank :one
+WITH totals AS (
+    SELECT
+        user_id,
+        SUM(LEAST(total_time_ms, (EXTRACT(EPOCH FROM (end_ts - @since)) * 1000)::bigint))::bigint AS total_time_ms
+    FROM
+        guild_voice_channel_stats
+    WHERE
+        guild_id = @guild_id
+        AND end_ts >= @since
+        AND (@channel_id::bigint = 0 OR channel_id = @channel_id)
+        AND NOT (channel_id = ANY(@excluded_channels::bigint[]))
+    GROUP BY
+        user_id
+),
+user_total AS (
+    SELECT
+        COALESCE(SUM(totals.total_time_ms), 0)::bigint AS total_time_ms
+    FROM
+        totals
+    WHERE
+        totals.user_id = @user_id
+)
+SELECT
+    user_total.total_time_ms,
+    (
+        SELECT
+            COUNT(*)
+        FROM
+            totals
+        WHERE
+            totals.total_time_ms > user_total.total_time_ms
+            AND NOT (totals.user_id = ANY(@excluded_users::bigint[]))
+    ) + 1 AS rank
+FROM
+    user_total;
@@ -12,3 +12,21 @@ FROM
 WHERE
     command_uuid = $1;
 
+-- name: GetGuildCommandErrorCounts :many
+SELECT
+    command,
+    COUNT(*) AS error_count
+FROM
+    science_command_usages
+WHERE
+    guild_id = @guild_id
+    AND errored = TRUE
+    AND created_at >= @since
+    AND created_at < @until
+GROUP BY
+    command
+ORDER BY
+    error_count DESC,
+    command
+LIMIT @entry_limit;
+
@@ -51,4 +51,161 @@ WHERE
     AND science_guild_events.data ->> 'message_id' IS NOT NULL
     AND science_guild_events.created_at < @welcome_message_lifetime
     AND message_deleted.guild_event_uuid IS NULL
-LIMIT @event_limit;
\ No newline at end of file
+LIMIT @event_limit;
+
+-- name: GetReactionRoleEventCounts :many
+SELECT
+    date_trunc(@bucket::text, science_guild_events.created_at)::timestamp AS bucket_ts,
+    CAST(science_guild_events.data ->> 'role_id' AS BIGINT) AS role_id,
+    COUNT(*) FILTER (WHERE science_guild_events.event_type = @science_guild_event_type_reaction_role_given) AS adds,
+    COUNT(*) FILTER (WHERE science_guild_events.event_type = @science_guild_event_type_reaction_role_removed) AS removes
+FROM
+    science_guild_events
+WHERE
+    science_guild_events.guild_id = @guild_id
+    AND science_guild_events.event_type IN (@science_guild_event_type_reaction_role_given, @science_guild_event_type_reaction_role_removed)
+    AND science_guild_events.data ->> 'reaction_role_uuid' = @reaction_role_uuid::text
+    AND science_guild_events.created_at >= @since
+GROUP BY
+    bucket_ts,
+    role_id
+ORDER BY
+    bucket_ts;
+
+-- name: GetGuildEventCountSeries :many
+SELECT
+    date_trunc(@bucket::text, science_guild_events.created_at)::timestamp AS bucket_ts,
+    science_guild_events.event_type,
+    COUNT(*) AS event_count
+FROM
+    science_guild_events
+WHERE
+    science_guild_events.guild_id = @guild_id
+    AND science_guild_events.event_type = ANY(@event_types::int[])
+    AND science_guild_events.created_at >= @since
+    AND science_guild_events.created_at < @until
+GROUP BY
+    bucket_ts,
+    science_guild_events.event_type
+ORDER BY
+    bucket_ts;
+
+-- name: GetGuildCohortRetention :many
+WITH joins AS (
+    SELECT
+        science_guild_events.user_id,
+        science_guild_events.created_at AS joined_at,
+        COALESCE(science_guild_events.data ->> 'invite_code', '') AS invite_code
+    FROM
+        science_guild_events
+    WHERE
+        science_guild_events.guild_id = @guild_id
+        AND science_guild_events.event_type = @science_guild_event_type_user_join
+        AND science_guild_events.user_id IS NOT NULL
+        AND science_guild_events.created_at >= @since
+        AND science_guild_events.created_at < @until
+),
+members AS (
+    SELECT
+        joins.joined_at,
+        joins.invite_code,
+        (
+            SELECT
+                MIN(leaves.created_at)
+            FROM
+                science_guild_events leaves
+            WHERE
+                leaves.guild_id = @guild_id
+                AND leaves.user_id = joins.user_id
+                AND leaves.event_type = @science_guild_event_type_user_leave
+                AND leaves.created_at > joins.joined_at) AS left_at,
+        EXISTS (
+            SELECT
+                1
+            FROM
+                science_guild_events welcomed
+            WHERE
+                welcomed.guild_id = @guild_id
+                AND welcomed.user_id = joins.user_id
+                AND welcomed.event_type = @science_guild_event_type_user_welcomed
+                AND welcomed.created_at >= joins.joined_at
+                AND welcomed.created_at < joins.joined_at + interval '7 days') AS is_welcomed,
+        EXISTS (
+            SELECT
+                1
+            FROM
+                science_guild_events welcomed
+            WHERE
+                welcomed.guild_id = @guild_id
+                AND welcomed.user_id = joins.user_id
+                AND welcomed.event_type = @science_guild_event_type_user_welcomed
+                AND welcomed.created_at >= joins.joined_at
+                AND welcomed.created_at < joins.joined_at + interval '7 days'
+                AND (welcomed.data ->> 'has_dm')::boolean) AS is_dmed,
+        EXISTS (
+            SELECT
+                1
+            FROM
+                science_guild_events verified
+            WHERE
+                verified.guild_id = @guild_id
+                AND verified.user_id = joins.user_id
+                AND verified.event_type = @science_guild_event_type_borderwall_completed
+                AND verified.created_at >= joins.joined_at
+                AND verified.created_at < joins.joined_at + interval '7 days') AS is_verified
+    FROM
+        joins
+)
+SELECT
+    date_trunc('week', members.joined_at)::timestamp AS cohort_week,
+    members.invite_code::text AS invite_code,
+    members.is_welcomed::boolean AS is_welcomed,
+    members.is_dmed::boolean AS is_dmed,
+    members.is_verified::boolean AS is_verified,
+    COUNT(*) AS members,
+    COUNT(*) FILTER (WHERE members.joined_at + interval '1 day' <= @now) AS day_1_eligible,
+    COUNT(*) FILTER (WHERE members.joined_at + interval '1 day' <= @now
+        AND (members.left_at IS NULL OR members.left_at > members.joined_at + interval '1 day')) AS day_1_retained,
+    COUNT(*) FILTER (WHERE members.joined_at + interval '7 days' <= @now) AS day_7_eligible,
+    COUNT(*) FILTER (WHERE members.joined_at + interval '7 days' <= @now
+        AND (members.left_at IS NULL OR members.left_at > members.joined_at + interval '7 days')) AS day_7_retained,
+    COUNT(*) FILTER (WHERE members.joined_at + interval '30 days' <= @now) AS day_30_eligible,
+    COUNT(*) FILTER (WHERE members.joined_at + interval '30 days' <= @now
+        AND (members.left_at IS NULL OR members.left_at > members.joined_at + interval '30 days')) AS day_30_retained
+FROM
+    members
+GROUP BY
+    cohort_week,
+    members.invite_code,
+    members.is_welcomed,
+    members.is_dmed,
+    members.is_verified
+ORDER BY
+    cohort_week;
+
+-- name: GetGuildEventCounts :many
+SELECT
+    science_guild_events.event_type,
+    COUNT(*) AS event_count
+FROM
+    science_guild_events
+WHERE
+    science_guild_events.guild_id = @guild_id
+    AND science_guild_events.event_type = ANY(@event_types::int[])
+    AND science_guild_events.created_at >= @since
+    AND science_guild_events.created_at < @until
+GROUP BY
+    science_guild_events.event_type;
+
+-- name: GetGuildFailedMessageRemovalCount :one
+SELECT
+    COUNT(*) AS failed_count
+FROM
+    science_guild_events
+WHERE
+    science_guild_events.guild_id = @guild_id
+    AND science_guild_events.event_type IN (@science_guild_event_type_welcome_message_removed, @science_guild_event_type_leaver_message_removed)
+    AND science_guild_events.created_at >= @since
+    AND science_guild_events.created_at < @until
+    AND COALESCE((science_guild_events.data ->> 'has_message')::boolean, FALSE)
+    AND NOT COALESCE((science_guild_events.data ->> 'successful')::boolean, FALSE);
\ No newline at end of file
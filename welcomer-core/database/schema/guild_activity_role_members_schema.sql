@@ -0,0 +1,8 @@
+CREATE TABLE IF NOT EXISTS guild_activity_role_members (
+    guild_id bigint NOT NULL,
+    user_id bigint NOT NULL,
+    role_id bigint NOT NULL,
+    assigned_at timestamp NOT NULL,
+    PRIMARY KEY (guild_id, user_id, role_id),
+    FOREIGN KEY (guild_id) REFERENCES guilds (guild_id) ON DELETE CASCADE ON UPDATE CASCADE
+);
\ No newline at end of file
@@ -0,0 +1,11 @@
+CREATE TABLE IF NOT EXISTS guild_giveaways_draws (
+    giveaway_uuid uuid NOT NULL UNIQUE PRIMARY KEY,
+    created_at timestamp NOT NULL,
+    server_seed text NOT NULL,
+    seed_commitment text NOT NULL,
+    entries jsonb NOT NULL,
+    prizes jsonb NOT NULL,
+    is_revealed boolean NOT NULL,
+    revealed_at timestamp NOT NULL,
+    FOREIGN KEY (giveaway_uuid) REFERENCES guild_giveaways (giveaway_uuid) ON DELETE CASCADE ON UPDATE CASCADE
+);
\ No newline at end of file
@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS guild_giveaways_prize_codes (
+    prize_code_uuid uuid NOT NULL UNIQUE PRIMARY KEY,
+    giveaway_uuid uuid NOT NULL,
+    created_at timestamp NOT NULL,
+    prize text NOT NULL,
+    code text NOT NULL,
+    user_id bigint NOT NULL DEFAULT 0,
+    assigned_at timestamp NOT NULL DEFAULT 'epoch',
+    FOREIGN KEY (giveaway_uuid) REFERENCES guild_giveaways (giveaway_uuid) ON DELETE CASCADE ON UPDATE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS guild_giveaways_prize_codes_giveaway_uuid_prize ON guild_giveaways_prize_codes (giveaway_uuid, prize);
\ No newline at end of file
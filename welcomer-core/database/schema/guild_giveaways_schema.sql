@@ -22,6 +22,17 @@ CREATE TABLE IF NOT EXISTS guild_giveaways (
     channel_id bigint NOT NULL,
     show_prizes boolean NOT NULL,
     show_entries boolean NOT NULL,
+    minimum_account_age bigint NOT NULL DEFAULT 0,
+    minimum_message_count integer NOT NULL DEFAULT 0,
+    minimum_voice_minutes integer NOT NULL DEFAULT 0,
+    activity_period_days integer NOT NULL DEFAULT 0,
+    recurrence_rule text NOT NULL DEFAULT '',
+    ping_content text NOT NULL DEFAULT '',
+    provably_fair boolean NOT NULL DEFAULT false,
+    seed_commitment text NOT NULL DEFAULT '',
+    claim_window bigint NOT NULL DEFAULT 0,
+    recurrence_anchor timestamp NOT NULL DEFAULT 'epoch',
+    prize_code_pool_uuid uuid NOT NULL DEFAULT '00000000-0000-0000-0000-000000000000',
     FOREIGN KEY (guild_id) REFERENCES guilds (guild_id) ON DELETE CASCADE ON UPDATE CASCADE
 );
 
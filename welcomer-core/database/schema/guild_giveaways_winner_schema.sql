@@ -4,6 +4,15 @@ CREATE TABLE IF NOT EXISTS guild_giveaways_winners (
     user_id bigint NOT NULL,
     prize text NOT NULL,
     message_id bigint NOT NULL,
+    claim_expires_at timestamp NOT NULL DEFAULT 'epoch',
+    is_claimed boolean NOT NULL DEFAULT false,
+    claimed_at timestamp NOT NULL DEFAULT 'epoch',
+    is_expired boolean NOT NULL DEFAULT false,
+    role_id bigint NOT NULL DEFAULT 0,
+    role_expires_at timestamp NOT NULL DEFAULT 'epoch',
+    is_role_removed boolean NOT NULL DEFAULT false,
+    is_delivered boolean NOT NULL DEFAULT false,
+    delivery_error text NOT NULL DEFAULT '',
     FOREIGN KEY (giveaway_uuid) REFERENCES guild_giveaways (giveaway_uuid) ON DELETE CASCADE ON UPDATE CASCADE
 );
 
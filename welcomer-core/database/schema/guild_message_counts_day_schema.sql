@@ -0,0 +1,14 @@
+CREATE TABLE IF NOT EXISTS guild_message_counts_day (
+    day_ts TIMESTAMPTZ NOT NULL,
+    guild_id BIGINT NOT NULL,
+    channel_id BIGINT NOT NULL,
+    user_id BIGINT NOT NULL,
+    message_count INTEGER NOT NULL,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    PRIMARY KEY (day_ts, guild_id, channel_id, user_id)
+);
+
+ALTER TABLE guild_message_counts_day ADD COLUMN IF NOT EXISTS updated_at TIMESTAMPTZ NOT NULL DEFAULT now();
+
+CREATE INDEX IF NOT EXISTS guild_message_counts_day_guild_id_day_ts ON guild_message_counts_day (guild_id, day_ts);
+CREATE INDEX IF NOT EXISTS guild_message_counts_day_updated_at ON guild_message_counts_day (updated_at);
\ No newline at end of file
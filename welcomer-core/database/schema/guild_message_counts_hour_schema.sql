@@ -5,8 +5,13 @@ CREATE TABLE IF NOT EXISTS guild_message_counts_hour (
     user_id BIGINT NOT NULL,
     message_count INTEGER NOT NULL,
     min_ts TIMESTAMPTZ NOT NULL,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT now(),
     PRIMARY KEY (hour_ts, guild_id, channel_id, user_id)
 );
 
+ALTER TABLE guild_message_counts_hour ADD COLUMN IF NOT EXISTS updated_at TIMESTAMPTZ NOT NULL DEFAULT now();
+
 CREATE INDEX IF NOT EXISTS guild_message_counts_hour_guild_id ON guild_message_counts_hour (guild_id);
-CREATE INDEX IF NOT EXISTS guild_message_counts_hour_channel_id ON guild_message_counts_hour (channel_id);
\ No newline at end of file
+CREATE INDEX IF NOT EXISTS guild_message_counts_hour_channel_id ON guild_message_counts_hour (channel_id);
+CREATE INDEX IF NOT EXISTS guild_message_counts_hour_guild_id_hour_ts ON guild_message_counts_hour (guild_id, hour_ts);
+CREATE INDEX IF NOT EXISTS guild_message_counts_hour_updated_at ON guild_message_counts_hour (updated_at);
\ No newline at end of file
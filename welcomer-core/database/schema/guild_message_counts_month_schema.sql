@@ -0,0 +1,10 @@
+CREATE TABLE IF NOT EXISTS guild_message_counts_month (
+    month_ts TIMESTAMPTZ NOT NULL,
+    guild_id BIGINT NOT NULL,
+    channel_id BIGINT NOT NULL,
+    user_id BIGINT NOT NULL,
+    message_count BIGINT NOT NULL,
+    PRIMARY KEY (month_ts, guild_id, channel_id, user_id)
+);
+
+CREATE INDEX IF NOT EXISTS guild_message_counts_month_guild_id_month_ts ON guild_message_counts_month (guild_id, month_ts);
\ No newline at end of file
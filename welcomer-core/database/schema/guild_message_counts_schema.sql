@@ -0,0 +1,32 @@
+-- guild_message_counts combines every message count granularity. Queries should filter on a single
+-- granularity, as counts are duplicated across granularities until they are compacted.
+CREATE OR REPLACE VIEW guild_message_counts AS
+SELECT
+    'hour'::text AS granularity,
+    hour_ts AS bucket_ts,
+    guild_id,
+    channel_id,
+    user_id,
+    message_count::bigint AS message_count
+FROM
+    guild_message_counts_hour
+UNION ALL
+SELECT
+    'day'::text AS granularity,
+    day_ts AS bucket_ts,
+    guild_id,
+    channel_id,
+    user_id,
+    message_count::bigint AS message_count
+FROM
+    guild_message_counts_day
+UNION ALL
+SELECT
+    'month'::text AS granularity,
+    month_ts AS bucket_ts,
+    guild_id,
+    channel_id,
+    user_id,
+    message_count
+FROM
+    guild_message_counts_month;
\ No newline at end of file
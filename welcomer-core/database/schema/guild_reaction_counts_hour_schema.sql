@@ -0,0 +1,11 @@
+CREATE TABLE IF NOT EXISTS guild_reaction_counts_hour (
+    hour_ts TIMESTAMPTZ NOT NULL,
+    guild_id BIGINT NOT NULL,
+    channel_id BIGINT NOT NULL,
+    user_id BIGINT NOT NULL,
+    reaction_count INTEGER NOT NULL,
+    min_ts TIMESTAMPTZ NOT NULL,
+    PRIMARY KEY (hour_ts, guild_id, channel_id, user_id)
+);
+
+CREATE INDEX IF NOT EXISTS guild_reaction_counts_hour_guild_id_hour_ts ON guild_reaction_counts_hour (guild_id, hour_ts);
\ No newline at end of file
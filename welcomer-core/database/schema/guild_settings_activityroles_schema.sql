@@ -0,0 +1,7 @@
+CREATE TABLE IF NOT EXISTS guild_settings_activityroles (
+    guild_id bigint NOT NULL UNIQUE PRIMARY KEY,
+    toggle_enabled boolean NOT NULL,
+    toggle_decay boolean NOT NULL,
+    activityroles jsonb NOT NULL,
+    FOREIGN KEY (guild_id) REFERENCES guilds (guild_id) ON DELETE CASCADE ON UPDATE CASCADE
+);
\ No newline at end of file
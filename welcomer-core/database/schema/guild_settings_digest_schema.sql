@@ -0,0 +1,9 @@
+CREATE TABLE IF NOT EXISTS guild_settings_digest (
+    guild_id bigint NOT NULL UNIQUE PRIMARY KEY,
+    toggle_enabled boolean NOT NULL,
+    channel_digest bigint NOT NULL,
+    frequency text NOT NULL,
+    FOREIGN KEY (guild_id) REFERENCES guilds (guild_id) ON DELETE CASCADE ON UPDATE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS guild_settings_digest_frequency ON guild_settings_digest (frequency) WHERE toggle_enabled;
\ No newline at end of file
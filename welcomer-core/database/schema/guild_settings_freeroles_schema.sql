@@ -2,6 +2,7 @@ CREATE TABLE IF NOT EXISTS guild_settings_freeroles (
     guild_id bigint NOT NULL UNIQUE PRIMARY KEY,
     toggle_enabled boolean NOT NULL,
     roles bigint[] NOT NULL,
+    role_durations jsonb NOT NULL DEFAULT '[]',
     FOREIGN KEY (guild_id) REFERENCES guilds (guild_id) ON DELETE CASCADE ON UPDATE CASCADE
 );
 
@@ -0,0 +1,5 @@
+CREATE TABLE IF NOT EXISTS guild_settings_inactive (
+    guild_id bigint NOT NULL UNIQUE PRIMARY KEY,
+    excluded_roles bigint[] NOT NULL,
+    FOREIGN KEY (guild_id) REFERENCES guilds (guild_id) ON DELETE CASCADE ON UPDATE CASCADE
+);
\ No newline at end of file
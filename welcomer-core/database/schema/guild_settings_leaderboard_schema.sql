@@ -0,0 +1,6 @@
+CREATE TABLE IF NOT EXISTS guild_settings_leaderboard (
+    guild_id bigint NOT NULL UNIQUE PRIMARY KEY,
+    toggle_include_bots boolean NOT NULL,
+    excluded_channels bigint[] NOT NULL,
+    FOREIGN KEY (guild_id) REFERENCES guilds (guild_id) ON DELETE CASCADE ON UPDATE CASCADE
+);
\ No newline at end of file
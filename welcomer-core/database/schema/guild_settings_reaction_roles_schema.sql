@@ -8,6 +8,11 @@ CREATE TABLE IF NOT EXISTS guild_settings_reaction_roles (
     system_message_format jsonb NOT NULL,
     reaction_role_type int NOT NULL,
     roles jsonb NOT NULL,
+    unique_selection boolean NOT NULL DEFAULT FALSE,
+    max_selections int NOT NULL DEFAULT 0,
+    required_role_id bigint NOT NULL DEFAULT 0,
+    verify_only boolean NOT NULL DEFAULT FALSE,
+    disable_toggle_off boolean NOT NULL DEFAULT FALSE,
 
     FOREIGN KEY (guild_id) REFERENCES guilds (guild_id) ON DELETE CASCADE ON UPDATE CASCADE
 );
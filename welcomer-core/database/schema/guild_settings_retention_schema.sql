@@ -0,0 +1,6 @@
+CREATE TABLE IF NOT EXISTS guild_settings_retention (
+    guild_id bigint NOT NULL UNIQUE PRIMARY KEY,
+    toggle_weekly_report boolean NOT NULL,
+    channel_report bigint NOT NULL,
+    FOREIGN KEY (guild_id) REFERENCES guilds (guild_id) ON DELETE CASCADE ON UPDATE CASCADE
+);
\ No newline at end of file
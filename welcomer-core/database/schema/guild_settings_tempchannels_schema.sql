@@ -5,6 +5,7 @@ CREATE TABLE IF NOT EXISTS guild_settings_tempchannels (
     channel_lobby bigint NOT NULL,
     channel_category bigint NOT NULL,
     default_user_count integer NOT NULL,
+    lobbies jsonb NOT NULL DEFAULT '[]',
     FOREIGN KEY (guild_id) REFERENCES guilds (guild_id) ON DELETE CASCADE ON UPDATE CASCADE
 );
 
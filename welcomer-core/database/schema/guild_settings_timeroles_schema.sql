@@ -2,5 +2,6 @@ CREATE TABLE IF NOT EXISTS guild_settings_timeroles (
     guild_id bigint NOT NULL UNIQUE PRIMARY KEY,
     toggle_enabled boolean NOT NULL,
     timeroles jsonb NOT NULL,
+    toggle_ladder boolean NOT NULL DEFAULT FALSE,
     FOREIGN KEY (guild_id) REFERENCES guilds (guild_id) ON DELETE CASCADE ON UPDATE CASCADE
 );
\ No newline at end of file
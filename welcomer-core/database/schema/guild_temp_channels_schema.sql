@@ -0,0 +1,11 @@
+CREATE TABLE IF NOT EXISTS guild_temp_channels (
+    channel_id bigint NOT NULL UNIQUE PRIMARY KEY,
+    guild_id bigint NOT NULL,
+    owner_id bigint NOT NULL,
+    lobby_id bigint NOT NULL,
+    text_channel_id bigint NOT NULL DEFAULT 0,
+    created_at timestamp NOT NULL,
+    FOREIGN KEY (guild_id) REFERENCES guilds (guild_id) ON DELETE CASCADE ON UPDATE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS guild_temp_channels_guild_id_owner_id ON guild_temp_channels (guild_id, owner_id);
\ No newline at end of file
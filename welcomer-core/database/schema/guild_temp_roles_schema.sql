@@ -0,0 +1,17 @@
+CREATE TABLE IF NOT EXISTS guild_temp_roles (
+    temp_role_uuid uuid NOT NULL UNIQUE PRIMARY KEY,
+    created_at timestamp NOT NULL,
+    guild_id bigint NOT NULL,
+    user_id bigint NOT NULL,
+    role_id bigint NOT NULL,
+    expires_at timestamp NOT NULL,
+    source int NOT NULL,
+    created_by bigint NOT NULL DEFAULT 0,
+    is_removed boolean NOT NULL DEFAULT FALSE,
+    source_uuid uuid NOT NULL DEFAULT '00000000-0000-0000-0000-000000000000',
+    FOREIGN KEY (guild_id) REFERENCES guilds (guild_id) ON DELETE CASCADE ON UPDATE CASCADE
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS guild_temp_roles_guild_id_user_id_role_id ON guild_temp_roles (guild_id, user_id, role_id);
+
+CREATE INDEX IF NOT EXISTS guild_temp_roles_expires_at ON guild_temp_roles (expires_at) WHERE is_removed = FALSE;
\ No newline at end of file
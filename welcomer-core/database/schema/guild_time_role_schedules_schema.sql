@@ -0,0 +1,11 @@
+CREATE TABLE IF NOT EXISTS guild_time_role_schedules (
+    guild_id bigint NOT NULL,
+    user_id bigint NOT NULL,
+    joined_at timestamp NOT NULL,
+    next_eligible_at timestamp NULL,
+    updated_at timestamp NOT NULL,
+    PRIMARY KEY (guild_id, user_id),
+    FOREIGN KEY (guild_id) REFERENCES guilds (guild_id) ON DELETE CASCADE ON UPDATE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS guild_time_role_schedules_next_eligible_at ON guild_time_role_schedules (next_eligible_at) WHERE next_eligible_at IS NOT NULL;
\ No newline at end of file
@@ -6,7 +6,16 @@ CREATE TABLE IF NOT EXISTS guild_voice_channel_stats (
     start_ts TIMESTAMP WITH TIME ZONE NOT NULL,
     end_ts TIMESTAMP WITH TIME ZONE NOT NULL,
     total_time_ms BIGINT NOT NULL,
-    inferred BOOLEAN NOT NULL DEFAULT FALSE
+    inferred BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now()
 );
 
+ALTER TABLE guild_voice_channel_stats ADD COLUMN IF NOT EXISTS created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now();
+
 CREATE INDEX IF NOT EXISTS guild_voice_channel_stats_guild_id ON guild_voice_channel_stats (guild_id);
+
+CREATE INDEX IF NOT EXISTS guild_voice_channel_stats_guild_id_start_ts ON guild_voice_channel_stats (guild_id, start_ts);
+
+CREATE INDEX IF NOT EXISTS guild_voice_channel_stats_created_at ON guild_voice_channel_stats (created_at);
+
+CREATE INDEX IF NOT EXISTS guild_voice_channel_stats_guild_id_user_id_end_ts ON guild_voice_channel_stats (guild_id, user_id, end_ts);
\ No newline at end of file
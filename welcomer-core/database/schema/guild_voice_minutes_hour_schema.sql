@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS guild_voice_minutes_hour (
+    hour_ts TIMESTAMPTZ NOT NULL,
+    guild_id BIGINT NOT NULL,
+    channel_id BIGINT NOT NULL,
+    user_id BIGINT NOT NULL,
+    minutes DOUBLE PRECISION NOT NULL,
+    inferred_minutes DOUBLE PRECISION NOT NULL,
+    PRIMARY KEY (hour_ts, guild_id, channel_id, user_id)
+);
+
+CREATE INDEX IF NOT EXISTS guild_voice_minutes_hour_guild_id_hour_ts ON guild_voice_minutes_hour (guild_id, hour_ts);
+CREATE INDEX IF NOT EXISTS guild_voice_minutes_hour_guild_id_user_id_hour_ts ON guild_voice_minutes_hour (guild_id, user_id, hour_ts);
\ No newline at end of file
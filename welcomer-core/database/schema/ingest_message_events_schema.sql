@@ -8,3 +8,5 @@ CREATE TABLE IF NOT EXISTS ingest_message_events (
     occurred_at TIMESTAMP WITH TIME ZONE NOT NULL,
     PRIMARY KEY (event_id, occurred_at)
 );
+
+CREATE INDEX IF NOT EXISTS ingest_message_events_message_id ON ingest_message_events (message_id);
\ No newline at end of file
@@ -7,4 +7,10 @@ CREATE TABLE IF NOT EXISTS science_guild_events (
     data json
 );
 
-ALTER TABLE science_guild_events ALTER COLUMN data SET STORAGE PLAIN;
\ No newline at end of file
+ALTER TABLE science_guild_events ALTER COLUMN data SET STORAGE PLAIN;
+
+CREATE INDEX IF NOT EXISTS science_guild_events_guild_id_created_at ON science_guild_events (guild_id, created_at);
+
+CREATE INDEX IF NOT EXISTS science_guild_events_guild_id_user_id_created_at ON science_guild_events (guild_id, user_id, created_at);
+
+CREATE INDEX IF NOT EXISTS science_guild_events_guild_id_reaction_role_uuid_created_at ON science_guild_events (guild_id, (data ->> 'reaction_role_uuid'), created_at) WHERE data ->> 'reaction_role_uuid' IS NOT NULL;
@@ -8,6 +8,7 @@ package database
 import (
 	"context"
 	"database/sql"
+	"time"
 
 	"github.com/gofrs/uuid"
 )
@@ -75,3 +76,59 @@ func (q *Queries) GetCommandUsage(ctx context.Context, commandUuid uuid.UUID) (*
 	)
 	return &i, err
 }
+
+const GetGuildCommandErrorCounts = `-- name: GetGuildCommandErrorCounts :many
+SELECT
+    command,
+    COUNT(*) AS error_count
+FROM
+    science_command_usages
+WHERE
+    guild_id = $1
+    AND errored = TRUE
+    AND created_at >= $2
+    AND created_at < $3
+GROUP BY
+    command
+ORDER BY
+    error_count DESC,
+    command
+LIMIT $4
+`
+
+type GetGuildCommandErrorCountsParams struct {
+	GuildID    int64     `json:"guild_id"`
+	Since      time.Time `json:"since"`
+	Until      time.Time `json:"until"`
+	EntryLimit int32     `json:"entry_limit"`
+}
+
+type GetGuildCommandErrorCountsRow struct {
+	Command    string `json:"command"`
+	ErrorCount int64  `json:"error_count"`
+}
+
+func (q *Queries) GetGuildCommandErrorCounts(ctx context.Context, arg GetGuildCommandErrorCountsParams) ([]*GetGuildCommandErrorCountsRow, error) {
+	rows, err := q.db.Query(ctx, GetGuildCommandErrorCounts,
+		arg.GuildID,
+		arg.Since,
+		arg.Until,
+		arg.EntryLimit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []*GetGuildCommandErrorCountsRow{}
+	for rows.Next() {
+		var i GetGuildCommandErrorCountsRow
+		if err := rows.Scan(&i.Command, &i.ErrorCount); err != nil {
+			return nil, err
+		}
+		items = append(items, &i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
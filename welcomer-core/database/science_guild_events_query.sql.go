@@ -126,6 +126,382 @@ func (q *Queries) GetExpiredWelcomeMessageEvents(ctx context.Context, arg GetExp
 	return items, nil
 }
 
+const GetGuildCohortRetention = `-- name: GetGuildCohortRetention :many
+WITH joins AS (
+    SELECT
+        science_guild_events.user_id,
+        science_guild_events.created_at AS joined_at,
+        COALESCE(science_guild_events.data ->> 'invite_code', '') AS invite_code
+    FROM
+        science_guild_events
+    WHERE
+        science_guild_events.guild_id = $1
+        AND science_guild_events.event_type = $2
+        AND science_guild_events.user_id IS NOT NULL
+        AND science_guild_events.created_at >= $3
+        AND science_guild_events.created_at < $4
+),
+members AS (
+    SELECT
+        joins.joined_at,
+        joins.invite_code,
+        (
+            SELECT
+                MIN(leaves.created_at)
+            FROM
+                science_guild_events leaves
+            WHERE
+                leaves.guild_id = $1
+                AND leaves.user_id = joins.user_id
+                AND leaves.event_type = $5
+                AND leaves.created_at > joins.joined_at) AS left_at,
+        EXISTS (
+            SELECT
+                1
+            FROM
+                science_guild_events welcomed
+            WHERE
+                welcomed.guild_id = $1
+                AND welcomed.user_id = joins.user_id
+                AND welcomed.event_type = $6
+                AND welcomed.created_at >= joins.joined_at
+                AND welcomed.created_at < joins.joined_at + interval '7 days') AS is_welcomed,
+        EXISTS (
+            SELECT
+                1
+            FROM
+                science_guild_events welcomed
+            WHERE
+                welcomed.guild_id = $1
+                AND welcomed.user_id = joins.user_id
+                AND welcomed.event_type = $6
+                AND welcomed.created_at >= joins.joined_at
+                AND welcomed.created_at < joins.joined_at + interval '7 days'
+                AND (welcomed.data ->> 'has_dm')::boolean) AS is_dmed,
+        EXISTS (
+            SELECT
+                1
+            FROM
+                science_guild_events verified
+            WHERE
+                verified.guild_id = $1
+                AND verified.user_id = joins.user_id
+                AND verified.event_type = $7
+                AND verified.created_at >= joins.joined_at
+                AND verified.created_at < joins.joined_at + interval '7 days') AS is_verified
+    FROM
+        joins
+)
+SELECT
+    date_trunc('week', members.joined_at)::timestamp AS cohort_week,
+    members.invite_code::text AS invite_code,
+    members.is_welcomed::boolean AS is_welcomed,
+    members.is_dmed::boolean AS is_dmed,
+    members.is_verified::boolean AS is_verified,
+    COUNT(*) AS members,
+    COUNT(*) FILTER (WHERE members.joined_at + interval '1 day' <= $8) AS day_1_eligible,
+    COUNT(*) FILTER (WHERE members.joined_at + interval '1 day' <= $8
+        AND (members.left_at IS NULL OR members.left_at > members.joined_at + interval '1 day')) AS day_1_retained,
+    COUNT(*) FILTER (WHERE members.joined_at + interval '7 days' <= $8) AS day_7_eligible,
+    COUNT(*) FILTER (WHERE members.joined_at + interval '7 days' <= $8
+        AND (members.left_at IS NULL OR members.left_at > members.joined_at + interval '7 days')) AS day_7_retained,
+    COUNT(*) FILTER (WHERE members.joined_at + interval '30 days' <= $8) AS day_30_eligible,
+    COUNT(*) FILTER (WHERE members.joined_at + interval '30 days' <= $8
+        AND (members.left_at IS NULL OR members.left_at > members.joined_at + interval '30 days')) AS day_30_retained
+FROM
+    members
+GROUP BY
+    cohort_week,
+    members.invite_code,
+    members.is_welcomed,
+    members.is_dmed,
+    members.is_verified
+ORDER BY
+    cohort_week
+`
+
+type GetGuildCohortRetentionParams struct {
+	GuildID                                  int64     `json:"guild_id"`
+	ScienceGuildEventTypeUserJoin            int32     `json:"science_guild_event_type_user_join"`
+	Since                                    time.Time `json:"since"`
+	Until                                    time.Time `json:"until"`
+	ScienceGuildEventTypeUserLeave           int32     `json:"science_guild_event_type_user_leave"`
+	ScienceGuildEventTypeUserWelcomed        int32     `json:"science_guild_event_type_user_welcomed"`
+	ScienceGuildEventTypeBorderwallCompleted int32     `json:"science_guild_event_type_borderwall_completed"`
+	Now                                      time.Time `json:"now"`
+}
+
+type GetGuildCohortRetentionRow struct {
+	CohortWeek    time.Time `json:"cohort_week"`
+	InviteCode    string    `json:"invite_code"`
+	IsWelcomed    bool      `json:"is_welcomed"`
+	IsDmed        bool      `json:"is_dmed"`
+	IsVerified    bool      `json:"is_verified"`
+	Members       int64     `json:"members"`
+	Day1Eligible  int64     `json:"day_1_eligible"`
+	Day1Retained  int64     `json:"day_1_retained"`
+	Day7Eligible  int64     `json:"day_7_eligible"`
+	Day7Retained  int64     `json:"day_7_retained"`
+	Day30Eligible int64     `json:"day_30_eligible"`
+	Day30Retained int64     `json:"day_30_retained"`
+}
+
+func (q *Queries) GetGuildCohortRetention(ctx context.Context, arg GetGuildCohortRetentionParams) ([]*GetGuildCohortRetentionRow, error) {
+	rows, err := q.db.Query(ctx, GetGuildCohortRetention,
+		arg.GuildID,
+		arg.ScienceGuildEventTypeUserJoin,
+		arg.Since,
+		arg.Until,
+		arg.ScienceGuildEventTypeUserLeave,
+		arg.ScienceGuildEventTypeUserWelcomed,
+		arg.ScienceGuildEventTypeBorderwallCompleted,
+		arg.Now,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []*GetGuildCohortRetentionRow{}
+	for rows.Next() {
+		var i GetGuildCohortRetentionRow
+		if err := rows.Scan(
+			&i.CohortWeek,
+			&i.InviteCode,
+			&i.IsWelcomed,
+			&i.IsDmed,
+			&i.IsVerified,
+			&i.Members,
+			&i.Day1Eligible,
+			&i.Day1Retained,
+			&i.Day7Eligible,
+			&i.Day7Retained,
+			&i.Day30Eligible,
+			&i.Day30Retained,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, &i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const GetGuildEventCountSeries = `-- name: GetGuildEventCountSeries :many
+SELECT
+    date_trunc($1::text, science_guild_events.created_at)::timestamp AS bucket_ts,
+    science_guild_events.event_type,
+    COUNT(*) AS event_count
+FROM
+    science_guild_events
+WHERE
+    science_guild_events.guild_id = $2
+    AND science_guild_events.event_type = ANY($3::int[])
+    AND science_guild_events.created_at >= $4
+    AND science_guild_events.created_at < $5
+GROUP BY
+    bucket_ts,
+    science_guild_events.event_type
+ORDER BY
+    bucket_ts
+`
+
+type GetGuildEventCountSeriesParams struct {
+	Bucket     string    `json:"bucket"`
+	GuildID    int64     `json:"guild_id"`
+	EventTypes []int32   `json:"event_types"`
+	Since      time.Time `json:"since"`
+	Until      time.Time `json:"until"`
+}
+
+type GetGuildEventCountSeriesRow struct {
+	BucketTs   time.Time `json:"bucket_ts"`
+	EventType  int32     `json:"event_type"`
+	EventCount int64     `json:"event_count"`
+}
+
+func (q *Queries) GetGuildEventCountSeries(ctx context.Context, arg GetGuildEventCountSeriesParams) ([]*GetGuildEventCountSeriesRow, error) {
+	rows, err := q.db.Query(ctx, GetGuildEventCountSeries,
+		arg.Bucket,
+		arg.GuildID,
+		arg.EventTypes,
+		arg.Since,
+		arg.Until,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []*GetGuildEventCountSeriesRow{}
+	for rows.Next() {
+		var i GetGuildEventCountSeriesRow
+		if err := rows.Scan(&i.BucketTs, &i.EventType, &i.EventCount); err != nil {
+			return nil, err
+		}
+		items = append(items, &i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const GetGuildEventCounts = `-- name: GetGuildEventCounts :many
+SELECT
+    science_guild_events.event_type,
+    COUNT(*) AS event_count
+FROM
+    science_guild_events
+WHERE
+    science_guild_events.guild_id = $1
+    AND science_guild_events.event_type = ANY($2::int[])
+    AND science_guild_events.created_at >= $3
+    AND science_guild_events.created_at < $4
+GROUP BY
+    science_guild_events.event_type
+`
+
+type GetGuildEventCountsParams struct {
+	GuildID    int64     `json:"guild_id"`
+	EventTypes []int32   `json:"event_types"`
+	Since      time.Time `json:"since"`
+	Until      time.Time `json:"until"`
+}
+
+type GetGuildEventCountsRow struct {
+	EventType  int32 `json:"event_type"`
+	EventCount int64 `json:"event_count"`
+}
+
+func (q *Queries) GetGuildEventCounts(ctx context.Context, arg GetGuildEventCountsParams) ([]*GetGuildEventCountsRow, error) {
+	rows, err := q.db.Query(ctx, GetGuildEventCounts,
+		arg.GuildID,
+		arg.EventTypes,
+		arg.Since,
+		arg.Until,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []*GetGuildEventCountsRow{}
+	for rows.Next() {
+		var i GetGuildEventCountsRow
+		if err := rows.Scan(&i.EventType, &i.EventCount); err != nil {
+			return nil, err
+		}
+		items = append(items, &i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const GetGuildFailedMessageRemovalCount = `-- name: GetGuildFailedMessageRemovalCount :one
+SELECT
+    COUNT(*) AS failed_count
+FROM
+    science_guild_events
+WHERE
+    science_guild_events.guild_id = $1
+    AND science_guild_events.event_type IN ($2, $3)
+    AND science_guild_events.created_at >= $4
+    AND science_guild_events.created_at < $5
+    AND COALESCE((science_guild_events.data ->> 'has_message')::boolean, FALSE)
+    AND NOT COALESCE((science_guild_events.data ->> 'successful')::boolean, FALSE)
+`
+
+type GetGuildFailedMessageRemovalCountParams struct {
+	GuildID                                    int64     `json:"guild_id"`
+	ScienceGuildEventTypeWelcomeMessageRemoved int32     `json:"science_guild_event_type_welcome_message_removed"`
+	ScienceGuildEventTypeLeaverMessageRemoved  int32     `json:"science_guild_event_type_leaver_message_removed"`
+	Since                                      time.Time `json:"since"`
+	Until                                      time.Time `json:"until"`
+}
+
+func (q *Queries) GetGuildFailedMessageRemovalCount(ctx context.Context, arg GetGuildFailedMessageRemovalCountParams) (int64, error) {
+	row := q.db.QueryRow(ctx, GetGuildFailedMessageRemovalCount,
+		arg.GuildID,
+		arg.ScienceGuildEventTypeWelcomeMessageRemoved,
+		arg.ScienceGuildEventTypeLeaverMessageRemoved,
+		arg.Since,
+		arg.Until,
+	)
+	var failed_count int64
+	err := row.Scan(&failed_count)
+	return failed_count, err
+}
+
+const GetReactionRoleEventCounts = `-- name: GetReactionRoleEventCounts :many
+SELECT
+    date_trunc($1::text, science_guild_events.created_at)::timestamp AS bucket_ts,
+    CAST(science_guild_events.data ->> 'role_id' AS BIGINT) AS role_id,
+    COUNT(*) FILTER (WHERE science_guild_events.event_type = $2) AS adds,
+    COUNT(*) FILTER (WHERE science_guild_events.event_type = $3) AS removes
+FROM
+    science_guild_events
+WHERE
+    science_guild_events.guild_id = $4
+    AND science_guild_events.event_type IN ($2, $3)
+    AND science_guild_events.data ->> 'reaction_role_uuid' = $5::text
+    AND science_guild_events.created_at >= $6
+GROUP BY
+    bucket_ts,
+    role_id
+ORDER BY
+    bucket_ts
+`
+
+type GetReactionRoleEventCountsParams struct {
+	Bucket                                   string    `json:"bucket"`
+	ScienceGuildEventTypeReactionRoleGiven   int32     `json:"science_guild_event_type_reaction_role_given"`
+	ScienceGuildEventTypeReactionRoleRemoved int32     `json:"science_guild_event_type_reaction_role_removed"`
+	GuildID                                  int64     `json:"guild_id"`
+	ReactionRoleUuid                         string    `json:"reaction_role_uuid"`
+	Since                                    time.Time `json:"since"`
+}
+
+type GetReactionRoleEventCountsRow struct {
+	BucketTs time.Time `json:"bucket_ts"`
+	RoleID   int64     `json:"role_id"`
+	Adds     int64     `json:"adds"`
+	Removes  int64     `json:"removes"`
+}
+
+func (q *Queries) GetReactionRoleEventCounts(ctx context.Context, arg GetReactionRoleEventCountsParams) ([]*GetReactionRoleEventCountsRow, error) {
+	rows, err := q.db.Query(ctx, GetReactionRoleEventCounts,
+		arg.Bucket,
+		arg.ScienceGuildEventTypeReactionRoleGiven,
+		arg.ScienceGuildEventTypeReactionRoleRemoved,
+		arg.GuildID,
+		arg.ReactionRoleUuid,
+		arg.Since,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []*GetReactionRoleEventCountsRow{}
+	for rows.Next() {
+		var i GetReactionRoleEventCountsRow
+		if err := rows.Scan(
+			&i.BucketTs,
+			&i.RoleID,
+			&i.Adds,
+			&i.Removes,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, &i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const GetScienceGuildEvent = `-- name: GetScienceGuildEvent :one
 SELECT
     guild_event_uuid, guild_id, user_id, created_at, event_type, data
@@ -53,6 +53,22 @@ func CreateOrUpdateWelcomerGuildSettingsWithAudit(ctx context.Context, params da
 }
 
 // Generic wrappers for guild settings create/update operations.
+func CreateOrUpdateActivityRolesGuildSettingsWithAudit(ctx context.Context, params database.CreateOrUpdateActivityRolesGuildSettingsParams, actor discord.Snowflake) (*database.GuildSettingsActivityroles, error) {
+	var old database.GuildSettingsActivityroles
+	if existing, err := Queries.GetActivityRolesGuildSettings(ctx, params.GuildID); err == nil {
+		old = *existing
+	}
+
+	newRow, err := Queries.CreateOrUpdateActivityRolesGuildSettings(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+
+	AuditChange(ctx, discord.Snowflake(params.GuildID), actor, old, *newRow, database.AuditTypeGuildSettingsActivityroles, "")
+
+	return newRow, nil
+}
+
 func CreateOrUpdateAutoRolesGuildSettingsWithAudit(ctx context.Context, params database.CreateOrUpdateAutoRolesGuildSettingsParams, actor discord.Snowflake) (*database.GuildSettingsAutoroles, error) {
 	var old database.GuildSettingsAutoroles
 	if existing, err := Queries.GetAutoRolesGuildSettings(ctx, params.GuildID); err == nil {
@@ -90,6 +106,22 @@ func CreateOrUpdateBorderwallGuildSettingsWithAudit(ctx context.Context, params
 	return newRow, nil
 }
 
+func CreateOrUpdateDigestGuildSettingsWithAudit(ctx context.Context, params database.CreateOrUpdateDigestGuildSettingsParams, actor discord.Snowflake) (*database.GuildSettingsDigest, error) {
+	var old database.GuildSettingsDigest
+	if existing, err := Queries.GetDigestGuildSettings(ctx, params.GuildID); err == nil {
+		old = *existing
+	}
+
+	newRow, err := Queries.CreateOrUpdateDigestGuildSettings(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+
+	AuditChange(ctx, discord.Snowflake(params.GuildID), actor, old, *newRow, database.AuditTypeGuildSettingsDigest, "")
+
+	return newRow, nil
+}
+
 func CreateOrUpdateFreeRolesGuildSettingsWithAudit(ctx context.Context, params database.CreateOrUpdateFreeRolesGuildSettingsParams, actor discord.Snowflake) (*database.GuildSettingsFreeroles, error) {
 	var old database.GuildSettingsFreeroles
 	if existing, err := Queries.GetFreeRolesGuildSettings(ctx, params.GuildID); err == nil {
@@ -106,6 +138,38 @@ func CreateOrUpdateFreeRolesGuildSettingsWithAudit(ctx context.Context, params d
 	return newRow, nil
 }
 
+func CreateOrUpdateInactiveGuildSettingsWithAudit(ctx context.Context, params database.CreateOrUpdateInactiveGuildSettingsParams, actor discord.Snowflake) (*database.GuildSettingsInactive, error) {
+	var old database.GuildSettingsInactive
+	if existing, err := Queries.GetInactiveGuildSettings(ctx, params.GuildID); err == nil {
+		old = *existing
+	}
+
+	newRow, err := Queries.CreateOrUpdateInactiveGuildSettings(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+
+	AuditChange(ctx, discord.Snowflake(params.GuildID), actor, old, *newRow, database.AuditTypeGuildSettingsInactive, "")
+
+	return newRow, nil
+}
+
+func CreateOrUpdateLeaderboardGuildSettingsWithAudit(ctx context.Context, params database.CreateOrUpdateLeaderboardGuildSettingsParams, actor discord.Snowflake) (*database.GuildSettingsLeaderboard, error) {
+	var old database.GuildSettingsLeaderboard
+	if existing, err := Queries.GetLeaderboardGuildSettings(ctx, params.GuildID); err == nil {
+		old = *existing
+	}
+
+	newRow, err := Queries.CreateOrUpdateLeaderboardGuildSettings(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+
+	AuditChange(ctx, discord.Snowflake(params.GuildID), actor, old, *newRow, database.AuditTypeGuildSettingsLeaderboard, "")
+
+	return newRow, nil
+}
+
 func CreateOrUpdateLeaverGuildSettingsWithAudit(ctx context.Context, params database.CreateOrUpdateLeaverGuildSettingsParams, actor discord.Snowflake) (*database.GuildSettingsLeaver, error) {
 	var old database.GuildSettingsLeaver
 	if existing, err := Queries.GetLeaverGuildSettings(ctx, params.GuildID); err == nil {
@@ -125,6 +189,22 @@ func CreateOrUpdateLeaverGuildSettingsWithAudit(ctx context.Context, params data
 	return newRow, nil
 }
 
+func CreateOrUpdateRetentionGuildSettingsWithAudit(ctx context.Context, params database.CreateOrUpdateRetentionGuildSettingsParams, actor discord.Snowflake) (*database.GuildSettingsRetention, error) {
+	var old database.GuildSettingsRetention
+	if existing, err := Queries.GetRetentionGuildSettings(ctx, params.GuildID); err == nil {
+		old = *existing
+	}
+
+	newRow, err := Queries.CreateOrUpdateRetentionGuildSettings(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+
+	AuditChange(ctx, discord.Snowflake(params.GuildID), actor, old, *newRow, database.AuditTypeGuildSettingsRetention, "")
+
+	return newRow, nil
+}
+
 func CreateOrUpdateRulesGuildSettingsWithAudit(ctx context.Context, params database.CreateOrUpdateRulesGuildSettingsParams, actor discord.Snowflake) (*database.GuildSettingsRules, error) {
 	var old database.GuildSettingsRules
 	if existing, err := Queries.GetRulesGuildSettings(ctx, params.GuildID); err == nil {
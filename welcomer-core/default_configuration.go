@@ -25,6 +25,12 @@ func MustConvertToJSONB(v any) pgtype.JSONB {
 	return jb
 }
 
+var DefaultActivityRoles database.GuildSettingsActivityroles = database.GuildSettingsActivityroles{
+	ToggleEnabled: false,
+	ToggleDecay:   false,
+	Activityroles: MustConvertToJSONB([]GuildSettingsActivityRolesRole{}),
+}
+
 var DefaultAutoroles database.GuildSettingsAutoroles = database.GuildSettingsAutoroles{
 	ToggleEnabled: false,
 	Roles:         []int64{},
@@ -54,9 +60,25 @@ var DefaultBorderwall database.GuildSettingsBorderwall = database.GuildSettingsB
 	RolesOnVerify: []int64{},
 }
 
+var DefaultDigest database.GuildSettingsDigest = database.GuildSettingsDigest{
+	ToggleEnabled: false,
+	ChannelDigest: 0,
+	Frequency:     DigestFrequencyWeekly,
+}
+
 var DefaultFreeRoles database.GuildSettingsFreeroles = database.GuildSettingsFreeroles{
 	ToggleEnabled: false,
 	Roles:         []int64{},
+	RoleDurations: MustConvertToJSONB([]TempRoleDuration{}),
+}
+
+var DefaultInactive database.GuildSettingsInactive = database.GuildSettingsInactive{
+	ExcludedRoles: []int64{},
+}
+
+var DefaultLeaderboard database.GuildSettingsLeaderboard = database.GuildSettingsLeaderboard{
+	ToggleIncludeBots: false,
+	ExcludedChannels:  []int64{},
 }
 
 var DefaultLeaver database.GuildSettingsLeaver = database.GuildSettingsLeaver{
@@ -69,6 +91,11 @@ var DefaultLeaver database.GuildSettingsLeaver = database.GuildSettingsLeaver{
 	LeaverMessageLifetime:    0,
 }
 
+var DefaultRetention database.GuildSettingsRetention = database.GuildSettingsRetention{
+	ToggleWeeklyReport: false,
+	ChannelReport:      0,
+}
+
 var DefaultRules database.GuildSettingsRules = database.GuildSettingsRules{
 	ToggleEnabled:    false,
 	ToggleDmsEnabled: true,
@@ -81,11 +108,13 @@ var DefaultTempChannels database.GuildSettingsTempchannels = database.GuildSetti
 	ChannelLobby:     0,
 	ChannelCategory:  0,
 	DefaultUserCount: 0,
+	Lobbies:          MustConvertToJSONB([]TempChannelLobby{}),
 }
 
 var DefaultTimeRoles database.GuildSettingsTimeroles = database.GuildSettingsTimeroles{
 	ToggleEnabled: false,
 	Timeroles:     pgtype.JSONB{Status: pgtype.Null},
+	ToggleLadder:  false,
 }
 
 var DefaultWelcomerText database.GuildSettingsWelcomerText = database.GuildSettingsWelcomerText{
@@ -0,0 +1,229 @@
+package welcomer
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/WelcomerTeam/Discord/discord"
+	"github.com/WelcomerTeam/Welcomer/welcomer-core/database"
+)
+
+const (
+	DigestFrequencyDaily  = "daily"
+	DigestFrequencyWeekly = "weekly"
+
+	// DigestMaximumEntries is the most entries shown in each section of a digest.
+	DigestMaximumEntries = 5
+)
+
+type DigestEntry struct {
+	ID    discord.Snowflake
+	Count int64
+}
+
+// GuildDigest is a summary of a guild over the period of a digest.
+type GuildDigest struct {
+	Frequency string
+	Since     time.Time
+	Until     time.Time
+
+	Joins       int64
+	Leaves      int64
+	TopInviters []DigestEntry
+
+	VerificationsStarted   int64
+	VerificationsCompleted int64
+
+	ActiveChannels []DigestEntry
+	ActiveMembers  []DigestEntry
+
+	Giveaways []*database.GetGuildEndedGiveawaysRow
+
+	CommandErrors []*database.GetGuildCommandErrorCountsRow
+
+	// FailedMessageRemovals is the number of welcome and leaver messages that could not be removed,
+	// which is usually from missing permissions.
+	FailedMessageRemovals int64
+}
+
+func IsValidDigestFrequency(frequency string) bool {
+	switch frequency {
+	case DigestFrequencyDaily, DigestFrequencyWeekly:
+		return true
+	default:
+		return false
+	}
+}
+
+// GetDigestSince returns the start of the period covered by a digest.
+func GetDigestSince(frequency string, now time.Time) time.Time {
+	if frequency == DigestFrequencyDaily {
+		return now.AddDate(0, 0, -1)
+	}
+
+	return now.AddDate(0, 0, -7)
+}
+
+// FormatDigestVerificationRate returns how many of the started verifications were completed, as a percentage.
+func FormatDigestVerificationRate(started, completed int64) string {
+	if started == 0 {
+		return "-"
+	}
+
+	return fmt.Sprintf("%.0f%%", float64(min(completed, started))/float64(started)*100)
+}
+
+func formatDigestEntries(entries []DigestEntry, mention func(discord.Snowflake) string, unit string) string {
+	var content strings.Builder
+
+	for i, entry := range entries[:min(len(entries), DigestMaximumEntries)] {
+		fmt.Fprintf(&content, "%d. %s - %d %s%s\n", i+1, mention(entry.ID), entry.Count, unit, If(entry.Count == 1, "", "s"))
+	}
+
+	return content.String()
+}
+
+func GetDigestMessage(guildID discord.Snowflake, digest GuildDigest) discord.MessageParams {
+	var members strings.Builder
+
+	members.WriteString("### Members\n")
+	fmt.Fprintf(&members, "**%d** joined · **%d** left · **%+d** overall\n", digest.Joins, digest.Leaves, digest.Joins-digest.Leaves)
+
+	if len(digest.TopInviters) > 0 {
+		members.WriteString("\n**Top inviters**\n")
+		members.WriteString(formatDigestEntries(digest.TopInviters, func(id discord.Snowflake) string { return "<@" + id.String() + ">" }, "invite"))
+	}
+
+	if digest.VerificationsStarted > 0 {
+		fmt.Fprintf(&members, "\n**Verification** %s passed (%d of %d)\n",
+			FormatDigestVerificationRate(digest.VerificationsStarted, digest.VerificationsCompleted), digest.VerificationsCompleted, digest.VerificationsStarted)
+	}
+
+	var activity strings.Builder
+
+	activity.WriteString("### Activity\n")
+
+	if len(digest.ActiveChannels) == 0 && len(digest.ActiveMembers) == 0 {
+		activity.WriteString("No messages have been sent.")
+	}
+
+	if len(digest.ActiveChannels) > 0 {
+		activity.WriteString("**Most active channels**\n")
+		activity.WriteString(formatDigestEntries(digest.ActiveChannels, func(id discord.Snowflake) string { return "<#" + id.String() + ">" }, "message"))
+	}
+
+	if len(digest.ActiveMembers) > 0 {
+		activity.WriteString("\n**Most active members**\n")
+		activity.WriteString(formatDigestEntries(digest.ActiveMembers, func(id discord.Snowflake) string { return "<@" + id.String() + ">" }, "message"))
+	}
+
+	components := []discord.InteractionComponent{
+		{
+			Type: discord.InteractionComponentTypeTextDisplay,
+			Content: "# " + If(digest.Frequency == DigestFrequencyDaily, "Daily", "Weekly") + " digest\n" +
+				"Here is what happened from <t:" + Itoa(digest.Since.Unix()) + ":f> to <t:" + Itoa(digest.Until.Unix()) + ":f>.",
+		},
+		{
+			Type: discord.InteractionComponentTypeSeparator,
+		},
+		{
+			Type:    discord.InteractionComponentTypeTextDisplay,
+			Content: strings.TrimSpace(members.String()),
+		},
+		{
+			Type: discord.InteractionComponentTypeSeparator,
+		},
+		{
+			Type:    discord.InteractionComponentTypeTextDisplay,
+			Content: strings.TrimSpace(activity.String()),
+		},
+	}
+
+	if len(digest.Giveaways) > 0 {
+		var giveaways strings.Builder
+
+		giveaways.WriteString("### Giveaways\n")
+
+		for _, giveaway := range digest.Giveaways[:min(len(digest.Giveaways), DigestMaximumEntries)] {
+			fmt.Fprintf(&giveaways, "**%s** in <#%d> · %d entries · %d winner%s\n",
+				giveaway.Title, giveaway.ChannelID, giveaway.Entries, giveaway.Winners, If(giveaway.Winners == 1, "", "s"))
+		}
+
+		components = append(components,
+			discord.InteractionComponent{
+				Type: discord.InteractionComponentTypeSeparator,
+			},
+			discord.InteractionComponent{
+				Type:    discord.InteractionComponentTypeTextDisplay,
+				Content: strings.TrimSpace(giveaways.String()),
+			},
+		)
+	}
+
+	var failedDeliveries int64
+	for _, giveaway := range digest.Giveaways {
+		failedDeliveries += giveaway.DeliveryFailures
+	}
+
+	if len(digest.CommandErrors) > 0 || digest.FailedMessageRemovals > 0 || failedDeliveries > 0 {
+		var problems strings.Builder
+
+		problems.WriteString("### Problems\n")
+
+		for _, commandError := range digest.CommandErrors[:min(len(digest.CommandErrors), DigestMaximumEntries)] {
+			fmt.Fprintf(&problems, "`/%s` failed %d time%s\n", commandError.Command, commandError.ErrorCount, If(commandError.ErrorCount == 1, "", "s"))
+		}
+
+		if digest.FailedMessageRemovals > 0 {
+			fmt.Fprintf(&problems, "%d welcome or leaver message%s could not be removed. Check that I can manage messages in those channels.\n",
+				digest.FailedMessageRemovals, If(digest.FailedMessageRemovals == 1, "", "s"))
+		}
+
+		if failedDeliveries > 0 {
+			fmt.Fprintf(&problems, "%d giveaway prize%s could not be delivered.\n", failedDeliveries, If(failedDeliveries == 1, "", "s"))
+		}
+
+		components = append(components,
+			discord.InteractionComponent{
+				Type: discord.InteractionComponentTypeSeparator,
+			},
+			discord.InteractionComponent{
+				Type:    discord.InteractionComponentTypeTextDisplay,
+				Content: strings.TrimSpace(problems.String()),
+			},
+		)
+	}
+
+	components = append(components,
+		discord.InteractionComponent{
+			Type: discord.InteractionComponentTypeSeparator,
+		},
+		discord.InteractionComponent{
+			Type: discord.InteractionComponentTypeSection,
+			Components: []discord.InteractionComponent{
+				{
+					Type:    discord.InteractionComponentTypeTextDisplay,
+					Content: "You can change where this digest is sent, how often, or turn it off, on your server's dashboard.",
+				},
+			},
+			Accessory: &discord.InteractionComponent{
+				Type:  discord.InteractionComponentTypeButton,
+				Style: discord.InteractionComponentStyleLink,
+				Label: "Dashboard",
+				URL:   WebsiteURL + "/dashboard/" + guildID.String(),
+			},
+		},
+	)
+
+	return discord.MessageParams{
+		Flags: discord.MessageFlagIsComponentsV2,
+		Components: []discord.InteractionComponent{
+			{
+				Type:        discord.InteractionComponentTypeContainer,
+				AccentColor: new(uint32(0xfbc01b)),
+				Components:  components,
+			},
+		},
+	}
+}
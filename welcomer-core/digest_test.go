@@ -0,0 +1,75 @@
+package welcomer
+
+import (
+	"testing"
+	"time"
+
+	"github.com/WelcomerTeam/Discord/discord"
+)
+
+func TestGetDigestSince(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2025, time.March, 31, 12, 0, 0, 0, time.UTC)
+
+	if since := GetDigestSince(DigestFrequencyDaily, now); !since.Equal(now.AddDate(0, 0, -1)) {
+		t.Errorf("expected daily digest to cover a day, got: %v", since)
+	}
+
+	if since := GetDigestSince(DigestFrequencyWeekly, now); !since.Equal(now.AddDate(0, 0, -7)) {
+		t.Errorf("expected weekly digest to cover a week, got: %v", since)
+	}
+}
+
+func TestIsValidDigestFrequency(t *testing.T) {
+	t.Parallel()
+
+	for frequency, expected := range map[string]bool{
+		DigestFrequencyDaily:  true,
+		DigestFrequencyWeekly: true,
+		"monthly":             false,
+		"":                    false,
+	} {
+		if valid := IsValidDigestFrequency(frequency); valid != expected {
+			t.Errorf("%q: expected: %v, got: %v", frequency, expected, valid)
+		}
+	}
+}
+
+func TestFormatDigestVerificationRate(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		started   int64
+		completed int64
+		expected  string
+	}{
+		{0, 0, "-"},
+		{4, 3, "75%"},
+		{3, 3, "100%"},
+		// Verifications started before the digest can be completed during it.
+		{2, 3, "100%"},
+	}
+
+	for _, test := range tests {
+		if rate := FormatDigestVerificationRate(test.started, test.completed); rate != test.expected {
+			t.Errorf("%d of %d: expected: %s, got: %s", test.completed, test.started, test.expected, rate)
+		}
+	}
+}
+
+func TestFormatDigestEntries(t *testing.T) {
+	t.Parallel()
+
+	entries := make([]DigestEntry, 0, DigestMaximumEntries+1)
+	for i := range DigestMaximumEntries + 1 {
+		entries = append(entries, DigestEntry{ID: discord.Snowflake(i + 1), Count: int64(DigestMaximumEntries + 1 - i)})
+	}
+
+	content := formatDigestEntries(entries, func(id discord.Snowflake) string { return "<@" + id.String() + ">" }, "message")
+
+	expected := "1. <@1> - 6 messages\n2. <@2> - 5 messages\n3. <@3> - 4 messages\n4. <@4> - 3 messages\n5. <@5> - 2 messages\n"
+	if content != expected {
+		t.Errorf("expected: %q, got: %q", expected, content)
+	}
+}
@@ -99,7 +99,25 @@ func LoadRSAKey(keyType KeyType) (any, error) {
 }
 
 func EncryptBotToken(token string, botID uuid.UUID) (string, error) {
-	if token == "" {
+	return encryptRSA(token)
+}
+
+func DecryptBotToken(encryptedToken string, botID uuid.UUID) (string, error) {
+	return decryptRSA(encryptedToken)
+}
+
+// EncryptGiveawayPrizeCode encrypts a giveaway prize code with the same key as custom bot tokens.
+func EncryptGiveawayPrizeCode(code string) (string, error) {
+	return encryptRSA(code)
+}
+
+// DecryptGiveawayPrizeCode decrypts a giveaway prize code encrypted with EncryptGiveawayPrizeCode.
+func DecryptGiveawayPrizeCode(encryptedCode string) (string, error) {
+	return decryptRSA(encryptedCode)
+}
+
+func encryptRSA(value string) (string, error) {
+	if value == "" {
 		return "", nil
 	}
 
@@ -113,16 +131,16 @@ func EncryptBotToken(token string, botID uuid.UUID) (string, error) {
 		return "", errors.New("invalid RSA public key")
 	}
 
-	cipherText, err := rsa.EncryptPKCS1v15(rand.Reader, pubKey, []byte(token))
+	cipherText, err := rsa.EncryptPKCS1v15(rand.Reader, pubKey, []byte(value))
 	if err != nil {
-		return "", errors.New("failed to encrypt token: " + err.Error())
+		return "", errors.New("failed to encrypt value: " + err.Error())
 	}
 
 	return base64.StdEncoding.EncodeToString(cipherText), nil
 }
 
-func DecryptBotToken(encryptedToken string, botID uuid.UUID) (string, error) {
-	if encryptedToken == "" {
+func decryptRSA(encryptedValue string) (string, error) {
+	if encryptedValue == "" {
 		return "", nil
 	}
 
@@ -136,14 +154,14 @@ func DecryptBotToken(encryptedToken string, botID uuid.UUID) (string, error) {
 		return "", errors.New("invalid RSA private key")
 	}
 
-	cipherBytes, err := base64.StdEncoding.DecodeString(encryptedToken)
+	cipherBytes, err := base64.StdEncoding.DecodeString(encryptedValue)
 	if err != nil {
 		return "", errors.New("failed to decode base64 string: " + err.Error())
 	}
 
 	plainText, err := rsa.DecryptPKCS1v15(rand.Reader, privKey, cipherBytes)
 	if err != nil {
-		return "", errors.New("failed to decrypt token: " + err.Error())
+		return "", errors.New("failed to decrypt value: " + err.Error())
 	}
 
 	return string(plainText), nil
@@ -1,6 +1,11 @@
 package welcomer
 
-import "errors"
+import (
+	"errors"
+	"net/http"
+
+	"github.com/WelcomerTeam/Discord/discord"
+)
 
 var (
 	ErrInvalidColour = errors.New("colour format is not recognised")
@@ -12,5 +17,15 @@ var (
 
 	ErrInvalidURL = errors.New("invalid url")
 
-	ErrInvalidTempChannel = errors.New("channel is not a temporary channel")
+	ErrInvalidTempChannel       = errors.New("channel is not a temporary channel")
+	ErrTempChannelOwnerIsBot    = errors.New("bots cannot own a temporary channel")
+	ErrTempChannelOwnerHasOther = errors.New("user already owns a temporary channel")
 )
+
+// IsDiscordNotFound returns true if the error is a discord response for an
+// unknown resource, such as a member that has left the guild.
+func IsDiscordNotFound(err error) bool {
+	var restError *discord.RestError
+
+	return errors.As(err, &restError) && restError.Response != nil && restError.Response.StatusCode == http.StatusNotFound
+}
@@ -18,7 +18,17 @@ const (
 
 	CustomEventInvokeReactionRoles = "WELCOMER_INVOKE_REACTION_ROLES"
 
-	CustomEventInvokeEndGiveaway = "WELCOMER_INVOKE_END_GIVEAWAY"
+	CustomEventInvokeStartGiveaway  = "WELCOMER_INVOKE_START_GIVEAWAY"
+	CustomEventInvokeEndGiveaway    = "WELCOMER_INVOKE_END_GIVEAWAY"
+	CustomEventInvokeRerollGiveaway = "WELCOMER_INVOKE_REROLL_GIVEAWAY"
+
+	CustomEventInvokeExpireGiveawayRoles = "WELCOMER_INVOKE_EXPIRE_GIVEAWAY_ROLES"
+
+	CustomEventInvokeExpireTempRoles = "WELCOMER_INVOKE_EXPIRE_TEMP_ROLES"
+
+	CustomEventInvokeTimeRoles = "WELCOMER_INVOKE_TIME_ROLES"
+
+	CustomEventInvokeActivityRoles = "WELCOMER_INVOKE_ACTIVITY_ROLES"
 )
 
 type OnInvokeWelcomerFuncType func(eventCtx *sandwich.EventContext, event CustomEventInvokeWelcomerStructure) error
@@ -72,6 +82,23 @@ type CustomEventInvokeReactionRolesStructure struct {
 	ReactionRoleUUID uuid.UUID
 	RoleID           discord.Snowflake
 	Assign           *bool
+
+	// Reaction is set when invoked by a message reaction, so it can be
+	// removed again when the role is not given.
+	Reaction *ReactionRoleReaction
+}
+
+type ReactionRoleReaction struct {
+	ChannelID discord.Snowflake
+	MessageID discord.Snowflake
+	Emoji     string
+}
+
+type OnInvokeStartGiveawayFuncType func(eventCtx *sandwich.EventContext, event CustomEventInvokeStartGiveawayStructure) error
+
+type CustomEventInvokeStartGiveawayStructure struct {
+	GiveawayUUID uuid.UUID
+	GuildID      discord.Snowflake
 }
 
 type OnInvokeEndGiveawayFuncType func(eventCtx *sandwich.EventContext, event CustomEventInvokeEndGiveawayStructure) error
@@ -80,3 +107,40 @@ type CustomEventInvokeEndGiveawayStructure struct {
 	GiveawayUUID uuid.UUID
 	GuildID      discord.Snowflake
 }
+
+type OnInvokeRerollGiveawayFuncType func(eventCtx *sandwich.EventContext, event CustomEventInvokeRerollGiveawayStructure) error
+
+type CustomEventInvokeRerollGiveawayStructure struct {
+	GiveawayUUID uuid.UUID
+	GuildID      discord.Snowflake
+
+	// GiveawayWinnerUUID is a winner to reroll. If empty, only winners
+	// who did not claim their prize in time are rerolled.
+	GiveawayWinnerUUID uuid.UUID
+}
+
+type OnInvokeExpireGiveawayRolesFuncType func(eventCtx *sandwich.EventContext, event CustomEventInvokeExpireGiveawayRolesStructure) error
+
+type CustomEventInvokeExpireGiveawayRolesStructure struct {
+	GiveawayUUID uuid.UUID
+	GuildID      discord.Snowflake
+}
+
+type OnInvokeExpireTempRolesFuncType func(eventCtx *sandwich.EventContext, event CustomEventInvokeExpireTempRolesStructure) error
+
+type CustomEventInvokeExpireTempRolesStructure struct {
+	GuildID discord.Snowflake
+}
+
+type OnInvokeTimeRolesFuncType func(eventCtx *sandwich.EventContext, event CustomEventInvokeTimeRolesStructure) error
+
+type CustomEventInvokeTimeRolesStructure struct {
+	GuildID discord.Snowflake
+}
+
+type OnInvokeActivityRolesFuncType func(eventCtx *sandwich.EventContext, event CustomEventInvokeActivityRolesStructure) error
+
+type CustomEventInvokeActivityRolesStructure struct {
+	GuildID discord.Snowflake
+	UserIDs []discord.Snowflake
+}
@@ -2,8 +2,10 @@ package welcomer
 
 import (
 	"encoding/json"
+	"fmt"
 
 	"github.com/WelcomerTeam/Discord/discord"
+	"github.com/WelcomerTeam/Welcomer/welcomer-core/database"
 )
 
 func UnmarshalRolesListJSON(rolesJSON []byte) (roles []discord.Snowflake) {
@@ -18,9 +20,32 @@ func MarshalRolesListJSON(roles []discord.Snowflake) (rolesJSON []byte) {
 	return
 }
 
+// GiveawayPrizeType is how a prize is delivered to a winner.
+type GiveawayPrizeType string
+
+const (
+	// GiveawayPrizeTypeManual prizes are delivered by the giveaway host.
+	GiveawayPrizeTypeManual GiveawayPrizeType = ""
+	// GiveawayPrizeTypeRole prizes assign a role, optionally for a limited time.
+	GiveawayPrizeTypeRole GiveawayPrizeType = "role"
+	// GiveawayPrizeTypeCode prizes DM the winner a code from the giveaway's code pool.
+	GiveawayPrizeTypeCode GiveawayPrizeType = "code"
+	// GiveawayPrizeTypeMembership prizes grant the winner a Welcomer Pro membership.
+	GiveawayPrizeTypeMembership GiveawayPrizeType = "membership"
+)
+
 type GiveawayPrize struct {
 	Title string `json:"title"`
 	Count int    `json:"count"`
+
+	Type GiveawayPrizeType `json:"type,omitempty"`
+
+	// RoleID is the role assigned for role prizes.
+	RoleID discord.Snowflake `json:"role_id,omitempty"`
+
+	// Duration is how long a role or membership prize lasts, in seconds.
+	// Role prizes are permanent when this is 0.
+	Duration int64 `json:"duration,omitempty"`
 }
 
 func UnmarshalGiveawayPrizeJSON(prizeJSON []byte) (prize []GiveawayPrize) {
@@ -41,3 +66,112 @@ func MarshalGiveawayPrizeJSON(prize []GiveawayPrize) (prizeJSON []byte) {
 
 	return
 }
+
+// GetGiveawayPrizesAsString returns the list of prizes formatted for display.
+func GetGiveawayPrizesAsString(giveawayPrizes []GiveawayPrize) string {
+	result := "**Prizes:**\n"
+
+	if len(giveawayPrizes) == 0 {
+		result += "No Prizes Configured"
+
+		return result
+	}
+
+	for i, prize := range giveawayPrizes {
+		result += fmt.Sprintf("**%d** x **%s**", prize.Count, prize.Title)
+
+		if i < len(giveawayPrizes)-1 {
+			result += "\n"
+		}
+	}
+
+	return result
+}
+
+// GetGiveawayMessage returns the public giveaway message shown to users.
+func GetGiveawayMessage(giveaway *database.GuildGiveaways, entries int32) discord.WebhookMessageParams {
+	giveawayPrizes := UnmarshalGiveawayPrizeJSON(giveaway.GiveawayPrizes.Bytes)
+
+	containerComponents := []discord.InteractionComponent{
+		{
+			Type:    discord.InteractionComponentTypeTextDisplay,
+			Content: "**" + Coalesce(giveaway.Title, "New Giveaway") + "**\n" + giveaway.Description,
+		},
+	}
+
+	if giveaway.ImageUrl != "" {
+		containerComponents = append(containerComponents, discord.InteractionComponent{
+			Type: discord.InteractionComponentTypeMediaGallery,
+			Items: []discord.InteractionComponentMediaGalleryItem{
+				{
+					Media: discord.MediaItem{
+						URL: giveaway.ImageUrl,
+					},
+				},
+			},
+		})
+	}
+
+	if giveaway.ShowPrizes {
+		containerComponents = append(containerComponents, []discord.InteractionComponent{
+			{
+				Type: discord.InteractionComponentTypeSeparator,
+			},
+			{
+				Type:    discord.InteractionComponentTypeTextDisplay,
+				Content: GetGiveawayPrizesAsString(giveawayPrizes),
+			},
+		}...)
+	}
+
+	containerComponents = append(containerComponents, []discord.InteractionComponent{
+		{
+			Type: discord.InteractionComponentTypeSeparator,
+		},
+		{
+			Type: discord.InteractionComponentTypeTextDisplay,
+			Content: "**Giveaway Ends:** " + If(giveaway.EndTime.Unix() > 0, "<t:"+Itoa(giveaway.EndTime.Unix())+":R> (<t:"+Itoa(giveaway.EndTime.Unix())+":f>)", "No end time (runs indefinitely)") +
+				"\n" + If(giveaway.ShowEntries, fmt.Sprintf("**Entries:** %d", entries), ""),
+		},
+	}...)
+
+	if giveaway.ProvablyFair && giveaway.SeedCommitment != "" {
+		containerComponents = append(containerComponents, discord.InteractionComponent{
+			Type: discord.InteractionComponentTypeTextDisplay,
+			Content: "-# Provably fair. Seed commitment: `" + giveaway.SeedCommitment + "`\n" +
+				"-# Verify with `/giveaways verify " + giveaway.GiveawayUuid.String() + "` once the giveaway has ended.",
+		})
+	}
+
+	message := discord.WebhookMessageParams{
+		Components: []discord.InteractionComponent{
+			{
+				Type:    discord.InteractionComponentTypeTextDisplay,
+				Content: fmt.Sprintf("-# <@%d> has started a new giveaway!", giveaway.CreatedBy),
+			},
+			{
+				Type:        discord.InteractionComponentTypeContainer,
+				AccentColor: new(uint32(If(giveaway.AccentColour >= 0, giveaway.AccentColour, EmbedColourInfo))),
+				Components:  containerComponents,
+			},
+			{
+				Type: discord.InteractionComponentTypeActionRow,
+				Components: []discord.InteractionComponent{
+					{
+						Type:     discord.InteractionComponentTypeButton,
+						Style:    discord.InteractionComponentStyleSuccess,
+						CustomID: "giveaway_enter:" + giveaway.GiveawayUuid.String(),
+						Label:    "Enter Giveaway",
+						Disabled: !giveaway.AllowEntries && !giveaway.IsSetup,
+						Emoji: &discord.Emoji{
+							Name: "🎉",
+						},
+					},
+				},
+			},
+		},
+		Flags: discord.MessageFlagIsComponentsV2,
+	}
+
+	return message
+}
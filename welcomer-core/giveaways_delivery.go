@@ -0,0 +1,230 @@
+package welcomer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/WelcomerTeam/Discord/discord"
+	"github.com/WelcomerTeam/Welcomer/welcomer-core/database"
+	"github.com/gofrs/uuid"
+	"github.com/jackc/pgx/v4"
+)
+
+// DefaultGiveawayMembershipDuration is how long membership prizes last when no duration is set.
+const DefaultGiveawayMembershipDuration = time.Hour * 24 * 30
+
+var (
+	ErrGiveawayPrizeMissingRole    = errors.New("prize does not have a role configured")
+	ErrGiveawayPrizeCodesExhausted = errors.New("no codes are left in the prize code pool")
+)
+
+// GetGiveawayPrize returns the prize with the given title.
+func GetGiveawayPrize(prizes []GiveawayPrize, title string) (GiveawayPrize, bool) {
+	for _, prize := range prizes {
+		if prize.Title == title {
+			return prize, true
+		}
+	}
+
+	return GiveawayPrize{}, false
+}
+
+// GetGiveawayPrizeCodePool returns the giveaway codes are drawn from. Recurring
+// giveaways share the pool of the giveaway they were first created from.
+func GetGiveawayPrizeCodePool(giveaway *database.GuildGiveaways) uuid.UUID {
+	if giveaway.PrizeCodePoolUuid.IsNil() {
+		return giveaway.GiveawayUuid
+	}
+
+	return giveaway.PrizeCodePoolUuid
+}
+
+// DeliverGiveawayPrize delivers the prize for a winner and records the
+// outcome on the winner, so failures can be shown to the giveaway host.
+// Manual prizes and prizes which have already been delivered are skipped.
+func DeliverGiveawayPrize(ctx context.Context, session *discord.Session, giveaway *database.GuildGiveaways, winner *database.GuildGiveawaysWinners) (*database.GuildGiveawaysWinners, error) {
+	if winner.IsDelivered {
+		return winner, nil
+	}
+
+	prize, ok := GetGiveawayPrize(UnmarshalGiveawayPrizeJSON(giveaway.GiveawayPrizes.Bytes), winner.Prize)
+	if !ok || prize.Type == GiveawayPrizeTypeManual {
+		return winner, nil
+	}
+
+	roleID := winner.RoleID
+	roleExpiresAt := winner.RoleExpiresAt
+
+	var deliveryErr error
+
+	switch prize.Type {
+	case GiveawayPrizeTypeRole:
+		roleID, roleExpiresAt, deliveryErr = deliverGiveawayRolePrize(ctx, session, giveaway, winner, prize)
+	case GiveawayPrizeTypeCode:
+		deliveryErr = deliverGiveawayCodePrize(ctx, session, giveaway, winner, prize)
+	case GiveawayPrizeTypeMembership:
+		deliveryErr = deliverGiveawayMembershipPrize(ctx, winner, prize)
+	}
+
+	if deliveryErr != nil {
+		Logger.Warn().Err(deliveryErr).
+			Str("giveaway_uuid", giveaway.GiveawayUuid.String()).
+			Int64("user_id", winner.UserID).
+			Str("prize", winner.Prize).
+			Msg("Failed to deliver giveaway prize")
+	}
+
+	updatedWinner, err := Queries.SetGiveawayWinnerDelivery(ctx, database.SetGiveawayWinnerDeliveryParams{
+		GiveawayWinnerUuid: winner.GiveawayWinnerUuid,
+		IsDelivered:        deliveryErr == nil,
+		DeliveryError:      If(deliveryErr != nil, fmt.Sprint(deliveryErr), ""),
+		RoleID:             roleID,
+		RoleExpiresAt:      roleExpiresAt,
+	})
+	if err != nil {
+		Logger.Error().Err(err).
+			Str("giveaway_uuid", giveaway.GiveawayUuid.String()).
+			Int64("user_id", winner.UserID).
+			Msg("Failed to update giveaway winner delivery")
+
+		return winner, err
+	}
+
+	return updatedWinner, deliveryErr
+}
+
+func deliverGiveawayRolePrize(ctx context.Context, session *discord.Session, giveaway *database.GuildGiveaways, winner *database.GuildGiveawaysWinners, prize GiveawayPrize) (int64, time.Time, error) {
+	if prize.RoleID == 0 {
+		return 0, time.Unix(0, 0), ErrGiveawayPrizeMissingRole
+	}
+
+	guildID := discord.Snowflake(giveaway.GuildID)
+	member := discord.GuildMember{GuildID: &guildID, User: &discord.User{ID: discord.Snowflake(winner.UserID)}}
+
+	err := member.AddRoles(ctx, session, []discord.Snowflake{prize.RoleID}, new("Giveaway prize: "+winner.Prize), true)
+	if err != nil {
+		return 0, time.Unix(0, 0), fmt.Errorf("failed to assign role: %w", err)
+	}
+
+	roleExpiresAt := time.Unix(0, 0)
+
+	if prize.Duration > 0 {
+		roleExpiresAt = time.Now().Add(time.Duration(prize.Duration) * time.Second)
+	}
+
+	return int64(prize.RoleID), roleExpiresAt, nil
+}
+
+func deliverGiveawayCodePrize(ctx context.Context, session *discord.Session, giveaway *database.GuildGiveaways, winner *database.GuildGiveawaysWinners, prize GiveawayPrize) error {
+	// Reuse a code that was already assigned to the winner if a previous delivery failed to send.
+	prizeCode, err := Queries.GetAssignedGiveawayPrizeCode(ctx, database.GetAssignedGiveawayPrizeCodeParams{
+		GiveawayUuid: giveaway.GiveawayUuid,
+		Prize:        prize.Title,
+		UserID:       winner.UserID,
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		prizeCode, err = Queries.AssignGiveawayPrizeCode(ctx, database.AssignGiveawayPrizeCodeParams{
+			GiveawayUuid:      giveaway.GiveawayUuid,
+			UserID:            winner.UserID,
+			PrizeCodePoolUuid: GetGiveawayPrizeCodePool(giveaway),
+			Prize:             prize.Title,
+		})
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ErrGiveawayPrizeCodesExhausted
+		}
+	}
+
+	if err != nil {
+		return fmt.Errorf("failed to assign code: %w", err)
+	}
+
+	code, err := DecryptGiveawayPrizeCode(prizeCode.Code)
+	if err != nil {
+		return fmt.Errorf("failed to decrypt code: %w", err)
+	}
+
+	user := discord.User{ID: discord.Snowflake(winner.UserID)}
+
+	_, err = user.Send(ctx, session, discord.MessageParams{
+		Content: fmt.Sprintf("### You won **%s** in %s!\nHere is your code:", winner.Prize, Coalesce(giveaway.Title, "a giveaway")),
+		Embeds: []discord.Embed{
+			{
+				Description: "```\n" + code + "\n```",
+				Color:       EmbedColourSuccess,
+			},
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to send code in direct messages: %w", err)
+	}
+
+	return nil
+}
+
+func deliverGiveawayMembershipPrize(ctx context.Context, winner *database.GuildGiveawaysWinners, prize GiveawayPrize) error {
+	duration := DefaultGiveawayMembershipDuration
+
+	if prize.Duration > 0 {
+		duration = time.Duration(prize.Duration) * time.Second
+	}
+
+	transaction, err := CreateTransactionForUser(ctx, discord.Snowflake(winner.UserID), database.PlatformTypeGiveaway, database.TransactionStatusCompleted, winner.GiveawayWinnerUuid.String(), "", "")
+	if err != nil {
+		return fmt.Errorf("failed to create transaction: %w", err)
+	}
+
+	err = CreateMembershipForUser(ctx, discord.Snowflake(winner.UserID), transaction.TransactionUuid, database.MembershipTypeWelcomerPro, time.Now().Add(duration), nil)
+	if err != nil {
+		return fmt.Errorf("failed to create membership: %w", err)
+	}
+
+	return nil
+}
+
+// RemoveExpiredGiveawayPrizeRole removes a timed or rerolled prize role from a winner.
+func RemoveExpiredGiveawayPrizeRole(ctx context.Context, session *discord.Session, giveaway *database.GuildGiveaways, winner *database.GuildGiveawaysWinners) error {
+	guildID := discord.Snowflake(giveaway.GuildID)
+	member := discord.GuildMember{GuildID: &guildID, User: &discord.User{ID: discord.Snowflake(winner.UserID)}}
+
+	err := member.RemoveRoles(ctx, session, []discord.Snowflake{discord.Snowflake(winner.RoleID)}, new("Giveaway prize expired: "+winner.Prize), true)
+
+	// A winner who has left the server no longer has the role. Any other
+	// failure leaves the winner as is, so removal is tried again later.
+	if err != nil && !IsDiscordNotFound(err) {
+		return fmt.Errorf("failed to remove role: %w", err)
+	}
+
+	_, err = Queries.SetGiveawayWinnerRoleRemoved(ctx, winner.GiveawayWinnerUuid)
+
+	return err
+}
+
+// GetGiveawayPrizeDeliveryAsString returns a short description of how a prize is delivered.
+func GetGiveawayPrizeDeliveryAsString(prize GiveawayPrize) string {
+	switch prize.Type {
+	case GiveawayPrizeTypeRole:
+		if prize.RoleID == 0 {
+			return "Role (no role selected)"
+		}
+
+		if prize.Duration > 0 {
+			return fmt.Sprintf("Role <@&%d> for %s", prize.RoleID, HumanizeDuration(int(prize.Duration), false))
+		}
+
+		return fmt.Sprintf("Role <@&%d>", prize.RoleID)
+	case GiveawayPrizeTypeCode:
+		return "Code sent in direct messages"
+	case GiveawayPrizeTypeMembership:
+		duration := int(DefaultGiveawayMembershipDuration.Seconds())
+
+		if prize.Duration > 0 {
+			duration = int(prize.Duration)
+		}
+
+		return "Welcomer Pro for " + HumanizeDuration(duration, false)
+	default:
+		return "Manual"
+	}
+}
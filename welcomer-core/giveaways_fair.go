@@ -0,0 +1,270 @@
+package welcomer
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+
+	"github.com/WelcomerTeam/Discord/discord"
+	"github.com/WelcomerTeam/Welcomer/welcomer-core/database"
+	"github.com/gofrs/uuid"
+	"github.com/jackc/pgtype"
+)
+
+// giveawaySeedLength is the number of random bytes in a server seed.
+const giveawaySeedLength = 32
+
+var ErrInvalidGiveawaySeed = errors.New("invalid giveaway seed")
+
+// GiveawayDrawWinner is a winner selected by a provably fair draw.
+type GiveawayDrawWinner struct {
+	UserID discord.Snowflake `json:"user_id"`
+	Prize  string            `json:"prize"`
+}
+
+// GiveawayDrawVerification is the result of recomputing a provably fair draw.
+// The server seed, entries and winners are only included once the draw has been revealed.
+type GiveawayDrawVerification struct {
+	GiveawayUUID    uuid.UUID            `json:"giveaway_uuid"`
+	SeedCommitment  string               `json:"seed_commitment"`
+	IsRevealed      bool                 `json:"is_revealed"`
+	ServerSeed      string               `json:"server_seed,omitempty"`
+	Entries         []discord.Snowflake  `json:"entries,omitempty"`
+	Prizes          []GiveawayPrize      `json:"prizes,omitempty"`
+	Winners         []GiveawayDrawWinner `json:"winners,omitempty"`
+	ExpectedWinners []GiveawayDrawWinner `json:"expected_winners,omitempty"`
+	CommitmentValid bool                 `json:"commitment_valid"`
+	WinnersValid    bool                 `json:"winners_valid"`
+}
+
+// GenerateGiveawaySeed returns a new hex encoded server seed and its commitment.
+func GenerateGiveawaySeed() (seed, commitment string, err error) {
+	seedBytes := make([]byte, giveawaySeedLength)
+
+	if _, err = rand.Read(seedBytes); err != nil {
+		return "", "", err
+	}
+
+	seed = hex.EncodeToString(seedBytes)
+
+	commitment, err = GetGiveawaySeedCommitment(seed)
+
+	return seed, commitment, err
+}
+
+// GetGiveawaySeedCommitment returns the hex encoded SHA-256 hash of a hex encoded server seed.
+func GetGiveawaySeedCommitment(seed string) (string, error) {
+	seedBytes, err := hex.DecodeString(seed)
+	if err != nil || len(seedBytes) == 0 {
+		return "", ErrInvalidGiveawaySeed
+	}
+
+	hash := sha256.Sum256(seedBytes)
+
+	return hex.EncodeToString(hash[:]), nil
+}
+
+// DrawGiveawayWinners deterministically selects winners from the server seed
+// and entries. Entries are sorted before drawing so the order they were
+// stored in does not matter. Prizes are drawn in order, and each winner can
+// only win once.
+func DrawGiveawayWinners(seed string, entries []discord.Snowflake, prizes []GiveawayPrize) ([]GiveawayDrawWinner, error) {
+	key, err := hex.DecodeString(seed)
+	if err != nil || len(key) == 0 {
+		return nil, ErrInvalidGiveawaySeed
+	}
+
+	remaining := slices.Clone(entries)
+	slices.Sort(remaining)
+	remaining = slices.Compact(remaining)
+
+	winners := make([]GiveawayDrawWinner, 0)
+	round := 0
+
+	for _, prize := range prizes {
+		for range prize.Count {
+			if len(remaining) == 0 {
+				return winners, nil
+			}
+
+			index := giveawayDrawIndex(key, round, len(remaining))
+
+			winners = append(winners, GiveawayDrawWinner{
+				UserID: remaining[index],
+				Prize:  prize.Title,
+			})
+
+			remaining = slices.Delete(remaining, index, index+1)
+			round++
+		}
+	}
+
+	return winners, nil
+}
+
+// giveawayDrawIndex returns an index in [0, n) for a draw round. Values are
+// taken from HMAC-SHA256(seed, "round:nonce") and values that would cause
+// modulo bias are rejected by incrementing the nonce.
+func giveawayDrawIndex(key []byte, round, n int) int {
+	limit := math.MaxUint64 - math.MaxUint64%uint64(n)
+
+	for nonce := 0; ; nonce++ {
+		mac := hmac.New(sha256.New, key)
+		_, _ = fmt.Fprintf(mac, "%d:%d", round, nonce)
+
+		value := binary.BigEndian.Uint64(mac.Sum(nil))
+		if value < limit {
+			return int(value % uint64(n))
+		}
+	}
+}
+
+// PrepareProvablyFairGiveaway generates the server seed for a provably fair
+// giveaway and publishes its commitment on the giveaway. Does nothing if the
+// giveaway is not provably fair or already has a commitment.
+func PrepareProvablyFairGiveaway(ctx context.Context, giveaway *database.GuildGiveaways) error {
+	if !giveaway.ProvablyFair || giveaway.SeedCommitment != "" {
+		return nil
+	}
+
+	seed, commitment, err := GenerateGiveawaySeed()
+	if err != nil {
+		return fmt.Errorf("failed to generate giveaway seed: %w", err)
+	}
+
+	// If a draw already exists, the existing seed is returned so the commitment never changes.
+	draw, err := Queries.CreateGiveawayDraw(ctx, database.CreateGiveawayDrawParams{
+		GiveawayUuid:   giveaway.GiveawayUuid,
+		ServerSeed:     seed,
+		SeedCommitment: commitment,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create giveaway draw: %w", err)
+	}
+
+	if _, err = Queries.SetGiveawaySeedCommitment(ctx, database.SetGiveawaySeedCommitmentParams{
+		GiveawayUuid:   giveaway.GiveawayUuid,
+		SeedCommitment: draw.SeedCommitment,
+	}); err != nil {
+		return fmt.Errorf("failed to set giveaway seed commitment: %w", err)
+	}
+
+	giveaway.SeedCommitment = draw.SeedCommitment
+
+	return nil
+}
+
+// DrawProvablyFairGiveaway selects the winners of a provably fair giveaway
+// using its stored server seed and reveals the draw with the entries and
+// prizes used. Prizes with no remaining winners are left out of the snapshot.
+// Returns the revealed draw and the winners selected.
+func DrawProvablyFairGiveaway(ctx context.Context, giveawayUUID uuid.UUID, entries []int64, prizes []GiveawayPrize) (*database.GuildGiveawaysDraws, []GiveawayDrawWinner, error) {
+	draw, err := Queries.GetGiveawayDraw(ctx, giveawayUUID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get giveaway draw: %w", err)
+	}
+
+	drawEntries := make([]discord.Snowflake, 0, len(entries))
+
+	for _, entry := range entries {
+		drawEntries = append(drawEntries, discord.Snowflake(entry))
+	}
+
+	slices.Sort(drawEntries)
+	drawEntries = slices.Compact(drawEntries)
+
+	drawPrizes := slices.DeleteFunc(slices.Clone(prizes), func(prize GiveawayPrize) bool {
+		return prize.Count <= 0
+	})
+
+	winners, err := DrawGiveawayWinners(draw.ServerSeed, drawEntries, drawPrizes)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	entriesJSON, _ := json.Marshal(drawEntries)
+
+	draw, err = Queries.RevealGiveawayDraw(ctx, database.RevealGiveawayDrawParams{
+		GiveawayUuid: giveawayUUID,
+		Entries:      pgtype.JSONB{Bytes: entriesJSON, Status: pgtype.Present},
+		Prizes:       pgtype.JSONB{Bytes: MarshalGiveawayPrizeJSON(drawPrizes), Status: pgtype.Present},
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to reveal giveaway draw: %w", err)
+	}
+
+	return draw, winners, nil
+}
+
+// VerifyGiveawayDraw recomputes the winners of a revealed draw and checks
+// them against the seed commitment and the stored winners.
+func VerifyGiveawayDraw(draw *database.GuildGiveawaysDraws, winners []*database.GuildGiveawaysWinners) (*GiveawayDrawVerification, error) {
+	verification := &GiveawayDrawVerification{
+		GiveawayUUID:   draw.GiveawayUuid,
+		SeedCommitment: draw.SeedCommitment,
+		IsRevealed:     draw.IsRevealed,
+	}
+
+	if !draw.IsRevealed {
+		return verification, nil
+	}
+
+	verification.ServerSeed = draw.ServerSeed
+
+	if err := json.Unmarshal(draw.Entries.Bytes, &verification.Entries); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal draw entries: %w", err)
+	}
+
+	if err := json.Unmarshal(draw.Prizes.Bytes, &verification.Prizes); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal draw prizes: %w", err)
+	}
+
+	commitment, err := GetGiveawaySeedCommitment(draw.ServerSeed)
+	if err != nil {
+		return nil, err
+	}
+
+	verification.CommitmentValid = commitment == draw.SeedCommitment
+
+	verification.ExpectedWinners, err = DrawGiveawayWinners(draw.ServerSeed, verification.Entries, verification.Prizes)
+	if err != nil {
+		return nil, err
+	}
+
+	verification.Winners = make([]GiveawayDrawWinner, 0, len(winners))
+
+	for _, winner := range winners {
+		verification.Winners = append(verification.Winners, GiveawayDrawWinner{
+			UserID: discord.Snowflake(winner.UserID),
+			Prize:  winner.Prize,
+		})
+	}
+
+	verification.WinnersValid = containsGiveawayDrawWinners(verification.Winners, verification.ExpectedWinners)
+
+	return verification, nil
+}
+
+// containsGiveawayDrawWinners returns true if every expected winner is present in winners.
+func containsGiveawayDrawWinners(winners, expected []GiveawayDrawWinner) bool {
+	remaining := slices.Clone(winners)
+
+	for _, expectedWinner := range expected {
+		index := slices.Index(remaining, expectedWinner)
+		if index == -1 {
+			return false
+		}
+
+		remaining = slices.Delete(remaining, index, index+1)
+	}
+
+	return true
+}
@@ -0,0 +1,119 @@
+package welcomer
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/WelcomerTeam/Discord/discord"
+)
+
+const testGiveawaySeed = "000102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f"
+
+func TestGetGiveawaySeedCommitment(t *testing.T) {
+	commitment, err := GetGiveawaySeedCommitment(testGiveawaySeed)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := "630dcd2966c4336691125448bbb25b4ff412a49c732db2c8abc1b8581bd710dd"
+	if commitment != expected {
+		t.Errorf("expected: %s, got: %s", expected, commitment)
+	}
+
+	if _, err := GetGiveawaySeedCommitment("not hex"); err == nil {
+		t.Error("expected error for invalid seed")
+	}
+}
+
+func TestGenerateGiveawaySeed(t *testing.T) {
+	seed, commitment, err := GenerateGiveawaySeed()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(seed) != giveawaySeedLength*2 {
+		t.Errorf("expected seed length: %d, got: %d", giveawaySeedLength*2, len(seed))
+	}
+
+	expected, _ := GetGiveawaySeedCommitment(seed)
+	if commitment != expected {
+		t.Errorf("expected commitment: %s, got: %s", expected, commitment)
+	}
+}
+
+func TestDrawGiveawayWinners(t *testing.T) {
+	entries := []discord.Snowflake{5, 3, 9, 1, 7, 3}
+	prizes := []GiveawayPrize{
+		{Title: "Nitro", Count: 2},
+		{Title: "Welcomer Pro", Count: 1},
+	}
+
+	winners, err := DrawGiveawayWinners(testGiveawaySeed, entries, prizes)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(winners) != 3 {
+		t.Fatalf("expected 3 winners, got: %d", len(winners))
+	}
+
+	if winners[0].Prize != "Nitro" || winners[1].Prize != "Nitro" || winners[2].Prize != "Welcomer Pro" {
+		t.Errorf("prizes were not drawn in order: %v", winners)
+	}
+
+	seen := make(map[discord.Snowflake]bool)
+
+	for _, winner := range winners {
+		if !slices.Contains(entries, winner.UserID) {
+			t.Errorf("winner %d is not an entry", winner.UserID)
+		}
+
+		if seen[winner.UserID] {
+			t.Errorf("winner %d was drawn more than once", winner.UserID)
+		}
+
+		seen[winner.UserID] = true
+	}
+
+	// The order entries are provided in must not change the result.
+	shuffled := []discord.Snowflake{9, 7, 5, 3, 1}
+
+	shuffledWinners, err := DrawGiveawayWinners(testGiveawaySeed, shuffled, prizes)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !slices.Equal(winners, shuffledWinners) {
+		t.Errorf("expected: %v, got: %v", winners, shuffledWinners)
+	}
+
+	// Not enough entries for every prize.
+	winners, err = DrawGiveawayWinners(testGiveawaySeed, []discord.Snowflake{1, 2}, prizes)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(winners) != 2 {
+		t.Errorf("expected 2 winners, got: %d", len(winners))
+	}
+
+	if _, err := DrawGiveawayWinners("", entries, prizes); err == nil {
+		t.Error("expected error for empty seed")
+	}
+}
+
+func TestContainsGiveawayDrawWinners(t *testing.T) {
+	winners := []GiveawayDrawWinner{{UserID: 1, Prize: "A"}, {UserID: 2, Prize: "A"}, {UserID: 3, Prize: "B"}}
+
+	if !containsGiveawayDrawWinners(winners, []GiveawayDrawWinner{{UserID: 3, Prize: "B"}, {UserID: 1, Prize: "A"}}) {
+		t.Error("expected winners to be contained")
+	}
+
+	if containsGiveawayDrawWinners(winners, []GiveawayDrawWinner{{UserID: 3, Prize: "A"}}) {
+		t.Error("expected winners to not be contained")
+	}
+
+	if containsGiveawayDrawWinners(winners, []GiveawayDrawWinner{{UserID: 1, Prize: "A"}, {UserID: 1, Prize: "A"}}) {
+		t.Error("expected duplicate winners to not be contained")
+	}
+}
@@ -0,0 +1,267 @@
+package welcomer
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	GiveawayRecurrenceDaily   = "daily"
+	GiveawayRecurrenceWeekly  = "weekly"
+	GiveawayRecurrenceMonthly = "monthly"
+
+	// maxCronSearchYears limits how far ahead a cron expression is searched
+	// before it is considered to never match, such as "0 0 31 2 *".
+	maxCronSearchYears = 5
+)
+
+var ErrInvalidRecurrenceRule = errors.New("invalid recurrence rule")
+
+// GiveawayRecurrence describes when a recurring giveaway should next start.
+// A recurrence is either a fixed interval (daily, weekly, monthly) or a
+// cron-like expression of the form "minute hour day-of-month month day-of-week".
+// Cron expressions are evaluated in UTC.
+type GiveawayRecurrence struct {
+	months, days int
+
+	cron *cronSchedule
+}
+
+type cronSchedule struct {
+	minute, hour, dayOfMonth, month, dayOfWeek uint64
+
+	// dayOfMonthStar and dayOfWeekStar track if the field started with "*", such
+	// as "*" or "*/2". When both fields are restricted, a day matches if either
+	// of them match.
+	dayOfMonthStar, dayOfWeekStar bool
+}
+
+// ParseGiveawayRecurrence parses a recurrence rule. An empty rule returns nil.
+func ParseGiveawayRecurrence(rule string) (*GiveawayRecurrence, error) {
+	rule = strings.ToLower(strings.TrimSpace(rule))
+
+	switch rule {
+	case "":
+		return nil, nil
+	case GiveawayRecurrenceDaily:
+		return &GiveawayRecurrence{days: 1}, nil
+	case GiveawayRecurrenceWeekly:
+		return &GiveawayRecurrence{days: 7}, nil
+	case GiveawayRecurrenceMonthly:
+		return &GiveawayRecurrence{months: 1}, nil
+	}
+
+	fields := strings.Fields(rule)
+	if len(fields) != 5 {
+		return nil, fmt.Errorf("%w: expected daily, weekly, monthly or 5 cron fields, got %q", ErrInvalidRecurrenceRule, rule)
+	}
+
+	schedule := &cronSchedule{
+		dayOfMonthStar: strings.HasPrefix(fields[2], "*"),
+		dayOfWeekStar:  strings.HasPrefix(fields[4], "*"),
+	}
+
+	var err error
+
+	if schedule.minute, err = parseCronField(fields[0], 0, 59); err != nil {
+		return nil, err
+	}
+
+	if schedule.hour, err = parseCronField(fields[1], 0, 23); err != nil {
+		return nil, err
+	}
+
+	if schedule.dayOfMonth, err = parseCronField(fields[2], 1, 31); err != nil {
+		return nil, err
+	}
+
+	if schedule.month, err = parseCronField(fields[3], 1, 12); err != nil {
+		return nil, err
+	}
+
+	if schedule.dayOfWeek, err = parseCronField(fields[4], 0, 7); err != nil {
+		return nil, err
+	}
+
+	// Sunday can be written as either 0 or 7.
+	if schedule.dayOfWeek&(1<<7) != 0 {
+		schedule.dayOfWeek |= 1
+	}
+
+	return &GiveawayRecurrence{cron: schedule}, nil
+}
+
+// parseCronField parses a single cron field into a bitmask. Supports "*",
+// single values, ranges "a-b", steps "*/n" or "a-b/n" and comma separated lists.
+func parseCronField(field string, minimum, maximum int) (uint64, error) {
+	var bits uint64
+
+	for part := range strings.SplitSeq(field, ",") {
+		rangePart, stepPart, hasStep := strings.Cut(part, "/")
+
+		step := 1
+
+		if hasStep {
+			var err error
+
+			step, err = strconv.Atoi(stepPart)
+			if err != nil || step <= 0 {
+				return 0, fmt.Errorf("%w: invalid step %q", ErrInvalidRecurrenceRule, part)
+			}
+		}
+
+		start, end := minimum, maximum
+
+		switch {
+		case rangePart == "*":
+		case strings.Contains(rangePart, "-"):
+			startString, endString, _ := strings.Cut(rangePart, "-")
+
+			var err error
+
+			if start, err = strconv.Atoi(startString); err != nil {
+				return 0, fmt.Errorf("%w: invalid range %q", ErrInvalidRecurrenceRule, part)
+			}
+
+			if end, err = strconv.Atoi(endString); err != nil {
+				return 0, fmt.Errorf("%w: invalid range %q", ErrInvalidRecurrenceRule, part)
+			}
+		default:
+			value, err := strconv.Atoi(rangePart)
+			if err != nil {
+				return 0, fmt.Errorf("%w: invalid value %q", ErrInvalidRecurrenceRule, part)
+			}
+
+			start = value
+
+			if !hasStep {
+				end = value
+			}
+		}
+
+		if start < minimum || end > maximum || start > end {
+			return 0, fmt.Errorf("%w: %q is out of range %d-%d", ErrInvalidRecurrenceRule, part, minimum, maximum)
+		}
+
+		for i := start; i <= end; i += step {
+			bits |= 1 << uint(i)
+		}
+	}
+
+	return bits, nil
+}
+
+// Next returns the next start time strictly after the previous start time.
+// Interval rules are counted from the anchor, the start time of the first
+// giveaway, so monthly giveaways started on the 31st stay on the last day of
+// shorter months instead of drifting. Returns the zero time if a cron
+// expression never matches.
+func (r *GiveawayRecurrence) Next(anchor, previous time.Time) time.Time {
+	if r.cron != nil {
+		return r.cron.next(previous)
+	}
+
+	n := 1
+
+	// Estimate how many occurrences have passed, so long running recurrences
+	// do not step through every occurrence since the anchor.
+	if previous.After(anchor) {
+		if r.months > 0 {
+			n = ((previous.Year()-anchor.Year())*12 + int(previous.Month()-anchor.Month())) / r.months
+		} else {
+			n = int(previous.Sub(anchor) / (time.Duration(r.days) * 24 * time.Hour))
+		}
+
+		n = max(n, 1)
+	}
+
+	for n > 1 && r.occurrence(anchor, n-1).After(previous) {
+		n--
+	}
+
+	for !r.occurrence(anchor, n).After(previous) {
+		n++
+	}
+
+	return r.occurrence(anchor, n)
+}
+
+// NextAfter returns the first start time after the previous start time which
+// is not before notBefore. This is used so a giveaway does not overlap with
+// the giveaway it replaces.
+func (r *GiveawayRecurrence) NextAfter(anchor, previous, notBefore time.Time) time.Time {
+	if after := notBefore.Add(-time.Nanosecond); after.After(previous) {
+		previous = after
+	}
+
+	return r.Next(anchor, previous)
+}
+
+// occurrence returns the nth start time of an interval rule after the anchor.
+func (r *GiveawayRecurrence) occurrence(anchor time.Time, n int) time.Time {
+	if r.months > 0 {
+		return addMonthsClamped(anchor, n*r.months)
+	}
+
+	return anchor.AddDate(0, 0, n*r.days)
+}
+
+// addMonthsClamped adds months to t, keeping the day of the month but
+// clamping it to the last day of months which are shorter.
+func addMonthsClamped(t time.Time, months int) time.Time {
+	year, month, day := t.Date()
+
+	firstOfMonth := time.Date(year, month+time.Month(months), 1, t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), t.Location())
+	lastDay := firstOfMonth.AddDate(0, 1, -1).Day()
+
+	return firstOfMonth.AddDate(0, 0, min(day, lastDay)-1)
+}
+
+func (s *cronSchedule) next(after time.Time) time.Time {
+	t := after.UTC().Truncate(time.Minute).Add(time.Minute)
+	limit := t.AddDate(maxCronSearchYears, 0, 0)
+
+	for t.Before(limit) {
+		if s.month&(1<<uint(t.Month())) == 0 {
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, time.UTC)
+
+			continue
+		}
+
+		if !s.matchesDay(t) {
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, time.UTC)
+
+			continue
+		}
+
+		if s.hour&(1<<uint(t.Hour())) == 0 {
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, time.UTC)
+
+			continue
+		}
+
+		if s.minute&(1<<uint(t.Minute())) == 0 {
+			t = t.Add(time.Minute)
+
+			continue
+		}
+
+		return t
+	}
+
+	return time.Time{}
+}
+
+func (s *cronSchedule) matchesDay(t time.Time) bool {
+	dayOfMonthMatch := s.dayOfMonth&(1<<uint(t.Day())) != 0
+	dayOfWeekMatch := s.dayOfWeek&(1<<uint(t.Weekday())) != 0
+
+	if s.dayOfMonthStar || s.dayOfWeekStar {
+		return dayOfMonthMatch && dayOfWeekMatch
+	}
+
+	return dayOfMonthMatch || dayOfWeekMatch
+}
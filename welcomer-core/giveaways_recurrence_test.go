@@ -0,0 +1,152 @@
+package welcomer
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseGiveawayRecurrence(t *testing.T) {
+	tests := []struct {
+		rule          string
+		expectedNil   bool
+		expectedError bool
+	}{
+		{"", true, false},
+		{"daily", false, false},
+		{"Weekly", false, false},
+		{" monthly ", false, false},
+		{"0 12 * * *", false, false},
+		{"*/15 9-17 * * 1-5", false, false},
+		{"0 0 1,15 * *", false, false},
+		{"0 0 * * 7", false, false},
+
+		{"yearly", false, true},
+		{"0 12 * *", false, true},
+		{"60 12 * * *", false, true},
+		{"0 24 * * *", false, true},
+		{"0 0 0 * *", false, true},
+		{"0 0 * 13 *", false, true},
+		{"*/0 * * * *", false, true},
+		{"5-1 * * * *", false, true},
+		{"a * * * *", false, true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.rule, func(t *testing.T) {
+			t.Parallel()
+
+			recurrence, err := ParseGiveawayRecurrence(test.rule)
+			if (err != nil) != test.expectedError {
+				t.Errorf("expected error: %v, got: %v", test.expectedError, err)
+			}
+
+			if !test.expectedError && (recurrence == nil) != test.expectedNil {
+				t.Errorf("expected nil: %v, got: %v", test.expectedNil, recurrence)
+			}
+		})
+	}
+}
+
+func TestGiveawayRecurrenceNext(t *testing.T) {
+	// Wednesday
+	previous := time.Date(2025, time.January, 15, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		rule     string
+		expected time.Time
+	}{
+		{"daily", time.Date(2025, time.January, 16, 12, 0, 0, 0, time.UTC)},
+		{"weekly", time.Date(2025, time.January, 22, 12, 0, 0, 0, time.UTC)},
+		{"monthly", time.Date(2025, time.February, 15, 12, 0, 0, 0, time.UTC)},
+		{"0 12 * * *", time.Date(2025, time.January, 16, 12, 0, 0, 0, time.UTC)},
+		{"30 12 * * *", time.Date(2025, time.January, 15, 12, 30, 0, 0, time.UTC)},
+		{"0 9 * * 1", time.Date(2025, time.January, 20, 9, 0, 0, 0, time.UTC)},
+		{"0 9 * * 7", time.Date(2025, time.January, 19, 9, 0, 0, 0, time.UTC)},
+		{"0 0 1 * *", time.Date(2025, time.February, 1, 0, 0, 0, 0, time.UTC)},
+		{"0 0 1 3 *", time.Date(2025, time.March, 1, 0, 0, 0, 0, time.UTC)},
+		{"0 0 31 2 *", time.Time{}},
+		// When both day fields are restricted, either can match.
+		{"0 0 20 * 5", time.Date(2025, time.January, 17, 0, 0, 0, 0, time.UTC)},
+		// A stepped star is still a star, so both day fields must match.
+		{"0 0 */2 * 1", time.Date(2025, time.January, 27, 0, 0, 0, 0, time.UTC)},
+	}
+
+	for _, test := range tests {
+		t.Run(test.rule, func(t *testing.T) {
+			t.Parallel()
+
+			recurrence, err := ParseGiveawayRecurrence(test.rule)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if next := recurrence.Next(previous, previous); !next.Equal(test.expected) {
+				t.Errorf("expected: %v, got: %v", test.expected, next)
+			}
+		})
+	}
+}
+
+func TestGiveawayRecurrenceNextAfter(t *testing.T) {
+	previous := time.Date(2025, time.January, 15, 12, 0, 0, 0, time.UTC)
+	notBefore := time.Date(2025, time.January, 18, 12, 0, 30, 0, time.UTC)
+
+	tests := []struct {
+		rule     string
+		expected time.Time
+	}{
+		{"daily", time.Date(2025, time.January, 19, 12, 0, 0, 0, time.UTC)},
+		{"weekly", time.Date(2025, time.January, 22, 12, 0, 0, 0, time.UTC)},
+		{"0 12 * * *", time.Date(2025, time.January, 19, 12, 0, 0, 0, time.UTC)},
+		{"*/15 * * * *", time.Date(2025, time.January, 18, 12, 15, 0, 0, time.UTC)},
+	}
+
+	for _, test := range tests {
+		t.Run(test.rule, func(t *testing.T) {
+			t.Parallel()
+
+			recurrence, err := ParseGiveawayRecurrence(test.rule)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if next := recurrence.NextAfter(previous, previous, notBefore); !next.Equal(test.expected) {
+				t.Errorf("expected: %v, got: %v", test.expected, next)
+			}
+		})
+	}
+}
+
+func TestGiveawayRecurrenceNextFromAnchor(t *testing.T) {
+	anchor := time.Date(2025, time.January, 31, 18, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		rule     string
+		previous time.Time
+		expected time.Time
+	}{
+		{"monthly clamps to february", "monthly", anchor, time.Date(2025, time.February, 28, 18, 0, 0, 0, time.UTC)},
+		{"monthly returns to anchor day", "monthly", time.Date(2025, time.February, 28, 18, 0, 0, 0, time.UTC), time.Date(2025, time.March, 31, 18, 0, 0, 0, time.UTC)},
+		{"monthly clamps to april", "monthly", time.Date(2025, time.March, 31, 18, 0, 0, 0, time.UTC), time.Date(2025, time.April, 30, 18, 0, 0, 0, time.UTC)},
+		{"monthly leap year", "monthly", time.Date(2027, time.December, 31, 18, 0, 0, 0, time.UTC), time.Date(2028, time.January, 31, 18, 0, 0, 0, time.UTC)},
+		{"monthly after late start", "monthly", time.Date(2025, time.March, 31, 19, 0, 0, 0, time.UTC), time.Date(2025, time.April, 30, 18, 0, 0, 0, time.UTC)},
+		{"weekly", "weekly", time.Date(2025, time.March, 3, 12, 0, 0, 0, time.UTC), time.Date(2025, time.March, 7, 18, 0, 0, 0, time.UTC)},
+		{"daily on occurrence", "daily", time.Date(2025, time.March, 3, 18, 0, 0, 0, time.UTC), time.Date(2025, time.March, 4, 18, 0, 0, 0, time.UTC)},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			recurrence, err := ParseGiveawayRecurrence(test.rule)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if next := recurrence.Next(anchor, test.previous); !next.Equal(test.expected) {
+				t.Errorf("expected: %v, got: %v", test.expected, next)
+			}
+		})
+	}
+}
@@ -1,7 +1,10 @@
 package welcomer
 
 import (
+	"time"
+
 	"github.com/WelcomerTeam/Discord/discord"
+	"github.com/WelcomerTeam/Welcomer/welcomer-core/database"
 	"github.com/gofrs/uuid"
 )
 
@@ -36,6 +39,10 @@ type GuildScienceTimeRoleGiven struct {
 	RoleID discord.Snowflake `json:"role_id"`
 }
 
+type GuildScienceActivityRoleGivenRemoved struct {
+	RoleID discord.Snowflake `json:"role_id"`
+}
+
 type GuildScienceBorderwallChallenge struct {
 	HasMessage bool `json:"has_message,omitempty"`
 	HasDM      bool `json:"has_dm,omitempty"`
@@ -77,3 +84,13 @@ type GuildScienceReactionRoleGivenRemoved struct {
 type GuildScienceGiveawayEvents struct {
 	GiveawayUUID uuid.UUID `json:"giveaway_uuid"`
 }
+
+type GuildScienceTempRoleRemoved struct {
+	RoleID    discord.Snowflake       `json:"role_id"`
+	Source    database.TempRoleSource `json:"source"`
+	GivenAt   time.Time               `json:"given_at"`
+	ExpiresAt time.Time               `json:"expires_at"`
+
+	// RemovedBy is the user that removed the role before it expired. It is 0 when the role expired.
+	RemovedBy discord.Snowflake `json:"removed_by,omitempty"`
+}
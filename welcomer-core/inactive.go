@@ -0,0 +1,97 @@
+package welcomer
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/WelcomerTeam/Discord/discord"
+)
+
+const (
+	InactiveDefaultDays = 30
+	InactiveMinimumDays = 1
+	InactiveMaximumDays = 365
+
+	InactivePageSize = 20
+
+	InactiveMaximumExcludedRoles = 25
+)
+
+// GetInactiveSince returns the time members must have been active after to not be inactive.
+func GetInactiveSince(days int, now time.Time) time.Time {
+	return now.AddDate(0, 0, -days)
+}
+
+// GetInactiveAvailableDays returns how many whole days of activity have been recorded since earliest.
+// Members cannot be counted as inactive for longer than this, as there is nothing to show they were active before it.
+func GetInactiveAvailableDays(earliest, now time.Time) int {
+	return max(int(now.Sub(earliest)/(24*time.Hour)), 0)
+}
+
+// GetInactiveMembers returns the members that have not sent a message or joined a voice channel since the time given.
+// Bots, members with an excluded role and members that joined after since are left out. Members are ordered by
+// when they joined, oldest first.
+func GetInactiveMembers(members []*discord.GuildMember, activeUserIDs, excludedRoles []int64, since time.Time) []*discord.GuildMember {
+	active := make(map[discord.Snowflake]bool, len(activeUserIDs))
+	for _, userID := range activeUserIDs {
+		active[discord.Snowflake(userID)] = true
+	}
+
+	inactiveMembers := make([]*discord.GuildMember, 0)
+
+	for _, member := range members {
+		if member.User == nil || member.User.Bot || active[member.User.ID] {
+			continue
+		}
+
+		if member.JoinedAt.After(since) {
+			continue
+		}
+
+		if slices.ContainsFunc(member.Roles, func(roleID discord.Snowflake) bool {
+			return slices.Contains(excludedRoles, int64(roleID))
+		}) {
+			continue
+		}
+
+		inactiveMembers = append(inactiveMembers, member)
+	}
+
+	slices.SortFunc(inactiveMembers, func(a, b *discord.GuildMember) int {
+		if c := a.JoinedAt.Compare(b.JoinedAt); c != 0 {
+			return c
+		}
+
+		return cmp.Compare(a.User.ID, b.User.ID)
+	})
+
+	return inactiveMembers
+}
+
+// GetInactivePage returns the members on a page, starting from 0, and the number of pages.
+// The page is clamped to the pages available.
+func GetInactivePage(members []*discord.GuildMember, page int) ([]*discord.GuildMember, int, int) {
+	pages := max((len(members)+InactivePageSize-1)/InactivePageSize, 1)
+	page = min(max(page, 0), pages-1)
+
+	start := page * InactivePageSize
+	end := min(start+InactivePageSize, len(members))
+
+	return members[start:end], page, pages
+}
+
+// GetInactiveWarningMessage returns the direct message sent to members before they are pruned for being inactive.
+func GetInactiveWarningMessage(guildName string, days int) discord.MessageParams {
+	return discord.MessageParams{
+		Embeds: []discord.Embed{
+			{
+				Description: fmt.Sprintf("You have not been active in **%s** for over %d day%s. "+
+					"Send a message or join a voice channel soon, or you may be removed from the server.",
+					guildName, days, If(days == 1, "", "s")),
+				Color: EmbedColourWarn,
+			},
+		},
+	}
+}
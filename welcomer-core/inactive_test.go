@@ -0,0 +1,99 @@
+package welcomer
+
+import (
+	"testing"
+	"time"
+
+	"github.com/WelcomerTeam/Discord/discord"
+)
+
+func TestGetInactiveMembers(t *testing.T) {
+	t.Parallel()
+
+	since := time.Date(2025, time.March, 1, 0, 0, 0, 0, time.UTC)
+
+	members := []*discord.GuildMember{
+		{User: &discord.User{ID: 1}, JoinedAt: since.AddDate(0, -1, 0)},
+		{User: &discord.User{ID: 2}, JoinedAt: since.AddDate(0, -2, 0)},
+		{User: &discord.User{ID: 3}, JoinedAt: since.AddDate(0, -3, 0)},
+		{User: &discord.User{ID: 4, Bot: true}, JoinedAt: since.AddDate(0, -1, 0)},
+		{User: &discord.User{ID: 5}, JoinedAt: since.AddDate(0, -1, 0), Roles: []discord.Snowflake{10, 20}},
+		{User: &discord.User{ID: 6}, JoinedAt: since.AddDate(0, 0, 1)},
+		{User: &discord.User{ID: 7}, JoinedAt: since.AddDate(0, -1, 0), Roles: []discord.Snowflake{30}},
+		{JoinedAt: since.AddDate(0, -1, 0)},
+	}
+
+	inactiveMembers := GetInactiveMembers(members, []int64{2}, []int64{20}, since)
+
+	expected := []discord.Snowflake{3, 1, 7}
+
+	if len(inactiveMembers) != len(expected) {
+		t.Fatalf("expected %d members, got: %+v", len(expected), inactiveMembers)
+	}
+
+	for i, userID := range expected {
+		if inactiveMembers[i].User.ID != userID {
+			t.Errorf("expected member %d to be %d, got: %d", i, userID, inactiveMembers[i].User.ID)
+		}
+	}
+}
+
+func TestGetInactiveAvailableDays(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2025, time.March, 31, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		earliest time.Time
+		expected int
+	}{
+		{"no history", now, 0},
+		{"partial day", now.Add(-time.Hour * 23), 0},
+		{"whole days", now.AddDate(0, 0, -10).Add(-time.Hour), 10},
+		{"future", now.Add(time.Hour), 0},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			if days := GetInactiveAvailableDays(test.earliest, now); days != test.expected {
+				t.Errorf("expected: %d, got: %d", test.expected, days)
+			}
+		})
+	}
+}
+
+func TestGetInactivePage(t *testing.T) {
+	t.Parallel()
+
+	members := make([]*discord.GuildMember, InactivePageSize*2+5)
+
+	tests := []struct {
+		name          string
+		members       []*discord.GuildMember
+		page          int
+		expectedLen   int
+		expectedPage  int
+		expectedPages int
+	}{
+		{"empty", nil, 0, 0, 0, 1},
+		{"first", members, 0, InactivePageSize, 0, 3},
+		{"last", members, 2, 5, 2, 3},
+		{"past last", members, 5, 5, 2, 3},
+		{"negative", members, -1, InactivePageSize, 0, 3},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			pageMembers, page, pages := GetInactivePage(test.members, test.page)
+
+			if len(pageMembers) != test.expectedLen || page != test.expectedPage || pages != test.expectedPages {
+				t.Errorf("expected: %d %d %d, got: %d %d %d", test.expectedLen, test.expectedPage, test.expectedPages, len(pageMembers), page, pages)
+			}
+		})
+	}
+}
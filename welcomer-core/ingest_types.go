@@ -2,7 +2,7 @@ package welcomer
 
 //go:generate go-enum -f=$GOFILE --marshal
 
-// ENUM(create, edit)
+// ENUM(create, edit, delete, bulk_delete, reaction_add)
 type IngestMessageEventType int16
 
 // ENUM(join, leave, checkpoint)
@@ -15,15 +15,24 @@ const (
 	IngestMessageEventTypeCreate IngestMessageEventType = iota
 	// IngestMessageEventTypeEdit is a IngestMessageEventType of type Edit.
 	IngestMessageEventTypeEdit
+	// IngestMessageEventTypeDelete is a IngestMessageEventType of type Delete.
+	IngestMessageEventTypeDelete
+	// IngestMessageEventTypeBulkDelete is a IngestMessageEventType of type Bulk_delete.
+	IngestMessageEventTypeBulkDelete
+	// IngestMessageEventTypeReactionAdd is a IngestMessageEventType of type Reaction_add.
+	IngestMessageEventTypeReactionAdd
 )
 
 var ErrInvalidIngestMessageEventType = errors.New("not a valid IngestMessageEventType")
 
-const _IngestMessageEventTypeName = "createedit"
+const _IngestMessageEventTypeName = "createeditdeletebulk_deletereaction_add"
 
 var _IngestMessageEventTypeMap = map[IngestMessageEventType]string{
-	IngestMessageEventTypeCreate: _IngestMessageEventTypeName[0:6],
-	IngestMessageEventTypeEdit:   _IngestMessageEventTypeName[6:10],
+	IngestMessageEventTypeCreate:      _IngestMessageEventTypeName[0:6],
+	IngestMessageEventTypeEdit:        _IngestMessageEventTypeName[6:10],
+	IngestMessageEventTypeDelete:      _IngestMessageEventTypeName[10:16],
+	IngestMessageEventTypeBulkDelete:  _IngestMessageEventTypeName[16:27],
+	IngestMessageEventTypeReactionAdd: _IngestMessageEventTypeName[27:39],
 }
 
 // String implements the Stringer interface.
@@ -42,8 +51,11 @@ func (x IngestMessageEventType) IsValid() bool {
 }
 
 var _IngestMessageEventTypeValue = map[string]IngestMessageEventType{
-	_IngestMessageEventTypeName[0:6]:  IngestMessageEventTypeCreate,
-	_IngestMessageEventTypeName[6:10]: IngestMessageEventTypeEdit,
+	_IngestMessageEventTypeName[0:6]:   IngestMessageEventTypeCreate,
+	_IngestMessageEventTypeName[6:10]:  IngestMessageEventTypeEdit,
+	_IngestMessageEventTypeName[10:16]: IngestMessageEventTypeDelete,
+	_IngestMessageEventTypeName[16:27]: IngestMessageEventTypeBulkDelete,
+	_IngestMessageEventTypeName[27:39]: IngestMessageEventTypeReactionAdd,
 }
 
 // ParseIngestMessageEventType attempts to convert a string to a IngestMessageEventType.
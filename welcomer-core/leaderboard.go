@@ -0,0 +1,77 @@
+package welcomer
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/WelcomerTeam/Discord/discord"
+)
+
+const (
+	LeaderboardTypeMessages = "messages"
+	LeaderboardTypeVoice    = "voice"
+
+	LeaderboardPeriodDay   = "day"
+	LeaderboardPeriodWeek  = "week"
+	LeaderboardPeriodMonth = "month"
+	LeaderboardPeriodAll   = "all"
+
+	// LeaderboardMaximumEntries is the most members that can be paged through on a leaderboard.
+	LeaderboardMaximumEntries = 100
+	LeaderboardPageSize       = 10
+
+	LeaderboardMaximumExcludedChannels = 50
+)
+
+type LeaderboardEntry struct {
+	Rank   int
+	UserID discord.Snowflake
+	User   *discord.User
+
+	// Value is the number of messages sent, or the milliseconds spent in voice channels.
+	Value int64
+}
+
+// GetLeaderboardSince returns the start of the period. All time returns the zero time.
+func GetLeaderboardSince(period string, now time.Time) time.Time {
+	switch period {
+	case LeaderboardPeriodDay:
+		return now.AddDate(0, 0, -1)
+	case LeaderboardPeriodWeek:
+		return now.AddDate(0, 0, -7)
+	case LeaderboardPeriodMonth:
+		// AddDate normalises overflowing days, so March 31 would become March 3 rather than February 28.
+		year, month, day := now.Date()
+		lastDay := time.Date(year, month, 0, 0, 0, 0, 0, now.Location()).Day()
+
+		return time.Date(year, month-1, min(day, lastDay), now.Hour(), now.Minute(), now.Second(), now.Nanosecond(), now.Location())
+	default:
+		return time.Time{}
+	}
+}
+
+// GetLeaderboardPage returns the entries on a page, starting from 0, and the number of pages.
+// The page is clamped to the pages available.
+func GetLeaderboardPage(entries []LeaderboardEntry, page int) ([]LeaderboardEntry, int, int) {
+	pages := max((len(entries)+LeaderboardPageSize-1)/LeaderboardPageSize, 1)
+	page = min(max(page, 0), pages-1)
+
+	start := page * LeaderboardPageSize
+	end := min(start+LeaderboardPageSize, len(entries))
+
+	return entries[start:end], page, pages
+}
+
+// FormatLeaderboardValue returns a human readable value, such as "500 messages" or "2 hours and 5 minutes".
+func FormatLeaderboardValue(leaderboardType string, value int64) string {
+	switch leaderboardType {
+	case LeaderboardTypeVoice:
+		if value < 60000 {
+			return "less than a minute"
+		}
+
+		return HumanizeDuration(int(value/1000), false)
+	default:
+		return fmt.Sprintf("%d message%s", value, If(value == 1, "", "s"))
+	}
+}
@@ -0,0 +1,73 @@
+package welcomer
+
+import (
+	"testing"
+	"time"
+)
+
+func TestGetLeaderboardSince(t *testing.T) {
+	now := time.Date(2025, time.March, 31, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		period   string
+		expected time.Time
+	}{
+		{LeaderboardPeriodDay, time.Date(2025, time.March, 30, 12, 0, 0, 0, time.UTC)},
+		{LeaderboardPeriodWeek, time.Date(2025, time.March, 24, 12, 0, 0, 0, time.UTC)},
+		{LeaderboardPeriodMonth, time.Date(2025, time.February, 28, 12, 0, 0, 0, time.UTC)},
+		{LeaderboardPeriodAll, time.Time{}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.period, func(t *testing.T) {
+			t.Parallel()
+
+			if since := GetLeaderboardSince(test.period, now); !since.Equal(test.expected) {
+				t.Errorf("expected: %v, got: %v", test.expected, since)
+			}
+		})
+	}
+}
+
+func TestGetLeaderboardPage(t *testing.T) {
+	entries := make([]LeaderboardEntry, 25)
+	for i := range entries {
+		entries[i].Rank = i + 1
+	}
+
+	tests := []struct {
+		name          string
+		entries       []LeaderboardEntry
+		page          int
+		expectedFirst int
+		expectedLen   int
+		expectedPage  int
+		expectedPages int
+	}{
+		{"first page", entries, 0, 1, 10, 0, 3},
+		{"last page", entries, 2, 21, 5, 2, 3},
+		{"past last page", entries, 5, 21, 5, 2, 3},
+		{"negative page", entries, -1, 1, 10, 0, 3},
+		{"empty", nil, 0, 0, 0, 0, 1},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			pageEntries, page, pages := GetLeaderboardPage(test.entries, test.page)
+
+			if len(pageEntries) != test.expectedLen {
+				t.Fatalf("expected %d entries, got: %d", test.expectedLen, len(pageEntries))
+			}
+
+			if len(pageEntries) > 0 && pageEntries[0].Rank != test.expectedFirst {
+				t.Errorf("expected first rank: %d, got: %d", test.expectedFirst, pageEntries[0].Rank)
+			}
+
+			if page != test.expectedPage || pages != test.expectedPages {
+				t.Errorf("expected page %d of %d, got: %d of %d", test.expectedPage, test.expectedPages, page, pages)
+			}
+		})
+	}
+}
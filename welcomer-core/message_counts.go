@@ -0,0 +1,77 @@
+package welcomer
+
+import (
+	"os"
+	"time"
+)
+
+const (
+	MessageCountGranularityHour  = "hour"
+	MessageCountGranularityDay   = "day"
+	MessageCountGranularityMonth = "month"
+)
+
+// Retention of each message count granularity. Hourly counts are compacted into daily counts, and daily
+// counts into monthly counts, once they are older than their retention. A retention of 0 keeps counts forever.
+// These are read by every service that queries message counts, not only the ingest service that compacts
+// them, so they must be set to the same values everywhere.
+var (
+	MessageCountsHourRetention  = getMessageCountRetention("MESSAGE_COUNTS_HOUR_RETENTION_DAYS", 14)
+	MessageCountsDayRetention   = getMessageCountRetention("MESSAGE_COUNTS_DAY_RETENTION_DAYS", 730)
+	MessageCountsMonthRetention = getMessageCountRetention("MESSAGE_COUNTS_MONTH_RETENTION_DAYS", 0)
+)
+
+func getMessageCountRetention(key string, defaultDays int64) time.Duration {
+	days := defaultDays
+
+	if value, ok := os.LookupEnv(key); ok {
+		days = max(TryParseInt(value), 0)
+	}
+
+	return time.Duration(days) * 24 * time.Hour
+}
+
+// GetMessageCountRetention returns how long a granularity is kept for. 0 is forever.
+func GetMessageCountRetention(granularity string) time.Duration {
+	switch granularity {
+	case MessageCountGranularityHour:
+		return MessageCountsHourRetention
+	case MessageCountGranularityDay:
+		return MessageCountsDayRetention
+	default:
+		return MessageCountsMonthRetention
+	}
+}
+
+// IsMessageCountGranularityRetained returns true if the granularity still holds counts from since.
+func IsMessageCountGranularityRetained(granularity string, since, now time.Time) bool {
+	retention := GetMessageCountRetention(granularity)
+
+	return retention == 0 || !since.Before(now.Add(-retention))
+}
+
+// GetMessageCountGranularity returns the finest granularity that still holds counts from since.
+// This is used for totals, which only count buckets starting at or after since, so the start of the
+// range should be as precise as possible.
+func GetMessageCountGranularity(since, now time.Time) string {
+	for _, granularity := range []string{MessageCountGranularityHour, MessageCountGranularityDay} {
+		if IsMessageCountGranularityRetained(granularity, since, now) {
+			return granularity
+		}
+	}
+
+	return MessageCountGranularityMonth
+}
+
+// GetMessageCountBucketGranularity returns the coarsest granularity that can be grouped into buckets,
+// such as daily counts for weekly buckets.
+func GetMessageCountBucketGranularity(bucket string) string {
+	switch bucket {
+	case MessageCountGranularityHour:
+		return MessageCountGranularityHour
+	case MessageCountGranularityMonth, "quarter", "year":
+		return MessageCountGranularityMonth
+	default:
+		return MessageCountGranularityDay
+	}
+}
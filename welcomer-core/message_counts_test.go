@@ -0,0 +1,55 @@
+package welcomer
+
+import (
+	"testing"
+	"time"
+)
+
+func TestGetMessageCountGranularity(t *testing.T) {
+	now := time.Date(2025, time.March, 31, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		since    time.Time
+		expected string
+	}{
+		{"last day", now.AddDate(0, 0, -1), MessageCountGranularityHour},
+		{"hour retention", now.Add(-MessageCountsHourRetention), MessageCountGranularityHour},
+		{"last month", now.AddDate(0, -1, 0), MessageCountGranularityDay},
+		{"past day retention", now.Add(-MessageCountsDayRetention - time.Hour), MessageCountGranularityMonth},
+		{"all time", time.Time{}, MessageCountGranularityMonth},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			if granularity := GetMessageCountGranularity(test.since, now); granularity != test.expected {
+				t.Errorf("expected: %s, got: %s", test.expected, granularity)
+			}
+		})
+	}
+}
+
+func TestGetMessageCountBucketGranularity(t *testing.T) {
+	tests := []struct {
+		bucket   string
+		expected string
+	}{
+		{"hour", MessageCountGranularityHour},
+		{"day", MessageCountGranularityDay},
+		{"week", MessageCountGranularityDay},
+		{"month", MessageCountGranularityMonth},
+		{"year", MessageCountGranularityMonth},
+	}
+
+	for _, test := range tests {
+		t.Run(test.bucket, func(t *testing.T) {
+			t.Parallel()
+
+			if granularity := GetMessageCountBucketGranularity(test.bucket); granularity != test.expected {
+				t.Errorf("expected: %s, got: %s", test.expected, granularity)
+			}
+		})
+	}
+}
@@ -25,6 +25,20 @@ type GuildSettingsReactionRole struct {
 	Message         string               `json:"message,omitempty"`
 	Type            ReactionRoleType     `json:"type"`
 	Roles           []ReactionRoleOption `json:"roles"`
+
+	// UniqueSelection removes any other option the member holds when they
+	// pick a new one.
+	UniqueSelection bool `json:"unique_selection"`
+	// MaxSelections is how many options a member can hold at once. There is
+	// no limit when this is 0.
+	MaxSelections int32 `json:"max_selections"`
+	// RequiredRoleID is a role the member must already have to pick an option.
+	RequiredRoleID discord.Snowflake `json:"required_role_id"`
+	// VerifyOnly only ever adds roles. Nothing is removed by the reaction
+	// role, including options replaced by other constraints.
+	VerifyOnly bool `json:"verify_only"`
+	// DisableToggleOff stops members removing an option by picking it again.
+	DisableToggleOff bool `json:"disable_toggle_off"`
 }
 
 type ReactionRoleOption struct {
@@ -33,6 +47,10 @@ type ReactionRoleOption struct {
 	Emoji       string                            `json:"emoji"`
 	Name        string                            `json:"name,omitempty"`
 	Description string                            `json:"description,omitempty"`
+
+	// Duration is how long the role lasts once given, in seconds. The role is
+	// permanent when this is 0.
+	Duration int64 `json:"duration,omitempty"`
 }
 
 func UnmarshalReactionRolesJSON(data []byte) (reactionRoles []ReactionRoleOption) {
@@ -0,0 +1,273 @@
+package welcomer
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/WelcomerTeam/Discord/discord"
+	"github.com/WelcomerTeam/Welcomer/welcomer-core/database"
+)
+
+const (
+	// RetentionReportWeeks is how many join weeks are included in the weekly retention report.
+	RetentionReportWeeks = 8
+
+	// RetentionMaximumInvites is the most invite codes that are broken down in a retention report.
+	RetentionMaximumInvites = 25
+
+	// retentionReportInvites is the most invite codes shown in the weekly retention report message.
+	retentionReportInvites = 5
+)
+
+type RetentionRate struct {
+	// Eligible is the number of members that joined long enough ago to be counted.
+	Eligible int64   `json:"eligible"`
+	Retained int64   `json:"retained"`
+	Rate     float64 `json:"rate"`
+}
+
+type CohortRetention struct {
+	Members int64         `json:"members"`
+	Day1    RetentionRate `json:"day_1"`
+	Day7    RetentionRate `json:"day_7"`
+	Day30   RetentionRate `json:"day_30"`
+}
+
+type WeekCohortRetention struct {
+	Week time.Time `json:"week"`
+	CohortRetention
+}
+
+type InviteCohortRetention struct {
+	// InviteCode is empty for members that joined without a tracked invite.
+	InviteCode string `json:"invite_code"`
+	CohortRetention
+}
+
+// RetentionSegment compares the retention of members that did something, against those that did not.
+type RetentionSegment struct {
+	With    CohortRetention `json:"with"`
+	Without CohortRetention `json:"without"`
+}
+
+type RetentionReport struct {
+	Total    CohortRetention         `json:"total"`
+	Weeks    []WeekCohortRetention   `json:"weeks"`
+	Invites  []InviteCohortRetention `json:"invites"`
+	Welcomed RetentionSegment        `json:"welcomed"`
+	DMed     RetentionSegment        `json:"dmed"`
+	Verified RetentionSegment        `json:"verified"`
+}
+
+func (c *CohortRetention) add(row *database.GetGuildCohortRetentionRow) {
+	c.Members += row.Members
+	c.Day1.Eligible += row.Day1Eligible
+	c.Day1.Retained += row.Day1Retained
+	c.Day7.Eligible += row.Day7Eligible
+	c.Day7.Retained += row.Day7Retained
+	c.Day30.Eligible += row.Day30Eligible
+	c.Day30.Retained += row.Day30Retained
+}
+
+func (c *CohortRetention) calculate() {
+	for _, rate := range []*RetentionRate{&c.Day1, &c.Day7, &c.Day30} {
+		if rate.Eligible > 0 {
+			rate.Rate = float64(rate.Retained) / float64(rate.Eligible)
+		}
+	}
+}
+
+func (s *RetentionSegment) add(with bool, row *database.GetGuildCohortRetentionRow) {
+	if with {
+		s.With.add(row)
+	} else {
+		s.Without.add(row)
+	}
+}
+
+func (s *RetentionSegment) calculate() {
+	s.With.calculate()
+	s.Without.calculate()
+}
+
+// BuildRetentionReport combines cohort rows into retention by join week, by invite code and by
+// whether members were welcomed, DMed or verified. Invites are ordered by the members that joined with them.
+func BuildRetentionReport(rows []*database.GetGuildCohortRetentionRow) RetentionReport {
+	report := RetentionReport{
+		Weeks:   make([]WeekCohortRetention, 0),
+		Invites: make([]InviteCohortRetention, 0),
+	}
+
+	inviteIndex := make(map[string]int)
+
+	// Rows are ordered by week, so each week is a run of rows.
+	for _, row := range rows {
+		report.Total.add(row)
+
+		if len(report.Weeks) == 0 || !report.Weeks[len(report.Weeks)-1].Week.Equal(row.CohortWeek) {
+			report.Weeks = append(report.Weeks, WeekCohortRetention{Week: row.CohortWeek})
+		}
+
+		report.Weeks[len(report.Weeks)-1].add(row)
+
+		i, ok := inviteIndex[row.InviteCode]
+		if !ok {
+			i = len(report.Invites)
+			inviteIndex[row.InviteCode] = i
+
+			report.Invites = append(report.Invites, InviteCohortRetention{InviteCode: row.InviteCode})
+		}
+
+		report.Invites[i].add(row)
+
+		report.Welcomed.add(row.IsWelcomed, row)
+		report.DMed.add(row.IsDmed, row)
+		report.Verified.add(row.IsVerified, row)
+	}
+
+	report.Total.calculate()
+
+	for i := range report.Weeks {
+		report.Weeks[i].calculate()
+	}
+
+	for i := range report.Invites {
+		report.Invites[i].calculate()
+	}
+
+	slices.SortFunc(report.Invites, func(a, b InviteCohortRetention) int {
+		if c := cmp.Compare(b.Members, a.Members); c != 0 {
+			return c
+		}
+
+		return strings.Compare(a.InviteCode, b.InviteCode)
+	})
+
+	if len(report.Invites) > RetentionMaximumInvites {
+		report.Invites = report.Invites[:RetentionMaximumInvites]
+	}
+
+	report.Welcomed.calculate()
+	report.DMed.calculate()
+	report.Verified.calculate()
+
+	return report
+}
+
+// GetRetentionReportSince returns the start of the first join week included in the weekly retention report.
+// Weeks start on a Monday, to match the weeks returned by postgres.
+func GetRetentionReportSince(now time.Time) time.Time {
+	now = now.UTC()
+
+	daysSinceMonday := (int(now.Weekday()) + 6) % 7
+
+	return time.Date(now.Year(), now.Month(), now.Day()-daysSinceMonday-(RetentionReportWeeks-1)*7, 0, 0, 0, 0, time.UTC)
+}
+
+// FormatRetentionRate returns the rate as a percentage, or a dash if no members have been in the guild long enough.
+func FormatRetentionRate(rate RetentionRate) string {
+	if rate.Eligible == 0 {
+		return "-"
+	}
+
+	return fmt.Sprintf("%.0f%%", rate.Rate*100)
+}
+
+func formatCohortRetention(cohort CohortRetention) string {
+	return fmt.Sprintf("%d joined · Day 1 **%s** · Day 7 **%s** · Day 30 **%s**",
+		cohort.Members, FormatRetentionRate(cohort.Day1), FormatRetentionRate(cohort.Day7), FormatRetentionRate(cohort.Day30))
+}
+
+func GetRetentionReportMessage(guildID discord.Snowflake, report RetentionReport) discord.MessageParams {
+	var weeks strings.Builder
+
+	weeks.WriteString("### By join week\n")
+
+	if len(report.Weeks) == 0 {
+		weeks.WriteString("No members have joined recently.")
+	}
+
+	for _, week := range report.Weeks {
+		fmt.Fprintf(&weeks, "`%s` %s\n", week.Week.Format(time.DateOnly), formatCohortRetention(week.CohortRetention))
+	}
+
+	var invites strings.Builder
+
+	invites.WriteString("### By invite\n")
+
+	if len(report.Invites) == 0 {
+		invites.WriteString("No invites have been used recently.")
+	}
+
+	for _, invite := range report.Invites[:min(len(report.Invites), retentionReportInvites)] {
+		inviteCode := If(invite.InviteCode == "", "Unknown", "`"+invite.InviteCode+"`")
+
+		fmt.Fprintf(&invites, "%s %s\n", inviteCode, formatCohortRetention(invite.CohortRetention))
+	}
+
+	return discord.MessageParams{
+		Flags: discord.MessageFlagIsComponentsV2,
+		Components: []discord.InteractionComponent{
+			{
+				Type:        discord.InteractionComponentTypeContainer,
+				AccentColor: new(uint32(0xfbc01b)),
+				Components: []discord.InteractionComponent{
+					{
+						Type: discord.InteractionComponentTypeTextDisplay,
+						Content: "# Weekly retention report\n" +
+							fmt.Sprintf("How many members stayed 1, 7 and 30 days after joining, over the last %d weeks.\n\n", RetentionReportWeeks) +
+							formatCohortRetention(report.Total),
+					},
+					{
+						Type: discord.InteractionComponentTypeSeparator,
+					},
+					{
+						Type:    discord.InteractionComponentTypeTextDisplay,
+						Content: strings.TrimSpace(weeks.String()),
+					},
+					{
+						Type: discord.InteractionComponentTypeSeparator,
+					},
+					{
+						Type:    discord.InteractionComponentTypeTextDisplay,
+						Content: strings.TrimSpace(invites.String()),
+					},
+					{
+						Type: discord.InteractionComponentTypeSeparator,
+					},
+					{
+						Type: discord.InteractionComponentTypeTextDisplay,
+						Content: "### Onboarding\n" +
+							"**Welcomed** " + formatCohortRetention(report.Welcomed.With) + "\n" +
+							"**Not welcomed** " + formatCohortRetention(report.Welcomed.Without) + "\n" +
+							"**Sent a DM** " + formatCohortRetention(report.DMed.With) + "\n" +
+							"**Not sent a DM** " + formatCohortRetention(report.DMed.Without) + "\n" +
+							"**Verified** " + formatCohortRetention(report.Verified.With) + "\n" +
+							"**Not verified** " + formatCohortRetention(report.Verified.Without),
+					},
+					{
+						Type: discord.InteractionComponentTypeSeparator,
+					},
+					{
+						Type: discord.InteractionComponentTypeSection,
+						Components: []discord.InteractionComponent{
+							{
+								Type:    discord.InteractionComponentTypeTextDisplay,
+								Content: "You can change where this report is sent, or turn it off, on your server's dashboard.",
+							},
+						},
+						Accessory: &discord.InteractionComponent{
+							Type:  discord.InteractionComponentTypeButton,
+							Style: discord.InteractionComponentStyleLink,
+							Label: "Dashboard",
+							URL:   WebsiteURL + "/dashboard/" + guildID.String(),
+						},
+					},
+				},
+			},
+		},
+	}
+}
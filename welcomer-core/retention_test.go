@@ -0,0 +1,88 @@
+package welcomer
+
+import (
+	"testing"
+	"time"
+
+	"github.com/WelcomerTeam/Welcomer/welcomer-core/database"
+)
+
+func TestBuildRetentionReport(t *testing.T) {
+	t.Parallel()
+
+	week := time.Date(2025, time.March, 24, 0, 0, 0, 0, time.UTC)
+
+	report := BuildRetentionReport([]*database.GetGuildCohortRetentionRow{
+		{CohortWeek: week, InviteCode: "abc", IsWelcomed: true, IsDmed: true, IsVerified: true, Members: 4, Day1Eligible: 4, Day1Retained: 4, Day7Eligible: 4, Day7Retained: 3},
+		{CohortWeek: week, InviteCode: "", IsWelcomed: true, Members: 2, Day1Eligible: 2, Day1Retained: 1, Day7Eligible: 2, Day7Retained: 0},
+		{CohortWeek: week.AddDate(0, 0, 7), InviteCode: "abc", Members: 3, Day1Eligible: 3, Day1Retained: 3},
+	})
+
+	if report.Total.Members != 9 || report.Total.Day1.Retained != 8 || report.Total.Day1.Eligible != 9 {
+		t.Errorf("unexpected total: %+v", report.Total)
+	}
+
+	if report.Total.Day30.Eligible != 0 || report.Total.Day30.Rate != 0 {
+		t.Errorf("expected no day 30 retention, got: %+v", report.Total.Day30)
+	}
+
+	if len(report.Weeks) != 2 || !report.Weeks[0].Week.Equal(week) || report.Weeks[0].Members != 6 || report.Weeks[1].Members != 3 {
+		t.Errorf("unexpected weeks: %+v", report.Weeks)
+	}
+
+	if report.Weeks[0].Day7.Rate != 0.5 {
+		t.Errorf("expected day 7 rate of 0.5, got: %v", report.Weeks[0].Day7.Rate)
+	}
+
+	if len(report.Invites) != 2 || report.Invites[0].InviteCode != "abc" || report.Invites[0].Members != 7 || report.Invites[1].InviteCode != "" {
+		t.Errorf("unexpected invites: %+v", report.Invites)
+	}
+
+	if report.Welcomed.With.Members != 6 || report.Welcomed.Without.Members != 3 {
+		t.Errorf("unexpected welcomed segment: %+v", report.Welcomed)
+	}
+
+	if report.DMed.With.Members != 4 || report.DMed.With.Day7.Rate != 0.75 || report.DMed.Without.Members != 5 {
+		t.Errorf("unexpected dmed segment: %+v", report.DMed)
+	}
+
+	if report.Verified.With.Members != 4 || report.Verified.Without.Members != 5 {
+		t.Errorf("unexpected verified segment: %+v", report.Verified)
+	}
+}
+
+func TestGetRetentionReportSince(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		now      time.Time
+		expected time.Time
+	}{
+		{"monday", time.Date(2025, time.March, 31, 0, 0, 0, 0, time.UTC), time.Date(2025, time.February, 10, 0, 0, 0, 0, time.UTC)},
+		{"sunday", time.Date(2025, time.April, 6, 23, 59, 0, 0, time.UTC), time.Date(2025, time.February, 10, 0, 0, 0, 0, time.UTC)},
+		{"wednesday", time.Date(2025, time.April, 9, 12, 0, 0, 0, time.UTC), time.Date(2025, time.February, 17, 0, 0, 0, 0, time.UTC)},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			if since := GetRetentionReportSince(test.now); !since.Equal(test.expected) {
+				t.Errorf("expected: %v, got: %v", test.expected, since)
+			}
+		})
+	}
+}
+
+func TestFormatRetentionRate(t *testing.T) {
+	t.Parallel()
+
+	if rate := FormatRetentionRate(RetentionRate{}); rate != "-" {
+		t.Errorf("expected: -, got: %s", rate)
+	}
+
+	if rate := FormatRetentionRate(RetentionRate{Eligible: 3, Retained: 2, Rate: 2.0 / 3}); rate != "67%" {
+		t.Errorf("expected: 67%%, got: %s", rate)
+	}
+}
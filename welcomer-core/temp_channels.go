@@ -0,0 +1,274 @@
+package welcomer
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"regexp"
+	"strconv"
+
+	"github.com/WelcomerTeam/Discord/discord"
+	"github.com/WelcomerTeam/Welcomer/welcomer-core/database"
+	"github.com/jackc/pgx/v4"
+)
+
+// DefaultTempChannelNameTemplate is used when a lobby does not have its own name template.
+const DefaultTempChannelNameTemplate = "🔊 {{User.Name}}'s Channel"
+
+// legacyTempChannelNameRegex matches the names of temp channels created before they were stored.
+var legacyTempChannelNameRegex = regexp.MustCompile(`^🔊 (.+)'s Channel \[(\d+)\]$`)
+
+// ParseLegacyTempChannelName returns the owner of a temp channel that was created
+// before temp channels were stored, using the user ID in its name.
+func ParseLegacyTempChannelName(name string) (ownerID discord.Snowflake, ok bool) {
+	matches := legacyTempChannelNameRegex.FindStringSubmatch(name)
+	if len(matches) != 3 {
+		return 0, false
+	}
+
+	userID, err := strconv.ParseInt(matches[2], 10, 64)
+	if err != nil {
+		return 0, false
+	}
+
+	return discord.Snowflake(userID), true
+}
+
+// TempChannelLobby configures the channels created when users join a lobby.
+type TempChannelLobby struct {
+	ChannelID         discord.Snowflake `json:"channel_id"`
+	CategoryID        discord.Snowflake `json:"category_id"`
+	NameTemplate      string            `json:"name_template"`
+	UserLimit         int32             `json:"user_limit"`
+	Bitrate           int32             `json:"bitrate"`
+	CopyPermissions   bool              `json:"copy_permissions"`
+	CreateTextChannel bool              `json:"create_text_channel"`
+}
+
+func UnmarshalTempChannelLobbiesJSON(lobbiesJSON []byte) (lobbies []TempChannelLobby) {
+	_ = json.Unmarshal(lobbiesJSON, &lobbies)
+
+	return
+}
+
+func MarshalTempChannelLobbiesJSON(lobbies []TempChannelLobby) (lobbiesJSON []byte) {
+	lobbiesJSON, _ = json.Marshal(lobbies)
+
+	return
+}
+
+// GetTempChannelLobbies returns the lobbies for a guild. Guilds that were set up before
+// lobbies were added have their single lobby and category turned into a lobby.
+func GetTempChannelLobbies(settings *database.GuildSettingsTempchannels) []TempChannelLobby {
+	lobbies := UnmarshalTempChannelLobbiesJSON(settings.Lobbies.Bytes)
+
+	if len(lobbies) == 0 && settings.ChannelLobby != 0 {
+		lobbies = []TempChannelLobby{
+			{
+				ChannelID:    discord.Snowflake(settings.ChannelLobby),
+				CategoryID:   discord.Snowflake(settings.ChannelCategory),
+				NameTemplate: DefaultTempChannelNameTemplate,
+				UserLimit:    settings.DefaultUserCount,
+			},
+		}
+	}
+
+	return lobbies
+}
+
+// GetTempChannelLobby returns the lobby configured for a channel, if there is one.
+func GetTempChannelLobby(settings *database.GuildSettingsTempchannels, channelID discord.Snowflake) (*TempChannelLobby, bool) {
+	for _, lobby := range GetTempChannelLobbies(settings) {
+		if lobby.ChannelID == channelID {
+			return &lobby, true
+		}
+	}
+
+	return nil, false
+}
+
+// SetTempChannelLobbies stores lobbies on the settings. The first lobby is also kept in the
+// legacy lobby and category fields.
+func SetTempChannelLobbies(settings *database.GuildSettingsTempchannels, lobbies []TempChannelLobby) {
+	if lobbies == nil {
+		lobbies = []TempChannelLobby{}
+	}
+
+	settings.Lobbies = MustConvertToJSONB(lobbies)
+
+	if len(lobbies) > 0 {
+		settings.ChannelLobby = int64(lobbies[0].ChannelID)
+		settings.ChannelCategory = int64(lobbies[0].CategoryID)
+		settings.DefaultUserCount = lobbies[0].UserLimit
+	} else {
+		settings.ChannelLobby = 0
+		settings.ChannelCategory = 0
+		settings.DefaultUserCount = 0
+	}
+}
+
+// GetTempChannel returns the temp channel for a channel, or ErrInvalidTempChannel if it is not one.
+func GetTempChannel(ctx context.Context, guildID, channelID discord.Snowflake) (*database.GuildTempChannels, error) {
+	tempChannel, err := Queries.GetTempChannel(ctx, database.GetTempChannelParams{
+		GuildID:   int64(guildID),
+		ChannelID: int64(channelID),
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrInvalidTempChannel
+		}
+
+		return nil, err
+	}
+
+	return tempChannel, nil
+}
+
+// CanOwnTempChannel returns ErrTempChannelOwnerIsBot or ErrTempChannelOwnerHasOther if the
+// user cannot be given the temp channel. Members can only own one temp channel at a time.
+func CanOwnTempChannel(ctx context.Context, guildID, channelID, userID discord.Snowflake) error {
+	if user, err := FetchUser(ctx, userID); err == nil && user.Bot {
+		return ErrTempChannelOwnerIsBot
+	}
+
+	ownedChannel, err := Queries.GetTempChannelByOwner(ctx, database.GetTempChannelByOwnerParams{
+		GuildID: int64(guildID),
+		OwnerID: int64(userID),
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil
+		}
+
+		return err
+	}
+
+	if ownedChannel.ChannelID != int64(channelID) {
+		return ErrTempChannelOwnerHasOther
+	}
+
+	return nil
+}
+
+// TransferTempChannel changes the owner of a temp channel. Permissions the previous
+// owner was given on the channel, such as being able to join it while locked, are
+// moved to the new owner.
+func TransferTempChannel(ctx context.Context, session *discord.Session, tempChannel *database.GuildTempChannels, ownerID discord.Snowflake) error {
+	previousOwnerID := discord.Snowflake(tempChannel.OwnerID)
+
+	if previousOwnerID != ownerID {
+		err := CanOwnTempChannel(ctx, discord.Snowflake(tempChannel.GuildID), discord.Snowflake(tempChannel.ChannelID), ownerID)
+		if err != nil {
+			return err
+		}
+	}
+
+	_, err := Queries.UpdateTempChannelOwner(ctx, database.UpdateTempChannelOwnerParams{
+		GuildID:   tempChannel.GuildID,
+		ChannelID: tempChannel.ChannelID,
+		OwnerID:   int64(ownerID),
+	})
+	if err != nil {
+		return err
+	}
+
+	tempChannel.OwnerID = int64(ownerID)
+
+	if previousOwnerID == ownerID {
+		return nil
+	}
+
+	channel, err := FetchGuildChannel(ctx, discord.Snowflake(tempChannel.GuildID), discord.Snowflake(tempChannel.ChannelID))
+	if err != nil {
+		return err
+	}
+
+	for _, overwrite := range channel.PermissionOverwrites {
+		if overwrite.ID != previousOwnerID || overwrite.Type != discord.ChannelOverrideTypeMember || overwrite.Allow == 0 {
+			continue
+		}
+
+		err = EditChannelOverwrite(ctx, session, channel, ownerID, discord.ChannelOverrideTypeMember, overwrite.Allow, 0, 0, "Tempchannel owner")
+		if err != nil {
+			return err
+		}
+
+		return EditChannelOverwrite(ctx, session, channel, previousOwnerID, discord.ChannelOverrideTypeMember, 0, 0, overwrite.Allow, "Tempchannel transferred")
+	}
+
+	return nil
+}
+
+// PassOnTempChannel gives a temp channel to whoever has been in it the longest,
+// excluding the current owner, bots and members that own another temp channel.
+// Returns 0 if nobody else in the channel can own it.
+func PassOnTempChannel(ctx context.Context, session *discord.Session, tempChannel *database.GuildTempChannels) (discord.Snowflake, error) {
+	sessions, err := Queries.GetGuildVoiceChannelOpenSessionsByChannel(ctx, database.GetGuildVoiceChannelOpenSessionsByChannelParams{
+		GuildID:   tempChannel.GuildID,
+		ChannelID: tempChannel.ChannelID,
+	})
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		return 0, err
+	}
+
+	for _, voiceSession := range sessions {
+		if voiceSession.UserID == tempChannel.OwnerID {
+			continue
+		}
+
+		err = CanOwnTempChannel(ctx, discord.Snowflake(tempChannel.GuildID), discord.Snowflake(tempChannel.ChannelID), discord.Snowflake(voiceSession.UserID))
+		switch {
+		case errors.Is(err, ErrTempChannelOwnerIsBot), errors.Is(err, ErrTempChannelOwnerHasOther):
+			continue
+		case err != nil:
+			return 0, err
+		}
+
+		return discord.Snowflake(voiceSession.UserID), TransferTempChannel(ctx, session, tempChannel, discord.Snowflake(voiceSession.UserID))
+	}
+
+	return 0, nil
+}
+
+// IsInTempChannel returns true if a user is currently connected to a temp channel.
+func IsInTempChannel(ctx context.Context, tempChannel *database.GuildTempChannels, userID discord.Snowflake) (bool, error) {
+	sessions, err := Queries.GetGuildVoiceChannelOpenSessionsByChannel(ctx, database.GetGuildVoiceChannelOpenSessionsByChannelParams{
+		GuildID:   tempChannel.GuildID,
+		ChannelID: tempChannel.ChannelID,
+	})
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		return false, err
+	}
+
+	for _, session := range sessions {
+		if session.UserID == int64(userID) {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// EditChannelOverwrite updates a single permission overwrite on a channel. Permissions in
+// allow and deny are set, permissions in reset are returned to neutral and all other
+// permissions in the existing overwrite are left untouched.
+func EditChannelOverwrite(ctx context.Context, session *discord.Session, channel *discord.Channel, overwriteID discord.Snowflake, overwriteType discord.ChannelOverrideType, allow, deny, reset discord.Int64, reason string) error {
+	overwrite := discord.ChannelOverwrite{
+		ID:   overwriteID,
+		Type: overwriteType,
+	}
+
+	for _, existingOverwrite := range channel.PermissionOverwrites {
+		if existingOverwrite.ID == overwriteID {
+			overwrite.Allow = existingOverwrite.Allow
+			overwrite.Deny = existingOverwrite.Deny
+
+			break
+		}
+	}
+
+	overwrite.Allow = (overwrite.Allow &^ (deny | reset)) | allow
+	overwrite.Deny = (overwrite.Deny &^ (allow | reset)) | deny
+
+	return discord.EditChannelPermissions(ctx, session, channel.ID, overwriteID, overwrite, &reason)
+}
@@ -0,0 +1,35 @@
+package welcomer
+
+import (
+	"testing"
+
+	"github.com/WelcomerTeam/Discord/discord"
+)
+
+func TestParseLegacyTempChannelName(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name            string
+		channelName     string
+		expectedOwnerID discord.Snowflake
+		expectedOk      bool
+	}{
+		{"Legacy name", "🔊 Welcomer's Channel [143090142360371200]", 143090142360371200, true},
+		{"Name with brackets", "🔊 [AFK] User's Channel [5]", 5, true},
+		{"Missing user ID", "🔊 Welcomer's Channel", 0, false},
+		{"Suffix after user ID", "🔊 Welcomer's Channel [5] copy", 0, false},
+		{"Other channel", "General", 0, false},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ownerID, ok := ParseLegacyTempChannelName(tc.channelName)
+			if ownerID != tc.expectedOwnerID || ok != tc.expectedOk {
+				t.Errorf("expected: %d %t, got: %d %t", tc.expectedOwnerID, tc.expectedOk, ownerID, ok)
+			}
+		})
+	}
+}
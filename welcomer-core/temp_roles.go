@@ -0,0 +1,131 @@
+package welcomer
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/WelcomerTeam/Discord/discord"
+	"github.com/WelcomerTeam/Welcomer/welcomer-core/database"
+	"github.com/gofrs/uuid"
+)
+
+// TempRoleDuration is how long a role lasts when it is given, in seconds.
+type TempRoleDuration struct {
+	RoleID   discord.Snowflake `json:"role_id"`
+	Duration int64             `json:"duration"`
+}
+
+func UnmarshalTempRoleDurationsJSON(durationsJSON []byte) (durations []TempRoleDuration) {
+	_ = json.Unmarshal(durationsJSON, &durations)
+
+	return
+}
+
+func MarshalTempRoleDurationsJSON(durations []TempRoleDuration) (durationsJSON []byte) {
+	durationsJSON, _ = json.Marshal(durations)
+
+	return
+}
+
+// GetTempRoleDuration returns how long a role lasts. Roles are permanent when this is 0.
+func GetTempRoleDuration(durations []TempRoleDuration, roleID discord.Snowflake) time.Duration {
+	for _, duration := range durations {
+		if duration.RoleID == roleID {
+			return time.Duration(duration.Duration) * time.Second
+		}
+	}
+
+	return 0
+}
+
+// RecordTempRole adds a role to the temp-role ledger so it is removed once it
+// expires. Giving a role that is already temporary replaces its expiry.
+// sourceUUID identifies what gave the role, such as the reaction role.
+func RecordTempRole(ctx context.Context, guildID, userID, roleID discord.Snowflake, duration time.Duration, source database.TempRoleSource, sourceUUID uuid.UUID, actor discord.Snowflake) (*database.GuildTempRoles, error) {
+	return Queries.CreateOrUpdateTempRole(ctx, database.CreateOrUpdateTempRoleParams{
+		GuildID:    int64(guildID),
+		UserID:     int64(userID),
+		RoleID:     int64(roleID),
+		ExpiresAt:  time.Now().Add(duration),
+		Source:     int32(source),
+		CreatedBy:  int64(actor),
+		SourceUuid: sourceUUID,
+	})
+}
+
+// ForgetTempRole removes a role from the temp-role ledger, such as when the
+// member removes the role themselves before it expires.
+func ForgetTempRole(ctx context.Context, guildID, userID, roleID discord.Snowflake) error {
+	_, err := Queries.RemoveTempRole(ctx, database.RemoveTempRoleParams{
+		GuildID: int64(guildID),
+		UserID:  int64(userID),
+		RoleID:  int64(roleID),
+	})
+
+	return err
+}
+
+// RemoveExpiredTempRole removes an expired temporary role from a member.
+func RemoveExpiredTempRole(ctx context.Context, session *discord.Session, tempRole *database.GuildTempRoles) error {
+	guildID := discord.Snowflake(tempRole.GuildID)
+	userID := discord.Snowflake(tempRole.UserID)
+	roleID := discord.Snowflake(tempRole.RoleID)
+	member := discord.GuildMember{GuildID: &guildID, User: &discord.User{ID: userID}}
+
+	err := member.RemoveRoles(ctx, session, []discord.Snowflake{roleID}, new("Temporary role expired"), true)
+	if err != nil {
+		// Members who have left the server, or roles that were deleted, have
+		// nothing left to remove. Other errors keep the role in the ledger
+		// so the next run tries again.
+		if !IsDiscordNotFound(err) {
+			return fmt.Errorf("failed to remove role: %w", err)
+		}
+
+		return ForgetTempRole(ctx, guildID, userID, roleID)
+	}
+
+	if err := ForgetTempRole(ctx, guildID, userID, roleID); err != nil {
+		return err
+	}
+
+	PushTempRoleRemoved(ctx, tempRole, 0)
+
+	return nil
+}
+
+// PushTempRoleRemoved records the removal of a temporary role. removedBy is the user that
+// removed it manually, or 0 if it expired.
+func PushTempRoleRemoved(ctx context.Context, tempRole *database.GuildTempRoles, removedBy discord.Snowflake) {
+	guildID := discord.Snowflake(tempRole.GuildID)
+	userID := discord.Snowflake(tempRole.UserID)
+	roleID := discord.Snowflake(tempRole.RoleID)
+
+	PusherGuildScience.Push(
+		ctx,
+		guildID,
+		userID,
+		database.ScienceGuildEventTypeTempRoleRemoved,
+		GuildScienceTempRoleRemoved{
+			RoleID:    roleID,
+			Source:    database.TempRoleSource(tempRole.Source),
+			GivenAt:   tempRole.CreatedAt,
+			ExpiresAt: tempRole.ExpiresAt,
+			RemovedBy: removedBy,
+		},
+	)
+
+	if database.TempRoleSource(tempRole.Source) == database.TempRoleSourceReactionRoles && !tempRole.SourceUuid.IsNil() {
+		PusherGuildScience.Push(
+			ctx,
+			guildID,
+			userID,
+			database.ScienceGuildEventTypeReactionRoleRemoved,
+			GuildScienceReactionRoleGivenRemoved{
+				RoleID:           roleID,
+				ReactionRoleUUID: tempRole.SourceUuid,
+			},
+		)
+	}
+}
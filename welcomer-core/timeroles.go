@@ -1,14 +1,22 @@
 package welcomer
 
 import (
+	"bytes"
 	"context"
+	"database/sql"
 	"encoding/json"
+	"fmt"
 	"slices"
+	"time"
 
 	"github.com/WelcomerTeam/Discord/discord"
 	pb "github.com/WelcomerTeam/Sandwich-Daemon/proto"
+	"github.com/WelcomerTeam/Welcomer/welcomer-core/database"
 )
 
+// TimeRolesBackfillBatchSize is the most members scheduled in a single query when backfilling.
+const TimeRolesBackfillBatchSize = 1000
+
 type GuildSettingsTimeRolesRole struct {
 	Role    discord.Snowflake `json:"role_id"`
 	Seconds int               `json:"seconds"`
@@ -47,3 +55,249 @@ func FilterAssignableTimeRoles(ctx context.Context, sandwichClient pb.SandwichCl
 
 	return out, nil
 }
+
+// GetEligibleTimeRoles returns the time roles a member that joined at joinedAt has earned by now.
+func GetEligibleTimeRoles(joinedAt time.Time, timeRoles []GuildSettingsTimeRolesRole, now time.Time) (out []GuildSettingsTimeRolesRole) {
+	for _, timeRole := range timeRoles {
+		if !timeRole.Role.IsNil() && !joinedAt.Add(time.Duration(timeRole.Seconds)*time.Second).After(now) {
+			out = append(out, timeRole)
+		}
+	}
+
+	return out
+}
+
+// NextTimeRoleEligibleAt returns the first time after now that a member that joined at joinedAt
+// earns another time role. Returns an invalid time when there are no time roles left to earn.
+func NextTimeRoleEligibleAt(joinedAt time.Time, timeRoles []GuildSettingsTimeRolesRole, now time.Time) sql.NullTime {
+	var next sql.NullTime
+
+	for _, timeRole := range timeRoles {
+		if timeRole.Role.IsNil() {
+			continue
+		}
+
+		eligibleAt := joinedAt.Add(time.Duration(timeRole.Seconds) * time.Second)
+		if eligibleAt.After(now) && (!next.Valid || eligibleAt.Before(next.Time)) {
+			next = sql.NullTime{Time: eligibleAt, Valid: true}
+		}
+	}
+
+	return next
+}
+
+// getFirstTimeRoleSeconds returns the lowest threshold of any time role.
+func getFirstTimeRoleSeconds(timeRoles []GuildSettingsTimeRolesRole) (seconds int, ok bool) {
+	for _, timeRole := range timeRoles {
+		if !timeRole.Role.IsNil() && (!ok || timeRole.Seconds < seconds) {
+			seconds = timeRole.Seconds
+			ok = true
+		}
+	}
+
+	return seconds, ok
+}
+
+// ScheduleTimeRoles schedules a member to be checked once they reach the first time role.
+// Members that are already scheduled are left as they are unless overwrite is set.
+func ScheduleTimeRoles(ctx context.Context, guildID, userID discord.Snowflake, joinedAt time.Time, timeRoles []GuildSettingsTimeRolesRole, overwrite bool) error {
+	var nextEligibleAt sql.NullTime
+
+	if seconds, ok := getFirstTimeRoleSeconds(timeRoles); ok {
+		nextEligibleAt = sql.NullTime{Time: joinedAt.Add(time.Duration(seconds) * time.Second), Valid: true}
+	}
+
+	if overwrite {
+		_, err := Queries.CreateOrUpdateTimeRoleSchedule(ctx, database.CreateOrUpdateTimeRoleScheduleParams{
+			GuildID:        int64(guildID),
+			UserID:         int64(userID),
+			JoinedAt:       joinedAt,
+			NextEligibleAt: nextEligibleAt,
+		})
+
+		return err
+	}
+
+	_, err := Queries.CreateTimeRoleSchedule(ctx, database.CreateTimeRoleScheduleParams{
+		GuildID:        int64(guildID),
+		UserID:         int64(userID),
+		JoinedAt:       joinedAt,
+		NextEligibleAt: nextEligibleAt,
+	})
+
+	return err
+}
+
+// RescheduleTimeRoles re-evaluates every scheduled member of a guild after its time roles change.
+// Members are due again from the first time role, so anyone missing a role they have earned is
+// picked up by the next run.
+func RescheduleTimeRoles(ctx context.Context, guildID discord.Snowflake, timeRoles []GuildSettingsTimeRolesRole) error {
+	seconds, ok := getFirstTimeRoleSeconds(timeRoles)
+	if !ok {
+		_, err := Queries.ClearGuildTimeRoleSchedules(ctx, int64(guildID))
+
+		return err
+	}
+
+	_, err := Queries.RescheduleGuildTimeRoles(ctx, database.RescheduleGuildTimeRolesParams{
+		Seconds: int32(seconds),
+		GuildID: int64(guildID),
+	})
+
+	return err
+}
+
+// SyncTimeRolesGuildSettings re-evaluates scheduled members after time roles settings change and,
+// when time roles are enabled or their roles change, schedules every member in the background.
+// Members are not scheduled while time roles are disabled and may not have been seen since they
+// joined. Chunking can be slow on large guilds.
+func SyncTimeRolesGuildSettings(ctx context.Context, old, newRow *database.GuildSettingsTimeroles) {
+	if !newRow.ToggleEnabled || (old.ToggleEnabled && old.ToggleLadder == newRow.ToggleLadder && bytes.Equal(old.Timeroles.Bytes, newRow.Timeroles.Bytes)) {
+		return
+	}
+
+	guildID := discord.Snowflake(newRow.GuildID)
+	timeRoles := UnmarshalTimeRolesJSON(newRow.Timeroles.Bytes)
+
+	err := RescheduleTimeRoles(ctx, guildID, timeRoles)
+	if err != nil {
+		Logger.Warn().Err(err).Int64("guild_id", int64(guildID)).Msg("Failed to reschedule time roles")
+	}
+
+	if old.ToggleEnabled && bytes.Equal(old.Timeroles.Bytes, newRow.Timeroles.Bytes) {
+		return
+	}
+
+	go func(ctx context.Context) {
+		backfilled, err := BackfillGuildTimeRoles(ctx, guildID, timeRoles)
+		if err != nil {
+			Logger.Warn().Err(err).Int64("guild_id", int64(guildID)).Msg("Failed to backfill time roles")
+
+			return
+		}
+
+		Logger.Info().Int64("guild_id", int64(guildID)).Int64("members", backfilled).Msg("Backfilled time roles")
+	}(context.WithoutCancel(ctx))
+}
+
+// GetTimeRoleTiers returns the highest time role a member has earned and the next one they will
+// earn. Either is nil when there is no such tier.
+func GetTimeRoleTiers(joinedAt time.Time, timeRoles []GuildSettingsTimeRolesRole, now time.Time) (current, next *GuildSettingsTimeRolesRole) {
+	for i, timeRole := range timeRoles {
+		if timeRole.Role.IsNil() {
+			continue
+		}
+
+		if !joinedAt.Add(time.Duration(timeRole.Seconds) * time.Second).After(now) {
+			if current == nil || timeRole.Seconds > current.Seconds {
+				current = &timeRoles[i]
+			}
+		} else if next == nil || timeRole.Seconds < next.Seconds {
+			next = &timeRoles[i]
+		}
+	}
+
+	return current, next
+}
+
+// GetTimeRoleChanges returns the roles to give to and take from a member. In ladder mode only the
+// highest tier a member has earned is given and any lower tiers they have are removed.
+func GetTimeRoleChanges(memberRoles []discord.Snowflake, joinedAt time.Time, timeRoles []GuildSettingsTimeRolesRole, ladder bool, now time.Time) (add, remove []discord.Snowflake) {
+	if !ladder {
+		for _, timeRole := range GetEligibleTimeRoles(joinedAt, timeRoles, now) {
+			if !slices.Contains(memberRoles, timeRole.Role) {
+				add = append(add, timeRole.Role)
+			}
+		}
+
+		return add, nil
+	}
+
+	current, _ := GetTimeRoleTiers(joinedAt, timeRoles, now)
+	if current == nil {
+		return nil, nil
+	}
+
+	if !slices.Contains(memberRoles, current.Role) {
+		add = append(add, current.Role)
+	}
+
+	for _, timeRole := range timeRoles {
+		if timeRole.Seconds < current.Seconds && timeRole.Role != current.Role && slices.Contains(memberRoles, timeRole.Role) {
+			remove = append(remove, timeRole.Role)
+		}
+	}
+
+	return add, remove
+}
+
+// BackfillGuildTimeRoles chunks a guild and schedules every member, so members that joined or
+// were last seen before time roles were enabled or changed are given the roles they have earned.
+func BackfillGuildTimeRoles(ctx context.Context, guildID discord.Snowflake, timeRoles []GuildSettingsTimeRolesRole) (int64, error) {
+	if _, ok := getFirstTimeRoleSeconds(timeRoles); !ok {
+		return 0, nil
+	}
+
+	_, err := SandwichClient.RequestGuildChunk(ctx, &pb.RequestGuildChunkRequest{
+		GuildId: int64(guildID),
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to request guild chunk: %w", err)
+	}
+
+	guildMembers, err := SandwichClient.FetchGuildMember(ctx, &pb.FetchGuildMemberRequest{
+		GuildId: int64(guildID),
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to fetch guild members: %w", err)
+	}
+
+	members := make([]*discord.GuildMember, 0, len(guildMembers.GetGuildMembers()))
+	for _, memberPb := range guildMembers.GetGuildMembers() {
+		members = append(members, pb.PBToGuildMember(memberPb))
+	}
+
+	return BackfillTimeRoles(ctx, guildID, members, timeRoles)
+}
+
+// BackfillTimeRoles schedules members to be checked from their first time role, so time roles
+// and ladder mode are applied to members that joined before they were configured. Members that
+// are already scheduled are left as they are.
+func BackfillTimeRoles(ctx context.Context, guildID discord.Snowflake, members []*discord.GuildMember, timeRoles []GuildSettingsTimeRolesRole) (int64, error) {
+	seconds, ok := getFirstTimeRoleSeconds(timeRoles)
+	if !ok || len(members) == 0 {
+		return 0, nil
+	}
+
+	userIDs := make([]int64, 0, len(members))
+	joinedAts := make([]time.Time, 0, len(members))
+
+	for _, member := range members {
+		if member.User == nil || member.User.Bot {
+			continue
+		}
+
+		userIDs = append(userIDs, int64(member.User.ID))
+		joinedAts = append(joinedAts, member.JoinedAt)
+	}
+
+	var backfilled int64
+
+	for start := 0; start < len(userIDs); start += TimeRolesBackfillBatchSize {
+		end := min(start+TimeRolesBackfillBatchSize, len(userIDs))
+
+		rows, err := Queries.BackfillTimeRoleSchedules(ctx, database.BackfillTimeRoleSchedulesParams{
+			GuildID:   int64(guildID),
+			Seconds:   int32(seconds),
+			UserIds:   userIDs[start:end],
+			JoinedAts: joinedAts[start:end],
+		})
+		if err != nil {
+			return backfilled, err
+		}
+
+		backfilled += rows
+	}
+
+	return backfilled, nil
+}
@@ -0,0 +1,78 @@
+package welcomer
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/WelcomerTeam/Discord/discord"
+)
+
+func TestGetTimeRoleChanges(t *testing.T) {
+	now := time.Now()
+	joinedAt := now.Add(-48 * time.Hour)
+
+	timeRoles := []GuildSettingsTimeRolesRole{
+		{Role: 3, Seconds: 72 * 60 * 60},
+		{Role: 1, Seconds: 60 * 60},
+		{Role: 2, Seconds: 24 * 60 * 60},
+	}
+
+	tests := []struct {
+		name           string
+		memberRoles    []discord.Snowflake
+		ladder         bool
+		expectedAdd    []discord.Snowflake
+		expectedRemove []discord.Snowflake
+	}{
+		{"stacked", nil, false, []discord.Snowflake{1, 2}, nil},
+		{"stacked partial", []discord.Snowflake{1}, false, []discord.Snowflake{2}, nil},
+		{"ladder", nil, true, []discord.Snowflake{2}, nil},
+		{"ladder removes lower", []discord.Snowflake{1}, true, []discord.Snowflake{2}, []discord.Snowflake{1}},
+		{"ladder current", []discord.Snowflake{1, 2}, true, nil, []discord.Snowflake{1}},
+		{"ladder unchanged", []discord.Snowflake{2}, true, nil, nil},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			add, remove := GetTimeRoleChanges(test.memberRoles, joinedAt, timeRoles, test.ladder, now)
+
+			slices.Sort(add)
+			slices.Sort(remove)
+
+			if !slices.Equal(add, test.expectedAdd) {
+				t.Errorf("expected add: %v, got: %v", test.expectedAdd, add)
+			}
+
+			if !slices.Equal(remove, test.expectedRemove) {
+				t.Errorf("expected remove: %v, got: %v", test.expectedRemove, remove)
+			}
+		})
+	}
+}
+
+func TestGetTimeRoleTiers(t *testing.T) {
+	now := time.Now()
+
+	timeRoles := []GuildSettingsTimeRolesRole{
+		{Role: 2, Seconds: 24 * 60 * 60},
+		{Role: 1, Seconds: 60 * 60},
+	}
+
+	current, next := GetTimeRoleTiers(now.Add(-30*time.Minute), timeRoles, now)
+	if current != nil || next == nil || next.Role != 1 {
+		t.Errorf("expected no current tier and next tier 1, got: %v, %v", current, next)
+	}
+
+	current, next = GetTimeRoleTiers(now.Add(-2*time.Hour), timeRoles, now)
+	if current == nil || current.Role != 1 || next == nil || next.Role != 2 {
+		t.Errorf("expected current tier 1 and next tier 2, got: %v, %v", current, next)
+	}
+
+	current, next = GetTimeRoleTiers(now.Add(-48*time.Hour), timeRoles, now)
+	if current == nil || current.Role != 2 || next != nil {
+		t.Errorf("expected current tier 2 and no next tier, got: %v, %v", current, next)
+	}
+}
@@ -0,0 +1,129 @@
+package welcomer
+
+import (
+	"cmp"
+	"slices"
+	"time"
+)
+
+type VoiceSession struct {
+	ChannelID int64
+	Start     time.Time
+	End       time.Time
+	Inferred  bool
+}
+
+type VoiceMinutes struct {
+	HourTs    time.Time
+	ChannelID int64
+	Minutes   float64
+
+	// InferredMinutes is the part of Minutes that came from inferred sessions.
+	InferredMinutes float64
+}
+
+type voiceInterval struct {
+	start, end time.Time
+}
+
+// SplitVoiceSessions splits the sessions of a single member across hour boundaries.
+// A member can only be in one voice channel at a time, so time that overlaps is only counted once.
+// Sessions that were not inferred are preferred, then the session that started first.
+func SplitVoiceSessions(sessions []VoiceSession) []VoiceMinutes {
+	sorted := slices.Clone(sessions)
+
+	slices.SortStableFunc(sorted, func(a, b VoiceSession) int {
+		if a.Inferred != b.Inferred {
+			return If(a.Inferred, 1, -1)
+		}
+
+		return a.Start.Compare(b.Start)
+	})
+
+	type key struct {
+		hourTs    time.Time
+		channelID int64
+	}
+
+	minutesByKey := make(map[key]*VoiceMinutes)
+	covered := make([]voiceInterval, 0, len(sorted))
+
+	for _, session := range sorted {
+		if !session.End.After(session.Start) {
+			continue
+		}
+
+		for _, free := range subtractVoiceIntervals(voiceInterval{session.Start, session.End}, covered) {
+			for start := free.start; start.Before(free.end); {
+				hourTs := start.Truncate(time.Hour)
+				end := hourTs.Add(time.Hour)
+
+				if end.After(free.end) {
+					end = free.end
+				}
+
+				k := key{hourTs, session.ChannelID}
+
+				minutes, ok := minutesByKey[k]
+				if !ok {
+					minutes = &VoiceMinutes{HourTs: hourTs, ChannelID: session.ChannelID}
+					minutesByKey[k] = minutes
+				}
+
+				minutes.Minutes += end.Sub(start).Minutes()
+
+				if session.Inferred {
+					minutes.InferredMinutes += end.Sub(start).Minutes()
+				}
+
+				start = end
+			}
+		}
+
+		covered = append(covered, voiceInterval{session.Start, session.End})
+	}
+
+	result := make([]VoiceMinutes, 0, len(minutesByKey))
+	for _, minutes := range minutesByKey {
+		result = append(result, *minutes)
+	}
+
+	slices.SortFunc(result, func(a, b VoiceMinutes) int {
+		if c := a.HourTs.Compare(b.HourTs); c != 0 {
+			return c
+		}
+
+		return cmp.Compare(a.ChannelID, b.ChannelID)
+	})
+
+	return result
+}
+
+// subtractVoiceIntervals returns the parts of interval that are not covered.
+func subtractVoiceIntervals(interval voiceInterval, covered []voiceInterval) []voiceInterval {
+	free := []voiceInterval{interval}
+
+	for _, c := range covered {
+		next := make([]voiceInterval, 0, len(free))
+
+		for _, f := range free {
+			if !c.start.Before(f.end) || !c.end.After(f.start) {
+				next = append(next, f)
+
+				continue
+			}
+
+			if f.start.Before(c.start) {
+				next = append(next, voiceInterval{f.start, c.start})
+			}
+
+			if c.end.Before(f.end) {
+				next = append(next, voiceInterval{c.end, f.end})
+			}
+		}
+
+		free = next
+	}
+
+	return free
+}
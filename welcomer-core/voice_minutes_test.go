@@ -0,0 +1,87 @@
+package welcomer
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSplitVoiceSessions(t *testing.T) {
+	at := func(hour, minute int) time.Time {
+		return time.Date(2025, time.March, 31, hour, minute, 0, 0, time.UTC)
+	}
+
+	tests := []struct {
+		name     string
+		sessions []VoiceSession
+		expected []VoiceMinutes
+	}{
+		{
+			name:     "within hour",
+			sessions: []VoiceSession{{ChannelID: 1, Start: at(10, 10), End: at(10, 40)}},
+			expected: []VoiceMinutes{{HourTs: at(10, 0), ChannelID: 1, Minutes: 30}},
+		},
+		{
+			name:     "across hours",
+			sessions: []VoiceSession{{ChannelID: 1, Start: at(10, 30), End: at(12, 15)}},
+			expected: []VoiceMinutes{
+				{HourTs: at(10, 0), ChannelID: 1, Minutes: 30},
+				{HourTs: at(11, 0), ChannelID: 1, Minutes: 60},
+				{HourTs: at(12, 0), ChannelID: 1, Minutes: 15},
+			},
+		},
+		{
+			name: "duplicate sessions",
+			sessions: []VoiceSession{
+				{ChannelID: 1, Start: at(10, 0), End: at(10, 20)},
+				{ChannelID: 1, Start: at(10, 0), End: at(10, 20)},
+			},
+			expected: []VoiceMinutes{{HourTs: at(10, 0), ChannelID: 1, Minutes: 20}},
+		},
+		{
+			name: "overlapping reconnect",
+			sessions: []VoiceSession{
+				{ChannelID: 1, Start: at(10, 0), End: at(10, 20)},
+				{ChannelID: 2, Start: at(10, 10), End: at(10, 30)},
+			},
+			expected: []VoiceMinutes{
+				{HourTs: at(10, 0), ChannelID: 1, Minutes: 20},
+				{HourTs: at(10, 0), ChannelID: 2, Minutes: 10},
+			},
+		},
+		{
+			name: "inferred overlapped by session",
+			sessions: []VoiceSession{
+				{ChannelID: 1, Start: at(10, 0), End: at(10, 50), Inferred: true},
+				{ChannelID: 2, Start: at(10, 20), End: at(10, 30)},
+			},
+			expected: []VoiceMinutes{
+				{HourTs: at(10, 0), ChannelID: 1, Minutes: 40, InferredMinutes: 40},
+				{HourTs: at(10, 0), ChannelID: 2, Minutes: 10},
+			},
+		},
+		{
+			name:     "empty session",
+			sessions: []VoiceSession{{ChannelID: 1, Start: at(10, 0), End: at(10, 0)}},
+			expected: []VoiceMinutes{},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			minutes := SplitVoiceSessions(test.sessions)
+
+			if len(minutes) != len(test.expected) {
+				t.Fatalf("expected %d rows, got: %v", len(test.expected), minutes)
+			}
+
+			for i, expected := range test.expected {
+				if !minutes[i].HourTs.Equal(expected.HourTs) || minutes[i].ChannelID != expected.ChannelID ||
+					minutes[i].Minutes != expected.Minutes || minutes[i].InferredMinutes != expected.InferredMinutes {
+					t.Errorf("expected: %v, got: %v", expected, minutes[i])
+				}
+			}
+		})
+	}
+}
@@ -7,6 +7,7 @@ require (
 	github.com/WelcomerTeam/Sandwich v0.0.0-20260322170931-61406f17b909
 	github.com/WelcomerTeam/Sandwich-Daemon v0.0.0-20260322165858-683b139b5584
 	github.com/WelcomerTeam/Welcomer/welcomer-core v0.0.0
+	github.com/WelcomerTeam/Welcomer/welcomer-images v0.0.0
 	github.com/gofrs/uuid v4.4.0+incompatible
 	github.com/jackc/pgx/v4 v4.18.3
 	github.com/joho/godotenv v1.5.1
@@ -58,3 +59,5 @@ require (
 )
 
 replace github.com/WelcomerTeam/Welcomer/welcomer-core v0.0.0 => ../welcomer-core
+
+replace github.com/WelcomerTeam/Welcomer/welcomer-images v0.0.0 => ../welcomer-images
@@ -0,0 +1,355 @@
+package plugins
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/WelcomerTeam/Discord/discord"
+	sandwich_daemon "github.com/WelcomerTeam/Sandwich-Daemon"
+	pb "github.com/WelcomerTeam/Sandwich-Daemon/proto"
+	sandwich "github.com/WelcomerTeam/Sandwich/sandwich"
+	"github.com/WelcomerTeam/Welcomer/welcomer-core"
+	core "github.com/WelcomerTeam/Welcomer/welcomer-core"
+	"github.com/WelcomerTeam/Welcomer/welcomer-core/database"
+	"github.com/jackc/pgx/v4"
+)
+
+const (
+	// ActivityRolesBatchSize is the most members checked for activity roles at once.
+	ActivityRolesBatchSize = 1000
+
+	ActivityRolesSlowWarning = time.Second * 5
+)
+
+type ActivityRolesCog struct {
+	EventHandler *sandwich.Handlers
+}
+
+// Assert Types
+
+var (
+	_ sandwich.Cog           = (*ActivityRolesCog)(nil)
+	_ sandwich.CogWithEvents = (*ActivityRolesCog)(nil)
+)
+
+func NewActivityRolesCog() *ActivityRolesCog {
+	return &ActivityRolesCog{
+		EventHandler: sandwich.SetupHandler(nil),
+	}
+}
+
+func (p *ActivityRolesCog) CogInfo() *sandwich.CogInfo {
+	return &sandwich.CogInfo{
+		Name:        "ActivityRoles",
+		Description: "Provides the functionality for the 'ActivityRoles' feature",
+	}
+}
+
+func (p *ActivityRolesCog) GetEventHandlers() *sandwich.Handlers {
+	return p.EventHandler
+}
+
+func (p *ActivityRolesCog) RegisterCog(bot *sandwich.Bot) error {
+	// Register CustomEventInvokeActivityRoles event.
+	p.EventHandler.RegisterEventHandler(core.CustomEventInvokeActivityRoles, func(eventCtx *sandwich.EventContext, payload sandwich_daemon.ProducedPayload) error {
+		var invokeActivityRolesPayload core.CustomEventInvokeActivityRolesStructure
+		if err := eventCtx.DecodeContent(payload, &invokeActivityRolesPayload); err != nil {
+			return fmt.Errorf("failed to unmarshal payload: %w", err)
+		}
+
+		eventCtx.Guild = sandwich.NewGuild(invokeActivityRolesPayload.GuildID)
+
+		eventCtx.EventHandler.EventsMu.RLock()
+		defer eventCtx.EventHandler.EventsMu.RUnlock()
+
+		for _, event := range eventCtx.EventHandler.Events {
+			if f, ok := event.(welcomer.OnInvokeActivityRolesFuncType); ok {
+				return eventCtx.Handlers.WrapFuncType(eventCtx, f(eventCtx, invokeActivityRolesPayload))
+			}
+		}
+
+		return nil
+	})
+
+	// Forget the roles given to members that have left.
+	p.EventHandler.RegisterOnGuildMemberRemoveEvent(func(eventCtx *sandwich.EventContext, user discord.User) error {
+		startTime := time.Now()
+		defer notifyTiming(startTime, eventCtx.Payload.Metadata.Shard, "ActivityRolesCog.OnGuildMemberRemove")
+
+		_, err := welcomer.Queries.DeleteActivityRoleMembersByUser(eventCtx.Context, database.DeleteActivityRoleMembersByUserParams{
+			GuildID: int64(eventCtx.Guild.ID),
+			UserID:  int64(user.ID),
+		})
+		if err != nil {
+			welcomer.Logger.Warn().Err(err).
+				Int64("guild_id", int64(eventCtx.Guild.ID)).
+				Int64("user_id", int64(user.ID)).
+				Msg("Failed to delete activity role members")
+		}
+
+		return nil
+	})
+
+	// Call OnInvokeActivityRoles when CustomEventInvokeActivityRoles is triggered.
+	p.EventHandler.RegisterEvent(core.CustomEventInvokeActivityRoles, nil, (welcomer.OnInvokeActivityRolesFuncType)(p.OnInvokeActivityRoles))
+
+	return nil
+}
+
+// OnInvokeActivityRoles gives and, when decay is enabled, takes activity roles from the members in the event.
+func (p *ActivityRolesCog) OnInvokeActivityRoles(eventCtx *sandwich.EventContext, event core.CustomEventInvokeActivityRolesStructure) (err error) {
+	startTime := time.Now()
+	defer func() {
+		dur := time.Since(startTime)
+
+		if dur > ActivityRolesSlowWarning {
+			welcomer.Logger.Warn().Dur("duration", dur).
+				Int64("guild_id", int64(event.GuildID)).
+				Int("users", len(event.UserIDs)).
+				Msg("Invoke activity roles took a long time to process")
+		}
+	}()
+
+	guildSettingsActivityRoles, activityRoles, err := p.FetchGuildInformation(eventCtx, event.GuildID)
+	if err != nil {
+		return err
+	}
+
+	if !guildSettingsActivityRoles.ToggleEnabled || len(activityRoles) == 0 || len(event.UserIDs) == 0 {
+		return nil
+	}
+
+	assignableActivityRoles, err := welcomer.FilterAssignableActivityRoles(eventCtx.Context, welcomer.SandwichClient, int64(event.GuildID), int64(eventCtx.Identifier.UserId), activityRoles)
+	if err != nil {
+		welcomer.Logger.Error().Err(err).
+			Int64("guild_id", int64(event.GuildID)).
+			Msg("Failed to filter assignable activity roles")
+
+		return err
+	}
+
+	if len(assignableActivityRoles) == 0 {
+		return nil
+	}
+
+	for start := 0; start < len(event.UserIDs); start += ActivityRolesBatchSize {
+		end := min(start+ActivityRolesBatchSize, len(event.UserIDs))
+
+		err = p.processBatch(eventCtx, event.GuildID, event.UserIDs[start:end], assignableActivityRoles, guildSettingsActivityRoles.ToggleDecay)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (p *ActivityRolesCog) processBatch(eventCtx *sandwich.EventContext, guildID discord.Snowflake, userIDs []discord.Snowflake, activityRoles []welcomer.GuildSettingsActivityRolesRole, decay bool) error {
+	userIDsInt := make([]int64, len(userIDs))
+	for i, userID := range userIDs {
+		userIDsInt[i] = int64(userID)
+	}
+
+	now := time.Now()
+
+	// Activity is summed once per window that is in use.
+	totals := make(map[int64]map[int]welcomer.ActivityTotals, len(userIDs))
+
+	for _, days := range welcomer.GetActivityRoleWindows(activityRoles) {
+		since := now.AddDate(0, 0, -days)
+
+		rows, err := welcomer.Queries.GetGuildActivityTotals(eventCtx.Context, database.GetGuildActivityTotalsParams{
+			Granularity: welcomer.GetMessageCountGranularity(since, now),
+			GuildID:     int64(guildID),
+			Since:       since,
+			UserIds:     userIDsInt,
+		})
+		if err != nil {
+			welcomer.Logger.Error().Err(err).
+				Int64("guild_id", int64(guildID)).
+				Int("days", days).
+				Msg("Failed to get guild activity totals")
+
+			return err
+		}
+
+		for _, row := range rows {
+			if _, ok := totals[row.UserID]; !ok {
+				totals[row.UserID] = make(map[int]welcomer.ActivityTotals)
+			}
+
+			totals[row.UserID][days] = welcomer.ActivityTotals{
+				MessageCount: row.MessageCount,
+				VoiceSeconds: row.TotalTimeMs / 1000,
+			}
+		}
+	}
+
+	assignedRoles := make(map[int64][]discord.Snowflake)
+
+	if decay {
+		activityRoleMembers, err := welcomer.Queries.GetActivityRoleMembersByUsers(eventCtx.Context, database.GetActivityRoleMembersByUsersParams{
+			GuildID: int64(guildID),
+			UserIds: userIDsInt,
+		})
+		if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+			welcomer.Logger.Error().Err(err).
+				Int64("guild_id", int64(guildID)).
+				Msg("Failed to get activity role members")
+
+			return err
+		}
+
+		for _, activityRoleMember := range activityRoleMembers {
+			assignedRoles[activityRoleMember.UserID] = append(assignedRoles[activityRoleMember.UserID], discord.Snowflake(activityRoleMember.RoleID))
+		}
+	}
+
+	guildMembers, err := welcomer.SandwichClient.FetchGuildMember(eventCtx.Context, &pb.FetchGuildMemberRequest{
+		GuildId: int64(guildID),
+		UserIds: userIDsInt,
+	})
+	if err != nil || guildMembers == nil {
+		welcomer.Logger.Error().Err(err).
+			Int64("guild_id", int64(guildID)).
+			Msg("Failed to fetch guild members")
+
+		return err
+	}
+
+	given := 0
+	removed := 0
+
+	for _, userID := range userIDsInt {
+		memberPb, ok := guildMembers.GuildMembers[userID]
+		if !ok {
+			continue
+		}
+
+		member := pb.PBToGuildMember(memberPb)
+		member.GuildID = &guildID
+
+		if member.User == nil || member.User.Bot {
+			continue
+		}
+
+		rolesToAssign, rolesToRemove := welcomer.GetActivityRoleChanges(member.Roles, assignedRoles[userID], activityRoles, totals[userID], decay)
+
+		if len(rolesToRemove) > 0 {
+			err = member.RemoveRoles(eventCtx.Context, eventCtx.Session, rolesToRemove, new("Automatically removed with ActivityRoles"), true)
+			if err != nil {
+				welcomer.Logger.Error().Err(err).
+					Int64("guild_id", int64(guildID)).
+					Int64("member_id", userID).
+					Interface("roles", rolesToRemove).
+					Msg("Failed to remove roles from member for activity roles")
+			} else {
+				for _, role := range rolesToRemove {
+					p.forgetRole(eventCtx, guildID, userID, role)
+
+					welcomer.PusherGuildScience.Push(
+						eventCtx.Context,
+						guildID,
+						member.User.ID,
+						database.ScienceGuildEventTypeActivityRoleRemoved,
+						welcomer.GuildScienceActivityRoleGivenRemoved{
+							RoleID: role,
+						})
+				}
+
+				removed += len(rolesToRemove)
+			}
+		}
+
+		if len(rolesToAssign) > 0 {
+			err = member.AddRoles(eventCtx.Context, eventCtx.Session, rolesToAssign, new("Automatically assigned with ActivityRoles"), true)
+			if err != nil {
+				welcomer.Logger.Error().Err(err).
+					Int64("guild_id", int64(guildID)).
+					Int64("member_id", userID).
+					Interface("roles", rolesToAssign).
+					Msg("Failed to add roles to member for activity roles")
+			} else {
+				for _, role := range rolesToAssign {
+					p.rememberRole(eventCtx, guildID, userID, role)
+
+					welcomer.PusherGuildScience.Push(
+						eventCtx.Context,
+						guildID,
+						member.User.ID,
+						database.ScienceGuildEventTypeActivityRoleGiven,
+						welcomer.GuildScienceActivityRoleGivenRemoved{
+							RoleID: role,
+						})
+				}
+
+				given += len(rolesToAssign)
+			}
+		}
+	}
+
+	welcomer.Logger.Info().
+		Int64("guild_id", int64(guildID)).
+		Int("users", len(userIDs)).
+		Int("given", given).
+		Int("removed", removed).
+		Msg("Processed activity roles for members")
+
+	return nil
+}
+
+// rememberRole records that a role was given by activity roles, so it can decay later.
+func (p *ActivityRolesCog) rememberRole(eventCtx *sandwich.EventContext, guildID discord.Snowflake, userID int64, roleID discord.Snowflake) {
+	_, err := welcomer.Queries.CreateActivityRoleMember(eventCtx.Context, database.CreateActivityRoleMemberParams{
+		GuildID: int64(guildID),
+		UserID:  userID,
+		RoleID:  int64(roleID),
+	})
+	if err != nil {
+		welcomer.Logger.Error().Err(err).
+			Int64("guild_id", int64(guildID)).
+			Int64("user_id", userID).
+			Int64("role_id", int64(roleID)).
+			Msg("Failed to create activity role member")
+	}
+}
+
+func (p *ActivityRolesCog) forgetRole(eventCtx *sandwich.EventContext, guildID discord.Snowflake, userID int64, roleID discord.Snowflake) {
+	_, err := welcomer.Queries.DeleteActivityRoleMember(eventCtx.Context, database.DeleteActivityRoleMemberParams{
+		GuildID: int64(guildID),
+		UserID:  userID,
+		RoleID:  int64(roleID),
+	})
+	if err != nil {
+		welcomer.Logger.Error().Err(err).
+			Int64("guild_id", int64(guildID)).
+			Int64("user_id", userID).
+			Int64("role_id", int64(roleID)).
+			Msg("Failed to delete activity role member")
+	}
+}
+
+func (p *ActivityRolesCog) FetchGuildInformation(eventCtx *sandwich.EventContext, guildID discord.Snowflake) (guildSettingsActivityRoles *database.GuildSettingsActivityroles, activityRoles []welcomer.GuildSettingsActivityRolesRole, err error) {
+	guildSettingsActivityRoles, err = welcomer.Queries.GetActivityRolesGuildSettings(eventCtx.Context, int64(guildID))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			guildSettingsActivityRoles = &database.GuildSettingsActivityroles{
+				GuildID:       int64(guildID),
+				ToggleEnabled: welcomer.DefaultActivityRoles.ToggleEnabled,
+				ToggleDecay:   welcomer.DefaultActivityRoles.ToggleDecay,
+				Activityroles: welcomer.DefaultActivityRoles.Activityroles,
+			}
+		} else {
+			welcomer.Logger.Error().Err(err).
+				Int64("guild_id", int64(guildID)).
+				Msg("Failed to get activity role settings")
+
+			return nil, nil, err
+		}
+	}
+
+	activityRoles = welcomer.UnmarshalActivityRolesJSON(guildSettingsActivityRoles.Activityroles.Bytes)
+
+	return guildSettingsActivityRoles, activityRoles, nil
+}
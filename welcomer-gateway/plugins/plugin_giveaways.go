@@ -2,9 +2,11 @@ package plugins
 
 import (
 	"crypto/rand"
+	"errors"
 	"fmt"
 	"math/big"
 	"slices"
+	"time"
 
 	"github.com/WelcomerTeam/Discord/discord"
 	sandwich_daemon "github.com/WelcomerTeam/Sandwich-Daemon"
@@ -13,6 +15,8 @@ import (
 	"github.com/WelcomerTeam/Welcomer/welcomer-core"
 	core "github.com/WelcomerTeam/Welcomer/welcomer-core"
 	"github.com/WelcomerTeam/Welcomer/welcomer-core/database"
+	"github.com/gofrs/uuid"
+	"github.com/jackc/pgx/v4"
 )
 
 type GiveawayCog struct {
@@ -44,6 +48,31 @@ func (g *GiveawayCog) GetEventHandlers() *sandwich.Handlers {
 }
 
 func (g *GiveawayCog) RegisterCog(bot *sandwich.Bot) error {
+	// Register giveaway start handler.
+
+	g.EventHandler.RegisterEventHandler(core.CustomEventInvokeStartGiveaway, func(eventCtx *sandwich.EventContext, payload sandwich_daemon.ProducedPayload) error {
+		var invokeGiveawayStartPayload core.CustomEventInvokeStartGiveawayStructure
+		if err := eventCtx.DecodeContent(payload, &invokeGiveawayStartPayload); err != nil {
+			return fmt.Errorf("failed to unmarshal payload: %w", err)
+		}
+
+		eventCtx.Guild = sandwich.NewGuild(invokeGiveawayStartPayload.GuildID)
+
+		eventCtx.EventHandler.EventsMu.RLock()
+		defer eventCtx.EventHandler.EventsMu.RUnlock()
+
+		for _, event := range eventCtx.EventHandler.Events {
+			if f, ok := event.(welcomer.OnInvokeStartGiveawayFuncType); ok {
+				return eventCtx.Handlers.WrapFuncType(eventCtx, f(eventCtx, invokeGiveawayStartPayload))
+			}
+		}
+
+		return nil
+	})
+
+	// Call OnInvokeStartGiveaway when CustomEventInvokeStartGiveaway is triggered.
+	g.EventHandler.RegisterEvent(core.CustomEventInvokeStartGiveaway, nil, (welcomer.OnInvokeStartGiveawayFuncType)(g.OnInvokeStartGiveaway))
+
 	// Register giveaway end handler.
 
 	g.EventHandler.RegisterEventHandler(core.CustomEventInvokeEndGiveaway, func(eventCtx *sandwich.EventContext, payload sandwich_daemon.ProducedPayload) error {
@@ -69,6 +98,84 @@ func (g *GiveawayCog) RegisterCog(bot *sandwich.Bot) error {
 	// Call OnInvokeEndGiveaway when CustomEventInvokeEndGiveaway is triggered.
 	g.EventHandler.RegisterEvent(core.CustomEventInvokeEndGiveaway, nil, (welcomer.OnInvokeEndGiveawayFuncType)(g.OnInvokeEndGiveaway))
 
+	// Register giveaway reroll handler.
+
+	g.EventHandler.RegisterEventHandler(core.CustomEventInvokeRerollGiveaway, func(eventCtx *sandwich.EventContext, payload sandwich_daemon.ProducedPayload) error {
+		var invokeGiveawayRerollPayload core.CustomEventInvokeRerollGiveawayStructure
+		if err := eventCtx.DecodeContent(payload, &invokeGiveawayRerollPayload); err != nil {
+			return fmt.Errorf("failed to unmarshal payload: %w", err)
+		}
+
+		eventCtx.Guild = sandwich.NewGuild(invokeGiveawayRerollPayload.GuildID)
+
+		eventCtx.EventHandler.EventsMu.RLock()
+		defer eventCtx.EventHandler.EventsMu.RUnlock()
+
+		for _, event := range eventCtx.EventHandler.Events {
+			if f, ok := event.(welcomer.OnInvokeRerollGiveawayFuncType); ok {
+				return eventCtx.Handlers.WrapFuncType(eventCtx, f(eventCtx, invokeGiveawayRerollPayload))
+			}
+		}
+
+		return nil
+	})
+
+	// Call OnInvokeRerollGiveaway when CustomEventInvokeRerollGiveaway is triggered.
+	g.EventHandler.RegisterEvent(core.CustomEventInvokeRerollGiveaway, nil, (welcomer.OnInvokeRerollGiveawayFuncType)(g.OnInvokeRerollGiveaway))
+
+	// Register giveaway prize role expiry handler.
+
+	g.EventHandler.RegisterEventHandler(core.CustomEventInvokeExpireGiveawayRoles, func(eventCtx *sandwich.EventContext, payload sandwich_daemon.ProducedPayload) error {
+		var invokeExpireGiveawayRolesPayload core.CustomEventInvokeExpireGiveawayRolesStructure
+		if err := eventCtx.DecodeContent(payload, &invokeExpireGiveawayRolesPayload); err != nil {
+			return fmt.Errorf("failed to unmarshal payload: %w", err)
+		}
+
+		eventCtx.Guild = sandwich.NewGuild(invokeExpireGiveawayRolesPayload.GuildID)
+
+		eventCtx.EventHandler.EventsMu.RLock()
+		defer eventCtx.EventHandler.EventsMu.RUnlock()
+
+		for _, event := range eventCtx.EventHandler.Events {
+			if f, ok := event.(welcomer.OnInvokeExpireGiveawayRolesFuncType); ok {
+				return eventCtx.Handlers.WrapFuncType(eventCtx, f(eventCtx, invokeExpireGiveawayRolesPayload))
+			}
+		}
+
+		return nil
+	})
+
+	// Call OnInvokeExpireGiveawayRoles when CustomEventInvokeExpireGiveawayRoles is triggered.
+	g.EventHandler.RegisterEvent(core.CustomEventInvokeExpireGiveawayRoles, nil, (welcomer.OnInvokeExpireGiveawayRolesFuncType)(g.OnInvokeExpireGiveawayRoles))
+
+	return nil
+}
+
+func (g *GiveawayCog) OnInvokeStartGiveaway(eventCtx *sandwich.EventContext, event core.CustomEventInvokeStartGiveawayStructure) error {
+	welcomer.Logger.Info().
+		Str("giveaway_uuid", event.GiveawayUUID.String()).
+		Msg("Received giveaway start event, processing giveaway start")
+
+	giveaway, err := welcomer.Queries.GetGiveaway(eventCtx.Context, database.GetGiveawayParams{
+		GiveawayUuid: event.GiveawayUUID,
+		GuildID:      int64(event.GuildID),
+	})
+	if err != nil {
+		welcomer.Logger.Error().Err(err).
+			Str("giveaway_uuid", event.GiveawayUUID.String()).
+			Msg("Failed to get giveaway for giveaway start event")
+
+		return err
+	}
+
+	if err := g.StartGiveaway(eventCtx, giveaway); err != nil {
+		welcomer.Logger.Error().Err(err).
+			Str("giveaway_uuid", event.GiveawayUUID.String()).
+			Msg("Failed to start giveaway for giveaway start event")
+
+		return err
+	}
+
 	return nil
 }
 
@@ -108,6 +215,320 @@ func (g *GiveawayCog) OnInvokeEndGiveaway(eventCtx *sandwich.EventContext, event
 	return nil
 }
 
+func (g *GiveawayCog) OnInvokeRerollGiveaway(eventCtx *sandwich.EventContext, event core.CustomEventInvokeRerollGiveawayStructure) error {
+	welcomer.Logger.Info().
+		Str("giveaway_uuid", event.GiveawayUUID.String()).
+		Msg("Received giveaway reroll event, processing unclaimed prizes")
+
+	giveaway, err := welcomer.Queries.GetGiveaway(eventCtx.Context, database.GetGiveawayParams{
+		GiveawayUuid: event.GiveawayUUID,
+		GuildID:      int64(event.GuildID),
+	})
+	if err != nil {
+		welcomer.Logger.Error().Err(err).
+			Str("giveaway_uuid", event.GiveawayUUID.String()).
+			Msg("Failed to get giveaway for giveaway reroll event")
+
+		return err
+	}
+
+	if err := g.RerollGiveaway(eventCtx, giveaway, event.GiveawayWinnerUUID); err != nil {
+		welcomer.Logger.Error().Err(err).
+			Str("giveaway_uuid", event.GiveawayUUID.String()).
+			Msg("Failed to reroll giveaway for giveaway reroll event")
+
+		return err
+	}
+
+	return nil
+}
+
+func (g *GiveawayCog) OnInvokeExpireGiveawayRoles(eventCtx *sandwich.EventContext, event core.CustomEventInvokeExpireGiveawayRolesStructure) error {
+	welcomer.Logger.Info().
+		Str("giveaway_uuid", event.GiveawayUUID.String()).
+		Msg("Received giveaway role expiry event, removing expired prize roles")
+
+	giveaway, err := welcomer.Queries.GetGiveaway(eventCtx.Context, database.GetGiveawayParams{
+		GiveawayUuid: event.GiveawayUUID,
+		GuildID:      int64(event.GuildID),
+	})
+	if err != nil {
+		welcomer.Logger.Error().Err(err).
+			Str("giveaway_uuid", event.GiveawayUUID.String()).
+			Msg("Failed to get giveaway for giveaway role expiry event")
+
+		return err
+	}
+
+	winners, err := welcomer.Queries.GetExpiredGiveawayPrizeRoles(eventCtx.Context, giveaway.GiveawayUuid)
+	if err != nil {
+		welcomer.Logger.Error().Err(err).
+			Str("giveaway_uuid", event.GiveawayUUID.String()).
+			Msg("Failed to get expired giveaway prize roles")
+
+		return err
+	}
+
+	for _, winner := range winners {
+		if err := welcomer.RemoveExpiredGiveawayPrizeRole(eventCtx.Context, eventCtx.Session, giveaway, winner); err != nil {
+			welcomer.Logger.Warn().Err(err).
+				Str("giveaway_uuid", giveaway.GiveawayUuid.String()).
+				Int64("user_id", winner.UserID).
+				Int64("role_id", winner.RoleID).
+				Msg("Failed to remove expired giveaway prize role")
+		}
+	}
+
+	return nil
+}
+
+// StartGiveaway sends the giveaway message for a scheduled giveaway.
+func (g *GiveawayCog) StartGiveaway(eventCtx *sandwich.EventContext, giveaway *database.GuildGiveaways) error {
+	if giveaway.HasEnded || giveaway.IsSetup || giveaway.MessageID != 0 {
+		welcomer.Logger.Warn().
+			Str("giveaway_uuid", giveaway.GiveawayUuid.String()).
+			Msg("Giveaway has already started, skipping giveaway start")
+
+		return nil
+	}
+
+	if err := welcomer.PrepareProvablyFairGiveaway(eventCtx.Context, giveaway); err != nil {
+		welcomer.Logger.Error().Err(err).
+			Str("giveaway_uuid", giveaway.GiveawayUuid.String()).
+			Msg("Failed to prepare provably fair giveaway")
+
+		return err
+	}
+
+	channel := discord.Channel{ID: discord.Snowflake(giveaway.ChannelID)}
+
+	message, err := channel.Send(eventCtx.Context, eventCtx.Session, welcomer.WebhookMessageParamsToMessageParams(welcomer.GetGiveawayMessage(giveaway, 0)))
+	if err != nil {
+		welcomer.Logger.Error().Err(err).
+			Str("giveaway_uuid", giveaway.GiveawayUuid.String()).
+			Int64("channel_id", giveaway.ChannelID).
+			Msg("Failed to send giveaway message")
+
+		return err
+	}
+
+	if giveaway.PingContent != "" {
+		_, err = channel.Send(eventCtx.Context, eventCtx.Session, discord.MessageParams{
+			Content: giveaway.PingContent,
+		})
+		if err != nil {
+			welcomer.Logger.Error().Err(err).
+				Str("giveaway_uuid", giveaway.GiveawayUuid.String()).
+				Msg("Failed to send giveaway ping message")
+		}
+	}
+
+	_, err = welcomer.Queries.UpdateGiveawayMessage(eventCtx.Context, database.UpdateGiveawayMessageParams{
+		GiveawayUuid: giveaway.GiveawayUuid,
+		MessageID:    int64(message.ID),
+		ChannelID:    int64(message.ChannelID),
+	})
+	if err != nil {
+		welcomer.Logger.Error().Err(err).
+			Str("giveaway_uuid", giveaway.GiveawayUuid.String()).
+			Msg("Failed to update giveaway message and channel")
+
+		return err
+	}
+
+	welcomer.PusherGuildScience.Push(
+		eventCtx.Context,
+		discord.Snowflake(giveaway.GuildID),
+		0,
+		database.ScienceGuildEventTypeGiveawayStarted,
+		&welcomer.GuildScienceGiveawayEvents{
+			GiveawayUUID: giveaway.GiveawayUuid,
+		},
+	)
+
+	return nil
+}
+
+// ScheduleNextGiveaway creates the next occurrence of a recurring giveaway.
+// The new giveaway copies all settings from the previous one and is started
+// by the finish-giveaways job once its start time has passed.
+func (g *GiveawayCog) ScheduleNextGiveaway(eventCtx *sandwich.EventContext, giveaway *database.GuildGiveaways) error {
+	recurrence, err := welcomer.ParseGiveawayRecurrence(giveaway.RecurrenceRule)
+	if err != nil || recurrence == nil {
+		return err
+	}
+
+	// Occurrences are counted from the first giveaway in the series, so the
+	// schedule does not drift when a month is shorter than the anchor day.
+	anchor := welcomer.If(giveaway.RecurrenceAnchor.Unix() > 0, giveaway.RecurrenceAnchor, giveaway.StartTime)
+
+	startTime := recurrence.NextAfter(anchor, giveaway.StartTime, time.Now())
+	if startTime.IsZero() {
+		welcomer.Logger.Warn().
+			Str("giveaway_uuid", giveaway.GiveawayUuid.String()).
+			Str("recurrence_rule", giveaway.RecurrenceRule).
+			Msg("Recurrence rule has no future occurrences, not scheduling next giveaway")
+
+		return nil
+	}
+
+	// Keep the same duration as the previous giveaway, unless it ran indefinitely.
+	endTime := time.Time{}
+
+	if giveaway.EndTime.After(giveaway.StartTime) {
+		endTime = startTime.Add(giveaway.EndTime.Sub(giveaway.StartTime))
+	}
+
+	nextGiveaway, err := welcomer.Queries.CreateGiveaway(eventCtx.Context, database.CreateGiveawayParams{
+		GuildID:     giveaway.GuildID,
+		CreatedBy:   giveaway.CreatedBy,
+		Title:       giveaway.Title,
+		Description: giveaway.Description,
+		EndTime:     endTime,
+	})
+	if err != nil {
+		welcomer.Logger.Error().Err(err).
+			Str("giveaway_uuid", giveaway.GiveawayUuid.String()).
+			Msg("Failed to create next recurring giveaway")
+
+		return err
+	}
+
+	_, err = welcomer.Queries.UpdateGiveawayMessage(eventCtx.Context, database.UpdateGiveawayMessageParams{
+		GiveawayUuid: nextGiveaway.GiveawayUuid,
+		MessageID:    0,
+		ChannelID:    giveaway.ChannelID,
+	})
+	if err != nil {
+		welcomer.Logger.Error().Err(err).
+			Str("giveaway_uuid", nextGiveaway.GiveawayUuid.String()).
+			Msg("Failed to update next recurring giveaway channel")
+
+		return err
+	}
+
+	_, err = welcomer.Queries.UpdateGiveaway(eventCtx.Context, database.UpdateGiveawayParams{
+		GiveawayUuid:    nextGiveaway.GiveawayUuid,
+		IsSetup:         false,
+		Title:           giveaway.Title,
+		StartTime:       startTime,
+		EndTime:         endTime,
+		AnnounceWinners: giveaway.AnnounceWinners,
+		GiveawayPrizes:  giveaway.GiveawayPrizes,
+		RolesAllowed:    giveaway.RolesAllowed,
+		RolesExcluded:   giveaway.RolesExcluded,
+		MinimumJoinDate: giveaway.MinimumJoinDate,
+		Description:     giveaway.Description,
+		AccentColour:    giveaway.AccentColour,
+		ImageUrl:        giveaway.ImageUrl,
+		ShowPrizes:      giveaway.ShowPrizes,
+		ShowEntries:     giveaway.ShowEntries,
+		AllowEntries:    true,
+		HasEnded:        false,
+
+		MinimumAccountAge:   giveaway.MinimumAccountAge,
+		MinimumMessageCount: giveaway.MinimumMessageCount,
+		MinimumVoiceMinutes: giveaway.MinimumVoiceMinutes,
+		ActivityPeriodDays:  giveaway.ActivityPeriodDays,
+
+		RecurrenceRule: giveaway.RecurrenceRule,
+		PingContent:    giveaway.PingContent,
+		ProvablyFair:   giveaway.ProvablyFair,
+		ClaimWindow:    giveaway.ClaimWindow,
+	})
+	if err != nil {
+		welcomer.Logger.Error().Err(err).
+			Str("giveaway_uuid", nextGiveaway.GiveawayUuid.String()).
+			Msg("Failed to update next recurring giveaway settings")
+
+		return err
+	}
+
+	err = welcomer.Queries.SetGiveawayRecurrenceAnchor(eventCtx.Context, database.SetGiveawayRecurrenceAnchorParams{
+		GiveawayUuid:     nextGiveaway.GiveawayUuid,
+		RecurrenceAnchor: anchor,
+	})
+	if err != nil {
+		welcomer.Logger.Error().Err(err).
+			Str("giveaway_uuid", nextGiveaway.GiveawayUuid.String()).
+			Msg("Failed to set next recurring giveaway anchor")
+
+		return err
+	}
+
+	// Codes are added to the first giveaway, so every occurrence draws from the same pool.
+	err = welcomer.Queries.SetGiveawayPrizeCodePool(eventCtx.Context, database.SetGiveawayPrizeCodePoolParams{
+		GiveawayUuid:      nextGiveaway.GiveawayUuid,
+		PrizeCodePoolUuid: welcomer.GetGiveawayPrizeCodePool(giveaway),
+	})
+	if err != nil {
+		welcomer.Logger.Error().Err(err).
+			Str("giveaway_uuid", nextGiveaway.GiveawayUuid.String()).
+			Msg("Failed to set next recurring giveaway prize code pool")
+
+		return err
+	}
+
+	welcomer.Logger.Info().
+		Str("giveaway_uuid", giveaway.GiveawayUuid.String()).
+		Str("next_giveaway_uuid", nextGiveaway.GiveawayUuid.String()).
+		Time("start_time", startTime).
+		Msg("Scheduled next recurring giveaway")
+
+	welcomer.PusherGuildScience.Push(
+		eventCtx.Context,
+		discord.Snowflake(giveaway.GuildID),
+		0,
+		database.ScienceGuildEventTypeGiveawayCreated,
+		&welcomer.GuildScienceGiveawayEvents{
+			GiveawayUUID: nextGiveaway.GiveawayUuid,
+		},
+	)
+
+	return nil
+}
+
+// assignProvablyFairWinners draws winners from the giveaway's committed seed.
+// Returns the revealed server seed, or an empty string if the giveaway has no
+// draw so winners can be drawn normally instead.
+func (g *GiveawayCog) assignProvablyFairWinners(eventCtx *sandwich.EventContext, giveaway *database.GuildGiveaways, entries []int64, prizes []welcomer.GiveawayPrize, claimExpiresAt time.Time) (string, error) {
+	draw, winners, err := welcomer.DrawProvablyFairGiveaway(eventCtx.Context, giveaway.GiveawayUuid, entries, prizes)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			welcomer.Logger.Warn().
+				Str("giveaway_uuid", giveaway.GiveawayUuid.String()).
+				Msg("Provably fair giveaway has no draw, falling back to random winner selection")
+
+			return "", nil
+		}
+
+		welcomer.Logger.Error().Err(err).
+			Str("giveaway_uuid", giveaway.GiveawayUuid.String()).
+			Msg("Failed to draw provably fair giveaway winners")
+
+		return "", err
+	}
+
+	for _, winner := range winners {
+		if _, err = welcomer.Queries.CreateGiveawayWinner(eventCtx.Context, database.CreateGiveawayWinnerParams{
+			GiveawayUuid:   giveaway.GiveawayUuid,
+			UserID:         int64(winner.UserID),
+			Prize:          winner.Prize,
+			MessageID:      0,
+			ClaimExpiresAt: claimExpiresAt,
+		}); err != nil {
+			welcomer.Logger.Error().Err(err).
+				Str("giveaway_uuid", giveaway.GiveawayUuid.String()).
+				Int64("winner_id", int64(winner.UserID)).
+				Msg("Failed to add giveaway winner to database")
+
+			return "", err
+		}
+	}
+
+	return draw.ServerSeed, nil
+}
+
 func secureRandomInt(n int64) (int64, error) {
 	max := big.NewInt(n)
 
@@ -165,9 +586,69 @@ func (g *GiveawayCog) EndGiveaway(eventCtx *sandwich.EventContext, giveaway *dat
 		}
 	}()
 
+	if err = g.assignGiveawayWinners(eventCtx, giveaway, nil); err != nil {
+		return err
+	}
+
+	msg, err := discord.GetChannelMessage(eventCtx.Context, eventCtx.Session, discord.Snowflake(giveaway.ChannelID), discord.Snowflake(giveaway.MessageID))
+	if err != nil {
+		welcomer.Logger.Error().Err(err).
+			Str("giveaway_uuid", giveaway.GiveawayUuid.String()).
+			Msg("Failed to fetch giveaway message for giveaway end")
+
+		return err
+	}
+
+	for i := range msg.Components {
+		for j := range msg.Components[i].Components {
+			if msg.Components[i].Components[j].Type == discord.InteractionComponentTypeButton {
+				msg.Components[i].Components[j].Label = "This giveaway has ended"
+				msg.Components[i].Components[j].Disabled = true
+			}
+		}
+	}
+
+	_, err = msg.Edit(eventCtx.Context, eventCtx.Session, discord.MessageParams{
+		Components: msg.Components,
+	})
+	if err != nil {
+		welcomer.Logger.Error().Err(err).
+			Str("giveaway_uuid", giveaway.GiveawayUuid.String()).
+			Msg("Failed to edit giveaway message to disable buttons for giveaway end")
+
+		return err
+	}
+
+	welcomer.PusherGuildScience.Push(
+		eventCtx.Context,
+		discord.Snowflake(giveaway.GuildID),
+		0,
+		database.ScienceGuildEventTypeGiveawayEnded,
+		&welcomer.GuildScienceGiveawayEvents{
+			GiveawayUUID: giveaway.GiveawayUuid,
+		},
+	)
+
+	if giveaway.RecurrenceRule != "" {
+		// Failing to schedule the next giveaway should not undo ending this one.
+		if scheduleErr := g.ScheduleNextGiveaway(eventCtx, giveaway); scheduleErr != nil {
+			welcomer.Logger.Error().Err(scheduleErr).
+				Str("giveaway_uuid", giveaway.GiveawayUuid.String()).
+				Msg("Failed to schedule next recurring giveaway")
+		}
+	}
+
+	return nil
+}
+
+// assignGiveawayWinners draws winners for the prizes which have not been won yet.
+// When rerolling, rerolled holds the winners being replaced. Their prizes are
+// drawn again and they are only expired once the new winners have been added,
+// so a failed reroll does not leave a prize without a winner.
+func (g *GiveawayCog) assignGiveawayWinners(eventCtx *sandwich.EventContext, giveaway *database.GuildGiveaways, rerolled []*database.GuildGiveawaysWinners) error {
 	prizes := welcomer.UnmarshalGiveawayPrizeJSON(giveaway.GiveawayPrizes.Bytes)
 
-	_, err = welcomer.SandwichClient.RequestGuildChunk(eventCtx.Context, &pb.RequestGuildChunkRequest{
+	_, err := welcomer.SandwichClient.RequestGuildChunk(eventCtx.Context, &pb.RequestGuildChunkRequest{
 		GuildId: giveaway.GuildID,
 	})
 	if err != nil {
@@ -232,8 +713,15 @@ func (g *GiveawayCog) EndGiveaway(eventCtx *sandwich.EventContext, giveaway *dat
 
 	wonPrizes := make(map[string]int)
 
-	// Remove prize count for existing winners.
+	// Remove prize count for existing winners. Winners who did not claim their
+	// prize in time, or are being rerolled, no longer hold it, so it is drawn again.
 	for _, winner := range existingWinners {
+		if winner.IsExpired || slices.ContainsFunc(rerolled, func(value *database.GuildGiveawaysWinners) bool {
+			return value.GiveawayWinnerUuid == winner.GiveawayWinnerUuid
+		}) {
+			continue
+		}
+
 		_, ok := wonPrizes[winner.Prize]
 		if !ok {
 			wonPrizes[winner.Prize] = 0
@@ -249,44 +737,87 @@ func (g *GiveawayCog) EndGiveaway(eventCtx *sandwich.EventContext, giveaway *dat
 		}
 	}
 
+	claimExpiresAt := time.Unix(0, 0)
+
+	if giveaway.AnnounceWinners && giveaway.ClaimWindow > 0 {
+		claimExpiresAt = time.Now().Add(time.Duration(giveaway.ClaimWindow) * time.Second)
+	}
+
+	var serverSeed string
+
+	// Rerolls are drawn normally so the revealed draw can still be verified.
+	if giveaway.ProvablyFair && len(rerolled) == 0 {
+		serverSeed, err = g.assignProvablyFairWinners(eventCtx, giveaway, entries, prizes, claimExpiresAt)
+		if err != nil {
+			return err
+		}
+	}
+
 	// Assign winners
-	for _, prize := range prizes {
-		for i := 0; i < prize.Count; i++ {
-			if len(entries) == 0 {
-				welcomer.Logger.Info().
-					Str("giveaway_uuid", giveaway.GiveawayUuid.String()).
-					Msg("Not enough entries to assign all winners for giveaway")
+	if serverSeed == "" {
+		for _, prize := range prizes {
+			for i := 0; i < prize.Count; i++ {
+				if len(entries) == 0 {
+					welcomer.Logger.Info().
+						Str("giveaway_uuid", giveaway.GiveawayUuid.String()).
+						Msg("Not enough entries to assign all winners for giveaway")
 
-				break
-			}
+					break
+				}
 
-			randomIndex, err := secureRandomInt(int64(len(entries)))
-			if err != nil {
-				welcomer.Logger.Error().Err(err).
-					Str("giveaway_uuid", giveaway.GiveawayUuid.String()).
-					Msg("Failed to generate secure random index for giveaway winner selection")
+				randomIndex, err := secureRandomInt(int64(len(entries)))
+				if err != nil {
+					welcomer.Logger.Error().Err(err).
+						Str("giveaway_uuid", giveaway.GiveawayUuid.String()).
+						Msg("Failed to generate secure random index for giveaway winner selection")
 
-				return err
-			}
+					return err
+				}
 
-			winnerID := entries[randomIndex]
+				winnerID := entries[randomIndex]
 
-			if _, err = welcomer.Queries.CreateGiveawayWinner(eventCtx.Context, database.CreateGiveawayWinnerParams{
-				GiveawayUuid: giveaway.GiveawayUuid,
-				UserID:       winnerID,
-				Prize:        prize.Title,
-				MessageID:    0,
-			}); err != nil {
-				welcomer.Logger.Error().Err(err).
-					Str("giveaway_uuid", giveaway.GiveawayUuid.String()).
-					Int64("winner_id", winnerID).
-					Msg("Failed to add giveaway winner to database")
+				if _, err = welcomer.Queries.CreateGiveawayWinner(eventCtx.Context, database.CreateGiveawayWinnerParams{
+					GiveawayUuid:   giveaway.GiveawayUuid,
+					UserID:         winnerID,
+					Prize:          prize.Title,
+					MessageID:      0,
+					ClaimExpiresAt: claimExpiresAt,
+				}); err != nil {
+					welcomer.Logger.Error().Err(err).
+						Str("giveaway_uuid", giveaway.GiveawayUuid.String()).
+						Int64("winner_id", winnerID).
+						Msg("Failed to add giveaway winner to database")
+
+					return err
+				}
 
-				return err
+				// Remove the selected winner from the entries slice
+				entries = append(entries[:randomIndex], entries[randomIndex+1:]...)
 			}
+		}
+	}
 
-			// Remove the selected winner from the entries slice
-			entries = append(entries[:randomIndex], entries[randomIndex+1:]...)
+	for _, winner := range rerolled {
+		_, err = welcomer.Queries.ExpireGiveawayWinner(eventCtx.Context, winner.GiveawayWinnerUuid)
+		if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+			welcomer.Logger.Error().Err(err).
+				Str("giveaway_uuid", giveaway.GiveawayUuid.String()).
+				Str("giveaway_winner_uuid", winner.GiveawayWinnerUuid.String()).
+				Msg("Failed to expire rerolled giveaway winner")
+
+			return err
+		}
+
+		// The prize now belongs to someone else, so a delivered role is taken back.
+		// Failures are retried by the prize role expiry job.
+		if winner.RoleID != 0 && !winner.IsRoleRemoved {
+			if err := welcomer.RemoveExpiredGiveawayPrizeRole(eventCtx.Context, eventCtx.Session, giveaway, winner); err != nil {
+				welcomer.Logger.Warn().Err(err).
+					Str("giveaway_uuid", giveaway.GiveawayUuid.String()).
+					Int64("user_id", winner.UserID).
+					Int64("role_id", winner.RoleID).
+					Msg("Failed to remove rerolled giveaway prize role")
+			}
 		}
 	}
 
@@ -318,14 +849,7 @@ func (g *GiveawayCog) EndGiveaway(eventCtx *sandwich.EventContext, giveaway *dat
 				Str("prize", winner.Prize).
 				Msg("Giveaway winner selected")
 
-			message, err := channel.Send(eventCtx.Context, eventCtx.Session, discord.MessageParams{
-				Content: fmt.Sprintf("Congratulations <@%d>, you won **%s**", winner.UserID, winner.Prize),
-				MessageReference: &discord.MessageReference{
-					ID:              new(discord.Snowflake(giveaway.MessageID)),
-					ChannelID:       new(discord.Snowflake(giveaway.ChannelID)),
-					FailIfNotExists: false,
-				},
-			})
+			message, err := channel.Send(eventCtx.Context, eventCtx.Session, getGiveawayWinnerMessage(giveaway, winner))
 			if err != nil {
 				welcomer.Logger.Error().Err(err).
 					Str("giveaway_uuid", giveaway.GiveawayUuid.String()).
@@ -350,44 +874,145 @@ func (g *GiveawayCog) EndGiveaway(eventCtx *sandwich.EventContext, giveaway *dat
 		}
 	}
 
-	msg, err := discord.GetChannelMessage(eventCtx.Context, eventCtx.Session, discord.Snowflake(giveaway.ChannelID), discord.Snowflake(giveaway.MessageID))
-	if err != nil {
+	// Deliver prizes to winners who do not have to claim them first. Winners
+	// with a claim window are delivered their prize when they claim it.
+	for _, winner := range winners {
+		if winner.IsDelivered || winner.IsExpired || winner.DeliveryError != "" || winner.ClaimExpiresAt.Unix() > 0 {
+			continue
+		}
+
+		// Failures are recorded on the winner and shown in the manage view.
+		_, _ = welcomer.DeliverGiveawayPrize(eventCtx.Context, eventCtx.Session, giveaway, winner)
+	}
+
+	if giveaway.AnnounceWinners && serverSeed != "" {
+		_, err = channel.Send(eventCtx.Context, eventCtx.Session, discord.MessageParams{
+			Content: fmt.Sprintf("This giveaway was provably fair.\nServer seed: `%s`\nSeed commitment: `%s`\nUse `/giveaways verify %s` to verify the winners.", serverSeed, giveaway.SeedCommitment, giveaway.GiveawayUuid.String()),
+			MessageReference: &discord.MessageReference{
+				ID:              new(discord.Snowflake(giveaway.MessageID)),
+				ChannelID:       new(discord.Snowflake(giveaway.ChannelID)),
+				FailIfNotExists: false,
+			},
+		})
+		if err != nil {
+			welcomer.Logger.Error().Err(err).
+				Str("giveaway_uuid", giveaway.GiveawayUuid.String()).
+				Msg("Failed to send giveaway seed reveal message")
+		}
+	}
+
+	return nil
+}
+
+// getGiveawayWinnerMessage returns the message announcing a giveaway winner.
+// If the winner has to claim their prize, a claim button is included.
+func getGiveawayWinnerMessage(giveaway *database.GuildGiveaways, winner *database.GuildGiveawaysWinners) discord.MessageParams {
+	message := discord.MessageParams{
+		Content: fmt.Sprintf("Congratulations <@%d>, you won **%s**", winner.UserID, winner.Prize),
+		MessageReference: &discord.MessageReference{
+			ID:              new(discord.Snowflake(giveaway.MessageID)),
+			ChannelID:       new(discord.Snowflake(giveaway.ChannelID)),
+			FailIfNotExists: false,
+		},
+	}
+
+	if winner.ClaimExpiresAt.Unix() > 0 {
+		message.Content += fmt.Sprintf("\nClaim your prize <t:%d:R> or it will be given to someone else.", winner.ClaimExpiresAt.Unix())
+		message.Components = []discord.InteractionComponent{
+			{
+				Type: discord.InteractionComponentTypeActionRow,
+				Components: []discord.InteractionComponent{
+					{
+						Type:     discord.InteractionComponentTypeButton,
+						Style:    discord.InteractionComponentStyleSuccess,
+						CustomID: "giveaway_claim:" + winner.GiveawayWinnerUuid.String(),
+						Label:    "Claim Prize",
+						Emoji: &discord.Emoji{
+							Name: "🎁",
+						},
+					},
+				},
+			},
+		}
+	}
+
+	return message
+}
+
+// RerollGiveaway draws new winners for the prizes of winners who did not claim
+// their prize in time, along with the winner passed if it is set. The old
+// winners are expired once their replacements have been drawn.
+func (g *GiveawayCog) RerollGiveaway(eventCtx *sandwich.EventContext, giveaway *database.GuildGiveaways, giveawayWinnerUUID uuid.UUID) error {
+	winners, err := welcomer.Queries.GetGiveawayWinners(eventCtx.Context, giveaway.GiveawayUuid)
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
 		welcomer.Logger.Error().Err(err).
 			Str("giveaway_uuid", giveaway.GiveawayUuid.String()).
-			Msg("Failed to fetch giveaway message for giveaway end")
+			Msg("Failed to get giveaway winners for reroll")
 
 		return err
 	}
 
-	for i := range msg.Components {
-		for j := range msg.Components[i].Components {
-			if msg.Components[i].Components[j].Type == discord.InteractionComponentTypeButton {
-				msg.Components[i].Components[j].Label = "This giveaway has ended"
-				msg.Components[i].Components[j].Disabled = true
-			}
+	now := time.Now()
+
+	expiredWinners := make([]*database.GuildGiveawaysWinners, 0)
+
+	for _, winner := range winners {
+		if winner.IsExpired {
+			continue
+		}
+
+		claimExpired := !winner.IsClaimed && winner.ClaimExpiresAt.Unix() > 0 && !winner.ClaimExpiresAt.After(now)
+
+		if claimExpired || (!giveawayWinnerUUID.IsNil() && winner.GiveawayWinnerUuid == giveawayWinnerUUID) {
+			expiredWinners = append(expiredWinners, winner)
 		}
 	}
 
-	_, err = msg.Edit(eventCtx.Context, eventCtx.Session, discord.MessageParams{
-		Components: msg.Components,
-	})
-	if err != nil {
-		welcomer.Logger.Error().Err(err).
-			Str("giveaway_uuid", giveaway.GiveawayUuid.String()).
-			Msg("Failed to edit giveaway message to disable buttons for giveaway end")
+	if len(expiredWinners) == 0 {
+		return nil
+	}
 
+	if err = g.assignGiveawayWinners(eventCtx, giveaway, expiredWinners); err != nil {
 		return err
 	}
 
-	welcomer.PusherGuildScience.Push(
-		eventCtx.Context,
-		discord.Snowflake(giveaway.GuildID),
-		0,
-		database.ScienceGuildEventTypeGiveawayEnded,
-		&welcomer.GuildScienceGiveawayEvents{
-			GiveawayUUID: giveaway.GiveawayUuid,
-		},
-	)
+	for _, winner := range expiredWinners {
+		welcomer.Logger.Info().
+			Str("giveaway_uuid", giveaway.GiveawayUuid.String()).
+			Int64("user_id", winner.UserID).
+			Str("prize", winner.Prize).
+			Msg("Giveaway winner has been rerolled")
+
+		if winner.MessageID == 0 {
+			continue
+		}
+
+		message := discord.Message{ID: discord.Snowflake(winner.MessageID), ChannelID: discord.Snowflake(giveaway.ChannelID)}
+
+		_, err = message.Edit(eventCtx.Context, eventCtx.Session, discord.MessageParams{
+			Content: fmt.Sprintf("~~Congratulations <@%d>, you won **%s**~~\n%s", winner.UserID, winner.Prize, welcomer.If(winner.GiveawayWinnerUuid == giveawayWinnerUUID, "This prize has been rerolled and given to someone else.", "This prize was not claimed in time and has been given to someone else.")),
+			Components: []discord.InteractionComponent{
+				{
+					Type: discord.InteractionComponentTypeActionRow,
+					Components: []discord.InteractionComponent{
+						{
+							Type:     discord.InteractionComponentTypeButton,
+							Style:    discord.InteractionComponentStyleSecondary,
+							CustomID: "giveaway_claim:" + winner.GiveawayWinnerUuid.String(),
+							Label:    welcomer.If(winner.GiveawayWinnerUuid == giveawayWinnerUUID, "Prize Rerolled", "Prize Expired"),
+							Disabled: true,
+						},
+					},
+				},
+			},
+		})
+		if err != nil {
+			welcomer.Logger.Warn().Err(err).
+				Str("giveaway_uuid", giveaway.GiveawayUuid.String()).
+				Int64("user_id", winner.UserID).
+				Msg("Failed to edit expired giveaway winner message")
+		}
+	}
 
 	return nil
 }
@@ -79,6 +79,56 @@ func (c *IngestCog) RegisterCog(bot *sandwich.Bot) error {
 		return nil
 	})
 
+	// Register event for message delete. The author is not known, so it is taken from the create event when aggregating.
+	c.EventHandler.RegisterOnMessageDeleteEvent(func(eventCtx *sandwich.EventContext, channel *discord.Channel, messageID discord.Snowflake) error {
+		startTime := time.Now()
+		defer notifyTiming(startTime, eventCtx.Payload.Metadata.Shard, "IngestCog.OnMessageDelete")
+
+		if channel.GuildID == nil {
+			return nil
+		}
+
+		welcomer.PusherIngestMessageEvents.Push(eventCtx.Context,
+			messageID, *channel.GuildID, channel.ID, 0,
+			welcomer.IngestMessageEventTypeDelete, time.Now())
+
+		return nil
+	})
+
+	// Register event for message bulk delete.
+	c.EventHandler.RegisterOnMessageDeleteBulkEvent(func(eventCtx *sandwich.EventContext, channel *discord.Channel, messageIDs []discord.Snowflake) error {
+		startTime := time.Now()
+		defer notifyTiming(startTime, eventCtx.Payload.Metadata.Shard, "IngestCog.OnMessageDeleteBulk")
+
+		if channel.GuildID == nil {
+			return nil
+		}
+
+		for _, messageID := range messageIDs {
+			welcomer.PusherIngestMessageEvents.Push(eventCtx.Context,
+				messageID, *channel.GuildID, channel.ID, 0,
+				welcomer.IngestMessageEventTypeBulkDelete, time.Now())
+		}
+
+		return nil
+	})
+
+	// Register event for message reaction add.
+	c.EventHandler.RegisterOnMessageReactionAddEvent(func(eventCtx *sandwich.EventContext, channel *discord.Channel, messageID discord.Snowflake, _ discord.Emoji, guildMember discord.GuildMember) error {
+		startTime := time.Now()
+		defer notifyTiming(startTime, eventCtx.Payload.Metadata.Shard, "IngestCog.OnMessageReactionAdd")
+
+		if guildMember.User == nil || guildMember.User.Bot || channel.GuildID == nil {
+			return nil
+		}
+
+		welcomer.PusherIngestMessageEvents.Push(eventCtx.Context,
+			messageID, *channel.GuildID, channel.ID, guildMember.User.ID,
+			welcomer.IngestMessageEventTypeReactionAdd, time.Now())
+
+		return nil
+	})
+
 	// Register event for voice state update.
 	c.EventHandler.RegisterOnVoiceStateUpdateEvent(func(eventCtx *sandwich.EventContext, member discord.GuildMember, before, after discord.VoiceState) error {
 		startTime := time.Now()
@@ -52,7 +52,7 @@ func (r *ReactionRolesCog) RegisterCog(bot *sandwich.Bot) error {
 			return nil
 		}
 
-		return r.OnReact(eventCtx, *channel.GuildID, messageID, emoji, guildMember, new(true))
+		return r.OnReact(eventCtx, *channel.GuildID, channel.ID, messageID, emoji, guildMember, new(true))
 	})
 
 	r.EventHandler.RegisterOnMessageReactionRemoveEvent(func(eventCtx *sandwich.EventContext, channel *discord.Channel, messageID discord.Snowflake, emoji discord.Emoji, user *discord.User) error {
@@ -60,7 +60,7 @@ func (r *ReactionRolesCog) RegisterCog(bot *sandwich.Bot) error {
 			return nil
 		}
 
-		return r.OnReact(eventCtx, *channel.GuildID, messageID, emoji, discord.GuildMember{User: user}, new(false))
+		return r.OnReact(eventCtx, *channel.GuildID, channel.ID, messageID, emoji, discord.GuildMember{User: user}, new(false))
 	})
 
 	r.EventHandler.RegisterEventHandler(core.CustomEventInvokeReactionRoles, func(eventCtx *sandwich.EventContext, payload sandwich_daemon.ProducedPayload) error {
@@ -123,7 +123,7 @@ func (r *ReactionRolesCog) RegisterCog(bot *sandwich.Bot) error {
 	return nil
 }
 
-func (r *ReactionRolesCog) OnReact(eventCtx *sandwich.EventContext, guildID, messageID discord.Snowflake, emoji discord.Emoji, member discord.GuildMember, assign *bool) error {
+func (r *ReactionRolesCog) OnReact(eventCtx *sandwich.EventContext, guildID, channelID, messageID discord.Snowflake, emoji discord.Emoji, member discord.GuildMember, assign *bool) error {
 	reactionRole, err := welcomer.Queries.GetReactionRoleSettingByMessageId(eventCtx.Context, database.GetReactionRoleSettingByMessageIdParams{
 		MessageID: int64(messageID),
 		GuildID:   int64(guildID),
@@ -170,6 +170,11 @@ func (r *ReactionRolesCog) OnReact(eventCtx *sandwich.EventContext, guildID, mes
 		ReactionRoleUUID: reactionRole.ReactionRoleID,
 		RoleID:           roleID,
 		Assign:           assign,
+		Reaction: &core.ReactionRoleReaction{
+			ChannelID: channelID,
+			MessageID: messageID,
+			Emoji:     welcomer.If(emoji.ID != 0, emoji.Name+":"+emoji.ID.String(), emoji.Name),
+		},
 	})
 }
 
@@ -247,9 +252,52 @@ func (r *ReactionRolesCog) OnInvokeReactionRolesInner(eventCtx *sandwich.EventCo
 		event.Member = pb.PBToGuildMember(memberPb)
 	}
 
+	reactionRole, err := welcomer.Queries.GetReactionRoleSettingById(eventCtx.Context, database.GetReactionRoleSettingByIdParams{
+		ReactionRoleID: event.ReactionRoleUUID,
+		GuildID:        int64(*event.Member.GuildID),
+	})
+	if err != nil {
+		welcomer.Logger.Error().Err(err).
+			Int64("guild_id", int64(*event.Member.GuildID)).
+			Str("reaction_role_uuid", event.ReactionRoleUUID.String()).
+			Msg("Failed to get reaction role setting")
+
+		return fmt.Errorf("failed to get reaction role setting: %w", err)
+	}
+
 	hasRole := slices.Contains(event.Member.Roles, event.RoleID)
 
 	if !hasRole && (event.Assign == nil || *event.Assign) {
+		// The required role only restricts picking roles, so members who lose it can still remove theirs.
+		if reactionRole.RequiredRoleID != 0 && !slices.Contains(event.Member.Roles, discord.Snowflake(reactionRole.RequiredRoleID)) {
+			r.rejectReactionRoles(eventCtx, event, fmt.Sprintf("You need the <@&%d> role to use this.", reactionRole.RequiredRoleID))
+
+			return nil
+		}
+
+		// Options the member already holds from this reaction role.
+		var heldRoles []discord.Snowflake
+
+		var duration time.Duration
+
+		for _, option := range welcomer.UnmarshalReactionRolesJSON(reactionRole.Roles.Bytes) {
+			if option.RoleID == event.RoleID {
+				duration = time.Duration(option.Duration) * time.Second
+			} else if slices.Contains(event.Member.Roles, option.RoleID) {
+				heldRoles = append(heldRoles, option.RoleID)
+			}
+		}
+
+		var removeRoles []discord.Snowflake
+
+		if reactionRole.UniqueSelection && !reactionRole.VerifyOnly {
+			removeRoles = heldRoles
+		} else if reactionRole.MaxSelections > 0 && len(heldRoles) >= int(reactionRole.MaxSelections) {
+			r.rejectReactionRoles(eventCtx, event, fmt.Sprintf("You can only pick up to %d %s here. Remove one before picking another.", reactionRole.MaxSelections, welcomer.If(reactionRole.MaxSelections == 1, "role", "roles")))
+
+			return nil
+		}
+
 		welcomer.Logger.Info().
 			Int64("guild_id", int64(*event.Member.GuildID)).
 			Int64("user_id", int64(event.Member.User.ID)).
@@ -295,10 +343,54 @@ func (r *ReactionRolesCog) OnInvokeReactionRolesInner(eventCtx *sandwich.EventCo
 			},
 		)
 
+		if duration > 0 {
+			_, err = welcomer.RecordTempRole(eventCtx.Context, *event.Member.GuildID, event.Member.User.ID, event.RoleID, duration, database.TempRoleSourceReactionRoles, event.ReactionRoleUUID, event.Member.User.ID)
+			if err != nil {
+				welcomer.Logger.Error().Err(err).
+					Int64("guild_id", int64(*event.Member.GuildID)).
+					Int64("user_id", int64(event.Member.User.ID)).
+					Int64("role_id", int64(event.RoleID)).
+					Msg("Failed to record temp role for reaction roles")
+			}
+		}
+
+		if len(removeRoles) > 0 {
+			err = event.Member.RemoveRoles(eventCtx.Context, eventCtx.Session, removeRoles, new("Automatically removed with Reaction Roles"), true)
+			if err != nil {
+				welcomer.Logger.Error().Err(err).
+					Int64("guild_id", int64(*event.Member.GuildID)).
+					Int64("user_id", int64(event.Member.User.ID)).
+					Interface("role_ids", removeRoles).
+					Msg("Failed to remove unique roles from member for reaction roles")
+			} else {
+				for _, roleID := range removeRoles {
+					if err := welcomer.ForgetTempRole(eventCtx.Context, *event.Member.GuildID, event.Member.User.ID, roleID); err != nil {
+						welcomer.Logger.Warn().Err(err).
+							Int64("guild_id", int64(*event.Member.GuildID)).
+							Int64("user_id", int64(event.Member.User.ID)).
+							Int64("role_id", int64(roleID)).
+							Msg("Failed to forget temp role for reaction roles")
+					}
+
+					welcomer.PusherGuildScience.Push(
+						eventCtx.Context,
+						eventCtx.Guild.ID,
+						event.Member.User.ID,
+						database.ScienceGuildEventTypeReactionRoleRemoved,
+						welcomer.GuildScienceReactionRoleGivenRemoved{
+							TimeToResolveMs:  time.Since(startedAt).Milliseconds(),
+							RoleID:           roleID,
+							ReactionRoleUUID: event.ReactionRoleUUID,
+						},
+					)
+				}
+			}
+		}
+
 		if event.Interaction != nil {
 			_, err = event.Interaction.EditOriginalResponse(eventCtx.Context, eventCtx.Session,
 				discord.WebhookMessageParams{
-					Embeds: welcomer.NewEmbed(fmt.Sprintf("Role <@&%d> added successfully!", event.RoleID), welcomer.EmbedColourSuccess),
+					Embeds: welcomer.NewEmbed(fmt.Sprintf("Role <@&%d> added successfully!", event.RoleID)+welcomer.If(duration > 0, fmt.Sprintf(" It will be removed <t:%d:R>.", time.Now().Add(duration).Unix()), ""), welcomer.EmbedColourSuccess),
 					Flags:  discord.MessageFlagEphemeral,
 				},
 			)
@@ -313,6 +405,12 @@ func (r *ReactionRolesCog) OnInvokeReactionRolesInner(eventCtx *sandwich.EventCo
 
 		return nil
 	} else if hasRole && (event.Assign == nil || !*event.Assign) {
+		if reactionRole.VerifyOnly || reactionRole.DisableToggleOff {
+			r.respondReactionRoles(eventCtx, event, fmt.Sprintf("You already have the <@&%d> role.", event.RoleID), welcomer.EmbedColourInfo)
+
+			return nil
+		}
+
 		welcomer.Logger.Info().
 			Int64("guild_id", int64(*event.Member.GuildID)).
 			Int64("user_id", int64(event.Member.User.ID)).
@@ -358,6 +456,15 @@ func (r *ReactionRolesCog) OnInvokeReactionRolesInner(eventCtx *sandwich.EventCo
 			},
 		)
 
+		err = welcomer.ForgetTempRole(eventCtx.Context, *event.Member.GuildID, event.Member.User.ID, event.RoleID)
+		if err != nil {
+			welcomer.Logger.Warn().Err(err).
+				Int64("guild_id", int64(*event.Member.GuildID)).
+				Int64("user_id", int64(event.Member.User.ID)).
+				Int64("role_id", int64(event.RoleID)).
+				Msg("Failed to forget temp role for reaction roles")
+		}
+
 		if event.Interaction != nil {
 			_, err := event.Interaction.EditOriginalResponse(eventCtx.Context, eventCtx.Session,
 				discord.WebhookMessageParams{
@@ -393,3 +500,43 @@ func (r *ReactionRolesCog) OnInvokeReactionRolesInner(eventCtx *sandwich.EventCo
 
 	return nil
 }
+
+// respondReactionRoles edits the deferred interaction response, if the reaction role was invoked by an interaction.
+func (r *ReactionRolesCog) respondReactionRoles(eventCtx *sandwich.EventContext, event core.CustomEventInvokeReactionRolesStructure, message string, colour int32) {
+	if event.Interaction == nil {
+		return
+	}
+
+	_, err := event.Interaction.EditOriginalResponse(eventCtx.Context, eventCtx.Session,
+		discord.WebhookMessageParams{
+			Embeds: welcomer.NewEmbed(message, colour),
+			Flags:  discord.MessageFlagEphemeral,
+		},
+	)
+	if err != nil {
+		welcomer.Logger.Warn().Err(err).
+			Int64("guild_id", int64(*event.Member.GuildID)).
+			Int64("user_id", int64(event.Member.User.ID)).
+			Int64("role_id", int64(event.RoleID)).
+			Msg("Failed to send interaction response for reaction roles")
+	}
+}
+
+// rejectReactionRoles tells the member why a role was not given. If they
+// reacted to the message, their reaction is removed so it does not look picked.
+func (r *ReactionRolesCog) rejectReactionRoles(eventCtx *sandwich.EventContext, event core.CustomEventInvokeReactionRolesStructure, message string) {
+	r.respondReactionRoles(eventCtx, event, message, welcomer.EmbedColourError)
+
+	if event.Reaction == nil {
+		return
+	}
+
+	err := discord.DeleteUserReaction(eventCtx.Context, eventCtx.Session, event.Reaction.ChannelID, event.Reaction.MessageID, event.Reaction.Emoji, event.Member.User.ID)
+	if err != nil {
+		welcomer.Logger.Warn().Err(err).
+			Int64("guild_id", int64(*event.Member.GuildID)).
+			Int64("user_id", int64(event.Member.User.ID)).
+			Int64("message_id", int64(event.Reaction.MessageID)).
+			Msg("Failed to remove rejected reaction for reaction roles")
+	}
+}
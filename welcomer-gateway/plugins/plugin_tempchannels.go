@@ -3,7 +3,7 @@ package plugins
 import (
 	"errors"
 	"fmt"
-	"regexp"
+	"strings"
 	"time"
 
 	"github.com/WelcomerTeam/Discord/discord"
@@ -15,6 +15,9 @@ import (
 	"github.com/jackc/pgx/v4"
 )
 
+// TempChannelNameMaxLength is the longest name Discord allows for a channel.
+const TempChannelNameMaxLength = 100
+
 type TempChannelsCog struct {
 	EventHandler *sandwich.Handlers
 }
@@ -142,9 +145,13 @@ func (p *TempChannelsCog) OnInvokeVoiceStateUpdate(eventCtx *sandwich.EventConte
 		return nil
 	}
 
-	// If user is moving to lobby, create channel and move user.
-	if guildSettingsTimeroles.ChannelLobby != 0 && after.ChannelID != nil && *after.ChannelID == discord.Snowflake(guildSettingsTimeroles.ChannelLobby) {
-		return p.createChannelAndMove(eventCtx, guildID, discord.Snowflake(guildSettingsTimeroles.ChannelCategory), discord.Snowflake(guildSettingsTimeroles.ChannelLobby), &member)
+	lobbies := welcomer.GetTempChannelLobbies(guildSettingsTimeroles)
+
+	// If user is moving to a lobby, create channel and move user.
+	if after.ChannelID != nil {
+		if lobby, ok := welcomer.GetTempChannelLobby(guildSettingsTimeroles, *after.ChannelID); ok {
+			return p.createChannelAndMove(eventCtx, guildID, lobbies, lobby, &member)
+		}
 	}
 
 	var beforeGuildID discord.Snowflake
@@ -158,29 +165,99 @@ func (p *TempChannelsCog) OnInvokeVoiceStateUpdate(eventCtx *sandwich.EventConte
 		afterGuildID = *after.GuildID
 	}
 
-	// If user is leaving or moving to a different channel, delete channel if empty.
-	if guildSettingsTimeroles.ToggleAutopurge {
-		if before.ChannelID != nil && (after.GuildID == nil || beforeGuildID != afterGuildID || before.ChannelID != after.ChannelID) {
-			_, err = p.deleteChannelIfEmpty(eventCtx, guildID, discord.Snowflake(guildSettingsTimeroles.ChannelCategory), discord.Snowflake(guildSettingsTimeroles.ChannelLobby), *before.ChannelID)
+	if before.ChannelID != nil && (after.GuildID == nil || beforeGuildID != afterGuildID || before.ChannelID != after.ChannelID) {
+		// If user is leaving or moving to a different channel, delete channel if empty.
+		if guildSettingsTimeroles.ToggleAutopurge {
+			deleted, err := p.deleteChannelIfEmpty(eventCtx, guildID, lobbies, *before.ChannelID)
 			if err != nil {
 				return err
 			}
+
+			if deleted {
+				return nil
+			}
 		}
+
+		// If the owner has left, pass the channel on to someone still in it.
+		return p.passOnChannelIfOwner(eventCtx, guildID, *before.ChannelID, member.User.ID)
 	}
 
 	return nil
 }
 
-func (p *TempChannelsCog) findChannelForUser(eventCtx *sandwich.EventContext, guildID, category, channelLobby discord.Snowflake, member *discord.GuildMember) (channel *discord.Channel, err error) {
+func (p *TempChannelsCog) passOnChannelIfOwner(eventCtx *sandwich.EventContext, guildID, channelID, userID discord.Snowflake) error {
+	tempChannel, err := welcomer.GetTempChannel(eventCtx.Context, guildID, channelID)
+	if err != nil {
+		if errors.Is(err, welcomer.ErrInvalidTempChannel) {
+			return nil
+		}
+
+		welcomer.Logger.Error().Err(err).
+			Str("guild_id", guildID.String()).
+			Str("channel_id", channelID.String()).
+			Msg("Failed to get temp channel")
+
+		return err
+	}
+
+	if tempChannel.OwnerID != int64(userID) {
+		return nil
+	}
+
+	newOwnerID, err := welcomer.PassOnTempChannel(eventCtx.Context, eventCtx.Session, tempChannel)
+	if err != nil {
+		welcomer.Logger.Error().Err(err).
+			Str("guild_id", guildID.String()).
+			Str("channel_id", channelID.String()).
+			Msg("Failed to pass on temp channel ownership")
+
+		return err
+	}
+
+	if !newOwnerID.IsNil() {
+		welcomer.Logger.Info().
+			Str("guild_id", guildID.String()).
+			Str("channel_id", channelID.String()).
+			Str("owner_id", newOwnerID.String()).
+			Msg("Passed on temp channel ownership")
+	}
+
+	return nil
+}
+
+func (p *TempChannelsCog) isLobby(lobbies []welcomer.TempChannelLobby, channelID discord.Snowflake) bool {
+	for _, lobby := range lobbies {
+		if lobby.ChannelID == channelID {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (p *TempChannelsCog) findChannelForUser(eventCtx *sandwich.EventContext, guildID discord.Snowflake, lobbies []welcomer.TempChannelLobby, lobby *welcomer.TempChannelLobby) (channel *discord.Channel, err error) {
 	channels, err := welcomer.FetchGuildChannels(eventCtx.Context, guildID)
 	if err != nil {
 		return nil, err
 	}
 
+	tempChannels, err := welcomer.Queries.GetTempChannelsByGuild(eventCtx.Context, int64(guildID))
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		return nil, err
+	}
+
+	tempChannelIDs := make(map[discord.Snowflake]bool, len(tempChannels))
+	for _, tempChannel := range tempChannels {
+		tempChannelIDs[discord.Snowflake(tempChannel.ChannelID)] = true
+	}
+
 	for _, guildChannel := range channels {
+		_, isLegacyTempChannel := welcomer.ParseLegacyTempChannelName(guildChannel.Name)
+
 		if guildChannel.Type == discord.ChannelTypeGuildVoice && // Filter for voice channels
-			(channelLobby.IsNil() || guildChannel.ID != channelLobby) && // Exclude the lobby channel
-			(category.IsNil() || (guildChannel.ParentID != nil && *guildChannel.ParentID == category)) && // Ensure the channel is in the specified category (if set)
+			!p.isLobby(lobbies, guildChannel.ID) && // Exclude the lobby channels
+			(tempChannelIDs[guildChannel.ID] || isLegacyTempChannel) && // Only reuse channels created by TempChannels
+			(lobby.CategoryID.IsNil() || (guildChannel.ParentID != nil && *guildChannel.ParentID == lobby.CategoryID)) && // Ensure the channel is in the specified category (if set)
 			guildChannel.MemberCount == 0 { // Check if the channel is empty
 			return guildChannel, nil
 		}
@@ -189,8 +266,8 @@ func (p *TempChannelsCog) findChannelForUser(eventCtx *sandwich.EventContext, gu
 	return nil, nil
 }
 
-func (p *TempChannelsCog) createChannelAndMove(eventCtx *sandwich.EventContext, guildID, category, channelLobby discord.Snowflake, member *discord.GuildMember) (err error) {
-	channel, err := p.findChannelForUser(eventCtx, guildID, category, channelLobby, member)
+func (p *TempChannelsCog) createChannelAndMove(eventCtx *sandwich.EventContext, guildID discord.Snowflake, lobbies []welcomer.TempChannelLobby, lobby *welcomer.TempChannelLobby, member *discord.GuildMember) (err error) {
+	channel, err := p.findChannelForUser(eventCtx, guildID, lobbies, lobby)
 	if err != nil {
 		welcomer.Logger.Error().Err(err).
 			Str("guild_id", guildID.String()).
@@ -200,20 +277,49 @@ func (p *TempChannelsCog) createChannelAndMove(eventCtx *sandwich.EventContext,
 		return err
 	}
 
-	if channel == nil {
-		if category.IsNil() {
+	var textChannelID discord.Snowflake
+
+	if channel != nil {
+		// Keep the text channel of a temp channel that is being reused.
+		if existingTempChannel, err := welcomer.GetTempChannel(eventCtx.Context, guildID, channel.ID); err == nil {
+			textChannelID = discord.Snowflake(existingTempChannel.TextChannelID)
+		}
+	} else {
+		if lobby.CategoryID.IsNil() {
 			welcomer.Logger.Error().
 				Msg("Failed to get category for temp channels")
 
 			return nil
 		}
 
-		guild := sandwich.NewGuild(guildID)
-		channel, err = guild.CreateChannel(eventCtx.Context, eventCtx.Session, discord.ChannelParams{
-			Name:     p.formatChannelName(member),
+		channelParams := discord.ChannelParams{
+			Name:     p.formatChannelName(eventCtx, guildID, lobby, member),
 			Type:     discord.ChannelTypeGuildVoice,
-			ParentID: &category,
-		}, new("Automatically created by TempChannels"))
+			ParentID: &lobby.CategoryID,
+		}
+
+		if lobby.UserLimit > 0 {
+			channelParams.UserLimit = &lobby.UserLimit
+		}
+
+		if lobby.Bitrate > 0 {
+			channelParams.Bitrate = &lobby.Bitrate
+		}
+
+		if lobby.CopyPermissions {
+			lobbyChannel, err := welcomer.FetchGuildChannel(eventCtx.Context, guildID, lobby.ChannelID)
+			if err != nil {
+				welcomer.Logger.Warn().Err(err).
+					Str("guild_id", guildID.String()).
+					Str("channel_id", lobby.ChannelID.String()).
+					Msg("Failed to fetch lobby channel for tempchannels")
+			} else {
+				channelParams.PermissionOverwrites = lobbyChannel.PermissionOverwrites
+			}
+		}
+
+		guild := sandwich.NewGuild(guildID)
+		channel, err = guild.CreateChannel(eventCtx.Context, eventCtx.Session, channelParams, new("Automatically created by TempChannels"))
 		if err != nil || channel == nil {
 			welcomer.Logger.Error().Err(err).
 				Str("guild_id", guildID.String()).
@@ -221,6 +327,39 @@ func (p *TempChannelsCog) createChannelAndMove(eventCtx *sandwich.EventContext,
 
 			return err
 		}
+
+		if lobby.CreateTextChannel {
+			textChannel, err := guild.CreateChannel(eventCtx.Context, eventCtx.Session, discord.ChannelParams{
+				Name:                 channelParams.Name,
+				Type:                 discord.ChannelTypeGuildText,
+				ParentID:             &lobby.CategoryID,
+				PermissionOverwrites: channelParams.PermissionOverwrites,
+			}, new("Automatically created by TempChannels"))
+			if err != nil || textChannel == nil {
+				welcomer.Logger.Warn().Err(err).
+					Str("guild_id", guildID.String()).
+					Str("channel_id", channel.ID.String()).
+					Msg("Failed to create text channel for tempchannels")
+			} else {
+				textChannelID = textChannel.ID
+			}
+		}
+	}
+
+	_, err = welcomer.Queries.CreateOrUpdateTempChannel(eventCtx.Context, database.CreateOrUpdateTempChannelParams{
+		ChannelID:     int64(channel.ID),
+		GuildID:       int64(guildID),
+		OwnerID:       int64(member.User.ID),
+		LobbyID:       int64(lobby.ChannelID),
+		TextChannelID: int64(textChannelID),
+	})
+	if err != nil {
+		welcomer.Logger.Error().Err(err).
+			Str("guild_id", guildID.String()).
+			Str("channel_id", channel.ID.String()).
+			Msg("Failed to create temp channel")
+
+		return err
 	}
 
 	err = member.MoveTo(eventCtx.Context, eventCtx.Session, &channel.ID, new("Automatically move by TempChannels"))
@@ -245,17 +384,56 @@ func (p *TempChannelsCog) createChannelAndMove(eventCtx *sandwich.EventContext,
 	return nil
 }
 
-func (p *TempChannelsCog) formatChannelName(member *discord.GuildMember) string {
-	return fmt.Sprintf("🔊 %s's Channel [%d]", welcomer.GetGuildMemberDisplayName(member), member.User.ID)
-}
+// formatChannelName renders the lobby's name template, falling back to the default name
+// if the template is empty or fails to render.
+func (p *TempChannelsCog) formatChannelName(eventCtx *sandwich.EventContext, guildID discord.Snowflake, lobby *welcomer.TempChannelLobby, member *discord.GuildMember) string {
+	if lobby.NameTemplate == "" || lobby.NameTemplate == welcomer.DefaultTempChannelNameTemplate {
+		return p.formatDefaultChannelName(member)
+	}
 
-var tempChannelRegex = regexp.MustCompile(`🔊 (.+)'s Channel \[(\d+)\]`)
+	guild, err := welcomer.FetchGuild(eventCtx.Context, guildID)
+	if err != nil {
+		guild = sandwich.NewGuild(guildID)
+	}
+
+	guildSettings, err := welcomer.Queries.GetGuild(eventCtx.Context, int64(guildID))
+	if err != nil {
+		guildSettings = &welcomer.DefaultGuild
+	}
+
+	functions := welcomer.GatherFunctions(database.NumberLocale(guildSettings.NumberLocale.Int32))
+	variables := welcomer.GatherVariables(eventCtx, member, core.GuildVariables{
+		Guild:         guild,
+		MembersJoined: guildSettings.MemberCount,
+		NumberLocale:  database.NumberLocale(guildSettings.NumberLocale.Int32),
+	}, nil, nil)
+
+	name, err := welcomer.FormatString(functions, variables, lobby.NameTemplate)
+	if err != nil || strings.TrimSpace(name) == "" {
+		welcomer.Logger.Warn().Err(err).
+			Str("guild_id", guildID.String()).
+			Str("lobby_id", lobby.ChannelID.String()).
+			Msg("Failed to format temp channel name")
+
+		return p.formatDefaultChannelName(member)
+	}
 
-func (p *TempChannelsCog) isTempChannel(name string) bool {
-	return tempChannelRegex.MatchString(name)
+	if len([]rune(name)) > TempChannelNameMaxLength {
+		name = string([]rune(name)[:TempChannelNameMaxLength])
+	}
+
+	return name
+}
+
+func (p *TempChannelsCog) formatDefaultChannelName(member *discord.GuildMember) string {
+	return fmt.Sprintf("🔊 %s's Channel [%d]", welcomer.GetGuildMemberDisplayName(member), member.User.ID)
 }
 
-func (p *TempChannelsCog) deleteChannelIfEmpty(eventCtx *sandwich.EventContext, guildID, category, lobby, channelID discord.Snowflake) (ok bool, err error) {
+func (p *TempChannelsCog) deleteChannelIfEmpty(eventCtx *sandwich.EventContext, guildID discord.Snowflake, lobbies []welcomer.TempChannelLobby, channelID discord.Snowflake) (ok bool, err error) {
+	if p.isLobby(lobbies, channelID) {
+		return false, nil
+	}
+
 	channel := sandwich.NewChannel(&guildID, channelID)
 
 	channel, err = sandwich.FetchChannel(eventCtx.ToGRPCContext(), channel)
@@ -268,25 +446,71 @@ func (p *TempChannelsCog) deleteChannelIfEmpty(eventCtx *sandwich.EventContext,
 		return false, err
 	}
 
-	if !p.isTempChannel(channel.Name) {
-		return false, welcomer.ErrInvalidTempChannel
+	tempChannel, err := welcomer.GetTempChannel(eventCtx.Context, guildID, channelID)
+	if err != nil {
+		if !errors.Is(err, welcomer.ErrInvalidTempChannel) {
+			return false, err
+		}
+
+		// Channels created before temp channels were stored are still recognised by their
+		// name and category.
+		if _, ok := welcomer.ParseLegacyTempChannelName(channel.Name); !ok || !p.isInLobbyCategory(lobbies, channel) {
+			return false, welcomer.ErrInvalidTempChannel
+		}
 	}
 
-	if (channel.ParentID != nil && *channel.ParentID == category) && channel.MemberCount == 0 && channel.ID != lobby {
-		err = channel.Delete(eventCtx.Context, eventCtx.Session, new("Automatically deleted by TempChannels"))
-		if err != nil {
-			welcomer.Logger.Error().Err(err).
-				Str("guild_id", guildID.String()).
-				Str("channel_id", channelID.String()).
-				Msg("Failed to delete channel for tempchannels")
+	if channel.MemberCount != 0 {
+		return false, nil
+	}
 
-			return false, err
+	err = channel.Delete(eventCtx.Context, eventCtx.Session, new("Automatically deleted by TempChannels"))
+	if err != nil {
+		welcomer.Logger.Error().Err(err).
+			Str("guild_id", guildID.String()).
+			Str("channel_id", channelID.String()).
+			Msg("Failed to delete channel for tempchannels")
+
+		return false, err
+	}
+
+	if tempChannel != nil {
+		p.deleteTextChannel(eventCtx, tempChannel)
+	}
+
+	p.forgetChannel(eventCtx, guildID, channelID)
+
+	return true, nil
+}
+
+func (p *TempChannelsCog) isInLobbyCategory(lobbies []welcomer.TempChannelLobby, channel *discord.Channel) bool {
+	if channel.ParentID == nil {
+		return false
+	}
+
+	for _, lobby := range lobbies {
+		if lobby.CategoryID == *channel.ParentID {
+			return true
 		}
+	}
 
-		return true, nil
+	return false
+}
+
+func (p *TempChannelsCog) deleteTextChannel(eventCtx *sandwich.EventContext, tempChannel *database.GuildTempChannels) {
+	if tempChannel.TextChannelID == 0 {
+		return
 	}
 
-	return false, nil
+	guildID := discord.Snowflake(tempChannel.GuildID)
+	textChannel := sandwich.NewChannel(&guildID, discord.Snowflake(tempChannel.TextChannelID))
+
+	err := textChannel.Delete(eventCtx.Context, eventCtx.Session, new("Automatically deleted by TempChannels"))
+	if err != nil {
+		welcomer.Logger.Warn().Err(err).
+			Int64("guild_id", tempChannel.GuildID).
+			Int64("channel_id", tempChannel.TextChannelID).
+			Msg("Failed to delete text channel for tempchannels")
+	}
 }
 
 func (p *TempChannelsCog) OnInvokeTempChannelsEvent(eventCtx *sandwich.EventContext, payload core.CustomEventInvokeTempChannelsStructure) error {
@@ -301,7 +525,12 @@ func (p *TempChannelsCog) OnInvokeTempChannelsEvent(eventCtx *sandwich.EventCont
 		return nil
 	}
 
-	return p.createChannelAndMove(eventCtx, *payload.Member.GuildID, discord.Snowflake(guildSettingsTimeroles.ChannelCategory), discord.Snowflake(guildSettingsTimeroles.ChannelLobby), &payload.Member)
+	lobbies := welcomer.GetTempChannelLobbies(guildSettingsTimeroles)
+	if len(lobbies) == 0 {
+		return nil
+	}
+
+	return p.createChannelAndMove(eventCtx, *payload.Member.GuildID, lobbies, &lobbies[0], &payload.Member)
 }
 
 func (p *TempChannelsCog) OnInvokeTempChannelsRemoveEvent(eventCtx *sandwich.EventContext, payload core.CustomEventInvokeTempChannelsRemoveStructure) error {
@@ -316,12 +545,27 @@ func (p *TempChannelsCog) OnInvokeTempChannelsRemoveEvent(eventCtx *sandwich.Eve
 		return nil
 	}
 
-	channel, err := p.findChannelForUser(eventCtx, *payload.Interaction.GuildID, discord.Snowflake(guildSettingsTimeroles.ChannelCategory), discord.Snowflake(guildSettingsTimeroles.ChannelLobby), &payload.Member)
+	tempChannel, err := welcomer.Queries.GetTempChannelByOwner(eventCtx.Context, database.GetTempChannelByOwnerParams{
+		GuildID: int64(*payload.Member.GuildID),
+		OwnerID: int64(payload.Member.User.ID),
+	})
 	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return p.removeLegacyChannel(eventCtx, *payload.Member.GuildID, welcomer.GetTempChannelLobbies(guildSettingsTimeroles), payload.Member.User.ID)
+		}
+
+		welcomer.Logger.Error().Err(err).
+			Str("guild_id", payload.Member.GuildID.String()).
+			Str("member_id", payload.Member.User.ID.String()).
+			Msg("Failed to get temp channel for owner")
+
 		return err
 	}
 
-	if channel != nil && channel.ID != discord.Snowflake(guildSettingsTimeroles.ChannelLobby) {
+	guildID := discord.Snowflake(tempChannel.GuildID)
+	channel := sandwich.NewChannel(&guildID, discord.Snowflake(tempChannel.ChannelID))
+
+	if !p.isLobby(welcomer.GetTempChannelLobbies(guildSettingsTimeroles), channel.ID) {
 		err = channel.Delete(eventCtx.Context, eventCtx.Session, new("Automatically deleted by TempChannels"))
 		if err != nil {
 			welcomer.Logger.Error().Err(err).
@@ -329,11 +573,63 @@ func (p *TempChannelsCog) OnInvokeTempChannelsRemoveEvent(eventCtx *sandwich.Eve
 				Str("channel_id", channel.ID.String()).
 				Msg("Failed to delete channel for tempchannels")
 		}
+
+		p.deleteTextChannel(eventCtx, tempChannel)
+	}
+
+	p.forgetChannel(eventCtx, guildID, channel.ID)
+
+	return nil
+}
+
+// removeLegacyChannel deletes a temp channel created before temp channels were stored,
+// which are only known by the owner's ID in their name.
+func (p *TempChannelsCog) removeLegacyChannel(eventCtx *sandwich.EventContext, guildID discord.Snowflake, lobbies []welcomer.TempChannelLobby, userID discord.Snowflake) error {
+	channels, err := welcomer.FetchGuildChannels(eventCtx.Context, guildID)
+	if err != nil {
+		welcomer.Logger.Error().Err(err).
+			Str("guild_id", guildID.String()).
+			Msg("Failed to fetch guild channels for tempchannels")
+
+		return err
+	}
+
+	for _, channel := range channels {
+		if channel.Type != discord.ChannelTypeGuildVoice || p.isLobby(lobbies, channel.ID) || !p.isInLobbyCategory(lobbies, channel) {
+			continue
+		}
+
+		if ownerID, ok := welcomer.ParseLegacyTempChannelName(channel.Name); !ok || ownerID != userID {
+			continue
+		}
+
+		err = channel.Delete(eventCtx.Context, eventCtx.Session, new("Automatically deleted by TempChannels"))
+		if err != nil {
+			welcomer.Logger.Error().Err(err).
+				Str("guild_id", guildID.String()).
+				Str("channel_id", channel.ID.String()).
+				Msg("Failed to delete channel for tempchannels")
+
+			return err
+		}
 	}
 
 	return nil
 }
 
+func (p *TempChannelsCog) forgetChannel(eventCtx *sandwich.EventContext, guildID, channelID discord.Snowflake) {
+	_, err := welcomer.Queries.DeleteTempChannel(eventCtx.Context, database.DeleteTempChannelParams{
+		GuildID:   int64(guildID),
+		ChannelID: int64(channelID),
+	})
+	if err != nil {
+		welcomer.Logger.Warn().Err(err).
+			Str("guild_id", guildID.String()).
+			Str("channel_id", channelID.String()).
+			Msg("Failed to delete temp channel")
+	}
+}
+
 func (p *TempChannelsCog) FetchGuildInformation(eventCtx *sandwich.EventContext, guildID discord.Snowflake) (guildSettingsTempChannels *database.GuildSettingsTempchannels, err error) {
 	guildSettingsTempChannels, err = welcomer.Queries.GetTempChannelsGuildSettings(eventCtx.Context, int64(guildID))
 	if err != nil {
@@ -345,6 +641,7 @@ func (p *TempChannelsCog) FetchGuildInformation(eventCtx *sandwich.EventContext,
 				ChannelLobby:     welcomer.DefaultTempChannels.ChannelLobby,
 				ChannelCategory:  welcomer.DefaultTempChannels.ChannelCategory,
 				DefaultUserCount: welcomer.DefaultTempChannels.DefaultUserCount,
+				Lobbies:          welcomer.DefaultTempChannels.Lobbies,
 			}
 		} else {
 			welcomer.Logger.Error().Err(err).
@@ -0,0 +1,95 @@
+package plugins
+
+import (
+	"fmt"
+
+	sandwich_daemon "github.com/WelcomerTeam/Sandwich-Daemon"
+	sandwich "github.com/WelcomerTeam/Sandwich/sandwich"
+	"github.com/WelcomerTeam/Welcomer/welcomer-core"
+	core "github.com/WelcomerTeam/Welcomer/welcomer-core"
+)
+
+type TempRolesCog struct {
+	EventHandler *sandwich.Handlers
+}
+
+// Assert types.
+
+var (
+	_ sandwich.Cog           = (*TempRolesCog)(nil)
+	_ sandwich.CogWithEvents = (*TempRolesCog)(nil)
+)
+
+func NewTempRolesCog() *TempRolesCog {
+	return &TempRolesCog{
+		EventHandler: sandwich.SetupHandler(nil),
+	}
+}
+
+func (c *TempRolesCog) CogInfo() *sandwich.CogInfo {
+	return &sandwich.CogInfo{
+		Name:        "TempRoles",
+		Description: "Provides the functionality for removing temporary roles once they expire",
+	}
+}
+
+func (c *TempRolesCog) GetEventHandlers() *sandwich.Handlers {
+	return c.EventHandler
+}
+
+func (c *TempRolesCog) RegisterCog(bot *sandwich.Bot) error {
+	// Register temp role expiry handler.
+
+	c.EventHandler.RegisterEventHandler(core.CustomEventInvokeExpireTempRoles, func(eventCtx *sandwich.EventContext, payload sandwich_daemon.ProducedPayload) error {
+		var invokeExpireTempRolesPayload core.CustomEventInvokeExpireTempRolesStructure
+		if err := eventCtx.DecodeContent(payload, &invokeExpireTempRolesPayload); err != nil {
+			return fmt.Errorf("failed to unmarshal payload: %w", err)
+		}
+
+		eventCtx.Guild = sandwich.NewGuild(invokeExpireTempRolesPayload.GuildID)
+
+		eventCtx.EventHandler.EventsMu.RLock()
+		defer eventCtx.EventHandler.EventsMu.RUnlock()
+
+		for _, event := range eventCtx.EventHandler.Events {
+			if f, ok := event.(welcomer.OnInvokeExpireTempRolesFuncType); ok {
+				return eventCtx.Handlers.WrapFuncType(eventCtx, f(eventCtx, invokeExpireTempRolesPayload))
+			}
+		}
+
+		return nil
+	})
+
+	// Call OnInvokeExpireTempRoles when CustomEventInvokeExpireTempRoles is triggered.
+	c.EventHandler.RegisterEvent(core.CustomEventInvokeExpireTempRoles, nil, (welcomer.OnInvokeExpireTempRolesFuncType)(c.OnInvokeExpireTempRoles))
+
+	return nil
+}
+
+func (c *TempRolesCog) OnInvokeExpireTempRoles(eventCtx *sandwich.EventContext, event core.CustomEventInvokeExpireTempRolesStructure) error {
+	tempRoles, err := welcomer.Queries.GetExpiredTempRoles(eventCtx.Context, int64(event.GuildID))
+	if err != nil {
+		welcomer.Logger.Error().Err(err).
+			Int64("guild_id", int64(event.GuildID)).
+			Msg("Failed to get expired temp roles")
+
+		return err
+	}
+
+	for _, tempRole := range tempRoles {
+		if err := welcomer.RemoveExpiredTempRole(eventCtx.Context, eventCtx.Session, tempRole); err != nil {
+			welcomer.Logger.Warn().Err(err).
+				Int64("guild_id", tempRole.GuildID).
+				Int64("user_id", tempRole.UserID).
+				Int64("role_id", tempRole.RoleID).
+				Msg("Failed to remove expired temp role")
+		}
+	}
+
+	welcomer.Logger.Info().
+		Int64("guild_id", int64(event.GuildID)).
+		Int("count", len(tempRoles)).
+		Msg("Removed expired temp roles")
+
+	return nil
+}
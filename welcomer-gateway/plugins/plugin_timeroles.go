@@ -1,19 +1,37 @@
 package plugins
 
 import (
+	"database/sql"
 	"errors"
+	"fmt"
 	"slices"
 	"strconv"
 	"time"
 
 	"github.com/WelcomerTeam/Discord/discord"
+	sandwich_daemon "github.com/WelcomerTeam/Sandwich-Daemon"
 	pb "github.com/WelcomerTeam/Sandwich-Daemon/proto"
 	sandwich "github.com/WelcomerTeam/Sandwich/sandwich"
 	"github.com/WelcomerTeam/Welcomer/welcomer-core"
+	core "github.com/WelcomerTeam/Welcomer/welcomer-core"
 	"github.com/WelcomerTeam/Welcomer/welcomer-core/database"
 	"github.com/jackc/pgx/v4"
 )
 
+const (
+	// TimeRolesDueBatchSize is the most members given time roles in a single run.
+	TimeRolesDueBatchSize = 1000
+
+	// TimeRolesMissingMemberRetry is how long to wait before retrying a member that is not cached.
+	TimeRolesMissingMemberRetry = time.Hour
+
+	// TimeRolesFailedRetry is how long to wait before retrying a member whose roles could not be changed.
+	TimeRolesFailedRetry = time.Minute * 15
+
+	// TimeRolesSlowWarning is how long a run can take before it is logged as slow.
+	TimeRolesSlowWarning = time.Second * 5
+)
+
 type TimeRolesCog struct {
 	EventHandler *sandwich.Handlers
 }
@@ -65,6 +83,70 @@ func buildDedupeKey2(eventType string, id1, id2 discord.Snowflake) string {
 }
 
 func (p *TimeRolesCog) RegisterCog(bot *sandwich.Bot) error {
+	// Register CustomEventInvokeTimeRoles event.
+	p.EventHandler.RegisterEventHandler(core.CustomEventInvokeTimeRoles, func(eventCtx *sandwich.EventContext, payload sandwich_daemon.ProducedPayload) error {
+		var invokeTimeRolesPayload core.CustomEventInvokeTimeRolesStructure
+		if err := eventCtx.DecodeContent(payload, &invokeTimeRolesPayload); err != nil {
+			return fmt.Errorf("failed to unmarshal payload: %w", err)
+		}
+
+		eventCtx.Guild = sandwich.NewGuild(invokeTimeRolesPayload.GuildID)
+
+		eventCtx.EventHandler.EventsMu.RLock()
+		defer eventCtx.EventHandler.EventsMu.RUnlock()
+
+		for _, event := range eventCtx.EventHandler.Events {
+			if f, ok := event.(welcomer.OnInvokeTimeRolesFuncType); ok {
+				return eventCtx.Handlers.WrapFuncType(eventCtx, f(eventCtx, invokeTimeRolesPayload))
+			}
+		}
+
+		return nil
+	})
+
+	// Schedule new members for their first time role.
+	p.EventHandler.RegisterOnGuildMemberAddEvent(func(eventCtx *sandwich.EventContext, member discord.GuildMember) error {
+		startTime := time.Now()
+		defer notifyTiming(startTime, eventCtx.Payload.Metadata.Shard, "TimeRolesCog.OnGuildMemberAdd")
+
+		if member.User == nil || member.User.Bot {
+			return nil
+		}
+
+		return p.scheduleMember(eventCtx, eventCtx.Guild.ID, &member, true)
+	})
+
+	// Re-evaluate members that have lost a time role.
+	p.EventHandler.RegisterOnGuildMemberUpdateEvent(func(eventCtx *sandwich.EventContext, before, after discord.GuildMember) error {
+		startTime := time.Now()
+		defer notifyTiming(startTime, eventCtx.Payload.Metadata.Shard, "TimeRolesCog.OnGuildMemberUpdate")
+
+		if after.User == nil || after.User.Bot || len(before.Roles) <= len(after.Roles) {
+			return nil
+		}
+
+		return p.OnMemberRolesRemoved(eventCtx, eventCtx.Guild.ID, before, after)
+	})
+
+	// Stop scheduling members that have left.
+	p.EventHandler.RegisterOnGuildMemberRemoveEvent(func(eventCtx *sandwich.EventContext, user discord.User) error {
+		startTime := time.Now()
+		defer notifyTiming(startTime, eventCtx.Payload.Metadata.Shard, "TimeRolesCog.OnGuildMemberRemove")
+
+		_, err := welcomer.Queries.DeleteTimeRoleSchedule(eventCtx.Context, database.DeleteTimeRoleScheduleParams{
+			GuildID: int64(eventCtx.Guild.ID),
+			UserID:  int64(user.ID),
+		})
+		if err != nil {
+			welcomer.Logger.Warn().Err(err).
+				Int64("guild_id", int64(eventCtx.Guild.ID)).
+				Int64("user_id", int64(user.ID)).
+				Msg("Failed to delete time role schedule")
+		}
+
+		return nil
+	})
+
 	// Trigger OnInvokeTimeRoles when ON_MESSAGE_CREATE event is received.
 	p.EventHandler.RegisterOnMessageCreateEvent(func(eventCtx *sandwich.EventContext, message discord.Message) error {
 		startTime := time.Now()
@@ -74,20 +156,116 @@ func (p *TimeRolesCog) RegisterCog(bot *sandwich.Bot) error {
 			return nil
 		}
 
-		go p.OnInvokeTimeRoles(eventCtx, *message.GuildID)
+		go func() {
+			// Members that joined before schedules existed are scheduled when they are next seen.
+			if message.Member != nil && welcomer.DedupeProvider.Deduplicate(
+				eventCtx,
+				buildDedupeKey2("SCHEDULE_TIMEROLE", *message.GuildID, message.Author.ID),
+				time.Hour,
+			) {
+				member := *message.Member
+				member.User = &message.Author
+
+				_ = p.scheduleMember(eventCtx, *message.GuildID, &member, false)
+			}
+
+			_ = p.OnInvokeTimeRoles(eventCtx, *message.GuildID)
+		}()
+
+		return nil
+	})
+
+	// Call OnInvokeTimeRolesEvent when CustomEventInvokeTimeRoles is triggered.
+	p.EventHandler.RegisterEvent(core.CustomEventInvokeTimeRoles, nil, (welcomer.OnInvokeTimeRolesFuncType)(p.OnInvokeTimeRolesEvent))
+
+	return nil
+}
+
+// scheduleMember schedules a member for their first time role. Members are not scheduled while
+// time roles are disabled, every member is backfilled when they are enabled.
+func (p *TimeRolesCog) scheduleMember(eventCtx *sandwich.EventContext, guildID discord.Snowflake, member *discord.GuildMember, overwrite bool) error {
+	guildSettingsTimeRoles, timeRoles, err := p.FetchGuildInformation(eventCtx, guildID)
+	if err != nil {
+		return err
+	}
+
+	if !guildSettingsTimeRoles.ToggleEnabled || len(timeRoles) == 0 {
+		return nil
+	}
+
+	err = welcomer.ScheduleTimeRoles(eventCtx.Context, guildID, member.User.ID, member.JoinedAt, timeRoles, overwrite)
+	if err != nil {
+		welcomer.Logger.Error().Err(err).
+			Int64("guild_id", int64(guildID)).
+			Int64("user_id", int64(member.User.ID)).
+			Msg("Failed to schedule time roles for member")
+
+		return err
+	}
+
+	return nil
+}
+
+// OnMemberRolesRemoved makes a member due again if one of the roles they lost is a time role.
+func (p *TimeRolesCog) OnMemberRolesRemoved(eventCtx *sandwich.EventContext, guildID discord.Snowflake, before, after discord.GuildMember) error {
+	guildSettingsTimeRoles, timeRoles, err := p.FetchGuildInformation(eventCtx, guildID)
+	if err != nil {
+		return err
+	}
+
+	if !guildSettingsTimeRoles.ToggleEnabled || len(timeRoles) == 0 {
+		return nil
+	}
+
+	lostTimeRole := false
 
+	if guildSettingsTimeRoles.ToggleLadder {
+		// Lower tiers are removed on purpose in ladder mode, only losing the current tier matters.
+		currentTier, _ := welcomer.GetTimeRoleTiers(after.JoinedAt, timeRoles, time.Now())
+		lostTimeRole = currentTier != nil &&
+			slices.Contains(before.Roles, currentTier.Role) && !slices.Contains(after.Roles, currentTier.Role)
+	} else {
+		for _, timeRole := range timeRoles {
+			if slices.Contains(before.Roles, timeRole.Role) && !slices.Contains(after.Roles, timeRole.Role) {
+				lostTimeRole = true
+
+				break
+			}
+		}
+	}
+
+	if !lostTimeRole {
 		return nil
+	}
+
+	_, err = welcomer.Queries.UpdateTimeRoleScheduleNextEligibleAt(eventCtx.Context, database.UpdateTimeRoleScheduleNextEligibleAtParams{
+		GuildID:        int64(guildID),
+		UserID:         int64(after.User.ID),
+		NextEligibleAt: sql.NullTime{Time: time.Now(), Valid: true},
 	})
+	if err != nil {
+		welcomer.Logger.Error().Err(err).
+			Int64("guild_id", int64(guildID)).
+			Int64("user_id", int64(after.User.ID)).
+			Msg("Failed to reschedule time roles for member")
+
+		return err
+	}
 
 	return nil
 }
 
+func (p *TimeRolesCog) OnInvokeTimeRolesEvent(eventCtx *sandwich.EventContext, event core.CustomEventInvokeTimeRolesStructure) error {
+	return p.OnInvokeTimeRoles(eventCtx, event.GuildID)
+}
+
+// OnInvokeTimeRoles gives time roles to the members of a guild that are due one.
 func (p *TimeRolesCog) OnInvokeTimeRoles(eventCtx *sandwich.EventContext, guildID discord.Snowflake) (err error) {
 	startTime := time.Now()
 	defer func() {
 		dur := time.Since(startTime)
 
-		if dur > time.Millisecond*1 {
+		if dur > TimeRolesSlowWarning {
 			welcomer.Logger.Warn().Dur("duration", dur).
 				Int64("guild_id", int64(guildID)).
 				Msg("Invoke timeroles took a long time to process")
@@ -112,18 +290,39 @@ func (p *TimeRolesCog) OnInvokeTimeRoles(eventCtx *sandwich.EventContext, guildI
 		return nil
 	}
 
-	// Chunk users
-	_, err = welcomer.SandwichClient.RequestGuildChunk(eventCtx.Context, &pb.RequestGuildChunkRequest{
-		GuildId: int64(guildID),
+	schedules, err := welcomer.Queries.GetDueTimeRoleSchedules(eventCtx.Context, database.GetDueTimeRoleSchedulesParams{
+		GuildID: int64(guildID),
+		Limit:   TimeRolesDueBatchSize,
 	})
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		welcomer.Logger.Error().Err(err).
+			Int64("guild_id", int64(guildID)).
+			Msg("Failed to get due time role schedules")
+
+		return err
+	}
+
+	if len(schedules) == 0 {
+		return nil
+	}
+
+	assignableTimeRoles, err := welcomer.FilterAssignableTimeRoles(eventCtx.Context, welcomer.SandwichClient, int64(guildID), int64(eventCtx.Identifier.UserId), timeRoles)
 	if err != nil {
 		welcomer.Logger.Error().Err(err).
 			Int64("guild_id", int64(guildID)).
-			Msg("Failed to request guild chunk")
+			Msg("Failed to filter assignable timeroles")
+
+		return err
+	}
+
+	userIDs := make([]int64, len(schedules))
+	for i, schedule := range schedules {
+		userIDs[i] = schedule.UserID
 	}
 
 	guildMembers, err := welcomer.SandwichClient.FetchGuildMember(eventCtx.Context, &pb.FetchGuildMemberRequest{
 		GuildId: int64(guildID),
+		UserIds: userIDs,
 	})
 	if err != nil || guildMembers == nil {
 		welcomer.Logger.Error().Err(err).
@@ -133,100 +332,106 @@ func (p *TimeRolesCog) OnInvokeTimeRoles(eventCtx *sandwich.EventContext, guildI
 		return err
 	}
 
-	allRolesToAssign := make(map[discord.Snowflake][]discord.Snowflake)
+	now := time.Now()
+	assigned := 0
 
-	for _, guildMember := range guildMembers.GuildMembers {
-		rolesToAssign := []discord.Snowflake{}
-
-		joinedAtTime, err := time.Parse(time.RFC3339, guildMember.JoinedAt)
-		if err != nil {
-			welcomer.Logger.Error().Err(err).
-				Str("joined_at", guildMember.JoinedAt).
-				Msg("Failed to parse joined_at time for user")
+	for _, schedule := range schedules {
+		memberPb, ok := guildMembers.GuildMembers[schedule.UserID]
+		if !ok {
+			// The member is not cached, try again later rather than dropping them.
+			p.updateSchedule(eventCtx, schedule, sql.NullTime{Time: now.Add(TimeRolesMissingMemberRetry), Valid: true})
 
 			continue
 		}
 
-		secondsSinceJoining := int(time.Since(joinedAtTime).Seconds())
-
-		assignableTimeRoles, err := welcomer.FilterAssignableTimeRoles(eventCtx.Context, welcomer.SandwichClient, int64(guildID), int64(guildMember.User.ID), timeRoles)
-		if err != nil {
-			welcomer.Logger.Error().Err(err).
-				Int64("guild_id", int64(guildID)).
-				Msg("Failed to filter assignable timeroles")
+		member := pb.PBToGuildMember(memberPb)
+		member.GuildID = &guildID
 
-			return nil
+		joinedAt := schedule.JoinedAt
+		if !member.JoinedAt.IsZero() {
+			joinedAt = member.JoinedAt
 		}
 
-		for _, timeRole := range assignableTimeRoles {
-			if timeRole.Seconds <= secondsSinceJoining {
-				hasRole := slices.Contains(guildMember.Roles, int64(timeRole.Role))
+		rolesToAssign, rolesToRemove := welcomer.GetTimeRoleChanges(member.Roles, joinedAt, assignableTimeRoles, guildSettingsTimeRoles.ToggleLadder, now)
 
-				if !hasRole && timeRole.Role != 0 {
-					rolesToAssign = append(rolesToAssign, timeRole.Role)
-				}
-			}
-		}
+		failed := false
 
-		if len(rolesToAssign) > 0 {
-			for _, role := range guildMember.Roles {
-				rolesToAssign = append(rolesToAssign, discord.Snowflake(role))
-			}
+		if len(rolesToRemove) > 0 {
+			err = member.RemoveRoles(eventCtx.Context, eventCtx.Session, rolesToRemove, new("Automatically removed with TimeRoles"), true)
+			if err != nil {
+				welcomer.Logger.Error().Err(err).
+					Int64("guild_id", int64(guildID)).
+					Int64("member_id", schedule.UserID).
+					Interface("roles", rolesToRemove).
+					Msg("Failed to remove roles from member for timeroles")
 
-			allRolesToAssign[discord.Snowflake(guildMember.User.ID)] = rolesToAssign
+				failed = true
+			} else {
+				member.Roles = slices.DeleteFunc(member.Roles, func(role discord.Snowflake) bool {
+					return slices.Contains(rolesToRemove, role)
+				})
+			}
 		}
-	}
-
-	welcomer.Logger.Info().
-		Int("guild_id", int(guildID)).
-		Int("members", len(allRolesToAssign)).
-		Msg("Assigning time roles for members")
-
-	for memberID, roles := range allRolesToAssign {
-		var member *discord.GuildMember
-
-		memberPb, ok := guildMembers.GuildMembers[int64(memberID)]
-		if ok {
-			member = pb.PBToGuildMember(memberPb)
-		} else {
-			welcomer.Logger.Warn().
-				Int64("guild_id", int64(guildID)).
-				Int64("user_id", int64(memberID)).
-				Msg("Failed to get member from state cache, falling back to default member")
 
-			member = &discord.GuildMember{
-				User: &discord.User{
-					ID: discord.Snowflake(memberID),
-				},
+		if len(rolesToAssign) > 0 {
+			err = member.AddRoles(eventCtx.Context, eventCtx.Session, append(rolesToAssign, member.Roles...), new("Automatically assigned with TimeRoles"), true)
+			if err != nil {
+				welcomer.Logger.Error().Err(err).
+					Int64("guild_id", int64(guildID)).
+					Int64("member_id", schedule.UserID).
+					Interface("roles", rolesToAssign).
+					Msg("Failed to add roles to member for timeroles")
+
+				failed = true
+			} else {
+				assigned++
+
+				for _, role := range rolesToAssign {
+					welcomer.PusherGuildScience.Push(
+						eventCtx.Context,
+						guildID,
+						member.User.ID,
+						database.ScienceGuildEventTypeTimeRoleGiven,
+						welcomer.GuildScienceTimeRoleGiven{
+							RoleID: role,
+						})
+				}
 			}
 		}
 
-		member.GuildID = &guildID
+		// Keep the member due so they are not moved on without the roles they have earned.
+		if failed {
+			p.updateSchedule(eventCtx, schedule, sql.NullTime{Time: now.Add(TimeRolesFailedRetry), Valid: true})
 
-		err = member.AddRoles(eventCtx.Context, eventCtx.Session, roles, new("Automatically assigned with TimeRoles"), true)
-		if err != nil {
-			welcomer.Logger.Error().Err(err).
-				Int64("guild_id", int64(guildID)).
-				Int64("member_id", int64(memberID)).
-				Interface("roles", roles).
-				Msg("Failed to add roles to member for timeroles")
+			continue
 		}
 
-		for _, role := range roles {
-			welcomer.PusherGuildScience.Push(
-				eventCtx.Context,
-				eventCtx.Guild.ID,
-				member.User.ID,
-				database.ScienceGuildEventTypeTimeRoleGiven,
-				welcomer.GuildScienceTimeRoleGiven{
-					RoleID: role,
-				})
-		}
+		p.updateSchedule(eventCtx, schedule, welcomer.NextTimeRoleEligibleAt(joinedAt, timeRoles, now))
 	}
 
+	welcomer.Logger.Info().
+		Int64("guild_id", int64(guildID)).
+		Int("due", len(schedules)).
+		Int("members", assigned).
+		Msg("Assigned time roles for members")
+
 	return nil
 }
 
+func (p *TimeRolesCog) updateSchedule(eventCtx *sandwich.EventContext, schedule *database.GuildTimeRoleSchedules, nextEligibleAt sql.NullTime) {
+	_, err := welcomer.Queries.UpdateTimeRoleScheduleNextEligibleAt(eventCtx.Context, database.UpdateTimeRoleScheduleNextEligibleAtParams{
+		GuildID:        schedule.GuildID,
+		UserID:         schedule.UserID,
+		NextEligibleAt: nextEligibleAt,
+	})
+	if err != nil {
+		welcomer.Logger.Error().Err(err).
+			Int64("guild_id", schedule.GuildID).
+			Int64("user_id", schedule.UserID).
+			Msg("Failed to update time role schedule")
+	}
+}
+
 func (p *TimeRolesCog) FetchGuildInformation(eventCtx *sandwich.EventContext, guildID discord.Snowflake) (guildSettingsTimeRoles *database.GuildSettingsTimeroles, timeRoles []welcomer.GuildSettingsTimeRolesRole, err error) {
 	// TODO: Add caching
 
@@ -237,6 +442,7 @@ func (p *TimeRolesCog) FetchGuildInformation(eventCtx *sandwich.EventContext, gu
 				GuildID:       int64(guildID),
 				ToggleEnabled: welcomer.DefaultTimeRoles.ToggleEnabled,
 				Timeroles:     welcomer.DefaultTimeRoles.Timeroles,
+				ToggleLadder:  welcomer.DefaultTimeRoles.ToggleLadder,
 			}
 		} else {
 			welcomer.Logger.Error().Err(err).
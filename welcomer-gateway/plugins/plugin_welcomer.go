@@ -21,8 +21,11 @@ import (
 	"github.com/WelcomerTeam/Welcomer/welcomer-core"
 	core "github.com/WelcomerTeam/Welcomer/welcomer-core"
 	"github.com/WelcomerTeam/Welcomer/welcomer-core/database"
+	images_protobuf "github.com/WelcomerTeam/Welcomer/welcomer-images/protobuf"
 	"github.com/jackc/pgx/v4"
 	"github.com/savsgio/gotils/strconv"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
 )
 
 const (
@@ -31,10 +34,30 @@ const (
 	DefaultProfileBorderWidth = 8
 
 	SendDMToUserTimeout = 10 * time.Second
+
+	// Deadline for a welcomer.image gRPC request, including any retries.
+	ImageGenerationTimeout = 30 * time.Second
 )
 
 var IsEasterEnabled = os.Getenv("EASTER_EGG_ENABLED") == "true"
 
+// When enabled, welcomer.image is requested over gRPC at IMAGE_GRPC_ADDRESS instead of over HTTP at IMAGE_ADDRESS.
+var IsImageGRPCEnabled = os.Getenv("IMAGE_GRPC_ENABLED") == "true"
+
+// Retry requests that failed before reaching welcomer.image, such as during a restart.
+const imageGenerationServiceConfig = `{
+	"methodConfig": [{
+		"name": [{"service": "service.ImageGenerationService"}],
+		"retryPolicy": {
+			"maxAttempts": 3,
+			"initialBackoff": "0.5s",
+			"maxBackoff": "2s",
+			"backoffMultiplier": 2,
+			"retryableStatusCodes": ["UNAVAILABLE", "RESOURCE_EXHAUSTED"]
+		}
+	}]
+}`
+
 var (
 	white = &color.RGBA{255, 255, 255, 255}
 	black = &color.RGBA{0, 0, 0, 255}
@@ -43,6 +66,7 @@ var (
 type WelcomerCog struct {
 	EventHandler *sandwich.Handlers
 	Client       http.Client
+	ImageClient  images_protobuf.ImageGenerationServiceClient
 }
 
 // Assert types.
@@ -53,10 +77,27 @@ var (
 )
 
 func NewWelcomerCog() *WelcomerCog {
-	return &WelcomerCog{
+	cog := &WelcomerCog{
 		EventHandler: sandwich.SetupHandler(nil),
 		Client:       http.Client{},
 	}
+
+	if IsImageGRPCEnabled {
+		conn, err := grpc.NewClient(
+			os.Getenv("IMAGE_GRPC_ADDRESS"),
+			grpc.WithTransportCredentials(insecure.NewCredentials()),
+			grpc.WithDefaultServiceConfig(imageGenerationServiceConfig),
+			grpc.WithDefaultCallOptions(grpc.MaxCallRecvMsgSize(1024*1024*1024)), // Set max message size to 1GB
+		)
+		if err != nil {
+			welcomer.Logger.Warn().Err(err).
+				Msg("Failed to create welcomer.image gRPC client, falling back to HTTP")
+		} else {
+			cog.ImageClient = images_protobuf.NewImageGenerationServiceClient(conn)
+		}
+	}
+
+	return cog
 }
 
 func (p *WelcomerCog) CogInfo() *sandwich.CogInfo {
@@ -199,7 +240,11 @@ func (p *WelcomerCog) RegisterCog(bot *sandwich.Bot) error {
 	return nil
 }
 
-func (p *WelcomerCog) FetchWelcomerImage(options welcomer.GenerateImageOptionsRaw) (io.ReadCloser, string, error) {
+func (p *WelcomerCog) FetchWelcomerImage(ctx context.Context, options welcomer.GenerateImageOptionsRaw) (io.ReadCloser, string, error) {
+	if p.ImageClient != nil {
+		return p.fetchWelcomerImageGRPC(ctx, options)
+	}
+
 	optionsJSON, _ := json.Marshal(options)
 
 	resp, err := p.Client.Post(os.Getenv("IMAGE_ADDRESS")+"/generate", "application/json", bytes.NewBuffer(optionsJSON))
@@ -214,6 +259,42 @@ func (p *WelcomerCog) FetchWelcomerImage(options welcomer.GenerateImageOptionsRa
 	return resp.Body, resp.Header.Get("Content-Type"), nil
 }
 
+func (p *WelcomerCog) fetchWelcomerImageGRPC(ctx context.Context, options welcomer.GenerateImageOptionsRaw) (io.ReadCloser, string, error) {
+	ctx, cancel := context.WithTimeout(ctx, ImageGenerationTimeout)
+	defer cancel()
+
+	resp, err := p.ImageClient.GenerateImage(ctx, &images_protobuf.GenerateImageRequest{
+		ShowAvatar:         options.ShowAvatar,
+		AvatarURL:          options.AvatarURL,
+		Background:         options.Background,
+		Text:               options.Text,
+		TextFont:           options.TextFont,
+		TextColor:          options.TextColor,
+		UserID:             options.UserID,
+		ProfileBorderColor: options.ProfileBorderColor,
+		GuildID:            options.GuildID,
+		ImageBorderColor:   options.ImageBorderColor,
+		TextStrokeColor:    options.TextStrokeColor,
+		Theme:              options.Theme,
+		TextAlign:          options.TextAlign,
+		ImageBorderWidth:   options.ImageBorderWidth,
+		ProfileFloat:       options.ProfileFloat,
+		ProfileBorderWidth: options.ProfileBorderWidth,
+		ProfileBorderCurve: options.ProfileBorderCurve,
+		TextStroke:         options.TextStroke,
+		AllowAnimated:      options.AllowAnimated,
+	})
+	if err != nil {
+		return nil, "", fmt.Errorf("fetch welcomer.image grpc request failed: %w", err)
+	}
+
+	if !resp.GetBaseResponse().GetOk() {
+		return nil, "", fmt.Errorf("failed to get welcomer.image: %s", resp.GetBaseResponse().GetError())
+	}
+
+	return io.NopCloser(bytes.NewReader(resp.GetFile())), resp.GetFiletype(), nil
+}
+
 func (p *WelcomerCog) FetchWelcomerImagesNew(request welcomer.CustomWelcomerImageGenerateRequest) (io.ReadCloser, string, error) {
 	requestJSON, _ := json.Marshal(request)
 
@@ -808,7 +889,7 @@ func (p *WelcomerCog) OnInvokeWelcomerEvent(eventCtx *sandwich.EventContext, eve
 			}
 		} else {
 			// Fetch the welcomer.image.
-			imageReaderCloser, contentType, err = p.FetchWelcomerImage(welcomer.GenerateImageOptionsRaw{
+			imageReaderCloser, contentType, err = p.FetchWelcomerImage(eventCtx.Context, welcomer.GenerateImageOptionsRaw{
 				ShowAvatar:         guildSettingsWelcomerImages.ToggleShowAvatar,
 				GuildID:            int64(eventCtx.Guild.ID),
 				UserID:             int64(event.Member.User.ID),
@@ -33,6 +33,7 @@ func (w *Welcomer) Register() error {
 	bot.MustRegisterCog(plugins.NewAutoRolesCog())
 	bot.MustRegisterCog(plugins.NewLeaverCog())
 	bot.MustRegisterCog(plugins.NewTimeRolesCog())
+	bot.MustRegisterCog(plugins.NewActivityRolesCog())
 	bot.MustRegisterCog(plugins.NewTempChannelsCog())
 	bot.MustRegisterCog(plugins.NewBorderwallCog())
 	bot.MustRegisterCog(plugins.NewEventsCog())
@@ -41,6 +42,7 @@ func (w *Welcomer) Register() error {
 	bot.MustRegisterCog(plugins.NewIngestCog())
 	bot.MustRegisterCog(plugins.NewReactionRolesCog())
 	bot.MustRegisterCog(plugins.NewGiveawayCog())
+	bot.MustRegisterCog(plugins.NewTempRolesCog())
 
 	w.Bot = bot
 
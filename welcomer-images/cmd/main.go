@@ -19,6 +19,7 @@ func main() {
 	prometheusAddress := flag.String("prometheusAddress", os.Getenv("IMAGE_PROMETHEUS_ADDRESS"), "Prometheus address")
 	postgresURL := flag.String("postgresURL", os.Getenv("POSTGRES_URL"), "Postgres connection URL")
 	imageHost := flag.String("host", os.Getenv("IMAGE_HOST"), "Host to serve the image service interface from")
+	imageGRPCHost := flag.String("grpcHost", os.Getenv("IMAGE_GRPC_HOST"), "Host to serve the image service gRPC interface from. gRPC is not served when empty")
 
 	releaseMode := flag.String("ginMode", os.Getenv("GIN_MODE"), "gin mode (release/debug)")
 	debug := flag.Bool("debug", false, "When enabled, images will be saved to a file.")
@@ -40,6 +41,7 @@ func main() {
 	if imageService, err = service.NewImageService(ctx, service.ImageServiceOptions{
 		Debug:             *debug,
 		Host:              *imageHost,
+		GRPCHost:          *imageGRPCHost,
 		PostgresAddress:   *postgresURL,
 		PrometheusAddress: *prometheusAddress,
 	}); err != nil {
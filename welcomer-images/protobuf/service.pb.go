@@ -169,6 +169,7 @@ type GenerateImageRequest struct {
 	ProfileBorderColor int64  `protobuf:"varint,16,opt,name=profileBorderColor,proto3" json:"profileBorderColor,omitempty"`
 	ProfileBorderWidth int32  `protobuf:"varint,17,opt,name=profileBorderWidth,proto3" json:"profileBorderWidth,omitempty"`
 	ProfileBorderCurve int32  `protobuf:"varint,18,opt,name=profileBorderCurve,proto3" json:"profileBorderCurve,omitempty"`
+	ShowAvatar         bool   `protobuf:"varint,19,opt,name=showAvatar,proto3" json:"showAvatar,omitempty"`
 }
 
 func (x *GenerateImageRequest) Reset() {
@@ -329,6 +330,13 @@ func (x *GenerateImageRequest) GetProfileBorderCurve() int32 {
 	return 0
 }
 
+func (x *GenerateImageRequest) GetShowAvatar() bool {
+	if x != nil {
+		return x.ShowAvatar
+	}
+	return false
+}
+
 var File_service_proto protoreflect.FileDescriptor
 
 var file_service_proto_rawDesc = []byte{
@@ -346,7 +354,7 @@ var file_service_proto_rawDesc = []byte{
 	0x0c, 0x62, 0x61, 0x73, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x12, 0x0a,
 	0x04, 0x66, 0x69, 0x6c, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x04, 0x66, 0x69, 0x6c,
 	0x65, 0x12, 0x1a, 0x0a, 0x08, 0x66, 0x69, 0x6c, 0x65, 0x74, 0x79, 0x70, 0x65, 0x18, 0x03, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x08, 0x66, 0x69, 0x6c, 0x65, 0x74, 0x79, 0x70, 0x65, 0x22, 0x84, 0x05,
+	0x01, 0x28, 0x09, 0x52, 0x08, 0x66, 0x69, 0x6c, 0x65, 0x74, 0x79, 0x70, 0x65, 0x22, 0xa4, 0x05,
 	0x0a, 0x14, 0x47, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x65, 0x49, 0x6d, 0x61, 0x67, 0x65, 0x52,
 	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x67, 0x75, 0x69, 0x6c, 0x64, 0x49,
 	0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x67, 0x75, 0x69, 0x6c, 0x64, 0x49, 0x44,
@@ -387,17 +395,21 @@ var file_service_proto_rawDesc = []byte{
 	0x69, 0x64, 0x74, 0x68, 0x12, 0x2e, 0x0a, 0x12, 0x70, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x42,
 	0x6f, 0x72, 0x64, 0x65, 0x72, 0x43, 0x75, 0x72, 0x76, 0x65, 0x18, 0x12, 0x20, 0x01, 0x28, 0x05,
 	0x52, 0x12, 0x70, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x42, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x43,
-	0x75, 0x72, 0x76, 0x65, 0x32, 0x6a, 0x0a, 0x16, 0x49, 0x6d, 0x61, 0x67, 0x65, 0x47, 0x65, 0x6e,
+	0x75, 0x72, 0x76, 0x65, 0x12, 0x1e, 0x0a, 0x0a, 0x73, 0x68, 0x6f, 0x77, 0x41, 0x76, 0x61, 0x74,
+	0x61, 0x72, 0x18, 0x13, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0a, 0x73, 0x68, 0x6f, 0x77, 0x41, 0x76,
+	0x61, 0x74, 0x61, 0x72, 0x32, 0x6a, 0x0a, 0x16, 0x49, 0x6d, 0x61, 0x67, 0x65, 0x47, 0x65, 0x6e,
 	0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x50,
 	0x0a, 0x0d, 0x47, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x65, 0x49, 0x6d, 0x61, 0x67, 0x65, 0x12,
 	0x1d, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x47, 0x65, 0x6e, 0x65, 0x72, 0x61,
 	0x74, 0x65, 0x49, 0x6d, 0x61, 0x67, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1e,
 	0x2e, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x47, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74,
 	0x65, 0x49, 0x6d, 0x61, 0x67, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00,
-	0x42, 0x2e, 0x5a, 0x2c, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x57,
+	0x42, 0x47, 0x5a, 0x45, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x57,
 	0x65, 0x6c, 0x63, 0x6f, 0x6d, 0x65, 0x72, 0x54, 0x65, 0x61, 0x6d, 0x2f, 0x57, 0x65, 0x6c, 0x63,
-	0x6f, 0x6d, 0x65, 0x72, 0x2f, 0x75, 0x74, 0x69, 0x6c, 0x73, 0x2e, 0x69, 0x6d, 0x61, 0x67, 0x65,
-	0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x6f, 0x6d, 0x65, 0x72, 0x2f, 0x77, 0x65, 0x6c, 0x63, 0x6f, 0x6d, 0x65, 0x72, 0x2d, 0x69, 0x6d,
+	0x61, 0x67, 0x65, 0x73, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x3b, 0x75, 0x74,
+	0x69, 0x6c, 0x73, 0x5f, 0x69, 0x6d, 0x61, 0x67, 0x65, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x33,
 }
 
 var (
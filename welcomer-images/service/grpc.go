@@ -0,0 +1,96 @@
+package service
+
+import (
+	"context"
+	"os"
+	"runtime/debug"
+	"time"
+
+	"github.com/WelcomerTeam/Welcomer/welcomer-core"
+	pb "github.com/WelcomerTeam/Welcomer/welcomer-images/protobuf"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// imageGenerationServer serves the same image generation as POST /generate, over gRPC.
+type imageGenerationServer struct {
+	pb.UnimplementedImageGenerationServiceServer
+
+	is *ImageService
+}
+
+func (s *imageGenerationServer) GenerateImage(ctx context.Context, req *pb.GenerateImageRequest) (*pb.GenerateImageResponse, error) {
+	onRequest()
+
+	requestRaw := generateImageRequestPBToRaw(req)
+
+	start := time.Now()
+
+	file, format, _, err := s.is.GenerateImage(ctx, generateImageRequestToOptions(requestRaw))
+	if err != nil {
+		return &pb.GenerateImageResponse{
+			BaseResponse: &pb.BaseResponse{
+				Version: VERSION,
+				Ok:      false,
+				Error:   err.Error(),
+			},
+		}, nil
+	}
+
+	onGenerationComplete(start, requestRaw.GuildID, requestRaw.Background, format)
+
+	if s.is.Options.Debug {
+		_ = os.WriteFile("output.png", file, 0o644)
+	}
+
+	return &pb.GenerateImageResponse{
+		BaseResponse: &pb.BaseResponse{
+			Version: VERSION,
+			Ok:      true,
+		},
+		File:     file,
+		Filetype: format.String(),
+	}, nil
+}
+
+// recoveryUnaryInterceptor turns a panic in a handler into an Internal error, like gin.Recovery does for HTTP.
+func recoveryUnaryInterceptor(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp any, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			welcomer.Logger.Error().
+				Interface("panic", r).
+				Str("method", info.FullMethod).
+				Str("stack", string(debug.Stack())).
+				Msg("Recovered from panic in gRPC handler")
+
+			err = status.Errorf(codes.Internal, "panic: %v", r)
+		}
+	}()
+
+	return handler(ctx, req)
+}
+
+func generateImageRequestPBToRaw(req *pb.GenerateImageRequest) welcomer.GenerateImageOptionsRaw {
+	return welcomer.GenerateImageOptionsRaw{
+		ShowAvatar:         req.GetShowAvatar(),
+		AvatarURL:          req.GetAvatarURL(),
+		Background:         req.GetBackground(),
+		Text:               req.GetText(),
+		TextFont:           req.GetTextFont(),
+		TextColor:          req.GetTextColor(),
+		UserID:             req.GetUserID(),
+		ProfileBorderColor: req.GetProfileBorderColor(),
+		GuildID:            req.GetGuildID(),
+		ImageBorderColor:   req.GetImageBorderColor(),
+		TextStrokeColor:    req.GetTextStrokeColor(),
+		Theme:              req.GetTheme(),
+		TextAlign:          req.GetTextAlign(),
+		ImageBorderWidth:   req.GetImageBorderWidth(),
+		ProfileFloat:       req.GetProfileFloat(),
+		ProfileBorderWidth: req.GetProfileBorderWidth(),
+		ProfileBorderCurve: req.GetProfileBorderCurve(),
+		TextStroke:         req.GetTextStroke(),
+		AllowAnimated:      req.GetAllowAnimated(),
+	}
+}
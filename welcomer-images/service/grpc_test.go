@@ -0,0 +1,47 @@
+package service
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/WelcomerTeam/Welcomer/welcomer-core"
+	pb "github.com/WelcomerTeam/Welcomer/welcomer-images/protobuf"
+)
+
+func TestGenerateImageRequestPBToRaw(t *testing.T) {
+	t.Parallel()
+
+	expected := welcomer.GenerateImageOptionsRaw{}
+	req := &pb.GenerateImageRequest{}
+
+	rawValue := reflect.ValueOf(&expected).Elem()
+	reqValue := reflect.ValueOf(req).Elem()
+
+	// Give every raw option a distinct value and set the request field of the same name to it.
+	for i := range rawValue.NumField() {
+		name := rawValue.Type().Field(i).Name
+		field := rawValue.Field(i)
+
+		reqField := reqValue.FieldByName(name)
+		if !reqField.IsValid() {
+			t.Fatalf("GenerateImageRequest has no field %s", name)
+		}
+
+		switch field.Kind() {
+		case reflect.Bool:
+			field.SetBool(true)
+		case reflect.String:
+			field.SetString(name)
+		case reflect.Int32, reflect.Int64:
+			field.SetInt(int64(i + 1))
+		default:
+			t.Fatalf("unexpected kind %s for field %s", field.Kind(), name)
+		}
+
+		reqField.Set(field)
+	}
+
+	if got := generateImageRequestPBToRaw(req); got != expected {
+		t.Errorf("expected: %+v, got: %+v", expected, got)
+	}
+}
@@ -3,14 +3,17 @@ package service
 import (
 	"context"
 	"fmt"
+	"net"
 	"net/http"
 	"time"
 
 	"github.com/WelcomerTeam/Welcomer/welcomer-core"
+	pb "github.com/WelcomerTeam/Welcomer/welcomer-images/protobuf"
 	"github.com/gin-contrib/logger"
 	"github.com/gin-gonic/gin"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"google.golang.org/grpc"
 )
 
 // VERSION follows semantic versioning.
@@ -28,6 +31,8 @@ type ImageService struct {
 
 	Client http.Client
 
+	GRPCServer *grpc.Server
+
 	Fonts map[string]*Font
 }
 
@@ -36,6 +41,7 @@ type ImageService struct {
 type ImageServiceOptions struct {
 	Debug             bool
 	Host              string
+	GRPCHost          string
 	PostgresAddress   string
 	PrometheusAddress string
 }
@@ -58,6 +64,14 @@ func (is *ImageService) Open() {
 	// Setup HTTP
 	go is.setupHTTP()
 
+	// Setup GRPC
+	if is.Options.GRPCHost != "" {
+		is.GRPCServer = grpc.NewServer(grpc.UnaryInterceptor(recoveryUnaryInterceptor))
+		pb.RegisterImageGenerationServiceServer(is.GRPCServer, &imageGenerationServer{is: is})
+
+		go is.setupGRPC()
+	}
+
 	// Setup Prometheus
 	go is.setupPrometheus()
 }
@@ -82,6 +96,26 @@ func (is *ImageService) setupHTTP() error {
 	return nil
 }
 
+func (is *ImageService) setupGRPC() error {
+	listener, err := net.Listen("tcp", is.Options.GRPCHost)
+	if err != nil {
+		welcomer.Logger.Panic().Err(err).Str("host", is.Options.GRPCHost).Msg("Failed to bind to host")
+
+		return fmt.Errorf("failed to bind to host: %w", err)
+	}
+
+	welcomer.Logger.Info().Msgf("Serving gRPC at %s", is.Options.GRPCHost)
+
+	err = is.GRPCServer.Serve(listener)
+	if err != nil {
+		welcomer.Logger.Panic().Err(err).Str("host", is.Options.GRPCHost).Msg("Failed to serve gRPC server")
+
+		return fmt.Errorf("failed to serve grpc: %w", err)
+	}
+
+	return nil
+}
+
 func (is *ImageService) setupPrometheus() error {
 	prometheus.MustRegister(imgenRequests)
 	prometheus.MustRegister(imgenTotalRequests)
@@ -108,5 +142,9 @@ func (is *ImageService) setupPrometheus() error {
 func (is *ImageService) Close() error {
 	welcomer.Logger.Info().Msg("Closing image service")
 
+	if is.GRPCServer != nil {
+		is.GRPCServer.GracefulStop()
+	}
+
 	return nil
 }
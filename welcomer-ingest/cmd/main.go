@@ -41,8 +41,14 @@ func main() {
 
 	ingest.AggregateMessageCounts(ctx, waitGroup, time.Minute*1)
 
+	ingest.RollupMessageCounts(ctx, waitGroup, time.Minute*5)
+
 	ingest.CheckpointVoiceChannels(ctx, waitGroup, time.Minute*1)
 
+	ingest.AggregateVoiceMinutes(ctx, waitGroup, time.Minute*5)
+
+	ingest.ActivityRoles(ctx, waitGroup, time.Minute*5)
+
 	sig := make(chan os.Signal, 1)
 	signal.Notify(sig, syscall.SIGINT, syscall.SIGTERM)
 	<-sig
@@ -3,6 +3,7 @@ module github.com/WelcomerTeam/Welcomer/welcomer-ingest
 go 1.26
 
 require (
+	github.com/WelcomerTeam/Discord v0.0.0-20260218220309-ab6ed1baf936
 	github.com/WelcomerTeam/Sandwich-Daemon v0.0.0-20260219211816-cc899f0920e7
 	github.com/WelcomerTeam/Welcomer/welcomer-core v0.0.0-20260119133355-7d41c01dbc33
 	github.com/jackc/pgx/v4 v4.18.3
@@ -12,7 +13,6 @@ require (
 
 require (
 	github.com/Knetic/govaluate v3.0.1-0.20171022003610-9aa49832a739+incompatible // indirect
-	github.com/WelcomerTeam/Discord v0.0.0-20260218220309-ab6ed1baf936 // indirect
 	github.com/WelcomerTeam/Mustachvulate v1.2.1-0.20231218130351-adad26f1e96e // indirect
 	github.com/WelcomerTeam/Sandwich v0.0.0-20260219212036-0ff755353119 // indirect
 	github.com/WelcomerTeam/Subway v0.0.0-20260215223101-ec5a0f747ca9 // indirect
@@ -0,0 +1,185 @@
+package ingest
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/WelcomerTeam/Discord/discord"
+	"github.com/WelcomerTeam/Welcomer/welcomer-core"
+	"github.com/WelcomerTeam/Welcomer/welcomer-core/database"
+	"github.com/jackc/pgx/v4"
+)
+
+const (
+	jobNameActivityRoles      = "activity_roles"
+	jobNameActivityRolesDecay = "activity_roles_decay"
+
+	// activityRolesDecayInterval is how often members holding an activity role are re-checked
+	// when they have not been active, so roles can decay.
+	activityRolesDecayInterval = time.Hour
+)
+
+// ActivityRoles runs on an interval and asks the gateway to check activity roles for members
+// that have been active since the message counts were last aggregated.
+func ActivityRoles(ctx context.Context, waitGroup *sync.WaitGroup, interval time.Duration) {
+	ticker := time.NewTicker(time.Millisecond)
+	hasReset := false
+
+	waitGroup.Go(func() {
+		for {
+			select {
+			case <-ticker.C:
+				if !hasReset {
+					ticker.Reset(interval)
+
+					hasReset = true
+				}
+
+				startTime := time.Now()
+
+				if err := activityRoles(ctx); err != nil {
+					welcomer.Logger.Error().Err(err).Msg("activity roles failed")
+				} else {
+					if time.Since(startTime) > slowJobWarning {
+						welcomer.Logger.Warn().Dur("duration", time.Since(startTime)).Msg("activity roles too long to run")
+					} else {
+						welcomer.Logger.Info().Dur("duration", time.Since(startTime)).Msg("activity roles completed")
+					}
+				}
+			case <-ctx.Done():
+				ticker.Stop()
+
+				return
+			}
+		}
+	})
+}
+
+func getCheckpoint(ctx context.Context, jobName string) (time.Time, error) {
+	checkpoint, err := welcomer.Queries.GetJobCheckpointByName(ctx, jobName)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return time.Time{}, nil
+		}
+
+		return time.Time{}, fmt.Errorf("error getting job checkpoint %s: %w", jobName, err)
+	}
+
+	return checkpoint.LastProcessedTs, nil
+}
+
+func activityRoles(ctx context.Context) error {
+	welcomer.Logger.Info().Msg("starting activity roles job")
+
+	lastProcessed, err := getCheckpoint(ctx, jobNameActivityRoles)
+	if err != nil {
+		return err
+	}
+
+	// Only act on activity that has been aggregated.
+	upperBound, err := getCheckpoint(ctx, jobNameMessageCounts)
+	if err != nil {
+		return err
+	}
+
+	lastDecay, err := getCheckpoint(ctx, jobNameActivityRolesDecay)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now().UTC()
+	includeDecay := now.Sub(lastDecay) >= activityRolesDecayInterval
+
+	if !upperBound.After(lastProcessed) && !includeDecay {
+		welcomer.Logger.Info().Msg("no new activity for activity roles")
+
+		return nil
+	}
+
+	// Activity roles have never run, only look at recent activity rather than all history.
+	if lastProcessed.IsZero() {
+		lastProcessed = upperBound.Add(-activityRolesDecayInterval)
+	}
+
+	welcomer.Logger.Info().
+		Time("lower_bound", lastProcessed).
+		Time("upper_bound", upperBound).
+		Bool("include_decay", includeDecay).
+		Msg("checking activity roles")
+
+	candidates, err := welcomer.Queries.GetActivityRoleCandidates(ctx, database.GetActivityRoleCandidatesParams{
+		Since:        lastProcessed.Add(-backfillWindow),
+		IncludeDecay: includeDecay,
+	})
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		return fmt.Errorf("error getting activity role candidates: %w", err)
+	}
+
+	usersByGuild := make(map[int64][]discord.Snowflake)
+	for _, candidate := range candidates {
+		usersByGuild[candidate.GuildID] = append(usersByGuild[candidate.GuildID], discord.Snowflake(candidate.UserID))
+	}
+
+	// Guilds that could not be reached are logged and skipped rather than holding back the
+	// checkpoint for every other guild. Their members are checked again when they are next
+	// active or by the decay check.
+	failedGuilds := 0
+
+	for guildID, userIDs := range usersByGuild {
+		failed := 0
+
+		for start := 0; start < len(userIDs); start += welcomer.ActivityRolesRelayBatchSize {
+			end := min(start+welcomer.ActivityRolesRelayBatchSize, len(userIDs))
+
+			data, err := json.Marshal(welcomer.CustomEventInvokeActivityRolesStructure{
+				GuildID: discord.Snowflake(guildID),
+				UserIDs: userIDs[start:end],
+			})
+			if err != nil {
+				return fmt.Errorf("error marshalling activity roles event: %w", err)
+			}
+
+			if err := welcomer.RelayActivityRolesEvent(ctx, guildID, data); err != nil {
+				welcomer.Logger.Warn().Err(err).Int64("guild_id", guildID).Msg("Failed to relay activity roles event")
+
+				failed++
+			}
+		}
+
+		if failed > 0 {
+			welcomer.Logger.Warn().Int64("guild_id", guildID).Int("failed", failed).Int("users", len(userIDs)).Msg("Skipped activity roles for guild")
+
+			failedGuilds++
+		}
+	}
+
+	if upperBound.After(lastProcessed) {
+		if err := welcomer.Queries.UpsertJobCheckpoint(ctx, database.UpsertJobCheckpointParams{
+			JobName:         jobNameActivityRoles,
+			LastProcessedTs: upperBound,
+		}); err != nil {
+			return fmt.Errorf("error upserting job checkpoint: %w", err)
+		}
+	}
+
+	if includeDecay {
+		if err := welcomer.Queries.UpsertJobCheckpoint(ctx, database.UpsertJobCheckpointParams{
+			JobName:         jobNameActivityRolesDecay,
+			LastProcessedTs: now,
+		}); err != nil {
+			return fmt.Errorf("error upserting job checkpoint: %w", err)
+		}
+	}
+
+	welcomer.Logger.Info().
+		Int("guilds", len(usersByGuild)).
+		Int("users", len(candidates)).
+		Int("failed_guilds", failedGuilds).
+		Msg("relayed activity roles events")
+
+	return nil
+}
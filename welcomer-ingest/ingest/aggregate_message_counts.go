@@ -17,27 +17,93 @@ const (
 	backfillWindow       = time.Minute * 2 // allow late events
 	slowJobWarning       = time.Second * 45
 
-	aggregateMessageCountsSQL = `
-SELECT date_trunc('hour', occurred_at) AS hour_ts,
+	// messageDeleteLookback is how long after a message is sent that deleting it is subtracted from the counts.
+	// ingest_message_events is pruned after 3 days, so older hours can no longer be recounted in full.
+	messageDeleteLookback = time.Hour * 48
+
+	getMaxOccurredAtSQL = `
+SELECT MAX(occurred_at)
+FROM ingest_message_events
+WHERE occurred_at > $1 AND occurred_at <= $2`
+
+	// Hours that have a message sent or deleted in the window are recounted in full, so deleted messages are
+	// no longer counted. Deletes do not have an author, so the hour and author are taken from the create event.
+	upsertMessageCountsSQL = `
+WITH touched_messages AS (
+	SELECT message_id
+	FROM ingest_message_events
+	WHERE occurred_at > $1 AND occurred_at <= $2 AND event_type IN ($3, $4, $5)
+),
+touched_hours AS (
+	SELECT DISTINCT date_trunc('hour', occurred_at) AS hour_ts, guild_id, channel_id, user_id
+	FROM ingest_message_events
+	WHERE event_type = $3
+		AND occurred_at >= $6
+		AND message_id IN (SELECT message_id FROM touched_messages)
+),
+messages AS (
+	SELECT touched_hours.hour_ts,
+		created.guild_id,
+		created.channel_id,
+		created.user_id,
+		created.message_id,
+		created.occurred_at,
+		EXISTS (
+			SELECT 1
+			FROM ingest_message_events deleted
+			WHERE deleted.message_id = created.message_id AND deleted.event_type IN ($4, $5)
+		) AS is_deleted
+	FROM ingest_message_events created
+	JOIN touched_hours ON touched_hours.hour_ts = date_trunc('hour', created.occurred_at)
+		AND touched_hours.guild_id = created.guild_id
+		AND touched_hours.channel_id = created.channel_id
+		AND touched_hours.user_id = created.user_id
+	WHERE created.event_type = $3
+		AND created.occurred_at >= date_trunc('hour', $6::timestamptz)
+)
+INSERT INTO guild_message_counts_hour (hour_ts, guild_id, channel_id, user_id, message_count, min_ts, updated_at)
+SELECT hour_ts,
 	guild_id,
 	channel_id,
 	user_id,
-	COUNT(message_id) AS message_count,
+	COUNT(DISTINCT message_id) FILTER (WHERE NOT is_deleted) AS message_count,
 	MIN(occurred_at) AS min_ts,
-	MAX(occurred_at) AS max_ts
-FROM ingest_message_events
-WHERE occurred_at > $1 AND occurred_at <= $2 AND event_type IN ($3, $4)
-GROUP BY 1, 2, 3, 4`
-
-	upsertMessageCountsSQL = `
-INSERT INTO guild_message_counts_hour (hour_ts, guild_id, channel_id, user_id, message_count, min_ts)
-VALUES ($1, $2, $3, $4, $5, $6)
+	now() AS updated_at
+FROM messages
+GROUP BY 1, 2, 3, 4
 ON CONFLICT (hour_ts, guild_id, channel_id, user_id) DO UPDATE SET
 	message_count = EXCLUDED.message_count,
-	min_ts = LEAST(guild_message_counts_hour.min_ts, EXCLUDED.min_ts)`
+	min_ts = LEAST(guild_message_counts_hour.min_ts, EXCLUDED.min_ts),
+	updated_at = EXCLUDED.updated_at`
+
+	// Hours that have a reaction added in the window are recounted in full.
+	upsertReactionCountsSQL = `
+WITH touched_hours AS (
+	SELECT DISTINCT date_trunc('hour', occurred_at) AS hour_ts, guild_id, channel_id, user_id
+	FROM ingest_message_events
+	WHERE occurred_at > $1 AND occurred_at <= $2 AND event_type = $3
+)
+INSERT INTO guild_reaction_counts_hour (hour_ts, guild_id, channel_id, user_id, reaction_count, min_ts)
+SELECT touched_hours.hour_ts,
+	reaction.guild_id,
+	reaction.channel_id,
+	reaction.user_id,
+	COUNT(*) AS reaction_count,
+	MIN(reaction.occurred_at) AS min_ts
+FROM ingest_message_events reaction
+JOIN touched_hours ON touched_hours.hour_ts = date_trunc('hour', reaction.occurred_at)
+	AND touched_hours.guild_id = reaction.guild_id
+	AND touched_hours.channel_id = reaction.channel_id
+	AND touched_hours.user_id = reaction.user_id
+WHERE reaction.event_type = $3
+	AND reaction.occurred_at >= date_trunc('hour', $1::timestamptz)
+GROUP BY 1, 2, 3, 4
+ON CONFLICT (hour_ts, guild_id, channel_id, user_id) DO UPDATE SET
+	reaction_count = EXCLUDED.reaction_count,
+	min_ts = LEAST(guild_reaction_counts_hour.min_ts, EXCLUDED.min_ts)`
 )
 
-// AggregateMessageCounts runs on an interval and rolls ingest_message_events into hourly aggregates.
+// AggregateMessageCounts runs on an interval and rolls ingest_message_events into hourly message and reaction aggregates.
 func AggregateMessageCounts(ctx context.Context, waitGroup *sync.WaitGroup, interval time.Duration) {
 	ticker := time.NewTicker(time.Millisecond)
 	hasReset := false
@@ -72,15 +138,6 @@ func AggregateMessageCounts(ctx context.Context, waitGroup *sync.WaitGroup, inte
 	})
 }
 
-type aggRow struct {
-	hourTimestamp      time.Time
-	guildID, channelID int64
-	userID             int64
-	messageCount       int64
-	minTimestamp       time.Time
-	maxTimestamp       time.Time
-}
-
 func aggregateMessageCounts(ctx context.Context) (err error) {
 	welcomer.Logger.Info().Msg("starting aggregate message counts job")
 
@@ -119,44 +176,26 @@ func aggregateMessageCounts(ctx context.Context) (err error) {
 		Time("upper_bound", upperBound).
 		Msg("aggregating message counts")
 
-	rows, err := tx.Query(ctx, aggregateMessageCountsSQL, lowerBound, upperBound, welcomer.IngestMessageEventTypeCreate, welcomer.IngestMessageEventTypeEdit)
-	if err != nil {
-		return fmt.Errorf("error querying aggregate message counts: %w", err)
-	}
-
-	var maxProcessed time.Time
-
-	results := make([]aggRow, 0)
-
-	for rows.Next() {
-		var aggregationRow aggRow
-
-		if err := rows.Scan(&aggregationRow.hourTimestamp, &aggregationRow.guildID, &aggregationRow.channelID, &aggregationRow.userID, &aggregationRow.messageCount, &aggregationRow.minTimestamp, &aggregationRow.maxTimestamp); err != nil {
-			rows.Close()
+	var maxOccurredAt *time.Time
 
-			return fmt.Errorf("error scanning aggregate message counts: %w", err)
-		}
-
-		results = append(results, aggregationRow)
+	if err = tx.QueryRow(ctx, getMaxOccurredAtSQL, lowerBound, upperBound).Scan(&maxOccurredAt); err != nil {
+		return fmt.Errorf("error getting latest message event: %w", err)
+	}
 
-		if aggregationRow.maxTimestamp.After(maxProcessed) {
-			maxProcessed = aggregationRow.maxTimestamp
-		}
+	if maxOccurredAt == nil {
+		return tx.Commit(ctx)
 	}
 
-	if err := rows.Err(); err != nil {
-		rows.Close()
+	maxProcessed := *maxOccurredAt
 
-		return fmt.Errorf("error iterating aggregate message counts: %w", err)
+	if _, err = tx.Exec(ctx, upsertMessageCountsSQL, lowerBound, upperBound,
+		welcomer.IngestMessageEventTypeCreate, welcomer.IngestMessageEventTypeDelete, welcomer.IngestMessageEventTypeBulkDelete,
+		upperBound.Add(-messageDeleteLookback)); err != nil {
+		return fmt.Errorf("error upserting aggregate message counts: %w", err)
 	}
 
-	// Close the result set before issuing any Exec to avoid conn busy
-	rows.Close()
-
-	for _, r := range results {
-		if _, err := tx.Exec(ctx, upsertMessageCountsSQL, r.hourTimestamp, r.guildID, r.channelID, r.userID, r.messageCount, r.minTimestamp); err != nil {
-			return fmt.Errorf("error upserting aggregate message counts: %w", err)
-		}
+	if _, err = tx.Exec(ctx, upsertReactionCountsSQL, lowerBound, upperBound, welcomer.IngestMessageEventTypeReactionAdd); err != nil {
+		return fmt.Errorf("error upserting aggregate reaction counts: %w", err)
 	}
 
 	if maxProcessed.After(lastProcessed) {
@@ -0,0 +1,276 @@
+package ingest
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/WelcomerTeam/Welcomer/welcomer-core"
+	"github.com/WelcomerTeam/Welcomer/welcomer-core/database"
+	"github.com/jackc/pgx/v4"
+)
+
+const (
+	jobNameVoiceMinutes = "guild_voice_minutes_hour"
+
+	// voiceMinutesBatchWindow is how much time of recorded sessions is aggregated in each transaction,
+	// so catching up after downtime or on the first run does not hold one long transaction.
+	voiceMinutesBatchWindow = time.Hour * 6
+
+	// Sessions are recorded with the database clock, so the window is bounded with it too.
+	getVoiceMinutesBoundsSQL = `
+SELECT now(),
+	COALESCE(MIN(created_at), now())
+FROM guild_voice_channel_stats
+WHERE created_at >= $1`
+
+	// Members with sessions that have been recorded in the window are recounted, for every hour their new sessions cover.
+	getVoiceMinutesMembersSQL = `
+SELECT guild_id,
+	user_id,
+	date_trunc('hour', MIN(start_ts)) AS start_ts,
+	MAX(end_ts) AS end_ts
+FROM guild_voice_channel_stats
+WHERE created_at >= $1 AND created_at < $2
+GROUP BY 1, 2`
+
+	getVoiceMinutesSessionsSQL = `
+SELECT channel_id,
+	start_ts,
+	end_ts,
+	inferred
+FROM guild_voice_channel_stats
+WHERE guild_id = $1 AND user_id = $2 AND end_ts > $3 AND start_ts < $4`
+
+	deleteVoiceMinutesSQL = `
+DELETE FROM guild_voice_minutes_hour
+WHERE guild_id = $1 AND user_id = $2 AND hour_ts >= $3 AND hour_ts < $4`
+
+	insertVoiceMinutesSQL = `
+INSERT INTO guild_voice_minutes_hour (hour_ts, guild_id, channel_id, user_id, minutes, inferred_minutes)
+SELECT hourly.hour_ts, $2, hourly.channel_id, $4, hourly.minutes, hourly.inferred_minutes
+FROM unnest($1::timestamptz[], $3::bigint[], $5::double precision[], $6::double precision[]) AS hourly (hour_ts, channel_id, minutes, inferred_minutes)`
+)
+
+// AggregateVoiceMinutes runs on an interval and splits guild_voice_channel_stats sessions into hourly minutes.
+func AggregateVoiceMinutes(ctx context.Context, waitGroup *sync.WaitGroup, interval time.Duration) {
+	ticker := time.NewTicker(time.Millisecond)
+	hasReset := false
+
+	waitGroup.Go(func() {
+		for {
+			select {
+			case <-ticker.C:
+				if !hasReset {
+					ticker.Reset(interval)
+
+					hasReset = true
+				}
+
+				startTime := time.Now()
+
+				if err := aggregateVoiceMinutes(ctx); err != nil {
+					welcomer.Logger.Error().Err(err).Msg("aggregate voice minutes failed")
+				} else {
+					if time.Since(startTime) > slowJobWarning {
+						welcomer.Logger.Warn().Dur("duration", time.Since(startTime)).Msg("aggregate voice minutes too long to run")
+					} else {
+						welcomer.Logger.Info().Dur("duration", time.Since(startTime)).Msg("aggregate voice minutes completed")
+					}
+				}
+			case <-ctx.Done():
+				ticker.Stop()
+
+				return
+			}
+		}
+	})
+}
+
+type voiceMinutesMember struct {
+	guildID, userID int64
+	startTimestamp  time.Time
+	endTimestamp    time.Time
+}
+
+func aggregateVoiceMinutes(ctx context.Context) error {
+	welcomer.Logger.Info().Msg("starting aggregate voice minutes job")
+
+	lastProcessed, err := getCheckpoint(ctx, jobNameVoiceMinutes)
+	if err != nil {
+		return err
+	}
+
+	for {
+		var caughtUp bool
+
+		lastProcessed, caughtUp, err = aggregateVoiceMinutesBatch(ctx, lastProcessed)
+		if err != nil {
+			return err
+		}
+
+		if caughtUp {
+			return nil
+		}
+	}
+}
+
+// aggregateVoiceMinutesBatch aggregates the sessions recorded in the window after the checkpoint and advances it.
+// caughtUp is false if there are more sessions after the window.
+func aggregateVoiceMinutesBatch(ctx context.Context, lastProcessed time.Time) (upperBound time.Time, caughtUp bool, err error) {
+	tx, err := welcomer.Pool.BeginTx(ctx, pgx.TxOptions{})
+	if err != nil {
+		return lastProcessed, false, fmt.Errorf("error beginning transaction: %w", err)
+	}
+
+	defer func() {
+		if err != nil {
+			welcomer.Logger.Info().Err(err).Msg("rolling back aggregate voice minutes transaction")
+
+			err = tx.Rollback(ctx)
+			if err != nil {
+				welcomer.Logger.Error().Err(err).Msg("error rolling back aggregate voice minutes transaction")
+			}
+		}
+	}()
+
+	queries := database.New(tx)
+
+	lowerBound := lastProcessed.Add(-backfillWindow)
+
+	var now, earliest time.Time
+
+	if err = tx.QueryRow(ctx, getVoiceMinutesBoundsSQL, lowerBound).Scan(&now, &earliest); err != nil {
+		return lastProcessed, false, fmt.Errorf("error querying voice minutes bounds: %w", err)
+	}
+
+	// Skip ahead to the earliest session, such as on the first run when there is no checkpoint.
+	if earliest.After(lowerBound) {
+		lowerBound = earliest
+	}
+
+	upperBound = lowerBound.Add(voiceMinutesBatchWindow)
+	caughtUp = !upperBound.Before(now)
+
+	if caughtUp {
+		upperBound = now
+	}
+
+	welcomer.Logger.Info().
+		Time("lower_bound", lowerBound).
+		Time("upper_bound", upperBound).
+		Msg("aggregating voice minutes")
+
+	rows, err := tx.Query(ctx, getVoiceMinutesMembersSQL, lowerBound, upperBound)
+	if err != nil {
+		return lastProcessed, false, fmt.Errorf("error querying voice minutes members: %w", err)
+	}
+
+	members := make([]voiceMinutesMember, 0)
+
+	for rows.Next() {
+		var member voiceMinutesMember
+
+		if err = rows.Scan(&member.guildID, &member.userID, &member.startTimestamp, &member.endTimestamp); err != nil {
+			rows.Close()
+
+			return lastProcessed, false, fmt.Errorf("error scanning voice minutes members: %w", err)
+		}
+
+		members = append(members, member)
+	}
+
+	if err = rows.Err(); err != nil {
+		rows.Close()
+
+		return lastProcessed, false, fmt.Errorf("error iterating voice minutes members: %w", err)
+	}
+
+	// Close the result set before issuing any Exec to avoid conn busy
+	rows.Close()
+
+	for _, member := range members {
+		if err = aggregateMemberVoiceMinutes(ctx, tx, member); err != nil {
+			return lastProcessed, false, err
+		}
+	}
+
+	if err = queries.UpsertJobCheckpoint(ctx, database.UpsertJobCheckpointParams{
+		JobName:         jobNameVoiceMinutes,
+		LastProcessedTs: upperBound,
+	}); err != nil {
+		return lastProcessed, false, fmt.Errorf("error upserting job checkpoint: %w", err)
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return lastProcessed, false, fmt.Errorf("error committing transaction: %w", err)
+	}
+
+	return upperBound, caughtUp, nil
+}
+
+// aggregateMemberVoiceMinutes replaces the hourly minutes of a member, for each hour their new sessions cover.
+// Every session in those hours is recounted, as a new session can replace the minutes of an overlapping one.
+func aggregateMemberVoiceMinutes(ctx context.Context, tx pgx.Tx, member voiceMinutesMember) error {
+	hourStart := member.startTimestamp
+	hourEnd := member.endTimestamp.Truncate(time.Hour).Add(time.Hour)
+
+	rows, err := tx.Query(ctx, getVoiceMinutesSessionsSQL, member.guildID, member.userID, hourStart, hourEnd)
+	if err != nil {
+		return fmt.Errorf("error querying voice sessions: %w", err)
+	}
+
+	sessions := make([]welcomer.VoiceSession, 0)
+
+	for rows.Next() {
+		var session welcomer.VoiceSession
+
+		if err = rows.Scan(&session.ChannelID, &session.Start, &session.End, &session.Inferred); err != nil {
+			rows.Close()
+
+			return fmt.Errorf("error scanning voice sessions: %w", err)
+		}
+
+		sessions = append(sessions, session)
+	}
+
+	if err = rows.Err(); err != nil {
+		rows.Close()
+
+		return fmt.Errorf("error iterating voice sessions: %w", err)
+	}
+
+	rows.Close()
+
+	if _, err = tx.Exec(ctx, deleteVoiceMinutesSQL, member.guildID, member.userID, hourStart, hourEnd); err != nil {
+		return fmt.Errorf("error deleting voice minutes: %w", err)
+	}
+
+	hourTimestamps := make([]time.Time, 0)
+	channelIDs := make([]int64, 0)
+	totalMinutes := make([]float64, 0)
+	inferredMinutes := make([]float64, 0)
+
+	for _, minutes := range welcomer.SplitVoiceSessions(sessions) {
+		// Sessions that reach outside of the recounted hours are only used to remove overlap.
+		if minutes.HourTs.Before(hourStart) || !minutes.HourTs.Before(hourEnd) {
+			continue
+		}
+
+		hourTimestamps = append(hourTimestamps, minutes.HourTs)
+		channelIDs = append(channelIDs, minutes.ChannelID)
+		totalMinutes = append(totalMinutes, minutes.Minutes)
+		inferredMinutes = append(inferredMinutes, minutes.InferredMinutes)
+	}
+
+	if len(hourTimestamps) == 0 {
+		return nil
+	}
+
+	if _, err = tx.Exec(ctx, insertVoiceMinutesSQL, hourTimestamps, member.guildID, channelIDs, member.userID, totalMinutes, inferredMinutes); err != nil {
+		return fmt.Errorf("error inserting voice minutes: %w", err)
+	}
+
+	return nil
+}
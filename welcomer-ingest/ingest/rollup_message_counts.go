@@ -0,0 +1,240 @@
+package ingest
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/WelcomerTeam/Welcomer/welcomer-core"
+	"github.com/WelcomerTeam/Welcomer/welcomer-core/database"
+	"github.com/jackc/pgx/v4"
+)
+
+const (
+	jobNameMessageCountsDay        = "guild_message_counts_day"
+	jobNameMessageCountsMonth      = "guild_message_counts_month"
+	jobNameMessageCountsCompaction = "guild_message_counts_compaction"
+
+	// messageCountsCompactionInterval is how often counts past their retention are removed.
+	messageCountsCompactionInterval = time.Hour
+
+	// The checkpoints are compared with updated_at, so they are read from the database clock.
+	getRollupMessageCountsTimeSQL = `SELECT now()`
+
+	// Only days with hourly counts that have changed since the last rollup are recounted.
+	rollupMessageCountsDaySQL = `
+WITH touched_days AS (
+	SELECT DISTINCT date_trunc('day', hour_ts) AS day_ts, guild_id, channel_id, user_id
+	FROM guild_message_counts_hour
+	WHERE updated_at >= $1
+)
+INSERT INTO guild_message_counts_day (day_ts, guild_id, channel_id, user_id, message_count, updated_at)
+SELECT touched_days.day_ts,
+	hourly.guild_id,
+	hourly.channel_id,
+	hourly.user_id,
+	SUM(hourly.message_count) AS message_count,
+	now() AS updated_at
+FROM guild_message_counts_hour hourly
+JOIN touched_days ON touched_days.day_ts = date_trunc('day', hourly.hour_ts)
+	AND touched_days.guild_id = hourly.guild_id
+	AND touched_days.channel_id = hourly.channel_id
+	AND touched_days.user_id = hourly.user_id
+WHERE hourly.hour_ts >= (SELECT MIN(day_ts) FROM touched_days)
+GROUP BY 1, 2, 3, 4
+ON CONFLICT (day_ts, guild_id, channel_id, user_id) DO UPDATE SET
+	message_count = EXCLUDED.message_count,
+	updated_at = EXCLUDED.updated_at`
+
+	// Only months with daily counts that have changed since the last rollup are recounted.
+	rollupMessageCountsMonthSQL = `
+WITH touched_months AS (
+	SELECT DISTINCT date_trunc('month', day_ts) AS month_ts, guild_id, channel_id, user_id
+	FROM guild_message_counts_day
+	WHERE updated_at >= $1
+)
+INSERT INTO guild_message_counts_month (month_ts, guild_id, channel_id, user_id, message_count)
+SELECT touched_months.month_ts,
+	daily.guild_id,
+	daily.channel_id,
+	daily.user_id,
+	SUM(daily.message_count) AS message_count
+FROM guild_message_counts_day daily
+JOIN touched_months ON touched_months.month_ts = date_trunc('month', daily.day_ts)
+	AND touched_months.guild_id = daily.guild_id
+	AND touched_months.channel_id = daily.channel_id
+	AND touched_months.user_id = daily.user_id
+WHERE daily.day_ts >= (SELECT MIN(month_ts) FROM touched_months)
+GROUP BY 1, 2, 3, 4
+ON CONFLICT (month_ts, guild_id, channel_id, user_id) DO UPDATE SET
+	message_count = EXCLUDED.message_count`
+
+	// Counts are never removed while they can still change, even if they are past their retention.
+	compactMessageCountsHourSQL = `
+DELETE FROM guild_message_counts_hour
+WHERE hour_ts < LEAST(date_trunc('day', $1::timestamptz), date_trunc('day', $2::timestamptz))`
+
+	compactMessageCountsDaySQL = `
+DELETE FROM guild_message_counts_day
+WHERE day_ts < LEAST(date_trunc('month', $1::timestamptz), date_trunc('month', $2::timestamptz))`
+
+	compactMessageCountsMonthSQL = `
+DELETE FROM guild_message_counts_month
+WHERE month_ts < date_trunc('month', $1::timestamptz)`
+)
+
+// RollupMessageCounts runs on an interval and rolls hourly message counts into daily and monthly counts.
+// Counts that are past their retention are removed once they have been rolled up.
+func RollupMessageCounts(ctx context.Context, waitGroup *sync.WaitGroup, interval time.Duration) {
+	ticker := time.NewTicker(time.Millisecond)
+	hasReset := false
+
+	waitGroup.Go(func() {
+		for {
+			select {
+			case <-ticker.C:
+				if !hasReset {
+					ticker.Reset(interval)
+
+					hasReset = true
+				}
+
+				startTime := time.Now()
+
+				if err := rollupMessageCounts(ctx); err != nil {
+					welcomer.Logger.Error().Err(err).Msg("rollup message counts failed")
+				} else {
+					if time.Since(startTime) > slowJobWarning {
+						welcomer.Logger.Warn().Dur("duration", time.Since(startTime)).Msg("rollup message counts too long to run")
+					} else {
+						welcomer.Logger.Info().Dur("duration", time.Since(startTime)).Msg("rollup message counts completed")
+					}
+				}
+			case <-ctx.Done():
+				ticker.Stop()
+
+				return
+			}
+		}
+	})
+}
+
+func rollupMessageCounts(ctx context.Context) (err error) {
+	welcomer.Logger.Info().Msg("starting rollup message counts job")
+
+	dayProcessed, err := getCheckpoint(ctx, jobNameMessageCountsDay)
+	if err != nil {
+		return err
+	}
+
+	monthProcessed, err := getCheckpoint(ctx, jobNameMessageCountsMonth)
+	if err != nil {
+		return err
+	}
+
+	tx, err := welcomer.Pool.BeginTx(ctx, pgx.TxOptions{})
+	if err != nil {
+		return fmt.Errorf("error beginning transaction: %w", err)
+	}
+
+	defer func() {
+		if err != nil {
+			welcomer.Logger.Info().Err(err).Msg("rolling back rollup message counts transaction")
+
+			err = tx.Rollback(ctx)
+			if err != nil {
+				welcomer.Logger.Error().Err(err).Msg("error rolling back rollup message counts transaction")
+			}
+		}
+	}()
+
+	queries := database.New(tx)
+
+	var now time.Time
+
+	if err = tx.QueryRow(ctx, getRollupMessageCountsTimeSQL).Scan(&now); err != nil {
+		return fmt.Errorf("error querying rollup message counts time: %w", err)
+	}
+
+	welcomer.Logger.Info().
+		Time("day_lower_bound", dayProcessed.Add(-backfillWindow)).
+		Time("month_lower_bound", monthProcessed.Add(-backfillWindow)).
+		Msg("rolling up message counts")
+
+	if _, err = tx.Exec(ctx, rollupMessageCountsDaySQL, dayProcessed.Add(-backfillWindow)); err != nil {
+		return fmt.Errorf("error rolling up daily message counts: %w", err)
+	}
+
+	if _, err = tx.Exec(ctx, rollupMessageCountsMonthSQL, monthProcessed.Add(-backfillWindow)); err != nil {
+		return fmt.Errorf("error rolling up monthly message counts: %w", err)
+	}
+
+	for _, jobName := range []string{jobNameMessageCountsDay, jobNameMessageCountsMonth} {
+		if err = queries.UpsertJobCheckpoint(ctx, database.UpsertJobCheckpointParams{
+			JobName:         jobName,
+			LastProcessedTs: now,
+		}); err != nil {
+			return fmt.Errorf("error upserting job checkpoint: %w", err)
+		}
+	}
+
+	lastCompacted, err := getCheckpoint(ctx, jobNameMessageCountsCompaction)
+	if err != nil {
+		return err
+	}
+
+	if now.Sub(lastCompacted) >= messageCountsCompactionInterval {
+		if err = compactMessageCounts(ctx, tx, now); err != nil {
+			return err
+		}
+
+		if err = queries.UpsertJobCheckpoint(ctx, database.UpsertJobCheckpointParams{
+			JobName:         jobNameMessageCountsCompaction,
+			LastProcessedTs: now,
+		}); err != nil {
+			return fmt.Errorf("error upserting job checkpoint: %w", err)
+		}
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return fmt.Errorf("error committing transaction: %w", err)
+	}
+
+	return nil
+}
+
+// compactMessageCounts removes counts past their retention, that have been rolled up into a coarser granularity.
+// Counts that may still change from a message being deleted are always kept, so they can be rolled up again.
+func compactMessageCounts(ctx context.Context, tx pgx.Tx, now time.Time) error {
+	changeCutoff := now.Add(-messageDeleteLookback - backfillWindow)
+
+	if welcomer.MessageCountsHourRetention > 0 {
+		result, err := tx.Exec(ctx, compactMessageCountsHourSQL, now.Add(-welcomer.MessageCountsHourRetention), changeCutoff)
+		if err != nil {
+			return fmt.Errorf("error compacting hourly message counts: %w", err)
+		}
+
+		welcomer.Logger.Info().Int64("rows", result.RowsAffected()).Msg("compacted hourly message counts")
+	}
+
+	if welcomer.MessageCountsDayRetention > 0 {
+		result, err := tx.Exec(ctx, compactMessageCountsDaySQL, now.Add(-welcomer.MessageCountsDayRetention), changeCutoff)
+		if err != nil {
+			return fmt.Errorf("error compacting daily message counts: %w", err)
+		}
+
+		welcomer.Logger.Info().Int64("rows", result.RowsAffected()).Msg("compacted daily message counts")
+	}
+
+	if welcomer.MessageCountsMonthRetention > 0 {
+		result, err := tx.Exec(ctx, compactMessageCountsMonthSQL, now.Add(-welcomer.MessageCountsMonthRetention))
+		if err != nil {
+			return fmt.Errorf("error removing monthly message counts: %w", err)
+		}
+
+		welcomer.Logger.Info().Int64("rows", result.RowsAffected()).Msg("removed monthly message counts")
+	}
+
+	return nil
+}
@@ -0,0 +1,597 @@
+package plugins
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/WelcomerTeam/Discord/discord"
+	subway "github.com/WelcomerTeam/Subway/subway"
+	"github.com/WelcomerTeam/Welcomer/welcomer-core"
+	"github.com/WelcomerTeam/Welcomer/welcomer-core/database"
+	"github.com/jackc/pgx/v4"
+)
+
+func NewActivityRolesCog() *ActivityRolesCog {
+	return &ActivityRolesCog{
+		InteractionCommands: subway.SetupInteractionCommandable(&subway.InteractionCommandable{}),
+	}
+}
+
+type ActivityRolesCog struct {
+	InteractionCommands *subway.InteractionCommandable
+}
+
+// Assert types.
+
+var (
+	_ subway.Cog                        = (*ActivityRolesCog)(nil)
+	_ subway.CogWithInteractionCommands = (*ActivityRolesCog)(nil)
+)
+
+func (r *ActivityRolesCog) CogInfo() *subway.CogInfo {
+	return &subway.CogInfo{
+		Name:        "ActivityRoles",
+		Description: "Provides the cog for the 'ActivityRoles' feature.",
+	}
+}
+
+func (r *ActivityRolesCog) GetInteractionCommandable() *subway.InteractionCommandable {
+	return r.InteractionCommands
+}
+
+func activityRolesResponse(message string, colour int32) *discord.InteractionResponse {
+	return &discord.InteractionResponse{
+		Type: discord.InteractionCallbackTypeChannelMessageSource,
+		Data: &discord.InteractionCallbackData{
+			Embeds: welcomer.NewEmbed(message, colour),
+			Flags:  uint32(discord.MessageFlagEphemeral),
+		},
+	}
+}
+
+func getActivityRolesGuildSettings(ctx context.Context, guildID discord.Snowflake) (*database.GuildSettingsActivityroles, error) {
+	guildSettingsActivityRoles, err := welcomer.Queries.GetActivityRolesGuildSettings(ctx, int64(guildID))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return &database.GuildSettingsActivityroles{
+				GuildID:       int64(guildID),
+				ToggleEnabled: welcomer.DefaultActivityRoles.ToggleEnabled,
+				ToggleDecay:   welcomer.DefaultActivityRoles.ToggleDecay,
+				Activityroles: welcomer.DefaultActivityRoles.Activityroles,
+			}, nil
+		}
+
+		welcomer.Logger.Error().Err(err).
+			Int64("guild_id", int64(guildID)).
+			Msg("Failed to get activityroles guild settings")
+
+		return nil, err
+	}
+
+	return guildSettingsActivityRoles, nil
+}
+
+func updateActivityRolesGuildSettings(ctx context.Context, interaction discord.Interaction, guildSettingsActivityRoles *database.GuildSettingsActivityroles) error {
+	oldActivityRoles := &database.GuildSettingsActivityroles{}
+	if existing, err := welcomer.Queries.GetActivityRolesGuildSettings(ctx, int64(*interaction.GuildID)); err == nil {
+		oldActivityRoles = existing
+	}
+
+	var newActivityRoles *database.GuildSettingsActivityroles
+
+	err := welcomer.RetryWithFallback(
+		func() error {
+			var err error
+
+			newActivityRoles, err = welcomer.CreateOrUpdateActivityRolesGuildSettingsWithAudit(ctx, database.CreateOrUpdateActivityRolesGuildSettingsParams{
+				GuildID:       int64(*interaction.GuildID),
+				ToggleEnabled: guildSettingsActivityRoles.ToggleEnabled,
+				ToggleDecay:   guildSettingsActivityRoles.ToggleDecay,
+				Activityroles: guildSettingsActivityRoles.Activityroles,
+			}, interaction.GetUser().ID)
+
+			return err
+		},
+		func() error {
+			return welcomer.EnsureGuild(ctx, discord.Snowflake(*interaction.GuildID))
+		},
+		nil,
+	)
+	if err != nil {
+		welcomer.Logger.Error().Err(err).
+			Int64("guild_id", int64(*interaction.GuildID)).
+			Msg("Failed to update activityroles guild settings")
+
+		return err
+	}
+
+	welcomer.SyncActivityRolesGuildSettings(ctx, oldActivityRoles, newActivityRoles)
+
+	return nil
+}
+
+func (r *ActivityRolesCog) RegisterCog(sub *subway.Subway) error {
+	activityRolesGroup := subway.NewSubcommandGroup(
+		"activityroles",
+		"Automatically assign roles to users depending on how active they are in the server.",
+	)
+
+	// Disable the ActivityRoles module for DM channels.
+	activityRolesGroup.DMPermission = new(false)
+
+	activityRolesGroup.MustAddInteractionCommand(&subway.InteractionCommandable{
+		Name:        "enable",
+		Description: "Enable activityroles for this server.",
+
+		Type: subway.InteractionCommandableTypeSubcommand,
+
+		DMPermission:            new(false),
+		DefaultMemberPermission: new(discord.Int64(welcomer.PermissionElevated)),
+
+		Handler: func(ctx context.Context, sub *subway.Subway, interaction discord.Interaction) (*discord.InteractionResponse, error) {
+			return welcomer.RequireGuildElevation(sub, interaction, func() (*discord.InteractionResponse, error) {
+				guildSettingsActivityRoles, err := getActivityRolesGuildSettings(ctx, *interaction.GuildID)
+				if err != nil {
+					return nil, err
+				}
+
+				guildSettingsActivityRoles.ToggleEnabled = true
+
+				err = updateActivityRolesGuildSettings(ctx, interaction, guildSettingsActivityRoles)
+				if err != nil {
+					return nil, err
+				}
+
+				return &discord.InteractionResponse{
+					Type: discord.InteractionCallbackTypeChannelMessageSource,
+					Data: &discord.InteractionCallbackData{
+						Embeds: welcomer.NewEmbed("Enabled activityroles. Run `/activityroles list` to see the list of activityroles configured.", welcomer.EmbedColourSuccess),
+					},
+				}, nil
+			})
+		},
+	})
+
+	activityRolesGroup.MustAddInteractionCommand(&subway.InteractionCommandable{
+		Name:        "disable",
+		Description: "Disables activityroles for this server.",
+
+		Type: subway.InteractionCommandableTypeSubcommand,
+
+		DMPermission:            new(false),
+		DefaultMemberPermission: new(discord.Int64(welcomer.PermissionElevated)),
+
+		Handler: func(ctx context.Context, sub *subway.Subway, interaction discord.Interaction) (*discord.InteractionResponse, error) {
+			return welcomer.RequireGuildElevation(sub, interaction, func() (*discord.InteractionResponse, error) {
+				guildSettingsActivityRoles, err := getActivityRolesGuildSettings(ctx, *interaction.GuildID)
+				if err != nil {
+					return nil, err
+				}
+
+				guildSettingsActivityRoles.ToggleEnabled = false
+
+				err = updateActivityRolesGuildSettings(ctx, interaction, guildSettingsActivityRoles)
+				if err != nil {
+					return nil, err
+				}
+
+				return &discord.InteractionResponse{
+					Type: discord.InteractionCallbackTypeChannelMessageSource,
+					Data: &discord.InteractionCallbackData{
+						Embeds: welcomer.NewEmbed("Disabled activityroles.", welcomer.EmbedColourSuccess),
+					},
+				}, nil
+			})
+		},
+	})
+
+	activityRolesGroup.MustAddInteractionCommand(&subway.InteractionCommandable{
+		Name:        "decay",
+		Description: "Remove activityroles from users when their activity drops below the threshold.",
+
+		Type: subway.InteractionCommandableTypeSubcommand,
+
+		ArgumentParameter: []subway.ArgumentParameter{
+			{
+				Required:     true,
+				ArgumentType: subway.ArgumentTypeBool,
+				Name:         "enabled",
+				Description:  "Whether decay is enabled.",
+			},
+		},
+
+		DMPermission:            new(false),
+		DefaultMemberPermission: new(discord.Int64(welcomer.PermissionElevated)),
+
+		Handler: func(ctx context.Context, sub *subway.Subway, interaction discord.Interaction) (*discord.InteractionResponse, error) {
+			return welcomer.RequireGuildElevation(sub, interaction, func() (*discord.InteractionResponse, error) {
+				enabled := subway.MustGetArgument(ctx, "enabled").MustBool()
+
+				guildSettingsActivityRoles, err := getActivityRolesGuildSettings(ctx, *interaction.GuildID)
+				if err != nil {
+					return nil, err
+				}
+
+				guildSettingsActivityRoles.ToggleDecay = enabled
+
+				err = updateActivityRolesGuildSettings(ctx, interaction, guildSettingsActivityRoles)
+				if err != nil {
+					return nil, err
+				}
+
+				var message string
+				if enabled {
+					message = "Enabled decay. Users will lose activityroles given by Welcomer when their activity drops below the threshold."
+				} else {
+					message = "Disabled decay. Users will keep activityroles once they have earned them."
+				}
+
+				return &discord.InteractionResponse{
+					Type: discord.InteractionCallbackTypeChannelMessageSource,
+					Data: &discord.InteractionCallbackData{
+						Embeds: welcomer.NewEmbed(message, welcomer.EmbedColourSuccess),
+					},
+				}, nil
+			})
+		},
+	})
+
+	activityRolesGroup.MustAddInteractionCommand(&subway.InteractionCommandable{
+		Name:        "check",
+		Description: "Check your activityroles progress on the server.",
+
+		Type: subway.InteractionCommandableTypeSubcommand,
+
+		ArgumentParameter: []subway.ArgumentParameter{
+			{
+				Required:     false,
+				ArgumentType: subway.ArgumentTypeMember,
+				Name:         "user",
+				Description:  "The user to check the activityroles progress of.",
+			},
+		},
+
+		DMPermission: new(false),
+
+		Handler: func(ctx context.Context, sub *subway.Subway, interaction discord.Interaction) (*discord.InteractionResponse, error) {
+			return welcomer.RequireGuild(interaction, func() (*discord.InteractionResponse, error) {
+				member := subway.MustGetArgument(ctx, "user").MustMember()
+				if member.User == nil || member.User.ID.IsNil() {
+					member = *interaction.Member
+				}
+
+				guildSettingsActivityRoles, err := getActivityRolesGuildSettings(ctx, *interaction.GuildID)
+				if err != nil {
+					return nil, err
+				}
+
+				if !guildSettingsActivityRoles.ToggleEnabled {
+					return activityRolesResponse("Activityroles are disabled for this server.", welcomer.EmbedColourError), nil
+				}
+
+				activityRoles := welcomer.UnmarshalActivityRolesJSON(guildSettingsActivityRoles.Activityroles.Bytes)
+				if len(activityRoles) == 0 {
+					return activityRolesResponse("There are no activityroles set for this server.", welcomer.EmbedColourInfo), nil
+				}
+
+				now := time.Now()
+				totals := make(map[int]welcomer.ActivityTotals)
+
+				for _, days := range welcomer.GetActivityRoleWindows(activityRoles) {
+					since := now.AddDate(0, 0, -days)
+
+					rows, err := welcomer.Queries.GetGuildActivityTotals(ctx, database.GetGuildActivityTotalsParams{
+						Granularity: welcomer.GetMessageCountGranularity(since, now),
+						GuildID:     int64(*interaction.GuildID),
+						Since:       since,
+						UserIds:     []int64{int64(member.User.ID)},
+					})
+					if err != nil {
+						welcomer.Logger.Error().Err(err).
+							Int64("guild_id", int64(*interaction.GuildID)).
+							Int64("user_id", int64(member.User.ID)).
+							Msg("Failed to get guild activity totals")
+
+						return nil, err
+					}
+
+					for _, row := range rows {
+						totals[days] = welcomer.ActivityTotals{
+							MessageCount: row.MessageCount,
+							VoiceSeconds: row.TotalTimeMs / 1000,
+						}
+					}
+				}
+
+				subject := "You have"
+				if member.User.ID != interaction.GetUser().ID {
+					subject = fmt.Sprintf("<@%d> has", member.User.ID)
+				}
+
+				embeds := []discord.Embed{}
+				embed := discord.Embed{Title: "ActivityRoles", Color: welcomer.EmbedColourInfo}
+				embed.Description = fmt.Sprintf("%s the following progress towards activityroles:\n\n", subject)
+
+				for _, activityRole := range activityRoles {
+					activity := totals[activityRole.GetDays()]
+
+					var progress string
+					if activityRole.Type == welcomer.ActivityRoleTypeVoice {
+						progress = fmt.Sprintf("%s / %s", welcomer.HumanizeDuration(int(activity.VoiceSeconds), false), welcomer.HumanizeDuration(int(activityRole.Threshold), false))
+					} else {
+						progress = fmt.Sprintf("%d / %d messages", activity.MessageCount, activityRole.Threshold)
+					}
+
+					emoji := welcomer.EmojiNeutral
+					if activityRole.IsReached(activity) {
+						emoji = welcomer.EmojiCheck
+					}
+
+					roleMessage := fmt.Sprintf("%s <@&%d> - `%s` in the last %d days\n", emoji, activityRole.Role, progress, activityRole.GetDays())
+
+					// If the embed content will go over 4000 characters then create a new embed and continue from that one.
+					if len(embed.Description)+len(roleMessage) > 4000 {
+						embeds = append(embeds, embed)
+						embed = discord.Embed{Color: welcomer.EmbedColourInfo}
+					}
+
+					embed.Description += roleMessage
+				}
+
+				embeds = append(embeds, embed)
+
+				return &discord.InteractionResponse{
+					Type: discord.InteractionCallbackTypeChannelMessageSource,
+					Data: &discord.InteractionCallbackData{
+						Embeds: embeds,
+						Flags:  uint32(discord.MessageFlagEphemeral),
+					},
+				}, nil
+			})
+		},
+	})
+
+	activityRolesGroup.MustAddInteractionCommand(&subway.InteractionCommandable{
+		Name:        "list",
+		Description: "List the activityroles for the server.",
+
+		Type: subway.InteractionCommandableTypeSubcommand,
+
+		DMPermission:            new(false),
+		DefaultMemberPermission: new(discord.Int64(welcomer.PermissionElevated)),
+
+		Handler: func(ctx context.Context, sub *subway.Subway, interaction discord.Interaction) (*discord.InteractionResponse, error) {
+			return welcomer.RequireGuildElevation(sub, interaction, func() (*discord.InteractionResponse, error) {
+				guildSettingsActivityRoles, err := getActivityRolesGuildSettings(ctx, *interaction.GuildID)
+				if err != nil {
+					return nil, err
+				}
+
+				activityRoles := welcomer.UnmarshalActivityRolesJSON(guildSettingsActivityRoles.Activityroles.Bytes)
+				if len(activityRoles) == 0 {
+					return activityRolesResponse("There are no activityroles set for this server.", welcomer.EmbedColourInfo), nil
+				}
+
+				embeds := []discord.Embed{}
+				embed := discord.Embed{Title: "ActivityRoles", Color: welcomer.EmbedColourInfo}
+
+				if guildSettingsActivityRoles.ToggleDecay {
+					embed.Description = "Roles are removed when activity drops below the threshold.\n\n"
+				}
+
+				for _, activityRole := range activityRoles {
+					roleMessage := fmt.Sprintf("- <@&%d> - `%s`\n", activityRole.Role, activityRole.String())
+
+					// If the embed content will go over 4000 characters then create a new embed and continue from that one.
+					if len(embed.Description)+len(roleMessage) > 4000 {
+						embeds = append(embeds, embed)
+						embed = discord.Embed{Color: welcomer.EmbedColourInfo}
+					}
+
+					embed.Description += roleMessage
+				}
+
+				embeds = append(embeds, embed)
+
+				return &discord.InteractionResponse{
+					Type: discord.InteractionCallbackTypeChannelMessageSource,
+					Data: &discord.InteractionCallbackData{
+						Embeds: embeds,
+						Flags:  uint32(discord.MessageFlagEphemeral),
+					},
+				}, nil
+			})
+		},
+	})
+
+	activityRolesGroup.MustAddInteractionCommand(&subway.InteractionCommandable{
+		Name:        "addrole",
+		Description: "Add an activityrole to the server.",
+
+		Type: subway.InteractionCommandableTypeSubcommand,
+
+		DMPermission:            new(false),
+		DefaultMemberPermission: new(discord.Int64(welcomer.PermissionElevated)),
+
+		ArgumentParameter: []subway.ArgumentParameter{
+			{
+				Name:         "role",
+				Description:  "The role to assign.",
+				ArgumentType: subway.ArgumentTypeRole,
+				Required:     true,
+			},
+			{
+				Name:         "messages",
+				Description:  "The number of messages a user must send to get the role.",
+				ArgumentType: subway.ArgumentTypeInt,
+				Required:     false,
+				MinValue:     new(int32(1)),
+			},
+			{
+				Name:         "voice",
+				Description:  "The time a user must spend in voice channels to get the role (e.g., `10h`, `30m`).",
+				ArgumentType: subway.ArgumentTypeString,
+				Required:     false,
+			},
+			{
+				Name:         "days",
+				Description:  "The number of days activity is counted over. Defaults to 30.",
+				ArgumentType: subway.ArgumentTypeInt,
+				Required:     false,
+				MinValue:     new(int32(1)),
+				MaxValue:     new(int32(welcomer.MaximumActivityRoleDays)),
+			},
+			{
+				Required:     false,
+				ArgumentType: subway.ArgumentTypeBool,
+				Name:         "ignore-permissions",
+				Description:  "Ignores role permissions.",
+			},
+		},
+
+		Handler: func(ctx context.Context, sub *subway.Subway, interaction discord.Interaction) (*discord.InteractionResponse, error) {
+			return welcomer.RequireGuildElevation(sub, interaction, func() (*discord.InteractionResponse, error) {
+				role := subway.MustGetArgument(ctx, "role").MustRole()
+				messages := subway.MustGetArgument(ctx, "messages").MustInt()
+				voiceString := subway.MustGetArgument(ctx, "voice").MustString()
+				days := subway.MustGetArgument(ctx, "days").MustInt()
+				ignoreRolePermissions := subway.MustGetArgument(ctx, "ignore-permissions").MustBool()
+
+				if (messages > 0) == (voiceString != "") {
+					return activityRolesResponse("Please provide either `messages` or `voice`.", welcomer.EmbedColourError), nil
+				}
+
+				activityRole := welcomer.GuildSettingsActivityRolesRole{
+					Role: role.ID,
+					Days: welcomer.DefaultActivityRoleDays,
+				}
+
+				if days > 0 {
+					activityRole.Days = int(days)
+				}
+
+				if messages > 0 {
+					activityRole.Type = welcomer.ActivityRoleTypeMessages
+					activityRole.Threshold = int64(messages)
+				} else {
+					seconds, err := welcomer.ParseDurationAsSeconds(voiceString)
+					if err != nil || seconds <= 0 {
+						return activityRolesResponse("Invalid voice duration. It must be a positive number in a valid format (e.g., `1d`, `10h`, `30m`, `3600s`, `3600`). Only years, days, hours, minutes and seconds are supported.", welcomer.EmbedColourError), nil
+					}
+
+					activityRole.Type = welcomer.ActivityRoleTypeVoice
+					activityRole.Threshold = int64(seconds)
+				}
+
+				canAssignRoles, isRoleAssignable, isRoleElevated, err := welcomer.Accelerator_CanAssignRole(ctx, *interaction.GuildID, &role)
+				if err != nil {
+					welcomer.Logger.Error().Err(err).
+						Int64("guild_id", int64(*interaction.GuildID)).
+						Msg("Failed to check if welcomer can assign role")
+
+					return nil, err
+				}
+
+				if !canAssignRoles {
+					return activityRolesResponse("Welcomer is missing permissions to assign roles", welcomer.EmbedColourError), nil
+				}
+
+				// Check if the role is assignable by welcomer using the guild roles and roles Welcomer has.
+				if !isRoleAssignable {
+					return activityRolesResponse("### This role is not assignable\nWelcomer cannot assign users this role as it does not have permission to manage roles or Welcomer's highest role is below this role's position. Please rearrange your roles in the server settings to move Welcomer's role above this role.", welcomer.EmbedColourError), nil
+				}
+
+				if !ignoreRolePermissions && isRoleElevated {
+					return activityRolesResponse("### This role is elevated\nThis role has elevated permissions. If you are sure you want to use this role, please run the command again with ignore-permissions set to true.\n\nPermissions:\n"+welcomer.GetRolePermissionListAsString(int(role.Permissions)), welcomer.EmbedColourError), nil
+				}
+
+				guildSettingsActivityRoles, err := getActivityRolesGuildSettings(ctx, *interaction.GuildID)
+				if err != nil {
+					return nil, err
+				}
+
+				activityRoles := welcomer.UnmarshalActivityRolesJSON(guildSettingsActivityRoles.Activityroles.Bytes)
+
+				// Check if the role already exists in the list
+				if slices.ContainsFunc(activityRoles, func(ar welcomer.GuildSettingsActivityRolesRole) bool { return ar.Role == role.ID }) {
+					return activityRolesResponse("This role is already in the list.", welcomer.EmbedColourError), nil
+				}
+
+				activityRoles = append(activityRoles, activityRole)
+				guildSettingsActivityRoles.Activityroles = welcomer.BytesToJSONB(welcomer.MarshalActivityRolesJSON(activityRoles))
+
+				err = updateActivityRolesGuildSettings(ctx, interaction, guildSettingsActivityRoles)
+				if err != nil {
+					return nil, err
+				}
+
+				return &discord.InteractionResponse{
+					Type: discord.InteractionCallbackTypeChannelMessageSource,
+					Data: &discord.InteractionCallbackData{
+						Embeds: welcomer.NewEmbed(fmt.Sprintf("Added activityrole <@&%d> for `%s`. Run `/activityroles list` to see the list of activityroles configured.", role.ID, activityRole.String()), welcomer.EmbedColourSuccess),
+					},
+				}, nil
+			})
+		},
+	})
+
+	activityRolesGroup.MustAddInteractionCommand(&subway.InteractionCommandable{
+		Name:        "removerole",
+		Description: "Remove an activityrole from the server.",
+
+		Type: subway.InteractionCommandableTypeSubcommand,
+
+		DMPermission:            new(false),
+		DefaultMemberPermission: new(discord.Int64(welcomer.PermissionElevated)),
+
+		ArgumentParameter: []subway.ArgumentParameter{
+			{
+				Name:         "role",
+				Description:  "The role to remove.",
+				ArgumentType: subway.ArgumentTypeRole,
+				Required:     true,
+			},
+		},
+
+		Handler: func(ctx context.Context, sub *subway.Subway, interaction discord.Interaction) (*discord.InteractionResponse, error) {
+			return welcomer.RequireGuildElevation(sub, interaction, func() (*discord.InteractionResponse, error) {
+				role := subway.MustGetArgument(ctx, "role").MustRole()
+
+				guildSettingsActivityRoles, err := getActivityRolesGuildSettings(ctx, *interaction.GuildID)
+				if err != nil {
+					return nil, err
+				}
+
+				activityRoles := welcomer.UnmarshalActivityRolesJSON(guildSettingsActivityRoles.Activityroles.Bytes)
+
+				// Check if the role exists in the list.
+				if !slices.ContainsFunc(activityRoles, func(ar welcomer.GuildSettingsActivityRolesRole) bool { return ar.Role == role.ID }) {
+					return activityRolesResponse("This role is not in the list.", welcomer.EmbedColourError), nil
+				}
+
+				// Remove the role from the list.
+				activityRoles = slices.DeleteFunc(activityRoles, func(ar welcomer.GuildSettingsActivityRolesRole) bool { return ar.Role == role.ID })
+				guildSettingsActivityRoles.Activityroles = welcomer.BytesToJSONB(welcomer.MarshalActivityRolesJSON(activityRoles))
+
+				err = updateActivityRolesGuildSettings(ctx, interaction, guildSettingsActivityRoles)
+				if err != nil {
+					return nil, err
+				}
+
+				return &discord.InteractionResponse{
+					Type: discord.InteractionCallbackTypeChannelMessageSource,
+					Data: &discord.InteractionCallbackData{
+						Embeds: welcomer.NewEmbed(fmt.Sprintf("Removed activityrole <@&%d>. Run `/activityroles list` to see the list of activityroles configured.", role.ID), welcomer.EmbedColourSuccess),
+					},
+				}, nil
+			})
+		},
+	})
+
+	r.InteractionCommands.MustAddInteractionCommand(activityRolesGroup)
+
+	return nil
+}
@@ -12,6 +12,7 @@ import (
 	subway "github.com/WelcomerTeam/Subway/subway"
 	"github.com/WelcomerTeam/Welcomer/welcomer-core"
 	"github.com/WelcomerTeam/Welcomer/welcomer-core/database"
+	"github.com/gofrs/uuid"
 	"github.com/jackc/pgx/v4"
 )
 
@@ -70,6 +71,7 @@ func (r *FreeRolesCog) RegisterCog(sub *subway.Subway) error {
 							GuildID:       int64(*interaction.GuildID),
 							ToggleEnabled: welcomer.DefaultFreeRoles.ToggleEnabled,
 							Roles:         welcomer.DefaultFreeRoles.Roles,
+							RoleDurations: welcomer.DefaultFreeRoles.RoleDurations,
 						}
 					} else {
 						welcomer.Logger.Error().Err(err).
@@ -88,6 +90,7 @@ func (r *FreeRolesCog) RegisterCog(sub *subway.Subway) error {
 							GuildID:       int64(*interaction.GuildID),
 							ToggleEnabled: guildSettingsFreeRoles.ToggleEnabled,
 							Roles:         guildSettingsFreeRoles.Roles,
+							RoleDurations: guildSettingsFreeRoles.RoleDurations,
 						}, interaction.GetUser().ID)
 
 						return err
@@ -133,6 +136,7 @@ func (r *FreeRolesCog) RegisterCog(sub *subway.Subway) error {
 							GuildID:       int64(*interaction.GuildID),
 							ToggleEnabled: welcomer.DefaultFreeRoles.ToggleEnabled,
 							Roles:         welcomer.DefaultFreeRoles.Roles,
+							RoleDurations: welcomer.DefaultFreeRoles.RoleDurations,
 						}
 					} else {
 						welcomer.Logger.Error().Err(err).
@@ -151,6 +155,7 @@ func (r *FreeRolesCog) RegisterCog(sub *subway.Subway) error {
 							GuildID:       int64(*interaction.GuildID),
 							ToggleEnabled: guildSettingsFreeRoles.ToggleEnabled,
 							Roles:         guildSettingsFreeRoles.Roles,
+							RoleDurations: guildSettingsFreeRoles.RoleDurations,
 						}, interaction.GetUser().ID)
 
 						return err
@@ -195,6 +200,7 @@ func (r *FreeRolesCog) RegisterCog(sub *subway.Subway) error {
 							GuildID:       int64(*interaction.GuildID),
 							ToggleEnabled: welcomer.DefaultFreeRoles.ToggleEnabled,
 							Roles:         welcomer.DefaultFreeRoles.Roles,
+							RoleDurations: welcomer.DefaultFreeRoles.RoleDurations,
 						}
 					} else {
 						welcomer.Logger.Error().Err(err).
@@ -276,6 +282,7 @@ func (r *FreeRolesCog) RegisterCog(sub *subway.Subway) error {
 						GuildID:       int64(*interaction.GuildID),
 						ToggleEnabled: welcomer.DefaultFreeRoles.ToggleEnabled,
 						Roles:         welcomer.DefaultFreeRoles.Roles,
+						RoleDurations: welcomer.DefaultFreeRoles.RoleDurations,
 					}
 				} else {
 					welcomer.Logger.Error().Err(err).
@@ -379,6 +386,7 @@ func (r *FreeRolesCog) RegisterCog(sub *subway.Subway) error {
 						GuildID:       int64(*interaction.GuildID),
 						ToggleEnabled: welcomer.DefaultFreeRoles.ToggleEnabled,
 						Roles:         welcomer.DefaultFreeRoles.Roles,
+						RoleDurations: welcomer.DefaultFreeRoles.RoleDurations,
 					}
 				} else {
 					welcomer.Logger.Error().Err(err).
@@ -437,10 +445,25 @@ func (r *FreeRolesCog) RegisterCog(sub *subway.Subway) error {
 				return nil, err
 			}
 
+			message := fmt.Sprintf("You have been assigned the role <@&%d>.", role.ID)
+
+			duration := welcomer.GetTempRoleDuration(welcomer.UnmarshalTempRoleDurationsJSON(guildSettingsFreeRoles.RoleDurations.Bytes), role.ID)
+			if duration > 0 {
+				tempRole, err := welcomer.RecordTempRole(ctx, *interaction.GuildID, interaction.Member.User.ID, role.ID, duration, database.TempRoleSourceFreeRoles, uuid.Nil, interaction.Member.User.ID)
+				if err != nil {
+					welcomer.Logger.Error().Err(err).
+						Int64("guild_id", int64(*interaction.GuildID)).
+						Int64("role_id", int64(role.ID)).
+						Msg("Failed to record temp role for freeroles")
+				} else {
+					message += fmt.Sprintf(" It will be removed <t:%d:R>.", tempRole.ExpiresAt.Unix())
+				}
+			}
+
 			return &discord.InteractionResponse{
 				Type: discord.InteractionCallbackTypeChannelMessageSource,
 				Data: &discord.InteractionCallbackData{
-					Embeds: welcomer.NewEmbed(fmt.Sprintf("You have been assigned the role <@&%d>.", role.ID), welcomer.EmbedColourSuccess),
+					Embeds: welcomer.NewEmbed(message, welcomer.EmbedColourSuccess),
 					Flags:  uint32(discord.MessageFlagEphemeral),
 				},
 			}, nil
@@ -484,6 +507,7 @@ func (r *FreeRolesCog) RegisterCog(sub *subway.Subway) error {
 						GuildID:       int64(*interaction.GuildID),
 						ToggleEnabled: welcomer.DefaultFreeRoles.ToggleEnabled,
 						Roles:         welcomer.DefaultFreeRoles.Roles,
+						RoleDurations: welcomer.DefaultFreeRoles.RoleDurations,
 					}
 				} else {
 					welcomer.Logger.Error().Err(err).
@@ -542,6 +566,14 @@ func (r *FreeRolesCog) RegisterCog(sub *subway.Subway) error {
 				return nil, err
 			}
 
+			err = welcomer.ForgetTempRole(ctx, *interaction.GuildID, interaction.Member.User.ID, role.ID)
+			if err != nil {
+				welcomer.Logger.Warn().Err(err).
+					Int64("guild_id", int64(*interaction.GuildID)).
+					Int64("role_id", int64(role.ID)).
+					Msg("Failed to forget temp role for freeroles")
+			}
+
 			return &discord.InteractionResponse{
 				Type: discord.InteractionCallbackTypeChannelMessageSource,
 				Data: &discord.InteractionCallbackData{
@@ -628,6 +660,7 @@ func (r *FreeRolesCog) RegisterCog(sub *subway.Subway) error {
 							GuildID:       int64(*interaction.GuildID),
 							ToggleEnabled: welcomer.DefaultFreeRoles.ToggleEnabled,
 							Roles:         welcomer.DefaultFreeRoles.Roles,
+							RoleDurations: welcomer.DefaultFreeRoles.RoleDurations,
 						}
 					} else {
 						welcomer.Logger.Error().Err(err).
@@ -657,6 +690,7 @@ func (r *FreeRolesCog) RegisterCog(sub *subway.Subway) error {
 							GuildID:       int64(*interaction.GuildID),
 							ToggleEnabled: guildSettingsFreeRoles.ToggleEnabled,
 							Roles:         guildSettingsFreeRoles.Roles,
+							RoleDurations: guildSettingsFreeRoles.RoleDurations,
 						}, interaction.GetUser().ID)
 
 						return err
@@ -714,6 +748,7 @@ func (r *FreeRolesCog) RegisterCog(sub *subway.Subway) error {
 							GuildID:       int64(*interaction.GuildID),
 							ToggleEnabled: welcomer.DefaultFreeRoles.ToggleEnabled,
 							Roles:         welcomer.DefaultFreeRoles.Roles,
+							RoleDurations: welcomer.DefaultFreeRoles.RoleDurations,
 						}
 					} else {
 						welcomer.Logger.Error().Err(err).
@@ -746,6 +781,7 @@ func (r *FreeRolesCog) RegisterCog(sub *subway.Subway) error {
 							GuildID:       int64(*interaction.GuildID),
 							ToggleEnabled: guildSettingsFreeRoles.ToggleEnabled,
 							Roles:         guildSettingsFreeRoles.Roles,
+							RoleDurations: guildSettingsFreeRoles.RoleDurations,
 						}, interaction.GetUser().ID)
 
 						return err
@@ -8,6 +8,7 @@ import (
 	"errors"
 	"fmt"
 	"slices"
+	"strconv"
 	"strings"
 	"time"
 
@@ -30,8 +31,17 @@ const (
 
 	giveawaySetupMenuPrizesKey = "prizes"
 
+	giveawaySetupMenuPrizeDeliveryKey         = "prize_delivery"
+	giveawaySetupMenuPrizeDeliveryPrizeKey    = "prize_delivery_prize"
+	giveawaySetupMenuPrizeDeliveryTypeKey     = "prize_delivery_type"
+	giveawaySetupMenuPrizeDeliveryRoleKey     = "prize_delivery_role"
+	giveawaySetupMenuPrizeDeliveryDurationKey = "prize_delivery_duration"
+	giveawaySetupMenuPrizeDeliveryCodesKey    = "prize_delivery_codes"
+
 	giveawaySetupMenuDurationKey        = "duration"
 	giveawaySetupMenuAnnounceWinnersKey = "announce_winners"
+	giveawaySetupMenuProvablyFairKey    = "provably_fair"
+	giveawaySetupMenuClaimWindowKey     = "claim_window"
 
 	giveawaySetupMenuRolesAllowedKey         = "roles_allowed"
 	giveawaySetupMenuRolesAllowedIncludedKey = "roles_allowed_included"
@@ -39,6 +49,14 @@ const (
 
 	giveawaySetupMenuMinimumJoinDateKey = "minimum_join_date"
 	giveawaySetupMenuStartKey           = "start"
+	giveawaySetupMenuStartDelayKey      = "start_delay"
+	giveawaySetupMenuRecurrenceKey      = "recurrence"
+
+	giveawaySetupMenuActivityRequirementsKey = "activity_requirements"
+	giveawaySetupMenuMinimumAccountAgeKey    = "minimum_account_age"
+	giveawaySetupMenuMinimumMessagesKey      = "minimum_messages"
+	giveawaySetupMenuMinimumVoiceKey         = "minimum_voice"
+	giveawaySetupMenuActivityPeriodKey       = "activity_period"
 
 	giveawaySetupMenuDisplayKey            = "display"
 	giveawaySetupMenuDisplayShowPrizesKey  = "display_show_prizes"
@@ -58,6 +76,13 @@ const (
 	giveawayManageMenuEndGiveawayKey        = "end_giveaway"
 	giveawayManageMenuExportEntriesKey      = "export_entries"
 	giveawayManageMenuExportWinnersKey      = "export_winners"
+	giveawayManageMenuStopRecurringKey      = "stop_recurring"
+	giveawayManageMenuRetryDeliveryKey      = "retry_delivery"
+	giveawayManageMenuOpenKey               = "open"
+	giveawayManageMenuCancelScheduledKey    = "cancel_scheduled"
+
+	// maxScheduledGiveawaysShown limits how many scheduled giveaways are listed at once.
+	maxScheduledGiveawaysShown = 10
 )
 
 func NewGiveawaysCog() *GiveawaysCog {
@@ -227,6 +252,127 @@ func (cog *GiveawaysCog) RegisterCog(sub *subway.Subway) error {
 		},
 	})
 
+	giveawaysGroup.MustAddInteractionCommand(&subway.InteractionCommandable{
+		Name:        "scheduled",
+		Description: "Manage or cancel giveaways which have not started yet",
+
+		Type: subway.InteractionCommandableTypeSubcommand,
+
+		DefaultMemberPermission: new(discord.Int64(welcomer.PermissionElevated)),
+		DMPermission:            new(false),
+
+		Handler: func(ctx context.Context, sub *subway.Subway, interaction discord.Interaction) (*discord.InteractionResponse, error) {
+			return welcomer.RequireGuildElevation(sub, interaction, func() (*discord.InteractionResponse, error) {
+				giveaways, err := welcomer.Queries.GetGuildScheduledGiveaways(ctx, int64(*interaction.GuildID))
+				if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+					welcomer.Logger.Error().Err(err).
+						Int64("guild_id", int64(*interaction.GuildID)).
+						Msg("Failed to get scheduled giveaways")
+
+					return nil, err
+				}
+
+				return &discord.InteractionResponse{
+					Type: discord.InteractionCallbackTypeChannelMessageSource,
+					Data: welcomer.WebhookMessageParamsToInteractionCallbackData(giveawayScheduledView(giveaways), uint32(discord.MessageFlagEphemeral+discord.MessageFlagIsComponentsV2)),
+				}, nil
+			})
+		},
+	})
+
+	giveawaysGroup.MustAddInteractionCommand(&subway.InteractionCommandable{
+		Name:        "verify",
+		Description: "Verify the winners of a provably fair giveaway",
+
+		Type: subway.InteractionCommandableTypeSubcommand,
+
+		ArgumentParameter: []subway.ArgumentParameter{
+			{
+				Required:     true,
+				ArgumentType: subway.ArgumentTypeString,
+				Name:         "giveaway",
+				Description:  "The giveaway ID shown on the giveaway message.",
+			},
+		},
+
+		DMPermission: new(false),
+
+		Handler: func(ctx context.Context, sub *subway.Subway, interaction discord.Interaction) (*discord.InteractionResponse, error) {
+			giveawayUUID, err := uuid.FromString(strings.TrimSpace(subway.MustGetArgument(ctx, "giveaway").MustString()))
+			if err != nil {
+				return &discord.InteractionResponse{
+					Type: discord.InteractionCallbackTypeChannelMessageSource,
+					Data: &discord.InteractionCallbackData{
+						Embeds: welcomer.NewEmbed("This is not a valid giveaway ID.", welcomer.EmbedColourError),
+						Flags:  uint32(discord.MessageFlagEphemeral),
+					},
+				}, nil
+			}
+
+			giveaway, err := welcomer.Queries.GetGiveaway(ctx, database.GetGiveawayParams{
+				GuildID:      int64(*interaction.GuildID),
+				GiveawayUuid: giveawayUUID,
+			})
+			if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+				welcomer.Logger.Error().Err(err).
+					Int64("guild_id", int64(*interaction.GuildID)).
+					Str("giveaway_uuid", giveawayUUID.String()).
+					Msg("Failed to get giveaway settings")
+
+				return nil, err
+			}
+
+			var draw *database.GuildGiveawaysDraws
+
+			if err == nil && giveaway.ProvablyFair {
+				draw, err = welcomer.Queries.GetGiveawayDraw(ctx, giveawayUUID)
+				if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+					welcomer.Logger.Error().Err(err).
+						Str("giveaway_uuid", giveawayUUID.String()).
+						Msg("Failed to get giveaway draw")
+
+					return nil, err
+				}
+			}
+
+			if draw == nil || draw.GiveawayUuid.IsNil() {
+				return &discord.InteractionResponse{
+					Type: discord.InteractionCallbackTypeChannelMessageSource,
+					Data: &discord.InteractionCallbackData{
+						Embeds: welcomer.NewEmbed("This giveaway does not exist or is not provably fair.", welcomer.EmbedColourError),
+						Flags:  uint32(discord.MessageFlagEphemeral),
+					},
+				}, nil
+			}
+
+			winners, err := welcomer.Queries.GetGiveawayWinners(ctx, giveawayUUID)
+			if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+				welcomer.Logger.Error().Err(err).
+					Str("giveaway_uuid", giveawayUUID.String()).
+					Msg("Failed to get giveaway winners")
+
+				return nil, err
+			}
+
+			verification, err := welcomer.VerifyGiveawayDraw(draw, winners)
+			if err != nil {
+				welcomer.Logger.Error().Err(err).
+					Str("giveaway_uuid", giveawayUUID.String()).
+					Msg("Failed to verify giveaway draw")
+
+				return nil, err
+			}
+
+			return &discord.InteractionResponse{
+				Type: discord.InteractionCallbackTypeChannelMessageSource,
+				Data: &discord.InteractionCallbackData{
+					Embeds: []discord.Embed{getGiveawayVerificationEmbed(giveaway, verification)},
+					Flags:  uint32(discord.MessageFlagEphemeral),
+				},
+			}, nil
+		},
+	})
+
 	cog.InteractionCommands.MustAddInteractionCommand(&subway.InteractionCommandable{
 		Name: "Manage Giveaway",
 
@@ -280,9 +426,16 @@ func (cog *GiveawaysCog) RegisterCog(sub *subway.Subway) error {
 				}, nil
 			}
 
+			winners, err := welcomer.Queries.GetGiveawayWinners(ctx, giveaway.GiveawayUuid)
+			if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+				welcomer.Logger.Error().Err(err).
+					Str("giveaway_uuid", giveaway.GiveawayUuid.String()).
+					Msg("Failed to get giveaway winners")
+			}
+
 			return &discord.InteractionResponse{
 				Type: discord.InteractionCallbackTypeChannelMessageSource,
-				Data: welcomer.WebhookMessageParamsToInteractionCallbackData(giveawayManageView(giveaway), uint32(discord.MessageFlagEphemeral+discord.MessageFlagIsComponentsV2)),
+				Data: welcomer.WebhookMessageParamsToInteractionCallbackData(giveawayManageView(giveaway, winners), uint32(discord.MessageFlagEphemeral+discord.MessageFlagIsComponentsV2)),
 			}, nil
 		},
 	})
@@ -290,6 +443,7 @@ func (cog *GiveawaysCog) RegisterCog(sub *subway.Subway) error {
 	// TODO: reroll giveaway on message
 	// TODO: resend giveaway message if accidentally deleted
 
+	sub.RegisterComponentListener("giveaway_claim:*", handleGiveawayClaimComponent)
 	sub.RegisterComponentListener("giveaway_edit:*", handleGiveawayEditComponent)
 	sub.RegisterComponentListener("giveaway_enter:*", handleGiveawayEnterComponent)
 	sub.RegisterComponentListener("giveaway_manage:*", handleGiveawayManageComponent)
@@ -377,10 +531,61 @@ func handleGiveawayManageComponent(ctx context.Context, sub *subway.Subway, inte
 					},
 				},
 			}, nil
+		case giveawayManageMenuOpenKey:
+			winners, err := welcomer.Queries.GetGiveawayWinners(ctx, giveaway.GiveawayUuid)
+			if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+				welcomer.Logger.Error().Err(err).
+					Str("giveaway_uuid", giveaway.GiveawayUuid.String()).
+					Msg("Failed to get giveaway winners")
+			}
+
+			return &discord.InteractionResponse{
+				Type: discord.InteractionCallbackTypeChannelMessageSource,
+				Data: welcomer.WebhookMessageParamsToInteractionCallbackData(giveawayManageView(giveaway, winners), uint32(discord.MessageFlagEphemeral+discord.MessageFlagIsComponentsV2)),
+			}, nil
+		case giveawayManageMenuCancelScheduledKey:
+			return &discord.InteractionResponse{
+				Type: discord.InteractionCallbackTypeModal,
+				Data: &discord.InteractionCallbackData{
+					Title:    "Cancel Giveaway",
+					CustomID: interaction.Data.CustomID,
+					Components: []discord.InteractionComponent{
+						{
+							Type:    discord.InteractionComponentTypeTextDisplay,
+							Content: "Are you sure you want to cancel this giveaway? It will be deleted and will not start. This cannot be undone.",
+						},
+					},
+				},
+			}, nil
 		case giveawayManageMenuExportEntriesKey:
 			return exportGiveawayEntries(ctx, sub, interaction, giveaway)
 		case giveawayManageMenuExportWinnersKey:
 			return exportGiveawayWinners(ctx, sub, interaction, giveaway)
+		case giveawayManageMenuStopRecurringKey:
+			giveaway.RecurrenceRule = ""
+		case giveawayManageMenuRetryDeliveryKey:
+			winners, err := welcomer.Queries.GetGiveawayWinners(ctx, giveaway.GiveawayUuid)
+			if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+				welcomer.Logger.Error().Err(err).
+					Str("giveaway_uuid", giveaway.GiveawayUuid.String()).
+					Msg("Failed to get giveaway winners")
+
+				return nil, err
+			}
+
+			session, err := welcomer.AcquireSession(ctx, welcomer.GetManagerNameFromContext(ctx))
+			if err != nil {
+				return nil, err
+			}
+
+			for _, winner := range winners {
+				if !isGiveawayDeliveryFailed(winner) {
+					continue
+				}
+
+				// Failures are recorded on the winner again and shown in the manage view.
+				_, _ = welcomer.DeliverGiveawayPrize(ctx, session, giveaway, winner)
+			}
 		default:
 			welcomer.Logger.Warn().
 				Int64("guild_id", int64(*interaction.GuildID)).
@@ -431,6 +636,38 @@ func handleGiveawayManageComponent(ctx context.Context, sub *subway.Subway, inte
 				// If no duration is passed, make the duration indefinite.
 				giveaway.EndTime = time.Time{}
 			}
+		case giveawayManageMenuCancelScheduledKey:
+			if !isGiveawayScheduled(giveaway) {
+				return &discord.InteractionResponse{
+					Type: discord.InteractionCallbackTypeChannelMessageSource,
+					Data: &discord.InteractionCallbackData{
+						Embeds: welcomer.NewEmbed("This giveaway has already started, so it can no longer be cancelled. You can end it instead.", welcomer.EmbedColourError),
+						Flags:  uint32(discord.MessageFlagEphemeral),
+					},
+				}, nil
+			}
+
+			_, err = welcomer.Queries.DeleteGiveaway(ctx, database.DeleteGiveawayParams{
+				GuildID:      int64(*interaction.GuildID),
+				GiveawayUuid: giveaway.GiveawayUuid,
+			})
+			if err != nil {
+				welcomer.Logger.Error().Err(err).
+					Int64("guild_id", int64(*interaction.GuildID)).
+					Str("giveaway_uuid", giveaway.GiveawayUuid.String()).
+					Msg("Failed to delete scheduled giveaway")
+
+				return nil, err
+			}
+
+			return &discord.InteractionResponse{
+				Type: discord.InteractionCallbackTypeUpdateMessage,
+				Data: &discord.InteractionCallbackData{
+					Embeds:     welcomer.NewEmbed(fmt.Sprintf("The giveaway **%s** has been cancelled.", welcomer.Coalesce(giveaway.Title, "New Giveaway")), welcomer.EmbedColourSuccess),
+					Components: []discord.InteractionComponent{},
+					Flags:      uint32(discord.MessageFlagEphemeral),
+				},
+			}, nil
 		case giveawayManageMenuEndGiveawayKey:
 			giveaway.EndTime = time.Now()
 
@@ -474,6 +711,16 @@ func handleGiveawayManageComponent(ctx context.Context, sub *subway.Subway, inte
 		ShowEntries:     giveaway.ShowEntries,
 		AllowEntries:    giveaway.AllowEntries,
 		HasEnded:        giveaway.HasEnded,
+
+		MinimumAccountAge:   giveaway.MinimumAccountAge,
+		MinimumMessageCount: giveaway.MinimumMessageCount,
+		MinimumVoiceMinutes: giveaway.MinimumVoiceMinutes,
+		ActivityPeriodDays:  giveaway.ActivityPeriodDays,
+
+		RecurrenceRule: giveaway.RecurrenceRule,
+		PingContent:    giveaway.PingContent,
+		ProvablyFair:   giveaway.ProvablyFair,
+		ClaimWindow:    giveaway.ClaimWindow,
 	}, interaction.GetUser().ID, *interaction.GuildID)
 	if err != nil {
 		welcomer.Logger.Error().Err(err).
@@ -488,9 +735,16 @@ func handleGiveawayManageComponent(ctx context.Context, sub *subway.Subway, inte
 		giveaway.HasEnded = true
 	}
 
+	winners, err := welcomer.Queries.GetGiveawayWinners(ctx, giveaway.GiveawayUuid)
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		welcomer.Logger.Error().Err(err).
+			Str("giveaway_uuid", giveaway.GiveawayUuid.String()).
+			Msg("Failed to get giveaway winners")
+	}
+
 	err = discord.CreateInteractionResponse(ctx, sub.EmptySession, interaction.ID, interaction.Token, discord.InteractionResponse{
 		Type: discord.InteractionCallbackTypeUpdateMessage,
-		Data: welcomer.WebhookMessageParamsToInteractionCallbackData(giveawayManageView(giveaway), uint32(discord.MessageFlagEphemeral+discord.MessageFlagIsComponentsV2)),
+		Data: welcomer.WebhookMessageParamsToInteractionCallbackData(giveawayManageView(giveaway, winners), uint32(discord.MessageFlagEphemeral+discord.MessageFlagIsComponentsV2)),
 	})
 	if err != nil {
 		welcomer.Logger.Error().Err(err).
@@ -572,13 +826,18 @@ func exportGiveawayWinners(ctx context.Context, sub *subway.Subway, interaction
 
 	writer := csv.NewWriter(&file)
 
-	_ = writer.Write([]string{"user_id", "prize", "message_id"})
+	_ = writer.Write([]string{"user_id", "prize", "message_id", "is_claimed", "claimed_at", "is_expired", "is_delivered", "delivery_error"})
 
 	for _, winner := range winners {
 		_ = writer.Write([]string{
 			welcomer.Itoa(winner.UserID),
 			winner.Prize,
 			welcomer.Itoa(winner.MessageID),
+			strconv.FormatBool(winner.IsClaimed),
+			welcomer.If(winner.IsClaimed, winner.ClaimedAt.Format(time.RFC3339), ""),
+			strconv.FormatBool(winner.IsExpired),
+			strconv.FormatBool(winner.IsDelivered),
+			winner.DeliveryError,
 		})
 	}
 
@@ -615,7 +874,7 @@ func exportGiveawayWinners(ctx context.Context, sub *subway.Subway, interaction
 	return nil, nil
 }
 
-func handleGiveawayEnterComponent(ctx context.Context, sub *subway.Subway, interaction discord.Interaction) (*discord.InteractionResponse, error) {
+func handleGiveawayClaimComponent(ctx context.Context, sub *subway.Subway, interaction discord.Interaction) (*discord.InteractionResponse, error) {
 	if interaction.GuildID == nil {
 		return nil, nil
 	}
@@ -629,134 +888,318 @@ func handleGiveawayEnterComponent(ctx context.Context, sub *subway.Subway, inter
 		return nil, nil
 	}
 
-	giveawayUUID, err := uuid.FromString(customIDSplit[1])
+	giveawayWinnerUUID, err := uuid.FromString(customIDSplit[1])
 	if err != nil {
 		return nil, err
 	}
 
-	giveaway, err := welcomer.Queries.GetGiveaway(ctx, database.GetGiveawayParams{
-		GuildID:      int64(*interaction.GuildID),
-		GiveawayUuid: giveawayUUID,
-	})
+	winner, err := welcomer.Queries.GetGiveawayWinner(ctx, giveawayWinnerUUID)
 	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
 		welcomer.Logger.Error().Err(err).
 			Int64("guild_id", int64(*interaction.GuildID)).
-			Str("giveaway_uuid", giveawayUUID.String()).
-			Msg("Failed to get giveaway settings")
+			Str("giveaway_winner_uuid", giveawayWinnerUUID.String()).
+			Msg("Failed to get giveaway winner")
 
 		return nil, err
-	} else if errors.Is(err, pgx.ErrNoRows) {
-		welcomer.Logger.Warn().
-			Int64("guild_id", int64(*interaction.GuildID)).
-			Str("giveaway_uuid", giveawayUUID.String()).
-			Msg("Giveaway not found for giveaway entry")
+	}
 
-		return &discord.InteractionResponse{
-			Type: discord.InteractionCallbackTypeChannelMessageSource,
-			Data: &discord.InteractionCallbackData{
-				Embeds: welcomer.NewEmbed("This giveaway no longer exists. It may have been deleted or ended.", welcomer.EmbedColourError),
-				Flags:  uint32(discord.MessageFlagEphemeral),
-			},
-		}, nil
+	var giveaway *database.GuildGiveaways
+
+	if err == nil {
+		// Make sure the winner belongs to a giveaway in this guild.
+		giveaway, err = welcomer.Queries.GetGiveaway(ctx, database.GetGiveawayParams{
+			GuildID:      int64(*interaction.GuildID),
+			GiveawayUuid: winner.GiveawayUuid,
+		})
+		if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+			welcomer.Logger.Error().Err(err).
+				Int64("guild_id", int64(*interaction.GuildID)).
+				Str("giveaway_uuid", winner.GiveawayUuid.String()).
+				Msg("Failed to get giveaway settings")
+
+			return nil, err
+		}
 	}
 
-	if giveaway.HasEnded {
+	if errors.Is(err, pgx.ErrNoRows) {
 		return &discord.InteractionResponse{
 			Type: discord.InteractionCallbackTypeChannelMessageSource,
 			Data: &discord.InteractionCallbackData{
-				Embeds: welcomer.NewEmbed("This giveaway has already ended. Better luck next time!", welcomer.EmbedColourError),
+				Embeds: welcomer.NewEmbed("This prize no longer exists. The giveaway may have been deleted.", welcomer.EmbedColourError),
 				Flags:  uint32(discord.MessageFlagEphemeral),
 			},
 		}, nil
 	}
 
-	if !giveaway.AllowEntries {
+	if discord.Snowflake(winner.UserID) != interaction.GetUser().ID {
 		return &discord.InteractionResponse{
 			Type: discord.InteractionCallbackTypeChannelMessageSource,
 			Data: &discord.InteractionCallbackData{
-				Embeds: welcomer.NewEmbed("This giveaway does not have entries enabled. Please try again later.", welcomer.EmbedColourError),
+				Embeds: welcomer.NewEmbed("Only the winner can claim this prize.", welcomer.EmbedColourError),
 				Flags:  uint32(discord.MessageFlagEphemeral),
 			},
 		}, nil
 	}
 
-	rolesAllowed := welcomer.UnmarshalRolesListJSON(giveaway.RolesAllowed.Bytes)
-	rolesExcluded := welcomer.UnmarshalRolesListJSON(giveaway.RolesExcluded.Bytes)
-
-	if len(rolesAllowed) > 0 && !hasAnyRoles(rolesAllowed, interaction.Member.Roles) {
+	if winner.IsClaimed {
 		return &discord.InteractionResponse{
 			Type: discord.InteractionCallbackTypeChannelMessageSource,
 			Data: &discord.InteractionCallbackData{
-				Embeds: welcomer.NewEmbed("Sorry, you are missing a required role to enter this giveaway.", welcomer.EmbedColourError),
+				Embeds: welcomer.NewEmbed("You have already claimed this prize.", welcomer.EmbedColourInfo),
 				Flags:  uint32(discord.MessageFlagEphemeral),
 			},
 		}, nil
 	}
 
-	if len(rolesExcluded) > 0 && hasAnyRoles(rolesExcluded, interaction.Member.Roles) {
+	// The job may not have expired the winner yet, so check the deadline as well.
+	if winner.IsExpired || (winner.ClaimExpiresAt.Unix() > 0 && time.Now().After(winner.ClaimExpiresAt)) {
 		return &discord.InteractionResponse{
 			Type: discord.InteractionCallbackTypeChannelMessageSource,
 			Data: &discord.InteractionCallbackData{
-				Embeds: welcomer.NewEmbed("Sorry, you have a role that disqualifies you from entering this giveaway.", welcomer.EmbedColourError),
+				Embeds: welcomer.NewEmbed("This prize was not claimed in time and will be given to someone else.", welcomer.EmbedColourError),
 				Flags:  uint32(discord.MessageFlagEphemeral),
 			},
 		}, nil
 	}
 
-	if !giveaway.MinimumJoinDate.IsZero() {
-		joinBefore := giveaway.StartTime.Add(-(time.Duration(giveaway.MinimumJoinDate.Unix()) * time.Second))
-		if interaction.Member.JoinedAt.After(joinBefore) {
-			return &discord.InteractionResponse{
-				Type: discord.InteractionCallbackTypeChannelMessageSource,
-				Data: &discord.InteractionCallbackData{
-					Embeds: welcomer.NewEmbed(fmt.Sprintf("Sorry, you must have joined the server before <t:%d:f> to enter this giveaway.", joinBefore.Unix()), welcomer.EmbedColourError),
-					Flags:  uint32(discord.MessageFlagEphemeral),
-				},
-			}, nil
-		}
-	}
-
-	_, err = welcomer.Queries.AddGiveawayEntry(ctx, database.AddGiveawayEntryParams{
-		GiveawayUuid: giveawayUUID,
-		UserID:       int64(interaction.Member.User.ID),
-	})
+	winner, err = welcomer.Queries.ClaimGiveawayWinner(ctx, giveawayWinnerUUID)
 	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
 		welcomer.Logger.Error().Err(err).
 			Int64("guild_id", int64(*interaction.GuildID)).
-			Str("giveaway_uuid", giveawayUUID.String()).
-			Msg("Failed to add giveaway entry")
-	}
+			Str("giveaway_winner_uuid", giveawayWinnerUUID.String()).
+			Msg("Failed to claim giveaway prize")
 
-	if err != nil && errors.Is(err, pgx.ErrNoRows) {
+		return nil, err
+	} else if errors.Is(err, pgx.ErrNoRows) {
 		return &discord.InteractionResponse{
 			Type: discord.InteractionCallbackTypeChannelMessageSource,
 			Data: &discord.InteractionCallbackData{
-				Embeds: welcomer.NewEmbed("You have already entered this giveaway! Good luck!", welcomer.EmbedColourInfo),
+				Embeds: welcomer.NewEmbed("This prize can no longer be claimed.", welcomer.EmbedColourError),
 				Flags:  uint32(discord.MessageFlagEphemeral),
 			},
 		}, nil
 	}
 
-	entries, err := welcomer.Queries.CountGiveawayEntries(ctx, giveawayUUID)
+	welcomer.Logger.Info().
+		Int64("guild_id", int64(*interaction.GuildID)).
+		Str("giveaway_uuid", winner.GiveawayUuid.String()).
+		Int64("user_id", winner.UserID).
+		Str("prize", winner.Prize).
+		Msg("Giveaway prize claimed")
+
+	content := fmt.Sprintf("Congratulations <@%d>, you won **%s**\nClaimed <t:%d:R>.", winner.UserID, winner.Prize, winner.ClaimedAt.Unix())
+
+	session, err := welcomer.AcquireSession(ctx, welcomer.GetManagerNameFromContext(ctx))
 	if err != nil {
-		welcomer.Logger.Error().Err(err).
-			Int64("guild_id", int64(*interaction.GuildID)).
-			Str("giveaway_uuid", giveawayUUID.String()).
-			Msg("Failed to count giveaway entries")
+		return nil, err
 	}
 
-	go func() {
-		time.Sleep(5)
+	// Failures are recorded on the winner so the host can retry delivery from the manage view.
+	_, err = welcomer.DeliverGiveawayPrize(ctx, session, giveaway, winner)
+	if err != nil {
+		content += "\n-# Your prize could not be delivered automatically. The giveaway host has been notified."
+	}
 
-		newEntries, err := welcomer.Queries.CountGiveawayEntries(ctx, giveawayUUID)
-		if err != nil {
-			welcomer.Logger.Error().Err(err).
-				Int64("guild_id", int64(*interaction.GuildID)).
-				Str("giveaway_uuid", giveawayUUID.String()).
-				Msg("Failed to count giveaway entries")
-		}
+	return &discord.InteractionResponse{
+		Type: discord.InteractionCallbackTypeUpdateMessage,
+		Data: &discord.InteractionCallbackData{
+			Content: content,
+			Components: []discord.InteractionComponent{
+				{
+					Type: discord.InteractionComponentTypeActionRow,
+					Components: []discord.InteractionComponent{
+						{
+							Type:     discord.InteractionComponentTypeButton,
+							Style:    discord.InteractionComponentStyleSuccess,
+							CustomID: "giveaway_claim:" + winner.GiveawayWinnerUuid.String(),
+							Label:    "Prize Claimed",
+							Disabled: true,
+							Emoji: &discord.Emoji{
+								Name: "🎁",
+							},
+						},
+					},
+				},
+			},
+		},
+	}, nil
+}
 
-		if entries == newEntries {
+func handleGiveawayEnterComponent(ctx context.Context, sub *subway.Subway, interaction discord.Interaction) (*discord.InteractionResponse, error) {
+	if interaction.GuildID == nil {
+		return nil, nil
+	}
+
+	if interaction.Data.CustomID == "" {
+		return nil, nil
+	}
+
+	customIDSplit := strings.Split(interaction.Data.CustomID, ":")
+	if len(customIDSplit) < 2 {
+		return nil, nil
+	}
+
+	giveawayUUID, err := uuid.FromString(customIDSplit[1])
+	if err != nil {
+		return nil, err
+	}
+
+	giveaway, err := welcomer.Queries.GetGiveaway(ctx, database.GetGiveawayParams{
+		GuildID:      int64(*interaction.GuildID),
+		GiveawayUuid: giveawayUUID,
+	})
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		welcomer.Logger.Error().Err(err).
+			Int64("guild_id", int64(*interaction.GuildID)).
+			Str("giveaway_uuid", giveawayUUID.String()).
+			Msg("Failed to get giveaway settings")
+
+		return nil, err
+	} else if errors.Is(err, pgx.ErrNoRows) {
+		welcomer.Logger.Warn().
+			Int64("guild_id", int64(*interaction.GuildID)).
+			Str("giveaway_uuid", giveawayUUID.String()).
+			Msg("Giveaway not found for giveaway entry")
+
+		return &discord.InteractionResponse{
+			Type: discord.InteractionCallbackTypeChannelMessageSource,
+			Data: &discord.InteractionCallbackData{
+				Embeds: welcomer.NewEmbed("This giveaway no longer exists. It may have been deleted or ended.", welcomer.EmbedColourError),
+				Flags:  uint32(discord.MessageFlagEphemeral),
+			},
+		}, nil
+	}
+
+	if giveaway.HasEnded {
+		return &discord.InteractionResponse{
+			Type: discord.InteractionCallbackTypeChannelMessageSource,
+			Data: &discord.InteractionCallbackData{
+				Embeds: welcomer.NewEmbed("This giveaway has already ended. Better luck next time!", welcomer.EmbedColourError),
+				Flags:  uint32(discord.MessageFlagEphemeral),
+			},
+		}, nil
+	}
+
+	if !giveaway.AllowEntries {
+		return &discord.InteractionResponse{
+			Type: discord.InteractionCallbackTypeChannelMessageSource,
+			Data: &discord.InteractionCallbackData{
+				Embeds: welcomer.NewEmbed("This giveaway does not have entries enabled. Please try again later.", welcomer.EmbedColourError),
+				Flags:  uint32(discord.MessageFlagEphemeral),
+			},
+		}, nil
+	}
+
+	rolesAllowed := welcomer.UnmarshalRolesListJSON(giveaway.RolesAllowed.Bytes)
+	rolesExcluded := welcomer.UnmarshalRolesListJSON(giveaway.RolesExcluded.Bytes)
+
+	if len(rolesAllowed) > 0 && !hasAnyRoles(rolesAllowed, interaction.Member.Roles) {
+		return &discord.InteractionResponse{
+			Type: discord.InteractionCallbackTypeChannelMessageSource,
+			Data: &discord.InteractionCallbackData{
+				Embeds: welcomer.NewEmbed("Sorry, you are missing a required role to enter this giveaway.", welcomer.EmbedColourError),
+				Flags:  uint32(discord.MessageFlagEphemeral),
+			},
+		}, nil
+	}
+
+	if len(rolesExcluded) > 0 && hasAnyRoles(rolesExcluded, interaction.Member.Roles) {
+		return &discord.InteractionResponse{
+			Type: discord.InteractionCallbackTypeChannelMessageSource,
+			Data: &discord.InteractionCallbackData{
+				Embeds: welcomer.NewEmbed("Sorry, you have a role that disqualifies you from entering this giveaway.", welcomer.EmbedColourError),
+				Flags:  uint32(discord.MessageFlagEphemeral),
+			},
+		}, nil
+	}
+
+	if !giveaway.MinimumJoinDate.IsZero() {
+		joinBefore := giveaway.StartTime.Add(-(time.Duration(giveaway.MinimumJoinDate.Unix()) * time.Second))
+		if interaction.Member.JoinedAt.After(joinBefore) {
+			return &discord.InteractionResponse{
+				Type: discord.InteractionCallbackTypeChannelMessageSource,
+				Data: &discord.InteractionCallbackData{
+					Embeds: welcomer.NewEmbed(fmt.Sprintf("Sorry, you must have joined the server before <t:%d:f> to enter this giveaway.", joinBefore.Unix()), welcomer.EmbedColourError),
+					Flags:  uint32(discord.MessageFlagEphemeral),
+				},
+			}, nil
+		}
+	}
+
+	if giveaway.MinimumAccountAge > 0 {
+		createdBefore := giveaway.StartTime.Add(-(time.Duration(giveaway.MinimumAccountAge) * time.Second))
+		if interaction.Member.User.ID.Time().After(createdBefore) {
+			return &discord.InteractionResponse{
+				Type: discord.InteractionCallbackTypeChannelMessageSource,
+				Data: &discord.InteractionCallbackData{
+					Embeds: welcomer.NewEmbed(fmt.Sprintf("Sorry, your Discord account must have been created before <t:%d:f> to enter this giveaway.", createdBefore.Unix()), welcomer.EmbedColourError),
+					Flags:  uint32(discord.MessageFlagEphemeral),
+				},
+			}, nil
+		}
+	}
+
+	reason, err := checkGiveawayActivityRequirements(ctx, giveaway, interaction.Member.User.ID)
+	if err != nil {
+		welcomer.Logger.Error().Err(err).
+			Int64("guild_id", int64(*interaction.GuildID)).
+			Str("giveaway_uuid", giveawayUUID.String()).
+			Msg("Failed to check giveaway activity requirements")
+
+		return nil, err
+	}
+
+	if reason != "" {
+		return &discord.InteractionResponse{
+			Type: discord.InteractionCallbackTypeChannelMessageSource,
+			Data: &discord.InteractionCallbackData{
+				Embeds: welcomer.NewEmbed(reason, welcomer.EmbedColourError),
+				Flags:  uint32(discord.MessageFlagEphemeral),
+			},
+		}, nil
+	}
+
+	_, err = welcomer.Queries.AddGiveawayEntry(ctx, database.AddGiveawayEntryParams{
+		GiveawayUuid: giveawayUUID,
+		UserID:       int64(interaction.Member.User.ID),
+	})
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		welcomer.Logger.Error().Err(err).
+			Int64("guild_id", int64(*interaction.GuildID)).
+			Str("giveaway_uuid", giveawayUUID.String()).
+			Msg("Failed to add giveaway entry")
+	}
+
+	if err != nil && errors.Is(err, pgx.ErrNoRows) {
+		return &discord.InteractionResponse{
+			Type: discord.InteractionCallbackTypeChannelMessageSource,
+			Data: &discord.InteractionCallbackData{
+				Embeds: welcomer.NewEmbed("You have already entered this giveaway! Good luck!", welcomer.EmbedColourInfo),
+				Flags:  uint32(discord.MessageFlagEphemeral),
+			},
+		}, nil
+	}
+
+	entries, err := welcomer.Queries.CountGiveawayEntries(ctx, giveawayUUID)
+	if err != nil {
+		welcomer.Logger.Error().Err(err).
+			Int64("guild_id", int64(*interaction.GuildID)).
+			Str("giveaway_uuid", giveawayUUID.String()).
+			Msg("Failed to count giveaway entries")
+	}
+
+	go func() {
+		time.Sleep(5)
+
+		newEntries, err := welcomer.Queries.CountGiveawayEntries(ctx, giveawayUUID)
+		if err != nil {
+			welcomer.Logger.Error().Err(err).
+				Int64("guild_id", int64(*interaction.GuildID)).
+				Str("giveaway_uuid", giveawayUUID.String()).
+				Msg("Failed to count giveaway entries")
+		}
+
+		if entries == newEntries {
 			message := discord.Message{
 				ID:        discord.Snowflake(giveaway.MessageID),
 				ChannelID: discord.Snowflake(giveaway.ChannelID),
@@ -772,7 +1215,7 @@ func handleGiveawayEnterComponent(ctx context.Context, sub *subway.Subway, inter
 				return
 			}
 
-			_, err = message.Edit(ctx, session, welcomer.WebhookMessageParamsToMessageParams(giveawayView(giveaway, newEntries)))
+			_, err = message.Edit(ctx, session, welcomer.WebhookMessageParamsToMessageParams(welcomer.GetGiveawayMessage(giveaway, newEntries)))
 			if err != nil {
 				welcomer.Logger.Error().Err(err).
 					Int64("guild_id", int64(*interaction.GuildID)).
@@ -797,6 +1240,59 @@ func handleGiveawayEnterComponent(ctx context.Context, sub *subway.Subway, inter
 	}, nil
 }
 
+// checkGiveawayActivityRequirements returns a reason the user cannot enter the giveaway
+// based on their message and voice activity. An empty reason means the user is eligible.
+func checkGiveawayActivityRequirements(ctx context.Context, giveaway *database.GuildGiveaways, userID discord.Snowflake) (string, error) {
+	if giveaway.MinimumMessageCount <= 0 && giveaway.MinimumVoiceMinutes <= 0 {
+		return "", nil
+	}
+
+	var since time.Time
+
+	periodString := ""
+
+	if giveaway.ActivityPeriodDays > 0 {
+		since = time.Now().AddDate(0, 0, -int(giveaway.ActivityPeriodDays))
+		periodString = fmt.Sprintf(" in the last %d day%s", giveaway.ActivityPeriodDays, welcomer.If(giveaway.ActivityPeriodDays == 1, "", "s"))
+	}
+
+	if giveaway.MinimumMessageCount > 0 {
+		messageCount, err := welcomer.Queries.GetUserMessageCountSince(ctx, database.GetUserMessageCountSinceParams{
+			Granularity: welcomer.GetMessageCountGranularity(since, time.Now()),
+			GuildID:     giveaway.GuildID,
+			UserID:      int64(userID),
+			Since:       since,
+		})
+		if err != nil {
+			return "", err
+		}
+
+		if messageCount < int64(giveaway.MinimumMessageCount) {
+			return fmt.Sprintf("Sorry, you must have sent at least **%d** messages in this server%s to enter this giveaway. You have sent **%d**.", giveaway.MinimumMessageCount, periodString, messageCount), nil
+		}
+	}
+
+	if giveaway.MinimumVoiceMinutes > 0 {
+		voiceTimeMs, err := welcomer.Queries.GetUserVoiceTimeSince(ctx, database.GetUserVoiceTimeSinceParams{
+			GuildID: giveaway.GuildID,
+			UserID:  int64(userID),
+			EndTs:   since,
+		})
+		if err != nil {
+			return "", err
+		}
+
+		voiceMinutes := voiceTimeMs / int64(time.Minute/time.Millisecond)
+
+		if voiceMinutes < int64(giveaway.MinimumVoiceMinutes) {
+			return fmt.Sprintf("Sorry, you must have spent at least **%s** in voice channels in this server%s to enter this giveaway. You have spent **%s**.",
+				welcomer.HumanizeDuration(int(giveaway.MinimumVoiceMinutes)*60, false), periodString, welcomer.Coalesce(welcomer.HumanizeDuration(int(voiceMinutes)*60, false), "0 minutes")), nil
+		}
+	}
+
+	return "", nil
+}
+
 func hasAnyRoles(roleList, userRoles []discord.Snowflake) bool {
 	for _, role := range roleList {
 		if slices.Contains(userRoles, role) {
@@ -949,6 +1445,140 @@ func handleGiveawayEditComponent(ctx context.Context, sub *subway.Subway, intera
 				},
 				Type: discord.InteractionCallbackTypeModal,
 			}, nil
+		case giveawaySetupMenuPrizeDeliveryKey:
+			prizes := welcomer.UnmarshalGiveawayPrizeJSON(giveaway.GiveawayPrizes.Bytes)
+
+			if len(prizes) == 0 {
+				return &discord.InteractionResponse{
+					Type: discord.InteractionCallbackTypeChannelMessageSource,
+					Data: &discord.InteractionCallbackData{
+						Embeds: welcomer.NewEmbed("Add some prizes to the giveaway before configuring how they are delivered.", welcomer.EmbedColourError),
+						Flags:  uint32(discord.MessageFlagEphemeral),
+					},
+				}, nil
+			}
+
+			codeCounts, err := welcomer.Queries.GetGiveawayPrizeCodeCounts(ctx, welcomer.GetGiveawayPrizeCodePool(giveaway))
+			if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+				welcomer.Logger.Error().Err(err).
+					Str("giveaway_uuid", giveaway.GiveawayUuid.String()).
+					Msg("Failed to get giveaway prize code counts")
+
+				return nil, err
+			}
+
+			prizeOptions := make([]discord.ApplicationSelectOption, 0, len(prizes))
+
+			for i, prize := range prizes {
+				description := welcomer.GetGiveawayPrizeDeliveryAsString(prize)
+
+				if prize.Type == welcomer.GiveawayPrizeTypeCode {
+					var remaining, total int64
+
+					for _, codeCount := range codeCounts {
+						if codeCount.Prize == prize.Title {
+							remaining, total = codeCount.Remaining, codeCount.Total
+						}
+					}
+
+					description += fmt.Sprintf(" (%d of %d codes remaining)", remaining, total)
+				}
+
+				prizeOptions = append(prizeOptions, discord.ApplicationSelectOption{
+					Label:       welcomer.Overflow(prize.Title, 100),
+					Value:       strconv.Itoa(i),
+					Description: welcomer.Overflow(description, 100),
+				})
+			}
+
+			typeOptions := []discord.ApplicationSelectOption{
+				{
+					Label:       "Manual",
+					Value:       string(welcomer.GiveawayPrizeTypeManual),
+					Description: "You deliver the prize to the winner yourself.",
+				},
+				{
+					Label:       "Role",
+					Value:       string(welcomer.GiveawayPrizeTypeRole),
+					Description: "Assigns the winner a role, optionally for a limited time.",
+				},
+				{
+					Label:       "Code",
+					Value:       string(welcomer.GiveawayPrizeTypeCode),
+					Description: "Sends the winner a code from the codes you provide.",
+				},
+			}
+
+			if slices.Contains(welcomer.ElevatedUsers, interaction.GetUser().ID) {
+				typeOptions = append(typeOptions, discord.ApplicationSelectOption{
+					Label:       "Welcomer Pro",
+					Value:       string(welcomer.GiveawayPrizeTypeMembership),
+					Description: "Grants the winner a Welcomer Pro membership.",
+				})
+			}
+
+			return &discord.InteractionResponse{
+				Data: &discord.InteractionCallbackData{
+					Title:    "Edit Giveaway Prize Delivery",
+					CustomID: interaction.Data.CustomID,
+					Components: []discord.InteractionComponent{
+						{
+							Type:  discord.InteractionComponentTypeLabel,
+							Label: "Prize",
+							Component: &discord.InteractionComponent{
+								CustomID: giveawaySetupMenuPrizeDeliveryPrizeKey,
+								Type:     discord.InteractionComponentTypeStringSelect,
+								Options:  prizeOptions,
+							},
+						},
+						{
+							Type:  discord.InteractionComponentTypeLabel,
+							Label: "Delivery",
+							Component: &discord.InteractionComponent{
+								CustomID: giveawaySetupMenuPrizeDeliveryTypeKey,
+								Type:     discord.InteractionComponentTypeStringSelect,
+								Options:  typeOptions,
+							},
+						},
+						{
+							Type:        discord.InteractionComponentTypeLabel,
+							Label:       "Role",
+							Description: "The role to assign when using role delivery.",
+							Component: &discord.InteractionComponent{
+								CustomID:  giveawaySetupMenuPrizeDeliveryRoleKey,
+								Type:      discord.InteractionComponentTypeRoleSelect,
+								Required:  new(false),
+								MaxValues: new(int32(1)),
+							},
+						},
+						{
+							Type:        discord.InteractionComponentTypeLabel,
+							Label:       "Duration",
+							Description: "How long the role or membership lasts. Roles are permanent if empty. e.g. 7d, 1h.",
+							Component: &discord.InteractionComponent{
+								CustomID:    giveawaySetupMenuPrizeDeliveryDurationKey,
+								Type:        discord.InteractionComponentTypeTextInput,
+								Placeholder: "30d",
+								Style:       discord.InteractionComponentStyleShort,
+								Required:    new(false),
+							},
+						},
+						{
+							Type:        discord.InteractionComponentTypeLabel,
+							Label:       "Codes",
+							Description: "One code per line, added to the codes for this prize when using code delivery.",
+							Component: &discord.InteractionComponent{
+								CustomID:    giveawaySetupMenuPrizeDeliveryCodesKey,
+								Type:        discord.InteractionComponentTypeTextInput,
+								Placeholder: "XXXX-XXXX-XXXX\nYYYY-YYYY-YYYY",
+								Style:       discord.InteractionComponentStyleParagraph,
+								Required:    new(false),
+							},
+						},
+					},
+				},
+				Type: discord.InteractionCallbackTypeModal,
+			}, nil
 		case giveawaySetupMenuDurationKey:
 			return &discord.InteractionResponse{
 				Data: &discord.InteractionCallbackData{
@@ -971,8 +1601,36 @@ func handleGiveawayEditComponent(ctx context.Context, sub *subway.Subway, intera
 				},
 				Type: discord.InteractionCallbackTypeModal,
 			}, nil
+		case giveawaySetupMenuClaimWindowKey:
+			return &discord.InteractionResponse{
+				Data: &discord.InteractionCallbackData{
+					Title:    "Edit Giveaway Claim Window",
+					CustomID: interaction.Data.CustomID,
+					Components: []discord.InteractionComponent{
+						{
+							Type:    discord.InteractionComponentTypeTextDisplay,
+							Content: "Winners must press the claim button within this time. Prizes which are not claimed in time are given to another entry.",
+						},
+						{
+							Type:        discord.InteractionComponentTypeLabel,
+							Label:       "Claim Window",
+							Description: "e.g. 1h, 30m, 2d. Leave empty if winners do not need to claim their prize.",
+							Component: &discord.InteractionComponent{
+								CustomID:    giveawaySetupMenuClaimWindowKey,
+								Type:        discord.InteractionComponentTypeTextInput,
+								Placeholder: "1d",
+								Style:       discord.InteractionComponentStyleShort,
+								Required:    new(false),
+							},
+						},
+					},
+				},
+				Type: discord.InteractionCallbackTypeModal,
+			}, nil
 		case giveawaySetupMenuAnnounceWinnersKey:
 			giveaway.AnnounceWinners = !giveaway.AnnounceWinners
+		case giveawaySetupMenuProvablyFairKey:
+			giveaway.ProvablyFair = !giveaway.ProvablyFair
 		case giveawaySetupMenuRolesAllowedKey:
 			return &discord.InteractionResponse{
 				Data: &discord.InteractionCallbackData{
@@ -981,44 +1639,133 @@ func handleGiveawayEditComponent(ctx context.Context, sub *subway.Subway, intera
 					Components: []discord.InteractionComponent{
 						{
 							Type:        discord.InteractionComponentTypeLabel,
-							Label:       "Roles Allowed to Enter",
-							Description: "Users must have at least one of these roles to enter. Ignored if empty.",
+							Label:       "Roles Allowed to Enter",
+							Description: "Users must have at least one of these roles to enter. Ignored if empty.",
+							Component: &discord.InteractionComponent{
+								CustomID:  giveawaySetupMenuRolesAllowedIncludedKey,
+								Type:      discord.InteractionComponentTypeRoleSelect,
+								Required:  new(false),
+								MaxValues: new(int32(25)),
+							},
+						},
+						{
+							Type:        discord.InteractionComponentTypeLabel,
+							Label:       "Roles Excluded from Entering",
+							Description: "Users with any of these roles cannot enter. Ignored if empty.",
+							Component: &discord.InteractionComponent{
+								CustomID:  giveawaySetupMenuRolesAllowedExcludedKey,
+								Type:      discord.InteractionComponentTypeRoleSelect,
+								Required:  new(false),
+								MaxValues: new(int32(25)),
+							},
+						},
+					},
+				},
+				Type: discord.InteractionCallbackTypeModal,
+			}, nil
+		case giveawaySetupMenuMinimumJoinDateKey:
+			return &discord.InteractionResponse{
+				Data: &discord.InteractionCallbackData{
+					Title:    "Edit Giveaway Minimum Join Date",
+					CustomID: interaction.Data.CustomID,
+					Components: []discord.InteractionComponent{
+						{
+							Type:        discord.InteractionComponentTypeLabel,
+							Label:       "Minimum Join Date",
+							Description: "Users joined within the duration specified cannot enter. Ignored if empty. e.g. 1h, 30m, 2d. ",
+							Component: &discord.InteractionComponent{
+								CustomID:    giveawaySetupMenuMinimumJoinDateKey,
+								Type:        discord.InteractionComponentTypeTextInput,
+								Placeholder: "7d 3h 60m",
+								Style:       discord.InteractionComponentStyleShort,
+								Required:    new(false),
+							},
+						},
+					},
+				},
+				Type: discord.InteractionCallbackTypeModal,
+			}, nil
+		case giveawaySetupMenuActivityRequirementsKey:
+			return &discord.InteractionResponse{
+				Data: &discord.InteractionCallbackData{
+					Title:    "Edit Giveaway Activity Requirements",
+					CustomID: interaction.Data.CustomID,
+					Components: []discord.InteractionComponent{
+						{
+							Type:        discord.InteractionComponentTypeLabel,
+							Label:       "Minimum Account Age",
+							Description: "Accounts created within the duration specified cannot enter. Ignored if empty. e.g. 7d, 1y.",
+							Component: &discord.InteractionComponent{
+								CustomID:    giveawaySetupMenuMinimumAccountAgeKey,
+								Type:        discord.InteractionComponentTypeTextInput,
+								Placeholder: "30d",
+								Value:       welcomer.If(giveaway.MinimumAccountAge <= 0, "", welcomer.SecondsToDurationString(int(giveaway.MinimumAccountAge))),
+								Style:       discord.InteractionComponentStyleShort,
+								Required:    new(false),
+							},
+						},
+						{
+							Type:        discord.InteractionComponentTypeLabel,
+							Label:       "Minimum Messages",
+							Description: "Users must have sent at least this many messages in the server. Ignored if empty.",
+							Component: &discord.InteractionComponent{
+								CustomID:    giveawaySetupMenuMinimumMessagesKey,
+								Type:        discord.InteractionComponentTypeTextInput,
+								Placeholder: "100",
+								Value:       welcomer.If(giveaway.MinimumMessageCount > 0, welcomer.Itoa(int64(giveaway.MinimumMessageCount)), ""),
+								Style:       discord.InteractionComponentStyleShort,
+								Required:    new(false),
+							},
+						},
+						{
+							Type:        discord.InteractionComponentTypeLabel,
+							Label:       "Minimum Voice Time",
+							Description: "Users must have spent at least this long in voice channels. Ignored if empty. e.g. 1h, 30m.",
 							Component: &discord.InteractionComponent{
-								CustomID:  giveawaySetupMenuRolesAllowedIncludedKey,
-								Type:      discord.InteractionComponentTypeRoleSelect,
-								Required:  new(false),
-								MaxValues: new(int32(25)),
+								CustomID:    giveawaySetupMenuMinimumVoiceKey,
+								Type:        discord.InteractionComponentTypeTextInput,
+								Placeholder: "2h 30m",
+								Value:       welcomer.If(giveaway.MinimumVoiceMinutes > 0, welcomer.SecondsToDurationString(int(giveaway.MinimumVoiceMinutes)*60), ""),
+								Style:       discord.InteractionComponentStyleShort,
+								Required:    new(false),
 							},
 						},
 						{
 							Type:        discord.InteractionComponentTypeLabel,
-							Label:       "Roles Excluded from Entering",
-							Description: "Users with any of these roles cannot enter. Ignored if empty.",
+							Label:       "Activity Period",
+							Description: "Only count messages and voice time within this period before entering. All time if empty. e.g. 7d, 30d.",
 							Component: &discord.InteractionComponent{
-								CustomID:  giveawaySetupMenuRolesAllowedExcludedKey,
-								Type:      discord.InteractionComponentTypeRoleSelect,
-								Required:  new(false),
-								MaxValues: new(int32(25)),
+								CustomID:    giveawaySetupMenuActivityPeriodKey,
+								Type:        discord.InteractionComponentTypeTextInput,
+								Placeholder: "30d",
+								Value:       welcomer.If(giveaway.ActivityPeriodDays > 0, fmt.Sprintf("%dd", giveaway.ActivityPeriodDays), ""),
+								Style:       discord.InteractionComponentStyleShort,
+								Required:    new(false),
 							},
 						},
 					},
 				},
 				Type: discord.InteractionCallbackTypeModal,
 			}, nil
-		case giveawaySetupMenuMinimumJoinDateKey:
+		case giveawaySetupMenuRecurrenceKey:
 			return &discord.InteractionResponse{
 				Data: &discord.InteractionCallbackData{
-					Title:    "Edit Giveaway Minimum Join Date",
+					Title:    "Edit Giveaway Recurrence",
 					CustomID: interaction.Data.CustomID,
 					Components: []discord.InteractionComponent{
+						{
+							Type:    discord.InteractionComponentTypeTextDisplay,
+							Content: "When a recurring giveaway ends, a new giveaway with the same settings and duration will be scheduled in the same channel.\n\nUse `daily`, `weekly`, `monthly` or a cron expression in UTC, such as `0 18 * * 5` for every Friday at 18:00.",
+						},
 						{
 							Type:        discord.InteractionComponentTypeLabel,
-							Label:       "Minimum Join Date",
-							Description: "Users joined within the duration specified cannot enter. Ignored if empty. e.g. 1h, 30m, 2d. ",
+							Label:       "Recurrence",
+							Description: "Leave empty if the giveaway should not repeat.",
 							Component: &discord.InteractionComponent{
-								CustomID:    giveawaySetupMenuMinimumJoinDateKey,
+								CustomID:    giveawaySetupMenuRecurrenceKey,
 								Type:        discord.InteractionComponentTypeTextInput,
-								Placeholder: "7d 3h 60m",
+								Placeholder: "weekly",
+								Value:       giveaway.RecurrenceRule,
 								Style:       discord.InteractionComponentStyleShort,
 								Required:    new(false),
 							},
@@ -1083,6 +1830,18 @@ func handleGiveawayEditComponent(ctx context.Context, sub *subway.Subway, intera
 								MaxValues: new(int32(25)),
 							},
 						},
+						{
+							Type:        discord.InteractionComponentTypeLabel,
+							Label:       "Start In",
+							Description: "Schedule the giveaway to start later. Starts immediately if empty. e.g. 1h, 30m, 2d.",
+							Component: &discord.InteractionComponent{
+								CustomID:    giveawaySetupMenuStartDelayKey,
+								Type:        discord.InteractionComponentTypeTextInput,
+								Placeholder: "1d 12h",
+								Style:       discord.InteractionComponentStyleShort,
+								Required:    new(false),
+							},
+						},
 					},
 				},
 				Type: discord.InteractionCallbackTypeModal,
@@ -1094,7 +1853,7 @@ func handleGiveawayEditComponent(ctx context.Context, sub *subway.Subway, intera
 				giveaway.EndTime = time.Now().Add(time.Duration(giveaway.EndTime.Unix()) * time.Second)
 			}
 
-			message := giveawayView(giveaway, 0)
+			message := welcomer.GetGiveawayMessage(giveaway, 0)
 
 			// Hack to disable giveaway button and add back button
 			message.Components[len(message.Components)-1].Components[0].Disabled = true
@@ -1150,7 +1909,7 @@ func handleGiveawayEditComponent(ctx context.Context, sub *subway.Subway, intera
 			}
 
 			if prizesArgument, err := subway.GetArgument(ctx, giveawaySetupMenuPrizesKey); err == nil {
-				prizes := parsePrizesFromString(prizesArgument.MustString())
+				prizes := mergeGiveawayPrizeDelivery(parsePrizesFromString(prizesArgument.MustString()), welcomer.UnmarshalGiveawayPrizeJSON(giveaway.GiveawayPrizes.Bytes))
 				giveaway.GiveawayPrizes = pgtype.JSONB{
 					Bytes:  welcomer.MarshalGiveawayPrizeJSON(prizes),
 					Status: pgtype.Present,
@@ -1225,7 +1984,7 @@ func handleGiveawayEditComponent(ctx context.Context, sub *subway.Subway, intera
 			}
 		case giveawaySetupMenuPrizesKey:
 			if prizesArgument, err := subway.GetArgument(ctx, giveawaySetupMenuPrizesKey); err == nil {
-				prizes := parsePrizesFromString(prizesArgument.MustString())
+				prizes := mergeGiveawayPrizeDelivery(parsePrizesFromString(prizesArgument.MustString()), welcomer.UnmarshalGiveawayPrizeJSON(giveaway.GiveawayPrizes.Bytes))
 				giveaway.GiveawayPrizes = pgtype.JSONB{
 					Bytes:  welcomer.MarshalGiveawayPrizeJSON(prizes),
 					Status: pgtype.Present,
@@ -1236,6 +1995,159 @@ func handleGiveawayEditComponent(ctx context.Context, sub *subway.Subway, intera
 					Status: pgtype.Null,
 				}
 			}
+		case giveawaySetupMenuPrizeDeliveryKey:
+			prizes := welcomer.UnmarshalGiveawayPrizeJSON(giveaway.GiveawayPrizes.Bytes)
+
+			prizeIndex := -1
+
+			if prizeArgument, err := subway.GetArgument(ctx, giveawaySetupMenuPrizeDeliveryPrizeKey); err == nil && len(prizeArgument.MustStrings()) > 0 {
+				if index, err := strconv.Atoi(prizeArgument.MustStrings()[0]); err == nil {
+					prizeIndex = index
+				}
+			}
+
+			if prizeIndex < 0 || prizeIndex >= len(prizes) {
+				return nil, nil
+			}
+
+			prize := prizes[prizeIndex]
+			prize.Type = welcomer.GiveawayPrizeTypeManual
+			prize.RoleID = 0
+			prize.Duration = 0
+
+			if typeArgument, err := subway.GetArgument(ctx, giveawaySetupMenuPrizeDeliveryTypeKey); err == nil && len(typeArgument.MustStrings()) > 0 {
+				prize.Type = welcomer.GiveawayPrizeType(typeArgument.MustStrings()[0])
+			}
+
+			if durationArgument, err := subway.GetArgument(ctx, giveawaySetupMenuPrizeDeliveryDurationKey); err == nil && strings.TrimSpace(durationArgument.MustString()) != "" {
+				seconds, err := welcomer.ParseDurationAsSeconds(durationArgument.MustString())
+				if err != nil || seconds <= 0 {
+					return &discord.InteractionResponse{
+						Type: discord.InteractionCallbackTypeChannelMessageSource,
+						Data: &discord.InteractionCallbackData{
+							Embeds: welcomer.NewEmbed("The duration you entered is not valid. Only years, days, hours and minutes are supported, e.g. 7d 12h.", welcomer.EmbedColourError),
+							Flags:  uint32(discord.MessageFlagEphemeral),
+						},
+					}, nil
+				}
+
+				prize.Duration = int64(seconds)
+			}
+
+			switch prize.Type {
+			case welcomer.GiveawayPrizeTypeManual:
+				prize.Duration = 0
+			case welcomer.GiveawayPrizeTypeRole:
+				var roleID int64
+
+				if roleArgument, err := subway.GetArgument(ctx, giveawaySetupMenuPrizeDeliveryRoleKey); err == nil && len(roleArgument.MustStrings()) > 0 {
+					roleID, _ = welcomer.Atoi(roleArgument.MustStrings()[0])
+				}
+
+				if roleID == 0 {
+					return &discord.InteractionResponse{
+						Type: discord.InteractionCallbackTypeChannelMessageSource,
+						Data: &discord.InteractionCallbackData{
+							Embeds: welcomer.NewEmbed("Please select a role to assign to winners of this prize.", welcomer.EmbedColourError),
+							Flags:  uint32(discord.MessageFlagEphemeral),
+						},
+					}, nil
+				}
+
+				canAssignRoles, isRoleAssignable, isRoleElevated, err := welcomer.Accelerator_CanAssignRole(ctx, *interaction.GuildID, &discord.Role{ID: discord.Snowflake(roleID)})
+				if err != nil {
+					welcomer.Logger.Error().Err(err).
+						Int64("guild_id", int64(*interaction.GuildID)).
+						Msg("Failed to check if welcomer can assign role")
+
+					return nil, err
+				}
+
+				if !canAssignRoles {
+					return &discord.InteractionResponse{
+						Type: discord.InteractionCallbackTypeChannelMessageSource,
+						Data: &discord.InteractionCallbackData{
+							Embeds: welcomer.NewEmbed("Welcomer is missing permissions to assign roles", welcomer.EmbedColourError),
+							Flags:  uint32(discord.MessageFlagEphemeral),
+						},
+					}, nil
+				}
+
+				if !isRoleAssignable {
+					return &discord.InteractionResponse{
+						Type: discord.InteractionCallbackTypeChannelMessageSource,
+						Data: &discord.InteractionCallbackData{
+							Embeds: welcomer.NewEmbed("### This role is not assignable\nWelcomer cannot assign users this role as it does not have permission to manage roles or Welcomer's highest role is below this role's position. Please rearrange your roles in the server settings to move Welcomer's role above this role.", welcomer.EmbedColourError),
+							Flags:  uint32(discord.MessageFlagEphemeral),
+						},
+					}, nil
+				}
+
+				if isRoleElevated {
+					return &discord.InteractionResponse{
+						Type: discord.InteractionCallbackTypeChannelMessageSource,
+						Data: &discord.InteractionCallbackData{
+							Embeds: welcomer.NewEmbed("### This role is elevated\nThis role has elevated permissions and cannot be given away as a prize.", welcomer.EmbedColourError),
+							Flags:  uint32(discord.MessageFlagEphemeral),
+						},
+					}, nil
+				}
+
+				prize.RoleID = discord.Snowflake(roleID)
+			case welcomer.GiveawayPrizeTypeCode:
+				prize.Duration = 0
+
+				if codesArgument, err := subway.GetArgument(ctx, giveawaySetupMenuPrizeDeliveryCodesKey); err == nil {
+					for line := range strings.SplitSeq(codesArgument.MustString(), "\n") {
+						code := strings.TrimSpace(line)
+						if code == "" {
+							continue
+						}
+
+						encryptedCode, err := welcomer.EncryptGiveawayPrizeCode(code)
+						if err != nil {
+							welcomer.Logger.Error().Err(err).
+								Str("giveaway_uuid", giveaway.GiveawayUuid.String()).
+								Msg("Failed to encrypt giveaway prize code")
+
+							return nil, err
+						}
+
+						_, err = welcomer.Queries.CreateGiveawayPrizeCode(ctx, database.CreateGiveawayPrizeCodeParams{
+							GiveawayUuid: welcomer.GetGiveawayPrizeCodePool(giveaway),
+							Prize:        prize.Title,
+							Code:         encryptedCode,
+						})
+						if err != nil {
+							welcomer.Logger.Error().Err(err).
+								Str("giveaway_uuid", giveaway.GiveawayUuid.String()).
+								Msg("Failed to create giveaway prize code")
+
+							return nil, err
+						}
+					}
+				}
+			case welcomer.GiveawayPrizeTypeMembership:
+				// Memberships are paid for, so only Welcomer staff can give them away.
+				if !slices.Contains(welcomer.ElevatedUsers, interaction.GetUser().ID) {
+					return &discord.InteractionResponse{
+						Type: discord.InteractionCallbackTypeChannelMessageSource,
+						Data: &discord.InteractionCallbackData{
+							Embeds: welcomer.NewEmbed("You do not have permission to give away Welcomer Pro.", welcomer.EmbedColourError),
+							Flags:  uint32(discord.MessageFlagEphemeral),
+						},
+					}, nil
+				}
+			default:
+				return nil, nil
+			}
+
+			prizes[prizeIndex] = prize
+
+			giveaway.GiveawayPrizes = pgtype.JSONB{
+				Bytes:  welcomer.MarshalGiveawayPrizeJSON(prizes),
+				Status: pgtype.Present,
+			}
 		case giveawaySetupMenuDurationKey:
 			if durationArgument, err := subway.GetArgument(ctx, giveawaySetupMenuDurationKey); err == nil {
 				seconds, err := welcomer.ParseDurationAsSeconds(durationArgument.MustString())
@@ -1252,6 +2164,22 @@ func handleGiveawayEditComponent(ctx context.Context, sub *subway.Subway, intera
 			} else {
 				giveaway.EndTime = time.Time{}
 			}
+		case giveawaySetupMenuClaimWindowKey:
+			if claimWindowArgument, err := subway.GetArgument(ctx, giveawaySetupMenuClaimWindowKey); err == nil && strings.TrimSpace(claimWindowArgument.MustString()) != "" {
+				seconds, err := welcomer.ParseDurationAsSeconds(claimWindowArgument.MustString())
+				if err != nil || seconds < 0 {
+					welcomer.Logger.Error().Err(err).
+						Int64("guild_id", int64(*interaction.GuildID)).
+						Str("claim_window", claimWindowArgument.MustString()).
+						Msg("Failed to parse claim window")
+
+					return nil, nil
+				}
+
+				giveaway.ClaimWindow = int64(seconds)
+			} else {
+				giveaway.ClaimWindow = 0
+			}
 		case giveawaySetupMenuRolesAllowedKey:
 			if allowedRoles, err := subway.GetArgument(ctx, giveawaySetupMenuRolesAllowedIncludedKey); err == nil {
 				allowedRolesList := make([]discord.Snowflake, 0, len(allowedRoles.MustStrings()))
@@ -1310,6 +2238,89 @@ func handleGiveawayEditComponent(ctx context.Context, sub *subway.Subway, intera
 			} else {
 				giveaway.MinimumJoinDate = time.Time{}
 			}
+		case giveawaySetupMenuActivityRequirementsKey:
+			if accountAgeArgument, err := subway.GetArgument(ctx, giveawaySetupMenuMinimumAccountAgeKey); err == nil && accountAgeArgument.MustString() != "" {
+				seconds, err := welcomer.ParseDurationAsSeconds(accountAgeArgument.MustString())
+				if err != nil || seconds < 0 {
+					welcomer.Logger.Error().Err(err).
+						Int64("guild_id", int64(*interaction.GuildID)).
+						Str("duration", accountAgeArgument.MustString()).
+						Msg("Failed to parse duration")
+
+					return nil, nil
+				}
+
+				giveaway.MinimumAccountAge = int64(seconds)
+			} else {
+				giveaway.MinimumAccountAge = 0
+			}
+
+			if messagesArgument, err := subway.GetArgument(ctx, giveawaySetupMenuMinimumMessagesKey); err == nil && messagesArgument.MustString() != "" {
+				messages, err := welcomer.Atoi(strings.TrimSpace(messagesArgument.MustString()))
+				if err != nil || messages < 0 {
+					welcomer.Logger.Error().Err(err).
+						Int64("guild_id", int64(*interaction.GuildID)).
+						Str("minimum_messages", messagesArgument.MustString()).
+						Msg("Failed to parse minimum messages")
+
+					return nil, nil
+				}
+
+				giveaway.MinimumMessageCount = int32(messages)
+			} else {
+				giveaway.MinimumMessageCount = 0
+			}
+
+			if voiceArgument, err := subway.GetArgument(ctx, giveawaySetupMenuMinimumVoiceKey); err == nil && voiceArgument.MustString() != "" {
+				seconds, err := welcomer.ParseDurationAsSeconds(voiceArgument.MustString())
+				if err != nil || seconds < 0 {
+					welcomer.Logger.Error().Err(err).
+						Int64("guild_id", int64(*interaction.GuildID)).
+						Str("duration", voiceArgument.MustString()).
+						Msg("Failed to parse duration")
+
+					return nil, nil
+				}
+
+				giveaway.MinimumVoiceMinutes = int32(seconds / 60)
+			} else {
+				giveaway.MinimumVoiceMinutes = 0
+			}
+
+			if periodArgument, err := subway.GetArgument(ctx, giveawaySetupMenuActivityPeriodKey); err == nil && periodArgument.MustString() != "" {
+				seconds, err := welcomer.ParseDurationAsSeconds(periodArgument.MustString())
+				if err != nil || seconds < 0 {
+					welcomer.Logger.Error().Err(err).
+						Int64("guild_id", int64(*interaction.GuildID)).
+						Str("duration", periodArgument.MustString()).
+						Msg("Failed to parse duration")
+
+					return nil, nil
+				}
+
+				// Round up to the nearest day as activity is only tracked hourly.
+				giveaway.ActivityPeriodDays = int32((seconds + 86399) / 86400)
+			} else {
+				giveaway.ActivityPeriodDays = 0
+			}
+		case giveawaySetupMenuRecurrenceKey:
+			if recurrenceArgument, err := subway.GetArgument(ctx, giveawaySetupMenuRecurrenceKey); err == nil && strings.TrimSpace(recurrenceArgument.MustString()) != "" {
+				rule := strings.ToLower(strings.TrimSpace(recurrenceArgument.MustString()))
+
+				if _, err := welcomer.ParseGiveawayRecurrence(rule); err != nil {
+					return &discord.InteractionResponse{
+						Type: discord.InteractionCallbackTypeChannelMessageSource,
+						Data: &discord.InteractionCallbackData{
+							Embeds: welcomer.NewEmbed("The recurrence you entered is not valid. Use `daily`, `weekly`, `monthly` or a cron expression such as `0 18 * * 5`.", welcomer.EmbedColourError),
+							Flags:  uint32(discord.MessageFlagEphemeral),
+						},
+					}, nil
+				}
+
+				giveaway.RecurrenceRule = rule
+			} else {
+				giveaway.RecurrenceRule = ""
+			}
 		case giveawaySetupMenuStartKey:
 			var pingOptions []string
 
@@ -1317,28 +2328,28 @@ func handleGiveawayEditComponent(ctx context.Context, sub *subway.Subway, intera
 				pingOptions = pingOptionsArgument.MustStrings()
 			}
 
-			giveaway.StartTime = time.Now()
-
-			if giveaway.EndTime.Unix() > 0 {
-				giveaway.EndTime = time.Now().Add(time.Duration(giveaway.EndTime.Unix()) * time.Second)
-			}
+			var startDelay int
 
-			giveaway.IsSetup = false
+			if startDelayArgument, err := subway.GetArgument(ctx, giveawaySetupMenuStartDelayKey); err == nil && startDelayArgument.MustString() != "" {
+				startDelay, err = welcomer.ParseDurationAsSeconds(startDelayArgument.MustString())
+				if err != nil || startDelay < 0 {
+					welcomer.Logger.Error().Err(err).
+						Int64("guild_id", int64(*interaction.GuildID)).
+						Str("duration", startDelayArgument.MustString()).
+						Msg("Failed to parse duration")
 
-			session, err := welcomer.AcquireSession(ctx, welcomer.GetManagerNameFromContext(ctx))
-			if err != nil {
-				return nil, err
+					return nil, nil
+				}
 			}
 
-			message, err := interaction.Channel.Send(ctx, session, welcomer.WebhookMessageParamsToMessageParams(giveawayView(giveaway, 0)))
-			if err != nil {
-				welcomer.Logger.Error().Err(err).
-					Int64("guild_id", int64(*interaction.GuildID)).
-					Msg("Failed to send giveaway message")
+			giveaway.StartTime = time.Now().Add(time.Duration(startDelay) * time.Second)
 
-				return nil, err
+			if giveaway.EndTime.Unix() > 0 {
+				giveaway.EndTime = giveaway.StartTime.Add(time.Duration(giveaway.EndTime.Unix()) * time.Second)
 			}
 
+			giveaway.IsSetup = false
+
 			pingMessage := ""
 
 			if len(pingOptions) > 0 {
@@ -1369,9 +2380,54 @@ func handleGiveawayEditComponent(ctx context.Context, sub *subway.Subway, intera
 				}
 			}
 
-			if pingMessage != "" {
+			// Stored so scheduled and recurring giveaways ping when they start.
+			giveaway.PingContent = strings.TrimSpace(pingMessage)
+
+			if startDelay > 0 {
+				// Scheduled giveaways are sent by the finish-giveaways job once the start time has passed.
+				_, err = welcomer.Queries.UpdateGiveawayMessage(ctx, database.UpdateGiveawayMessageParams{
+					GiveawayUuid: giveawayUUID,
+					MessageID:    0,
+					ChannelID:    int64(interaction.Channel.ID),
+				})
+				if err != nil {
+					welcomer.Logger.Error().Err(err).
+						Int64("guild_id", int64(*interaction.GuildID)).
+						Str("giveaway_uuid", giveaway.GiveawayUuid.String()).
+						Msg("Failed to update giveaway channel")
+
+					return nil, err
+				}
+
+				break
+			}
+
+			session, err := welcomer.AcquireSession(ctx, welcomer.GetManagerNameFromContext(ctx))
+			if err != nil {
+				return nil, err
+			}
+
+			if err = welcomer.PrepareProvablyFairGiveaway(ctx, giveaway); err != nil {
+				welcomer.Logger.Error().Err(err).
+					Int64("guild_id", int64(*interaction.GuildID)).
+					Str("giveaway_uuid", giveaway.GiveawayUuid.String()).
+					Msg("Failed to prepare provably fair giveaway")
+
+				return nil, err
+			}
+
+			message, err := interaction.Channel.Send(ctx, session, welcomer.WebhookMessageParamsToMessageParams(welcomer.GetGiveawayMessage(giveaway, 0)))
+			if err != nil {
+				welcomer.Logger.Error().Err(err).
+					Int64("guild_id", int64(*interaction.GuildID)).
+					Msg("Failed to send giveaway message")
+
+				return nil, err
+			}
+
+			if giveaway.PingContent != "" {
 				_, err = interaction.Channel.Send(ctx, session, discord.MessageParams{
-					Content: pingMessage,
+					Content: giveaway.PingContent,
 				})
 				if err != nil {
 					welcomer.Logger.Error().Err(err).
@@ -1438,6 +2494,16 @@ func handleGiveawayEditComponent(ctx context.Context, sub *subway.Subway, intera
 		ShowEntries:     giveaway.ShowEntries,
 		AllowEntries:    giveaway.AllowEntries,
 		HasEnded:        giveaway.HasEnded,
+
+		MinimumAccountAge:   giveaway.MinimumAccountAge,
+		MinimumMessageCount: giveaway.MinimumMessageCount,
+		MinimumVoiceMinutes: giveaway.MinimumVoiceMinutes,
+		ActivityPeriodDays:  giveaway.ActivityPeriodDays,
+
+		RecurrenceRule: giveaway.RecurrenceRule,
+		PingContent:    giveaway.PingContent,
+		ProvablyFair:   giveaway.ProvablyFair,
+		ClaimWindow:    giveaway.ClaimWindow,
 	}, interaction.GetUser().ID, *interaction.GuildID)
 	if err != nil {
 		welcomer.Logger.Error().Err(err).
@@ -1462,8 +2528,12 @@ func handleGiveawayEditComponent(ctx context.Context, sub *subway.Subway, intera
 						Type: discord.InteractionComponentTypeContainer,
 						Components: []discord.InteractionComponent{
 							{
-								Type:    discord.InteractionComponentTypeTextDisplay,
-								Content: "Your giveaway has now started!\n\nYou can manage your giveaways settings such as disabling entries, extending the duration or ending the giveaway early by right clicking the giveaway message and selecting \"Manage Giveaway\".",
+								Type: discord.InteractionComponentTypeTextDisplay,
+								Content: welcomer.If(
+									giveaway.StartTime.After(time.Now()),
+									"Your giveaway has been scheduled and will start <t:"+welcomer.Itoa(giveaway.StartTime.Unix())+":R>.",
+									"Your giveaway has now started!",
+								) + "\n\nYou can manage your giveaways settings such as disabling entries, extending the duration or ending the giveaway early by right clicking the giveaway message and selecting \"Manage Giveaway\".",
 							},
 							{
 								Type: discord.InteractionComponentTypeMediaGallery,
@@ -1491,9 +2561,38 @@ func handleGiveawayEditComponent(ctx context.Context, sub *subway.Subway, intera
 		return nil, err
 	}
 
-	return &discord.InteractionResponse{
-		Type: discord.InteractionCallbackTypeDeferredUpdateMessage,
-	}, nil
+	return &discord.InteractionResponse{
+		Type: discord.InteractionCallbackTypeDeferredUpdateMessage,
+	}, nil
+}
+
+func getGiveawayVerificationEmbed(giveaway *database.GuildGiveaways, verification *welcomer.GiveawayDrawVerification) discord.Embed {
+	description := fmt.Sprintf("**%s**\n\n**Seed Commitment:** `%s`\n", welcomer.Coalesce(giveaway.Title, "New Giveaway"), verification.SeedCommitment)
+
+	if !verification.IsRevealed {
+		return discord.Embed{
+			Description: description + "\nThe server seed is revealed when the giveaway ends. Check back once the giveaway has ended to verify the winners.",
+			Color:       welcomer.EmbedColourInfo,
+		}
+	}
+
+	description += fmt.Sprintf("**Server Seed:** `%s`\n**Entries:** %d\n\n", verification.ServerSeed, len(verification.Entries))
+
+	description += welcomer.If(verification.CommitmentValid, "✅ The server seed matches the commitment.\n", "❌ The server seed does not match the commitment.\n")
+	description += welcomer.If(verification.WinnersValid, "✅ The winners match the draw.\n", "❌ The winners do not match the draw.\n")
+
+	if len(verification.ExpectedWinners) > 0 {
+		description += "\n**Drawn Winners:**\n"
+
+		for _, winner := range verification.ExpectedWinners {
+			description += fmt.Sprintf("<@%d> - %s\n", winner.UserID, winner.Prize)
+		}
+	}
+
+	return discord.Embed{
+		Description: description,
+		Color:       int32(welcomer.If(verification.CommitmentValid && verification.WinnersValid, welcomer.EmbedColourSuccess, welcomer.EmbedColourError)),
+	}
 }
 
 func joinRolesList(roles []discord.Snowflake) string {
@@ -1510,106 +2609,46 @@ func joinRolesList(roles []discord.Snowflake) string {
 	return result
 }
 
-func getGiveawayPrizesAsString(giveawayPrizes []welcomer.GiveawayPrize) string {
-	result := "**Prizes:**\n"
-
-	if len(giveawayPrizes) == 0 {
-		result += "No Prizes Configured"
-
-		return result
-	}
-
-	for i, prize := range giveawayPrizes {
-		result += fmt.Sprintf("**%d** x **%s**", prize.Count, prize.Title)
+func getGiveawayActivityRequirementsAsString(giveaway *database.GuildGiveaways) string {
+	requirements := make([]string, 0)
 
-		if i < len(giveawayPrizes)-1 {
-			result += "\n"
-		}
+	if giveaway.MinimumAccountAge > 0 {
+		requirements = append(requirements, "Account older than "+welcomer.HumanizeDuration(int(giveaway.MinimumAccountAge), true))
 	}
 
-	return result
-}
-
-func giveawayView(giveaway *database.GuildGiveaways, entries int32) discord.WebhookMessageParams {
-	giveawayPrizes := welcomer.UnmarshalGiveawayPrizeJSON(giveaway.GiveawayPrizes.Bytes)
+	periodString := welcomer.If(giveaway.ActivityPeriodDays > 0, fmt.Sprintf(" in the last %d day%s", giveaway.ActivityPeriodDays, welcomer.If(giveaway.ActivityPeriodDays == 1, "", "s")), "")
 
-	containerComponents := []discord.InteractionComponent{
-		{
-			Type:    discord.InteractionComponentTypeTextDisplay,
-			Content: "**" + welcomer.Coalesce(giveaway.Title, "New Giveaway") + "**\n" + giveaway.Description,
-		},
+	if giveaway.MinimumMessageCount > 0 {
+		requirements = append(requirements, fmt.Sprintf("At least %d messages%s", giveaway.MinimumMessageCount, periodString))
 	}
 
-	if giveaway.ImageUrl != "" {
-		containerComponents = append(containerComponents, discord.InteractionComponent{
-			Type: discord.InteractionComponentTypeMediaGallery,
-			Items: []discord.InteractionComponentMediaGalleryItem{
-				{
-					Media: discord.MediaItem{
-						URL: giveaway.ImageUrl,
-					},
-				},
-			},
-		})
+	if giveaway.MinimumVoiceMinutes > 0 {
+		requirements = append(requirements, fmt.Sprintf("At least %s in voice%s", welcomer.HumanizeDuration(int(giveaway.MinimumVoiceMinutes)*60, false), periodString))
 	}
 
-	if giveaway.ShowPrizes {
-		containerComponents = append(containerComponents, []discord.InteractionComponent{
-			{
-				Type: discord.InteractionComponentTypeSeparator,
-			},
-			{
-				Type:    discord.InteractionComponentTypeTextDisplay,
-				Content: getGiveawayPrizesAsString(giveawayPrizes),
-			},
-		}...)
+	if len(requirements) == 0 {
+		return "None"
 	}
 
-	containerComponents = append(containerComponents, []discord.InteractionComponent{
-		{
-			Type: discord.InteractionComponentTypeSeparator,
-		},
-		{
-			Type: discord.InteractionComponentTypeTextDisplay,
-			Content: "**Giveaway Ends:** " + welcomer.If(giveaway.EndTime.Unix() > 0, "<t:"+welcomer.Itoa(giveaway.EndTime.Unix())+":R> (<t:"+welcomer.Itoa(giveaway.EndTime.Unix())+":f>)", "No end time (runs indefinitely)") +
-				"\n" + welcomer.If(giveaway.ShowEntries, fmt.Sprintf("**Entries:** %d", entries), ""),
-		},
-	}...)
+	return strings.Join(requirements, "\n")
+}
 
-	message := discord.WebhookMessageParams{
-		Components: []discord.InteractionComponent{
-			{
-				Type:    discord.InteractionComponentTypeTextDisplay,
-				Content: fmt.Sprintf("-# <@%d> has started a new giveaway!", giveaway.CreatedBy),
-			},
-			{
-				Type:        discord.InteractionComponentTypeContainer,
-				AccentColor: new(uint32(welcomer.If(giveaway.AccentColour >= 0, giveaway.AccentColour, welcomer.EmbedColourInfo))),
-				Components:  containerComponents,
-			},
-			{
-				Type: discord.InteractionComponentTypeActionRow,
-				Components: []discord.InteractionComponent{
-					{
-						Type:     discord.InteractionComponentTypeButton,
-						Style:    discord.InteractionComponentStyleSuccess,
-						CustomID: "giveaway_enter:" + giveaway.GiveawayUuid.String(),
-						Label:    "Enter Giveaway",
-						Disabled: !giveaway.AllowEntries && !giveaway.IsSetup,
-						Emoji: &discord.Emoji{
-							Name: "🎉",
-						},
-					},
-				},
-			},
-		},
-		Flags: discord.MessageFlagIsComponentsV2,
+func getGiveawayRecurrenceAsString(rule string) string {
+	switch rule {
+	case "":
+		return "Does not repeat"
+	case welcomer.GiveawayRecurrenceDaily:
+		return "Every day"
+	case welcomer.GiveawayRecurrenceWeekly:
+		return "Every week"
+	case welcomer.GiveawayRecurrenceMonthly:
+		return "Every month"
+	default:
+		return "`" + rule + "` (UTC)"
 	}
-
-	return message
 }
 
-func giveawayManageView(giveaway *database.GuildGiveaways) discord.WebhookMessageParams {
+func giveawayManageView(giveaway *database.GuildGiveaways, winners []*database.GuildGiveawaysWinners) discord.WebhookMessageParams {
 	customIDPrefix := "giveaway_manage:" + giveaway.GiveawayUuid.String() + ":"
 
 	return discord.WebhookMessageParams{
@@ -1670,22 +2709,40 @@ func giveawayManageView(giveaway *database.GuildGiveaways) discord.WebhookMessag
 					{
 						Type: discord.InteractionComponentTypeSeparator,
 					},
-					{
-						Type: discord.InteractionComponentTypeSection,
-						Components: []discord.InteractionComponent{
-							{
-								Type:    discord.InteractionComponentTypeTextDisplay,
-								Content: "**End Giveaway**",
+					welcomer.If(isGiveawayScheduled(giveaway),
+						discord.InteractionComponent{
+							Type: discord.InteractionComponentTypeSection,
+							Components: []discord.InteractionComponent{
+								{
+									Type: discord.InteractionComponentTypeTextDisplay,
+									Content: "**Cancel Giveaway**\n" +
+										"-# This giveaway starts <t:" + welcomer.Itoa(giveaway.StartTime.Unix()) + ":R>. Cancelling deletes it so it never starts.",
+								},
+							},
+							Accessory: &discord.InteractionComponent{
+								Type:     discord.InteractionComponentTypeButton,
+								Style:    discord.InteractionComponentStyleDanger,
+								Label:    "Cancel Giveaway",
+								CustomID: customIDPrefix + giveawayManageMenuCancelScheduledKey,
 							},
 						},
-						Accessory: &discord.InteractionComponent{
-							Type:     discord.InteractionComponentTypeButton,
-							Style:    discord.InteractionComponentStyleDanger,
-							Label:    "End Giveaway",
-							CustomID: customIDPrefix + giveawayManageMenuEndGiveawayKey,
-							Disabled: giveaway.HasEnded,
+						discord.InteractionComponent{
+							Type: discord.InteractionComponentTypeSection,
+							Components: []discord.InteractionComponent{
+								{
+									Type:    discord.InteractionComponentTypeTextDisplay,
+									Content: "**End Giveaway**",
+								},
+							},
+							Accessory: &discord.InteractionComponent{
+								Type:     discord.InteractionComponentTypeButton,
+								Style:    discord.InteractionComponentStyleDanger,
+								Label:    "End Giveaway",
+								CustomID: customIDPrefix + giveawayManageMenuEndGiveawayKey,
+								Disabled: giveaway.HasEnded,
+							},
 						},
-					},
+					),
 					{
 						Type: discord.InteractionComponentTypeSeparator,
 					},
@@ -1728,6 +2785,46 @@ func giveawayManageView(giveaway *database.GuildGiveaways) discord.WebhookMessag
 					{
 						Type: discord.InteractionComponentTypeSeparator,
 					},
+					{
+						Type: discord.InteractionComponentTypeSection,
+						Components: []discord.InteractionComponent{
+							{
+								Type: discord.InteractionComponentTypeTextDisplay,
+								Content: "**Recurrence**:\n" + getGiveawayRecurrenceAsString(giveaway.RecurrenceRule) + "\n" +
+									"-# Stops a new giveaway being scheduled when this giveaway ends.",
+							},
+						},
+						Accessory: &discord.InteractionComponent{
+							Type:     discord.InteractionComponentTypeButton,
+							Style:    discord.InteractionComponentStyleDanger,
+							Label:    "Stop Recurring",
+							CustomID: customIDPrefix + giveawayManageMenuStopRecurringKey,
+							Disabled: giveaway.RecurrenceRule == "",
+						},
+					},
+					{
+						Type: discord.InteractionComponentTypeSeparator,
+					},
+					{
+						Type: discord.InteractionComponentTypeSection,
+						Components: []discord.InteractionComponent{
+							{
+								Type: discord.InteractionComponentTypeTextDisplay,
+								Content: "**Prize Delivery**:\n" + getGiveawayDeliveryFailuresAsString(winners) + "\n" +
+									"-# Prizes that could not be delivered automatically. Retrying will attempt to deliver them again.",
+							},
+						},
+						Accessory: &discord.InteractionComponent{
+							Type:     discord.InteractionComponentTypeButton,
+							Style:    discord.InteractionComponentStylePrimary,
+							Label:    "Retry Delivery",
+							CustomID: customIDPrefix + giveawayManageMenuRetryDeliveryKey,
+							Disabled: !slices.ContainsFunc(winners, isGiveawayDeliveryFailed),
+						},
+					},
+					{
+						Type: discord.InteractionComponentTypeSeparator,
+					},
 					// {
 					// 	Type: discord.InteractionComponentTypeTextDisplay,
 					// 	Content: "**Reroll Giveaway Winners**\n" +
@@ -1739,6 +2836,91 @@ func giveawayManageView(giveaway *database.GuildGiveaways) discord.WebhookMessag
 	}
 }
 
+// isGiveawayScheduled returns if a giveaway is waiting to be started, such as
+// the next occurrence of a recurring giveaway. Scheduled giveaways have no message yet.
+func isGiveawayScheduled(giveaway *database.GuildGiveaways) bool {
+	return !giveaway.IsSetup && !giveaway.HasEnded && giveaway.MessageID == 0
+}
+
+func giveawayScheduledView(giveaways []*database.GuildGiveaways) discord.WebhookMessageParams {
+	components := []discord.InteractionComponent{
+		{
+			Type:    discord.InteractionComponentTypeTextDisplay,
+			Content: "### Scheduled Giveaways",
+		},
+	}
+
+	if len(giveaways) == 0 {
+		components = append(components, discord.InteractionComponent{
+			Type:    discord.InteractionComponentTypeTextDisplay,
+			Content: "There are no giveaways waiting to start.",
+		})
+	}
+
+	for _, giveaway := range giveaways[:min(len(giveaways), maxScheduledGiveawaysShown)] {
+		components = append(components,
+			discord.InteractionComponent{
+				Type: discord.InteractionComponentTypeSeparator,
+			},
+			discord.InteractionComponent{
+				Type: discord.InteractionComponentTypeSection,
+				Components: []discord.InteractionComponent{
+					{
+						Type: discord.InteractionComponentTypeTextDisplay,
+						Content: "**" + welcomer.Coalesce(giveaway.Title, "New Giveaway") + "**\n" +
+							"Starts <t:" + welcomer.Itoa(giveaway.StartTime.Unix()) + ":R> in <#" + welcomer.Itoa(giveaway.ChannelID) + ">\n" +
+							"-# " + getGiveawayRecurrenceAsString(giveaway.RecurrenceRule),
+					},
+				},
+				Accessory: &discord.InteractionComponent{
+					Type:     discord.InteractionComponentTypeButton,
+					Style:    discord.InteractionComponentStyleSecondary,
+					Label:    "Manage",
+					CustomID: "giveaway_manage:" + giveaway.GiveawayUuid.String() + ":" + giveawayManageMenuOpenKey,
+				},
+			},
+		)
+	}
+
+	if len(giveaways) > maxScheduledGiveawaysShown {
+		components = append(components, discord.InteractionComponent{
+			Type:    discord.InteractionComponentTypeTextDisplay,
+			Content: fmt.Sprintf("-# and %d more, which can be managed from the dashboard.", len(giveaways)-maxScheduledGiveawaysShown),
+		})
+	}
+
+	return discord.WebhookMessageParams{
+		Components: []discord.InteractionComponent{
+			{
+				Type:       discord.InteractionComponentTypeContainer,
+				Components: components,
+			},
+		},
+	}
+}
+
+func isGiveawayDeliveryFailed(winner *database.GuildGiveawaysWinners) bool {
+	return !winner.IsDelivered && !winner.IsExpired && winner.DeliveryError != ""
+}
+
+func getGiveawayDeliveryFailuresAsString(winners []*database.GuildGiveawaysWinners) string {
+	var failures strings.Builder
+
+	for _, winner := range winners {
+		if !isGiveawayDeliveryFailed(winner) {
+			continue
+		}
+
+		failures.WriteString(fmt.Sprintf("- <@%d> **%s**: %s\n", winner.UserID, winner.Prize, winner.DeliveryError))
+	}
+
+	if failures.Len() == 0 {
+		return "No failed deliveries"
+	}
+
+	return strings.TrimSuffix(failures.String(), "\n")
+}
+
 func giveawaySetupView(giveaway *database.GuildGiveaways) discord.WebhookMessageParams {
 	giveawayPrizes := welcomer.UnmarshalGiveawayPrizeJSON(giveaway.GiveawayPrizes.Bytes)
 	rolesAllowed := welcomer.UnmarshalRolesListJSON(giveaway.RolesAllowed.Bytes)
@@ -1793,7 +2975,7 @@ func giveawaySetupView(giveaway *database.GuildGiveaways) discord.WebhookMessage
 			Components: []discord.InteractionComponent{
 				{
 					Type:    discord.InteractionComponentTypeTextDisplay,
-					Content: getGiveawayPrizesAsString(giveawayPrizes),
+					Content: welcomer.GetGiveawayPrizesAsString(giveawayPrizes),
 				},
 			},
 			Accessory: &discord.InteractionComponent{
@@ -1806,6 +2988,26 @@ func giveawaySetupView(giveaway *database.GuildGiveaways) discord.WebhookMessage
 		{
 			Type: discord.InteractionComponentTypeSeparator,
 		},
+		{
+			Type: discord.InteractionComponentTypeSection,
+			Components: []discord.InteractionComponent{
+				{
+					Type: discord.InteractionComponentTypeTextDisplay,
+					Content: "**Prize Delivery**:\n" + getGiveawayPrizeDeliveryListAsString(giveawayPrizes) +
+						"\n-# Role, code and Welcomer Pro prizes are delivered to winners automatically.",
+				},
+			},
+			Accessory: &discord.InteractionComponent{
+				Type:     discord.InteractionComponentTypeButton,
+				Style:    discord.InteractionComponentStyleSecondary,
+				Label:    "Edit",
+				CustomID: customIDPrefix + giveawaySetupMenuPrizeDeliveryKey,
+				Disabled: len(giveawayPrizes) == 0,
+			},
+		},
+		{
+			Type: discord.InteractionComponentTypeSeparator,
+		},
 		{
 			Type: discord.InteractionComponentTypeSection,
 			Components: []discord.InteractionComponent{
@@ -1845,6 +3047,45 @@ func giveawaySetupView(giveaway *database.GuildGiveaways) discord.WebhookMessage
 		{
 			Type: discord.InteractionComponentTypeSeparator,
 		},
+		{
+			Type: discord.InteractionComponentTypeSection,
+			Components: []discord.InteractionComponent{
+				{
+					Type: discord.InteractionComponentTypeTextDisplay,
+					Content: "**Claim Window**:\n" + welcomer.If(giveaway.ClaimWindow > 0, welcomer.HumanizeDuration(int(giveaway.ClaimWindow), true), "None") +
+						welcomer.If(giveaway.ClaimWindow > 0, "\n-# Winners must claim their prize in time or it will be given to another entry. Requires winners to be announced.", ""),
+				},
+			},
+			Accessory: &discord.InteractionComponent{
+				Type:     discord.InteractionComponentTypeButton,
+				Style:    discord.InteractionComponentStyleSecondary,
+				Label:    "Edit",
+				CustomID: customIDPrefix + giveawaySetupMenuClaimWindowKey,
+			},
+		},
+		{
+			Type: discord.InteractionComponentTypeSeparator,
+		},
+		{
+			Type: discord.InteractionComponentTypeSection,
+			Components: []discord.InteractionComponent{
+				{
+					Type: discord.InteractionComponentTypeTextDisplay,
+					Content: "**Provably Fair**:\n" +
+						welcomer.If(giveaway.ProvablyFair, "True", "False") +
+						"\n-# When enabled, a hash of a secret seed is shown on the giveaway message. When the giveaway ends, the seed is revealed and anyone can check the winners with `/giveaways verify`.",
+				},
+			},
+			Accessory: &discord.InteractionComponent{
+				Type:     discord.InteractionComponentTypeButton,
+				Style:    discord.InteractionComponentStyleSecondary,
+				Label:    welcomer.If(giveaway.ProvablyFair, "Disable", "Enable"),
+				CustomID: customIDPrefix + giveawaySetupMenuProvablyFairKey,
+			},
+		},
+		{
+			Type: discord.InteractionComponentTypeSeparator,
+		},
 		{
 			Type: discord.InteractionComponentTypeSection,
 			Components: []discord.InteractionComponent{
@@ -1879,6 +3120,42 @@ func giveawaySetupView(giveaway *database.GuildGiveaways) discord.WebhookMessage
 				CustomID: customIDPrefix + giveawaySetupMenuMinimumJoinDateKey,
 			},
 		},
+		{
+			Type: discord.InteractionComponentTypeSeparator,
+		},
+		{
+			Type: discord.InteractionComponentTypeSection,
+			Components: []discord.InteractionComponent{
+				{
+					Type:    discord.InteractionComponentTypeTextDisplay,
+					Content: "**Activity Requirements**:\n" + getGiveawayActivityRequirementsAsString(giveaway),
+				},
+			},
+			Accessory: &discord.InteractionComponent{
+				Type:     discord.InteractionComponentTypeButton,
+				Style:    discord.InteractionComponentStyleSecondary,
+				Label:    "Edit",
+				CustomID: customIDPrefix + giveawaySetupMenuActivityRequirementsKey,
+			},
+		},
+		{
+			Type: discord.InteractionComponentTypeSeparator,
+		},
+		{
+			Type: discord.InteractionComponentTypeSection,
+			Components: []discord.InteractionComponent{
+				{
+					Type:    discord.InteractionComponentTypeTextDisplay,
+					Content: "**Recurrence**:\n" + getGiveawayRecurrenceAsString(giveaway.RecurrenceRule),
+				},
+			},
+			Accessory: &discord.InteractionComponent{
+				Type:     discord.InteractionComponentTypeButton,
+				Style:    discord.InteractionComponentStyleSecondary,
+				Label:    "Edit",
+				CustomID: customIDPrefix + giveawaySetupMenuRecurrenceKey,
+			},
+		},
 	}...)
 
 	message := discord.WebhookMessageParams{
@@ -1960,6 +3237,34 @@ func parsePrizesFromString(prizesString string) []welcomer.GiveawayPrize {
 	return prizes
 }
 
+// mergeGiveawayPrizeDelivery keeps the delivery settings of existing prizes
+// with the same title when the list of prizes is edited.
+func mergeGiveawayPrizeDelivery(prizes, existingPrizes []welcomer.GiveawayPrize) []welcomer.GiveawayPrize {
+	for i, prize := range prizes {
+		if existingPrize, ok := welcomer.GetGiveawayPrize(existingPrizes, prize.Title); ok {
+			prizes[i].Type = existingPrize.Type
+			prizes[i].RoleID = existingPrize.RoleID
+			prizes[i].Duration = existingPrize.Duration
+		}
+	}
+
+	return prizes
+}
+
+func getGiveawayPrizeDeliveryListAsString(prizes []welcomer.GiveawayPrize) string {
+	if len(prizes) == 0 {
+		return "No Prizes Configured"
+	}
+
+	lines := make([]string, len(prizes))
+
+	for i, prize := range prizes {
+		lines[i] = fmt.Sprintf("**%s**: %s", prize.Title, welcomer.GetGiveawayPrizeDeliveryAsString(prize))
+	}
+
+	return strings.Join(lines, "\n")
+}
+
 func formatGiveawayPrizesAsString(prizes []welcomer.GiveawayPrize) string {
 	lines := make([]string, len(prizes))
 
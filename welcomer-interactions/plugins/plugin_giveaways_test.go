@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	"github.com/WelcomerTeam/Welcomer/welcomer-core"
+	"github.com/WelcomerTeam/Welcomer/welcomer-core/database"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -99,3 +100,49 @@ func TestParsePrizesFromString(t *testing.T) {
 		})
 	}
 }
+
+func TestGetGiveawayActivityRequirementsAsString(t *testing.T) {
+	testCases := map[string]struct {
+		input    database.GuildGiveaways
+		expected string
+	}{
+		"no requirements": {
+			input:    database.GuildGiveaways{},
+			expected: "None",
+		},
+		"account age only": {
+			input: database.GuildGiveaways{
+				MinimumAccountAge: 86400 * 7,
+			},
+			expected: "Account older than 7 days",
+		},
+		"unset account age": {
+			input: database.GuildGiveaways{
+				MinimumAccountAge:   0,
+				MinimumMessageCount: 50,
+			},
+			expected: "At least 50 messages",
+		},
+		"messages all time": {
+			input: database.GuildGiveaways{
+				MinimumMessageCount: 50,
+			},
+			expected: "At least 50 messages",
+		},
+		"messages and voice with period": {
+			input: database.GuildGiveaways{
+				MinimumMessageCount: 100,
+				MinimumVoiceMinutes: 90,
+				ActivityPeriodDays:  1,
+			},
+			expected: "At least 100 messages in the last 1 day\nAt least 1 hour and 30 minutes in voice in the last 1 day",
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			result := getGiveawayActivityRequirementsAsString(&tc.input)
+			assert.Equal(t, tc.expected, result)
+		})
+	}
+}
@@ -0,0 +1,670 @@
+package plugins
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/WelcomerTeam/Discord/discord"
+	sandwich "github.com/WelcomerTeam/Sandwich-Daemon/proto"
+	subway "github.com/WelcomerTeam/Subway/subway"
+	"github.com/WelcomerTeam/Welcomer/welcomer-core"
+	"github.com/WelcomerTeam/Welcomer/welcomer-core/database"
+	"github.com/jackc/pgx/v4"
+)
+
+func NewInactiveCog() *InactiveCog {
+	return &InactiveCog{
+		InteractionCommands: subway.SetupInteractionCommandable(&subway.InteractionCommandable{}),
+	}
+}
+
+type InactiveCog struct {
+	InteractionCommands *subway.InteractionCommandable
+}
+
+// Assert types.
+
+var (
+	_ subway.Cog                        = (*InactiveCog)(nil)
+	_ subway.CogWithInteractionCommands = (*InactiveCog)(nil)
+)
+
+func (r *InactiveCog) CogInfo() *subway.CogInfo {
+	return &subway.CogInfo{
+		Name:        "Inactive",
+		Description: "Provides the cog for the 'Inactive' feature.",
+	}
+}
+
+func (r *InactiveCog) GetInteractionCommandable() *subway.InteractionCommandable {
+	return r.InteractionCommands
+}
+
+// errInactiveNoHistory is returned when not enough activity has been recorded in the guild to tell which members are inactive.
+var errInactiveNoHistory = errors.New("no activity history")
+
+// inactiveErrorMessage returns the message shown when inactive members could not be found.
+func inactiveErrorMessage(err error, fallback string) string {
+	if errors.Is(err, errInactiveNoHistory) {
+		return fmt.Sprintf("Welcomer has not recorded at least %d day%s of activity in this server yet, so it cannot tell which members are inactive. Please try again later.",
+			welcomer.InactiveMinimumDays, welcomer.If(welcomer.InactiveMinimumDays == 1, "", "s"))
+	}
+
+	return fallback
+}
+
+// inactiveOptions is the list of inactive members being viewed. It is stored in the custom ID of the page buttons.
+type inactiveOptions struct {
+	Days int
+	Page int
+}
+
+func (o inactiveOptions) customID(page int) string {
+	return fmt.Sprintf("inactive:%d:%d", o.Days, page)
+}
+
+func parseInactiveCustomID(customID string) (options inactiveOptions, ok bool) {
+	customIDSplit := strings.Split(customID, ":")
+	if len(customIDSplit) < 3 {
+		return options, false
+	}
+
+	days, err := strconv.Atoi(customIDSplit[1])
+	if err != nil {
+		return options, false
+	}
+
+	page, err := strconv.Atoi(customIDSplit[2])
+	if err != nil {
+		return options, false
+	}
+
+	return inactiveOptions{
+		Days: min(max(days, welcomer.InactiveMinimumDays), welcomer.InactiveMaximumDays),
+		Page: page,
+	}, true
+}
+
+func inactiveResponse(message string, colour int32) *discord.InteractionResponse {
+	return &discord.InteractionResponse{
+		Type: discord.InteractionCallbackTypeChannelMessageSource,
+		Data: &discord.InteractionCallbackData{
+			Embeds: welcomer.NewEmbed(message, colour),
+			Flags:  uint32(discord.MessageFlagEphemeral),
+		},
+	}
+}
+
+func getInactiveGuildSettings(ctx context.Context, guildID discord.Snowflake) (*database.GuildSettingsInactive, error) {
+	guildSettingsInactive, err := welcomer.Queries.GetInactiveGuildSettings(ctx, int64(guildID))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return &database.GuildSettingsInactive{
+				GuildID:       int64(guildID),
+				ExcludedRoles: welcomer.DefaultInactive.ExcludedRoles,
+			}, nil
+		}
+
+		welcomer.Logger.Error().Err(err).
+			Int64("guild_id", int64(guildID)).
+			Msg("Failed to get inactive guild settings")
+
+		return nil, err
+	}
+
+	return guildSettingsInactive, nil
+}
+
+func updateInactiveGuildSettings(ctx context.Context, interaction discord.Interaction, guildSettingsInactive *database.GuildSettingsInactive) error {
+	err := welcomer.RetryWithFallback(
+		func() error {
+			_, err := welcomer.CreateOrUpdateInactiveGuildSettingsWithAudit(ctx, database.CreateOrUpdateInactiveGuildSettingsParams{
+				GuildID:       int64(*interaction.GuildID),
+				ExcludedRoles: guildSettingsInactive.ExcludedRoles,
+			}, interaction.GetUser().ID)
+
+			return err
+		},
+		func() error {
+			return welcomer.EnsureGuild(ctx, discord.Snowflake(*interaction.GuildID))
+		},
+		nil,
+	)
+	if err != nil {
+		welcomer.Logger.Error().Err(err).
+			Int64("guild_id", int64(*interaction.GuildID)).
+			Msg("Failed to update inactive guild settings")
+	}
+
+	return err
+}
+
+// getInactiveMembers returns the members that have not sent a message or joined a voice channel in the days given.
+// The days are clamped to the activity that has been recorded, so members are not counted as inactive because
+// there is no history for them. The days that were used are returned.
+func getInactiveMembers(ctx context.Context, sub *subway.Subway, guildID discord.Snowflake, days int) ([]*discord.GuildMember, int, error) {
+	guildSettingsInactive, err := getInactiveGuildSettings(ctx, guildID)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	now := time.Now()
+
+	earliest, err := welcomer.Queries.GetGuildEarliestActivity(ctx, int64(guildID))
+	if err != nil {
+		welcomer.Logger.Error().Err(err).
+			Int64("guild_id", int64(guildID)).
+			Msg("Failed to get earliest activity")
+
+		return nil, 0, err
+	}
+
+	availableDays := welcomer.GetInactiveAvailableDays(earliest, now)
+	if availableDays < welcomer.InactiveMinimumDays {
+		return nil, 0, errInactiveNoHistory
+	}
+
+	days = min(days, availableDays)
+
+	// Members are only returned for guilds that have been chunked.
+	_, err = sub.SandwichClient.RequestGuildChunk(ctx, &sandwich.RequestGuildChunkRequest{
+		GuildId:     int64(guildID),
+		AlwaysChunk: false,
+	})
+	if err != nil {
+		welcomer.Logger.Warn().Err(err).
+			Int64("guild_id", int64(guildID)).
+			Msg("Failed to chunk guild")
+	}
+
+	guildMembersResp, err := sub.SandwichClient.FetchGuildMember(ctx, &sandwich.FetchGuildMemberRequest{
+		GuildId: int64(guildID),
+	})
+	if err != nil {
+		welcomer.Logger.Error().Err(err).
+			Int64("guild_id", int64(guildID)).
+			Msg("Failed to fetch guild members")
+
+		return nil, 0, err
+	}
+
+	guildMembers := make([]*discord.GuildMember, 0, len(guildMembersResp.GetGuildMembers()))
+	for _, guildMemberPb := range guildMembersResp.GetGuildMembers() {
+		guildMembers = append(guildMembers, sandwich.PBToGuildMember(guildMemberPb))
+	}
+
+	since := welcomer.GetInactiveSince(days, now)
+
+	activeUserIDs, err := welcomer.Queries.GetGuildActiveUserIDs(ctx, database.GetGuildActiveUserIDsParams{
+		Granularity: welcomer.GetMessageCountGranularity(since, now),
+		GuildID:     int64(guildID),
+		Since:       since,
+	})
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		welcomer.Logger.Error().Err(err).
+			Int64("guild_id", int64(guildID)).
+			Msg("Failed to get active users")
+
+		return nil, 0, err
+	}
+
+	return welcomer.GetInactiveMembers(guildMembers, activeUserIDs, guildSettingsInactive.ExcludedRoles, since), days, nil
+}
+
+// inactiveView returns the message for a page of inactive members.
+func inactiveView(ctx context.Context, sub *subway.Subway, interaction discord.Interaction, options inactiveOptions) (*discord.InteractionCallbackData, error) {
+	inactiveMembers, days, err := getInactiveMembers(ctx, sub, *interaction.GuildID, options.Days)
+	if err != nil {
+		return nil, err
+	}
+
+	pageMembers, page, pages := welcomer.GetInactivePage(inactiveMembers, options.Page)
+
+	embed := discord.Embed{
+		Title: "Inactive Members",
+		Description: fmt.Sprintf("**%d** member%s have not sent a message or joined a voice channel in the last %d day%s.\n",
+			len(inactiveMembers), welcomer.If(len(inactiveMembers) == 1, "", "s"), days, welcomer.If(days == 1, "", "s")),
+		Color: welcomer.EmbedColourInfo,
+	}
+
+	if days < options.Days {
+		embed.Description += fmt.Sprintf("Activity has only been recorded for the last %d day%s.\n", days, welcomer.If(days == 1, "", "s"))
+	}
+
+	embed.Description += "\n"
+
+	for _, member := range pageMembers {
+		embed.Description += fmt.Sprintf("<@%d> - joined <t:%d:R>\n", member.User.ID, member.JoinedAt.Unix())
+	}
+
+	embed.Footer = &discord.EmbedFooter{Text: fmt.Sprintf("Page %d/%d • Use /inactive exclude to leave out members with a role", page+1, pages)}
+
+	return &discord.InteractionCallbackData{
+		Embeds: []discord.Embed{embed},
+		Components: []discord.InteractionComponent{
+			{
+				Type: discord.InteractionComponentTypeActionRow,
+				Components: []discord.InteractionComponent{
+					{
+						Type:     discord.InteractionComponentTypeButton,
+						Style:    discord.InteractionComponentStyleSecondary,
+						CustomID: options.customID(page - 1),
+						Label:    "Previous",
+						Disabled: page == 0,
+					},
+					{
+						Type:     discord.InteractionComponentTypeButton,
+						Style:    discord.InteractionComponentStyleSecondary,
+						CustomID: options.customID(page + 1),
+						Label:    "Next",
+						Disabled: page >= pages-1,
+					},
+				},
+			},
+		},
+		Flags: uint32(discord.MessageFlagEphemeral),
+	}, nil
+}
+
+func handleInactiveComponent(ctx context.Context, sub *subway.Subway, interaction discord.Interaction) (*discord.InteractionResponse, error) {
+	if interaction.GuildID == nil {
+		return nil, nil
+	}
+
+	options, ok := parseInactiveCustomID(interaction.Data.CustomID)
+	if !ok {
+		return nil, nil
+	}
+
+	return welcomer.RequireGuildElevation(sub, interaction, func() (*discord.InteractionResponse, error) {
+		// Chunking large guilds can take longer than the interaction allows, so the page is edited afterwards.
+		go func(ctx context.Context, sub *subway.Subway, interaction discord.Interaction) {
+			params := discord.WebhookMessageParams{}
+
+			data, err := inactiveView(ctx, sub, interaction, options)
+			if err != nil {
+				params.Embeds = welcomer.NewEmbed(inactiveErrorMessage(err, "Failed to find inactive members. Please try again later."), welcomer.EmbedColourError)
+			} else {
+				params.Embeds = data.Embeds
+				params.Components = data.Components
+			}
+
+			_, err = interaction.EditOriginalResponse(ctx, sub.EmptySession, params)
+			if err != nil {
+				welcomer.Logger.Error().Err(err).Msg("Failed to edit original response")
+			}
+		}(ctx, sub, interaction)
+
+		return &discord.InteractionResponse{
+			Type: discord.InteractionCallbackTypeDeferredUpdateMessage,
+		}, nil
+	})
+}
+
+// giveInactiveRole gives the role to inactive members that do not have it yet, and optionally warns them in
+// direct messages. It returns a summary of what was done.
+func giveInactiveRole(ctx context.Context, sub *subway.Subway, interaction discord.Interaction, session *discord.Session, role discord.Role, days int, warn bool) (string, error) {
+	requestedDays := days
+
+	inactiveMembers, days, err := getInactiveMembers(ctx, sub, *interaction.GuildID, days)
+	if err != nil {
+		return "", err
+	}
+
+	guildName := "the server"
+
+	if warn {
+		guild, err := welcomer.FetchGuild(ctx, *interaction.GuildID)
+		if err != nil {
+			welcomer.Logger.Warn().Err(err).
+				Int64("guild_id", int64(*interaction.GuildID)).
+				Msg("Failed to fetch guild")
+		} else {
+			guildName = guild.Name
+		}
+	}
+
+	var given, alreadyGiven, failed, warned, warnFailed int
+
+	for _, member := range inactiveMembers {
+		if slices.Contains(member.Roles, role.ID) {
+			alreadyGiven++
+
+			continue
+		}
+
+		// GuildID may be missing, fill it in.
+		member.GuildID = interaction.GuildID
+
+		err = member.AddRoles(ctx, session,
+			[]discord.Snowflake{role.ID},
+			new(fmt.Sprintf("Inactive for %d days, given by %s", days, interaction.GetUser().Username)),
+			true,
+		)
+		if err != nil {
+			welcomer.Logger.Warn().Err(err).
+				Int64("guild_id", int64(*interaction.GuildID)).
+				Int64("user_id", int64(member.User.ID)).
+				Int64("role_id", int64(role.ID)).
+				Msg("Failed to give inactive role")
+
+			failed++
+
+			continue
+		}
+
+		given++
+
+		if !warn {
+			continue
+		}
+
+		_, err = member.User.Send(ctx, session, welcomer.GetInactiveWarningMessage(guildName, days))
+		if err != nil {
+			welcomer.Logger.Info().Err(err).
+				Int64("guild_id", int64(*interaction.GuildID)).
+				Int64("user_id", int64(member.User.ID)).
+				Msg("Failed to send inactive warning")
+
+			warnFailed++
+
+			continue
+		}
+
+		warned++
+	}
+
+	var summary strings.Builder
+
+	if days < requestedDays {
+		fmt.Fprintf(&summary, "Activity has only been recorded for the last %d day%s, so members were counted as inactive over those days instead.\n",
+			days, welcomer.If(days == 1, "", "s"))
+	}
+
+	fmt.Fprintf(&summary, "Gave <@&%d> to **%d** member%s that have not been active in the last %d day%s.\n",
+		role.ID, given, welcomer.If(given == 1, "", "s"), days, welcomer.If(days == 1, "", "s"))
+
+	if alreadyGiven > 0 {
+		fmt.Fprintf(&summary, "%d member%s already had the role.\n", alreadyGiven, welcomer.If(alreadyGiven == 1, "", "s"))
+	}
+
+	if failed > 0 {
+		fmt.Fprintf(&summary, "%d member%s could not be given the role.\n", failed, welcomer.If(failed == 1, "", "s"))
+	}
+
+	if warn {
+		fmt.Fprintf(&summary, "Sent a warning to %d member%s", warned, welcomer.If(warned == 1, "", "s"))
+
+		if warnFailed > 0 {
+			fmt.Fprintf(&summary, ", %d could not be messaged", warnFailed)
+		}
+
+		summary.WriteString(".\n")
+	}
+
+	fmt.Fprintf(&summary, "\nWhen you are ready, you can prune them in your server settings under **Members**, including members with <@&%d>.", role.ID)
+
+	return summary.String(), nil
+}
+
+func (r *InactiveCog) RegisterCog(sub *subway.Subway) error {
+	inactiveGroup := subway.NewSubcommandGroup(
+		"inactive",
+		"Find members that have not been active in the server.",
+	)
+
+	// Disable the Inactive module for DM channels.
+	inactiveGroup.DMPermission = new(false)
+
+	daysArgument := subway.ArgumentParameter{
+		Name:         "days",
+		Description:  fmt.Sprintf("How many days members have not been active for. Defaults to %d days.", welcomer.InactiveDefaultDays),
+		ArgumentType: subway.ArgumentTypeInt,
+		Required:     false,
+		MinValue:     new(int32(welcomer.InactiveMinimumDays)),
+		MaxValue:     new(int32(welcomer.InactiveMaximumDays)),
+	}
+
+	getDaysArgument := func(ctx context.Context) int {
+		days := int(subway.MustGetArgument(ctx, "days").MustInt())
+		if days <= 0 {
+			return welcomer.InactiveDefaultDays
+		}
+
+		return min(max(days, welcomer.InactiveMinimumDays), welcomer.InactiveMaximumDays)
+	}
+
+	inactiveGroup.MustAddInteractionCommand(&subway.InteractionCommandable{
+		Name:        "list",
+		Description: "List the members that have not sent a message or joined a voice channel recently.",
+
+		Type: subway.InteractionCommandableTypeSubcommand,
+
+		ArgumentParameter: []subway.ArgumentParameter{
+			daysArgument,
+		},
+
+		DMPermission:            new(false),
+		DefaultMemberPermission: new(discord.Int64(welcomer.PermissionElevated)),
+
+		Handler: func(ctx context.Context, sub *subway.Subway, interaction discord.Interaction) (*discord.InteractionResponse, error) {
+			return welcomer.RequireGuildElevation(sub, interaction, func() (*discord.InteractionResponse, error) {
+				options := inactiveOptions{
+					Days: getDaysArgument(ctx),
+				}
+
+				// Chunking large guilds can take longer than the interaction allows, so the list is sent afterwards.
+				go func(ctx context.Context, sub *subway.Subway, interaction discord.Interaction) {
+					params := discord.WebhookMessageParams{}
+
+					data, err := inactiveView(ctx, sub, interaction, options)
+					if err != nil {
+						params.Embeds = welcomer.NewEmbed(inactiveErrorMessage(err, "Failed to find inactive members. Please try again later."), welcomer.EmbedColourError)
+					} else {
+						params.Embeds = data.Embeds
+						params.Components = data.Components
+					}
+
+					_, err = interaction.EditOriginalResponse(ctx, sub.EmptySession, params)
+					if err != nil {
+						welcomer.Logger.Error().Err(err).Msg("Failed to edit original response")
+					}
+				}(ctx, sub, interaction)
+
+				return &discord.InteractionResponse{
+					Type: discord.InteractionCallbackTypeDeferredChannelMessageSource,
+					Data: &discord.InteractionCallbackData{
+						Flags: uint32(discord.MessageFlagEphemeral),
+					},
+				}, nil
+			})
+		},
+	})
+
+	inactiveGroup.MustAddInteractionCommand(&subway.InteractionCommandable{
+		Name:        "role",
+		Description: "Give a role to members that have not been active recently, so they can be pruned.",
+
+		Type: subway.InteractionCommandableTypeSubcommand,
+
+		ArgumentParameter: []subway.ArgumentParameter{
+			{
+				Name:         "role",
+				Description:  "The role to give inactive members.",
+				ArgumentType: subway.ArgumentTypeRole,
+				Required:     true,
+			},
+			daysArgument,
+			{
+				Name:         "warn",
+				Description:  "Send inactive members a direct message warning them they may be removed.",
+				ArgumentType: subway.ArgumentTypeBool,
+				Required:     false,
+			},
+		},
+
+		DMPermission:            new(false),
+		DefaultMemberPermission: new(discord.Int64(welcomer.PermissionElevated)),
+
+		Handler: func(ctx context.Context, sub *subway.Subway, interaction discord.Interaction) (*discord.InteractionResponse, error) {
+			return welcomer.RequireGuildElevation(sub, interaction, func() (*discord.InteractionResponse, error) {
+				role := subway.MustGetArgument(ctx, "role").MustRole()
+				days := getDaysArgument(ctx)
+				warn := subway.MustGetArgument(ctx, "warn").MustBool()
+
+				canAssignRoles, isRoleAssignable, isRoleElevated, err := welcomer.Accelerator_CanAssignRole(ctx, *interaction.GuildID, &role)
+				if err != nil {
+					welcomer.Logger.Error().Err(err).
+						Int64("guild_id", int64(*interaction.GuildID)).
+						Msg("Failed to check if welcomer can assign role")
+
+					return nil, err
+				}
+
+				if !canAssignRoles {
+					return inactiveResponse("Welcomer is missing permissions to assign roles", welcomer.EmbedColourError), nil
+				}
+
+				if !isRoleAssignable {
+					return inactiveResponse("### This role is not assignable\nWelcomer cannot assign users this role as it does not have permission to manage roles or Welcomer's highest role is below this role's position. Please rearrange your roles in the server settings to move Welcomer's role above this role.", welcomer.EmbedColourError), nil
+				}
+
+				if isRoleElevated {
+					return inactiveResponse("This role has elevated permissions and cannot be given to inactive members.", welcomer.EmbedColourError), nil
+				}
+
+				session, err := welcomer.AcquireSession(ctx, welcomer.GetManagerNameFromContext(ctx))
+				if err != nil {
+					return nil, err
+				}
+
+				// Giving roles to every inactive member can take a while, so the summary is sent afterwards.
+				go func(ctx context.Context, sub *subway.Subway, interaction discord.Interaction) {
+					params := discord.WebhookMessageParams{}
+
+					summary, err := giveInactiveRole(ctx, sub, interaction, session, role, days, warn)
+					if err != nil {
+						params.Embeds = welcomer.NewEmbed(inactiveErrorMessage(err, "Failed to give the role to inactive members. Please try again later."), welcomer.EmbedColourError)
+					} else {
+						params.Embeds = welcomer.NewEmbed(summary, welcomer.EmbedColourSuccess)
+					}
+
+					_, err = interaction.EditOriginalResponse(ctx, sub.EmptySession, params)
+					if err != nil {
+						welcomer.Logger.Error().Err(err).Msg("Failed to edit original response")
+					}
+				}(ctx, sub, interaction)
+
+				return &discord.InteractionResponse{
+					Type: discord.InteractionCallbackTypeDeferredChannelMessageSource,
+					Data: &discord.InteractionCallbackData{
+						Flags: uint32(discord.MessageFlagEphemeral),
+					},
+				}, nil
+			})
+		},
+	})
+
+	inactiveGroup.MustAddInteractionCommand(&subway.InteractionCommandable{
+		Name:        "exclude",
+		Description: "Never count members with a role as inactive.",
+
+		Type: subway.InteractionCommandableTypeSubcommand,
+
+		ArgumentParameter: []subway.ArgumentParameter{
+			{
+				Name:         "role",
+				Description:  "The role to exclude.",
+				ArgumentType: subway.ArgumentTypeRole,
+				Required:     true,
+			},
+		},
+
+		DMPermission:            new(false),
+		DefaultMemberPermission: new(discord.Int64(welcomer.PermissionElevated)),
+
+		Handler: func(ctx context.Context, sub *subway.Subway, interaction discord.Interaction) (*discord.InteractionResponse, error) {
+			return welcomer.RequireGuildElevation(sub, interaction, func() (*discord.InteractionResponse, error) {
+				role := subway.MustGetArgument(ctx, "role").MustRole()
+
+				guildSettingsInactive, err := getInactiveGuildSettings(ctx, *interaction.GuildID)
+				if err != nil {
+					return nil, err
+				}
+
+				if slices.Contains(guildSettingsInactive.ExcludedRoles, int64(role.ID)) {
+					return inactiveResponse(fmt.Sprintf("<@&%d> is already excluded.", role.ID), welcomer.EmbedColourInfo), nil
+				}
+
+				if len(guildSettingsInactive.ExcludedRoles) >= welcomer.InactiveMaximumExcludedRoles {
+					return inactiveResponse(fmt.Sprintf("You can only exclude up to %d roles.", welcomer.InactiveMaximumExcludedRoles), welcomer.EmbedColourError), nil
+				}
+
+				guildSettingsInactive.ExcludedRoles = append(guildSettingsInactive.ExcludedRoles, int64(role.ID))
+
+				err = updateInactiveGuildSettings(ctx, interaction, guildSettingsInactive)
+				if err != nil {
+					return nil, err
+				}
+
+				return inactiveResponse(fmt.Sprintf("Members with <@&%d> are no longer counted as inactive.", role.ID), welcomer.EmbedColourSuccess), nil
+			})
+		},
+	})
+
+	inactiveGroup.MustAddInteractionCommand(&subway.InteractionCommandable{
+		Name:        "include",
+		Description: "Count members with an excluded role as inactive again.",
+
+		Type: subway.InteractionCommandableTypeSubcommand,
+
+		ArgumentParameter: []subway.ArgumentParameter{
+			{
+				Name:         "role",
+				Description:  "The role to include.",
+				ArgumentType: subway.ArgumentTypeRole,
+				Required:     true,
+			},
+		},
+
+		DMPermission:            new(false),
+		DefaultMemberPermission: new(discord.Int64(welcomer.PermissionElevated)),
+
+		Handler: func(ctx context.Context, sub *subway.Subway, interaction discord.Interaction) (*discord.InteractionResponse, error) {
+			return welcomer.RequireGuildElevation(sub, interaction, func() (*discord.InteractionResponse, error) {
+				role := subway.MustGetArgument(ctx, "role").MustRole()
+
+				guildSettingsInactive, err := getInactiveGuildSettings(ctx, *interaction.GuildID)
+				if err != nil {
+					return nil, err
+				}
+
+				if !slices.Contains(guildSettingsInactive.ExcludedRoles, int64(role.ID)) {
+					return inactiveResponse(fmt.Sprintf("<@&%d> is not excluded.", role.ID), welcomer.EmbedColourInfo), nil
+				}
+
+				guildSettingsInactive.ExcludedRoles = slices.DeleteFunc(guildSettingsInactive.ExcludedRoles, func(excludedRoleID int64) bool {
+					return excludedRoleID == int64(role.ID)
+				})
+
+				err = updateInactiveGuildSettings(ctx, interaction, guildSettingsInactive)
+				if err != nil {
+					return nil, err
+				}
+
+				return inactiveResponse(fmt.Sprintf("Members with <@&%d> can be counted as inactive again.", role.ID), welcomer.EmbedColourSuccess), nil
+			})
+		},
+	})
+
+	sub.RegisterComponentListener("inactive:*", handleInactiveComponent)
+
+	r.InteractionCommands.MustAddInteractionCommand(inactiveGroup)
+
+	return nil
+}
@@ -0,0 +1,801 @@
+package plugins
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/WelcomerTeam/Discord/discord"
+	sandwich "github.com/WelcomerTeam/Sandwich-Daemon/proto"
+	subway "github.com/WelcomerTeam/Subway/subway"
+	"github.com/WelcomerTeam/Welcomer/welcomer-core"
+	"github.com/WelcomerTeam/Welcomer/welcomer-core/database"
+	"github.com/jackc/pgx/v4"
+)
+
+func NewLeaderboardCog() *LeaderboardCog {
+	return &LeaderboardCog{
+		InteractionCommands: subway.SetupInteractionCommandable(&subway.InteractionCommandable{}),
+	}
+}
+
+type LeaderboardCog struct {
+	InteractionCommands *subway.InteractionCommandable
+}
+
+// Assert types.
+
+var (
+	_ subway.Cog                        = (*LeaderboardCog)(nil)
+	_ subway.CogWithInteractionCommands = (*LeaderboardCog)(nil)
+)
+
+func (r *LeaderboardCog) CogInfo() *subway.CogInfo {
+	return &subway.CogInfo{
+		Name:        "Leaderboard",
+		Description: "Provides the cog for the 'Leaderboard' feature.",
+	}
+}
+
+func (r *LeaderboardCog) GetInteractionCommandable() *subway.InteractionCommandable {
+	return r.InteractionCommands
+}
+
+// leaderboardOptions is the leaderboard being viewed. It is stored in the custom ID of the page buttons.
+type leaderboardOptions struct {
+	Type      string
+	Period    string
+	ChannelID discord.Snowflake
+	Page      int
+	Image     bool
+
+	// UserID is the user that requested the leaderboard, only they can change pages.
+	UserID discord.Snowflake
+}
+
+func (o leaderboardOptions) customID(page int) string {
+	return fmt.Sprintf("leaderboard:%s:%s:%d:%d:%t:%d", o.Type, o.Period, o.ChannelID, page, o.Image, o.UserID)
+}
+
+func parseLeaderboardCustomID(customID string) (options leaderboardOptions, ok bool) {
+	customIDSplit := strings.Split(customID, ":")
+	if len(customIDSplit) < 7 {
+		return options, false
+	}
+
+	channelID, err := strconv.ParseInt(customIDSplit[3], 10, 64)
+	if err != nil {
+		return options, false
+	}
+
+	page, err := strconv.Atoi(customIDSplit[4])
+	if err != nil {
+		return options, false
+	}
+
+	userID, err := strconv.ParseInt(customIDSplit[6], 10, 64)
+	if err != nil {
+		return options, false
+	}
+
+	return leaderboardOptions{
+		Type:      customIDSplit[1],
+		Period:    customIDSplit[2],
+		ChannelID: discord.Snowflake(channelID),
+		Page:      page,
+		Image:     customIDSplit[5] == "true",
+		UserID:    discord.Snowflake(userID),
+	}, true
+}
+
+func leaderboardResponse(message string, colour int32) *discord.InteractionResponse {
+	return &discord.InteractionResponse{
+		Type: discord.InteractionCallbackTypeChannelMessageSource,
+		Data: &discord.InteractionCallbackData{
+			Embeds: welcomer.NewEmbed(message, colour),
+			Flags:  uint32(discord.MessageFlagEphemeral),
+		},
+	}
+}
+
+func getLeaderboardGuildSettings(ctx context.Context, guildID discord.Snowflake) (*database.GuildSettingsLeaderboard, error) {
+	guildSettingsLeaderboard, err := welcomer.Queries.GetLeaderboardGuildSettings(ctx, int64(guildID))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return &database.GuildSettingsLeaderboard{
+				GuildID:           int64(guildID),
+				ToggleIncludeBots: welcomer.DefaultLeaderboard.ToggleIncludeBots,
+				ExcludedChannels:  welcomer.DefaultLeaderboard.ExcludedChannels,
+			}, nil
+		}
+
+		welcomer.Logger.Error().Err(err).
+			Int64("guild_id", int64(guildID)).
+			Msg("Failed to get leaderboard guild settings")
+
+		return nil, err
+	}
+
+	return guildSettingsLeaderboard, nil
+}
+
+func updateLeaderboardGuildSettings(ctx context.Context, interaction discord.Interaction, guildSettingsLeaderboard *database.GuildSettingsLeaderboard) error {
+	err := welcomer.RetryWithFallback(
+		func() error {
+			_, err := welcomer.CreateOrUpdateLeaderboardGuildSettingsWithAudit(ctx, database.CreateOrUpdateLeaderboardGuildSettingsParams{
+				GuildID:           int64(*interaction.GuildID),
+				ToggleIncludeBots: guildSettingsLeaderboard.ToggleIncludeBots,
+				ExcludedChannels:  guildSettingsLeaderboard.ExcludedChannels,
+			}, interaction.GetUser().ID)
+
+			return err
+		},
+		func() error {
+			return welcomer.EnsureGuild(ctx, discord.Snowflake(*interaction.GuildID))
+		},
+		nil,
+	)
+	if err != nil {
+		welcomer.Logger.Error().Err(err).
+			Int64("guild_id", int64(*interaction.GuildID)).
+			Msg("Failed to update leaderboard guild settings")
+	}
+
+	return err
+}
+
+// leaderboardImageClient generates leaderboard images. Requests time out so a slow image
+// service cannot hold up the response.
+var leaderboardImageClient = http.Client{Timeout: time.Second * 10}
+
+// getLeaderboardBots returns the bots in the guild. The guild is chunked first so bots that are
+// not cached are not counted as members.
+func getLeaderboardBots(ctx context.Context, sub *subway.Subway, guildID discord.Snowflake) ([]int64, error) {
+	_, err := sub.SandwichClient.RequestGuildChunk(ctx, &sandwich.RequestGuildChunkRequest{
+		GuildId: int64(guildID),
+	})
+	if err != nil {
+		welcomer.Logger.Warn().Err(err).
+			Int64("guild_id", int64(guildID)).
+			Msg("Failed to chunk guild for leaderboard")
+	}
+
+	guildMembers, err := sub.SandwichClient.FetchGuildMember(ctx, &sandwich.FetchGuildMemberRequest{
+		GuildId: int64(guildID),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	bots := make([]int64, 0)
+
+	for _, memberPb := range guildMembers.GetGuildMembers() {
+		member := sandwich.PBToGuildMember(memberPb)
+
+		if member.User != nil && member.User.Bot {
+			bots = append(bots, int64(member.User.ID))
+		}
+	}
+
+	return bots, nil
+}
+
+// getLeaderboardEntries returns the ranked entries of the leaderboard and the bots that were left out of it.
+func getLeaderboardEntries(ctx context.Context, sub *subway.Subway, guildID discord.Snowflake, options leaderboardOptions, guildSettingsLeaderboard *database.GuildSettingsLeaderboard) ([]welcomer.LeaderboardEntry, []int64, error) {
+	now := time.Now()
+	since := welcomer.GetLeaderboardSince(options.Period, now)
+
+	// Bots are left out by the query, so they do not take up places in the entries returned.
+	bots := make([]int64, 0)
+
+	if !guildSettingsLeaderboard.ToggleIncludeBots {
+		var err error
+
+		bots, err = getLeaderboardBots(ctx, sub, guildID)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	var totals []welcomer.LeaderboardEntry
+
+	switch options.Type {
+	case welcomer.LeaderboardTypeVoice:
+		rows, err := welcomer.Queries.GetGuildVoiceLeaderboard(ctx, database.GetGuildVoiceLeaderboardParams{
+			GuildID:          int64(guildID),
+			Since:            since,
+			ChannelID:        int64(options.ChannelID),
+			ExcludedChannels: guildSettingsLeaderboard.ExcludedChannels,
+			ExcludedUsers:    bots,
+			EntryLimit:       welcomer.LeaderboardMaximumEntries,
+		})
+		if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil, err
+		}
+
+		for _, row := range rows {
+			totals = append(totals, welcomer.LeaderboardEntry{UserID: discord.Snowflake(row.UserID), Value: row.TotalTimeMs})
+		}
+	default:
+		rows, err := welcomer.Queries.GetGuildMessageLeaderboard(ctx, database.GetGuildMessageLeaderboardParams{
+			Granularity:      welcomer.GetMessageCountGranularity(since, now),
+			GuildID:          int64(guildID),
+			Since:            since,
+			ChannelID:        int64(options.ChannelID),
+			ExcludedChannels: guildSettingsLeaderboard.ExcludedChannels,
+			ExcludedUsers:    bots,
+			EntryLimit:       welcomer.LeaderboardMaximumEntries,
+		})
+		if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil, err
+		}
+
+		for _, row := range rows {
+			totals = append(totals, welcomer.LeaderboardEntry{UserID: discord.Snowflake(row.UserID), Value: row.MessageCount})
+		}
+	}
+
+	if len(totals) == 0 {
+		return nil, bots, nil
+	}
+
+	userIDs := make([]int64, len(totals))
+	for i, total := range totals {
+		userIDs[i] = int64(total.UserID)
+	}
+
+	guildMembers, err := sub.SandwichClient.FetchGuildMember(ctx, &sandwich.FetchGuildMemberRequest{
+		GuildId: int64(guildID),
+		UserIds: userIDs,
+	})
+	if err != nil {
+		welcomer.Logger.Warn().Err(err).
+			Int64("guild_id", int64(guildID)).
+			Msg("Failed to fetch leaderboard guild members")
+	}
+
+	entries := make([]welcomer.LeaderboardEntry, 0, len(totals))
+
+	for _, total := range totals {
+		if memberPb, ok := guildMembers.GetGuildMembers()[int64(total.UserID)]; ok {
+			total.User = sandwich.PBToGuildMember(memberPb).User
+		}
+
+		total.Rank = len(entries) + 1
+		entries = append(entries, total)
+	}
+
+	return entries, bots, nil
+}
+
+// getLeaderboardUserRank returns the rank and value of a user. Rank is 0 when the user has no activity.
+func getLeaderboardUserRank(ctx context.Context, guildID discord.Snowflake, userID discord.Snowflake, options leaderboardOptions, guildSettingsLeaderboard *database.GuildSettingsLeaderboard, entries []welcomer.LeaderboardEntry, bots []int64) (int64, int64, error) {
+	if i := slices.IndexFunc(entries, func(entry welcomer.LeaderboardEntry) bool { return entry.UserID == userID }); i >= 0 {
+		return int64(entries[i].Rank), entries[i].Value, nil
+	}
+
+	if slices.Contains(bots, int64(userID)) {
+		return 0, 0, nil
+	}
+
+	now := time.Now()
+	since := welcomer.GetLeaderboardSince(options.Period, now)
+
+	var rank, value int64
+
+	switch options.Type {
+	case welcomer.LeaderboardTypeVoice:
+		row, err := welcomer.Queries.GetGuildVoiceLeaderboardRank(ctx, database.GetGuildVoiceLeaderboardRankParams{
+			GuildID:          int64(guildID),
+			Since:            since,
+			ChannelID:        int64(options.ChannelID),
+			ExcludedChannels: guildSettingsLeaderboard.ExcludedChannels,
+			UserID:           int64(userID),
+			ExcludedUsers:    bots,
+		})
+		if err != nil {
+			return 0, 0, err
+		}
+
+		rank, value = row.Rank, row.TotalTimeMs
+	default:
+		row, err := welcomer.Queries.GetGuildMessageLeaderboardRank(ctx, database.GetGuildMessageLeaderboardRankParams{
+			Granularity:      welcomer.GetMessageCountGranularity(since, now),
+			GuildID:          int64(guildID),
+			Since:            since,
+			ChannelID:        int64(options.ChannelID),
+			ExcludedChannels: guildSettingsLeaderboard.ExcludedChannels,
+			UserID:           int64(userID),
+			ExcludedUsers:    bots,
+		})
+		if err != nil {
+			return 0, 0, err
+		}
+
+		rank, value = row.Rank, row.MessageCount
+	}
+
+	if value == 0 {
+		return 0, 0, nil
+	}
+
+	return rank, value, nil
+}
+
+func generateLeaderboardImage(title string, options leaderboardOptions, entries []welcomer.LeaderboardEntry) (*discord.File, error) {
+	var text strings.Builder
+
+	text.WriteString(title)
+
+	for _, entry := range entries {
+		name := entry.UserID.String()
+		if entry.User != nil {
+			name = welcomer.GetUserDisplayName(entry.User)
+		}
+
+		fmt.Fprintf(&text, "\n#%d %s - %s", entry.Rank, name, welcomer.FormatLeaderboardValue(options.Type, entry.Value))
+	}
+
+	generateOptionsRaw := welcomer.GenerateImageOptionsRaw{
+		ShowAvatar:         len(entries) > 0 && entries[0].User != nil,
+		Background:         welcomer.DefaultWelcomerImages.BackgroundName,
+		Text:               text.String(),
+		TextColor:          0xFFFFFFFF,
+		TextStrokeColor:    0x000000FF,
+		ProfileBorderColor: 0xFFFFFFFF,
+		ImageBorderColor:   0xFFFFFFFF,
+		Theme:              int32(welcomer.ImageThemeCard),
+		TextAlign:          int32(welcomer.ImageAlignmentLeft),
+		ImageBorderWidth:   16,
+		ProfileBorderWidth: 8,
+		ProfileBorderCurve: int32(welcomer.ImageProfileBorderTypeCircular),
+		TextStroke:         true,
+		AllowAnimated:      false,
+	}
+
+	if generateOptionsRaw.ShowAvatar {
+		generateOptionsRaw.AvatarURL = welcomer.GetUserAvatar(entries[0].User)
+		generateOptionsRaw.UserID = int64(entries[0].UserID)
+	}
+
+	optionsJSON, err := json.Marshal(generateOptionsRaw)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := leaderboardImageClient.Post(os.Getenv("IMAGE_ADDRESS")+"/generate", "application/json", bytes.NewBuffer(optionsJSON))
+	if err != nil {
+		return nil, err
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code %d", resp.StatusCode)
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	return &discord.File{
+		Name:        "leaderboard.png",
+		ContentType: resp.Header.Get("Content-Type"),
+		Reader:      bytes.NewBuffer(body),
+	}, nil
+}
+
+// leaderboardView returns the message for a page of the leaderboard.
+func leaderboardView(ctx context.Context, sub *subway.Subway, interaction discord.Interaction, options leaderboardOptions) (*discord.InteractionCallbackData, error) {
+	guildSettingsLeaderboard, err := getLeaderboardGuildSettings(ctx, *interaction.GuildID)
+	if err != nil {
+		return nil, err
+	}
+
+	entries, bots, err := getLeaderboardEntries(ctx, sub, *interaction.GuildID, options, guildSettingsLeaderboard)
+	if err != nil {
+		welcomer.Logger.Error().Err(err).
+			Int64("guild_id", int64(*interaction.GuildID)).
+			Str("type", options.Type).
+			Msg("Failed to get leaderboard entries")
+
+		return nil, err
+	}
+
+	rank, value, err := getLeaderboardUserRank(ctx, *interaction.GuildID, options.UserID, options, guildSettingsLeaderboard, entries, bots)
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		welcomer.Logger.Warn().Err(err).
+			Int64("guild_id", int64(*interaction.GuildID)).
+			Int64("user_id", int64(options.UserID)).
+			Msg("Failed to get leaderboard rank")
+	}
+
+	pageEntries, page, pages := welcomer.GetLeaderboardPage(entries, options.Page)
+
+	title := welcomer.If(options.Type == welcomer.LeaderboardTypeVoice, "Voice Leaderboard", "Message Leaderboard")
+
+	embed := discord.Embed{Title: title, Color: welcomer.EmbedColourInfo}
+
+	switch options.Period {
+	case welcomer.LeaderboardPeriodDay:
+		embed.Description = "Activity in the last day"
+	case welcomer.LeaderboardPeriodWeek:
+		embed.Description = "Activity in the last week"
+	case welcomer.LeaderboardPeriodMonth:
+		embed.Description = "Activity in the last month"
+	default:
+		embed.Description = "All time activity"
+	}
+
+	if !options.ChannelID.IsNil() {
+		embed.Description += fmt.Sprintf(" in <#%d>", options.ChannelID)
+	}
+
+	embed.Description += ".\n\n"
+
+	if len(pageEntries) == 0 {
+		embed.Description += "There is no activity to show yet."
+	}
+
+	for _, entry := range pageEntries {
+		embed.Description += fmt.Sprintf("**#%d** <@%d> - %s\n", entry.Rank, entry.UserID, welcomer.FormatLeaderboardValue(options.Type, entry.Value))
+	}
+
+	footer := fmt.Sprintf("Page %d/%d", page+1, pages)
+
+	if rank > 0 {
+		footer += fmt.Sprintf(" • Your rank: #%d (%s)", rank, welcomer.FormatLeaderboardValue(options.Type, value))
+	} else {
+		footer += " • You are not ranked yet"
+	}
+
+	embed.Footer = &discord.EmbedFooter{Text: footer}
+
+	data := &discord.InteractionCallbackData{
+		Embeds: []discord.Embed{embed},
+		Components: []discord.InteractionComponent{
+			{
+				Type: discord.InteractionComponentTypeActionRow,
+				Components: []discord.InteractionComponent{
+					{
+						Type:     discord.InteractionComponentTypeButton,
+						Style:    discord.InteractionComponentStyleSecondary,
+						CustomID: options.customID(page - 1),
+						Label:    "Previous",
+						Disabled: page == 0,
+					},
+					{
+						Type:     discord.InteractionComponentTypeButton,
+						Style:    discord.InteractionComponentStyleSecondary,
+						CustomID: options.customID(page + 1),
+						Label:    "Next",
+						Disabled: page >= pages-1,
+					},
+				},
+			},
+		},
+	}
+
+	if options.Image && len(pageEntries) > 0 {
+		file, err := generateLeaderboardImage(title, options, pageEntries)
+		if err != nil {
+			welcomer.Logger.Error().Err(err).
+				Int64("guild_id", int64(*interaction.GuildID)).
+				Msg("Failed to generate leaderboard image")
+		} else {
+			// The image replaces the list, the footer still shows the page and rank.
+			data.Embeds[0].Description = ""
+			data.Embeds[0].SetImage(discord.NewEmbedImage("attachment://" + file.Name))
+			data.Files = []discord.File{*file}
+		}
+	}
+
+	return data, nil
+}
+
+// sendLeaderboardView defers the response and then edits it with the leaderboard. Counting activity over
+// long periods and generating the image can take longer than the interaction allows.
+func sendLeaderboardView(ctx context.Context, sub *subway.Subway, interaction discord.Interaction, options leaderboardOptions, callbackType discord.InteractionCallbackType) (*discord.InteractionResponse, error) {
+	go func(ctx context.Context, sub *subway.Subway, interaction discord.Interaction) {
+		params := discord.WebhookMessageParams{}
+
+		data, err := leaderboardView(ctx, sub, interaction, options)
+		if err != nil {
+			params.Embeds = welcomer.NewEmbed("Failed to get the leaderboard. Please try again later.", welcomer.EmbedColourError)
+		} else {
+			params.Embeds = data.Embeds
+			params.Components = data.Components
+			params.Files = data.Files
+		}
+
+		_, err = interaction.EditOriginalResponse(ctx, sub.EmptySession, params)
+		if err != nil {
+			welcomer.Logger.Error().Err(err).
+				Int64("guild_id", int64(*interaction.GuildID)).
+				Msg("Failed to send leaderboard response")
+		}
+	}(ctx, sub, interaction)
+
+	return &discord.InteractionResponse{
+		Type: callbackType,
+	}, nil
+}
+
+func handleLeaderboardComponent(ctx context.Context, sub *subway.Subway, interaction discord.Interaction) (*discord.InteractionResponse, error) {
+	if interaction.GuildID == nil {
+		return nil, nil
+	}
+
+	options, ok := parseLeaderboardCustomID(interaction.Data.CustomID)
+	if !ok {
+		return nil, nil
+	}
+
+	if interaction.GetUser().ID != options.UserID {
+		return leaderboardResponse("Only the user who ran this command can change pages. Run `/leaderboard` to see your own.", welcomer.EmbedColourError), nil
+	}
+
+	return sendLeaderboardView(ctx, sub, interaction, options, discord.InteractionCallbackTypeDeferredUpdateMessage)
+}
+
+func (r *LeaderboardCog) RegisterCog(sub *subway.Subway) error {
+	leaderboardGroup := subway.NewSubcommandGroup(
+		"leaderboard",
+		"See the most active members in the server.",
+	)
+
+	// Disable the Leaderboard module for DM channels.
+	leaderboardGroup.DMPermission = new(false)
+
+	periodArgument := subway.ArgumentParameter{
+		Name:         "period",
+		Description:  "The period to show activity for. Defaults to a week.",
+		ArgumentType: subway.ArgumentTypeString,
+		Required:     false,
+		Choices: []discord.ApplicationCommandOptionChoice{
+			{Name: welcomer.LeaderboardPeriodDay, Value: welcomer.StringToJsonLiteral(welcomer.LeaderboardPeriodDay)},
+			{Name: welcomer.LeaderboardPeriodWeek, Value: welcomer.StringToJsonLiteral(welcomer.LeaderboardPeriodWeek)},
+			{Name: welcomer.LeaderboardPeriodMonth, Value: welcomer.StringToJsonLiteral(welcomer.LeaderboardPeriodMonth)},
+			{Name: welcomer.LeaderboardPeriodAll, Value: welcomer.StringToJsonLiteral(welcomer.LeaderboardPeriodAll)},
+		},
+	}
+
+	imageArgument := subway.ArgumentParameter{
+		Name:         "image",
+		Description:  "Show the leaderboard as an image.",
+		ArgumentType: subway.ArgumentTypeBool,
+		Required:     false,
+	}
+
+	leaderboardHandler := func(leaderboardType string) func(context.Context, *subway.Subway, discord.Interaction) (*discord.InteractionResponse, error) {
+		return func(ctx context.Context, sub *subway.Subway, interaction discord.Interaction) (*discord.InteractionResponse, error) {
+			options := leaderboardOptions{
+				Type:   leaderboardType,
+				Period: subway.MustGetArgument(ctx, "period").MustString(),
+				Image:  subway.MustGetArgument(ctx, "image").MustBool(),
+				UserID: interaction.GetUser().ID,
+			}
+
+			if options.Period == "" {
+				options.Period = welcomer.LeaderboardPeriodWeek
+			}
+
+			channel := subway.MustGetArgument(ctx, "channel").MustChannel()
+			if !channel.ID.IsNil() {
+				options.ChannelID = channel.ID
+			}
+
+			return sendLeaderboardView(ctx, sub, interaction, options, discord.InteractionCallbackTypeDeferredChannelMessageSource)
+		}
+	}
+
+	leaderboardGroup.MustAddInteractionCommand(&subway.InteractionCommandable{
+		Name:        "messages",
+		Description: "See the members who have sent the most messages.",
+
+		Type: subway.InteractionCommandableTypeSubcommand,
+
+		ArgumentParameter: []subway.ArgumentParameter{
+			periodArgument,
+			{
+				Name:         "channel",
+				Description:  "Only count messages sent in this channel.",
+				ArgumentType: subway.ArgumentTypeTextChannel,
+				Required:     false,
+			},
+			imageArgument,
+		},
+
+		DMPermission: new(false),
+
+		Handler: leaderboardHandler(welcomer.LeaderboardTypeMessages),
+	})
+
+	leaderboardGroup.MustAddInteractionCommand(&subway.InteractionCommandable{
+		Name:        "voice",
+		Description: "See the members who have spent the most time in voice channels.",
+
+		Type: subway.InteractionCommandableTypeSubcommand,
+
+		ArgumentParameter: []subway.ArgumentParameter{
+			periodArgument,
+			{
+				Name:         "channel",
+				Description:  "Only count time spent in this voice channel.",
+				ArgumentType: subway.ArgumentTypeVoiceChannel,
+				Required:     false,
+			},
+			imageArgument,
+		},
+
+		DMPermission: new(false),
+
+		Handler: leaderboardHandler(welcomer.LeaderboardTypeVoice),
+	})
+
+	leaderboardGroup.MustAddInteractionCommand(&subway.InteractionCommandable{
+		Name:        "bots",
+		Description: "Choose if bots are shown on the leaderboard.",
+
+		Type: subway.InteractionCommandableTypeSubcommand,
+
+		ArgumentParameter: []subway.ArgumentParameter{
+			{
+				Name:         "enabled",
+				Description:  "Show bots on the leaderboard.",
+				ArgumentType: subway.ArgumentTypeBool,
+				Required:     true,
+			},
+		},
+
+		DMPermission:            new(false),
+		DefaultMemberPermission: new(discord.Int64(welcomer.PermissionElevated)),
+
+		Handler: func(ctx context.Context, sub *subway.Subway, interaction discord.Interaction) (*discord.InteractionResponse, error) {
+			return welcomer.RequireGuildElevation(sub, interaction, func() (*discord.InteractionResponse, error) {
+				enabled := subway.MustGetArgument(ctx, "enabled").MustBool()
+
+				guildSettingsLeaderboard, err := getLeaderboardGuildSettings(ctx, *interaction.GuildID)
+				if err != nil {
+					return nil, err
+				}
+
+				guildSettingsLeaderboard.ToggleIncludeBots = enabled
+
+				err = updateLeaderboardGuildSettings(ctx, interaction, guildSettingsLeaderboard)
+				if err != nil {
+					return nil, err
+				}
+
+				if enabled {
+					return leaderboardResponse("Bots are now shown on the leaderboard.", welcomer.EmbedColourSuccess), nil
+				}
+
+				return leaderboardResponse("Bots are no longer shown on the leaderboard.", welcomer.EmbedColourSuccess), nil
+			})
+		},
+	})
+
+	channelArguments := []subway.ArgumentParameter{
+		{
+			Name:         "channel",
+			Description:  "The text channel.",
+			ArgumentType: subway.ArgumentTypeTextChannel,
+			Required:     false,
+		},
+		{
+			Name:         "voice-channel",
+			Description:  "The voice channel.",
+			ArgumentType: subway.ArgumentTypeVoiceChannel,
+			Required:     false,
+		},
+	}
+
+	getChannelArgument := func(ctx context.Context) discord.Snowflake {
+		if channel := subway.MustGetArgument(ctx, "channel").MustChannel(); !channel.ID.IsNil() {
+			return channel.ID
+		}
+
+		return subway.MustGetArgument(ctx, "voice-channel").MustChannel().ID
+	}
+
+	leaderboardGroup.MustAddInteractionCommand(&subway.InteractionCommandable{
+		Name:        "exclude",
+		Description: "Stop counting activity in a channel on the leaderboard.",
+
+		Type: subway.InteractionCommandableTypeSubcommand,
+
+		ArgumentParameter: channelArguments,
+
+		DMPermission:            new(false),
+		DefaultMemberPermission: new(discord.Int64(welcomer.PermissionElevated)),
+
+		Handler: func(ctx context.Context, sub *subway.Subway, interaction discord.Interaction) (*discord.InteractionResponse, error) {
+			return welcomer.RequireGuildElevation(sub, interaction, func() (*discord.InteractionResponse, error) {
+				channelID := getChannelArgument(ctx)
+				if channelID.IsNil() {
+					return leaderboardResponse("Please provide a `channel` or `voice-channel`.", welcomer.EmbedColourError), nil
+				}
+
+				guildSettingsLeaderboard, err := getLeaderboardGuildSettings(ctx, *interaction.GuildID)
+				if err != nil {
+					return nil, err
+				}
+
+				if slices.Contains(guildSettingsLeaderboard.ExcludedChannels, int64(channelID)) {
+					return leaderboardResponse(fmt.Sprintf("<#%d> is already excluded from the leaderboard.", channelID), welcomer.EmbedColourInfo), nil
+				}
+
+				if len(guildSettingsLeaderboard.ExcludedChannels) >= welcomer.LeaderboardMaximumExcludedChannels {
+					return leaderboardResponse(fmt.Sprintf("You can only exclude up to %d channels.", welcomer.LeaderboardMaximumExcludedChannels), welcomer.EmbedColourError), nil
+				}
+
+				guildSettingsLeaderboard.ExcludedChannels = append(guildSettingsLeaderboard.ExcludedChannels, int64(channelID))
+
+				err = updateLeaderboardGuildSettings(ctx, interaction, guildSettingsLeaderboard)
+				if err != nil {
+					return nil, err
+				}
+
+				return leaderboardResponse(fmt.Sprintf("Activity in <#%d> is no longer counted on the leaderboard.", channelID), welcomer.EmbedColourSuccess), nil
+			})
+		},
+	})
+
+	leaderboardGroup.MustAddInteractionCommand(&subway.InteractionCommandable{
+		Name:        "include",
+		Description: "Count activity in an excluded channel on the leaderboard again.",
+
+		Type: subway.InteractionCommandableTypeSubcommand,
+
+		ArgumentParameter: channelArguments,
+
+		DMPermission:            new(false),
+		DefaultMemberPermission: new(discord.Int64(welcomer.PermissionElevated)),
+
+		Handler: func(ctx context.Context, sub *subway.Subway, interaction discord.Interaction) (*discord.InteractionResponse, error) {
+			return welcomer.RequireGuildElevation(sub, interaction, func() (*discord.InteractionResponse, error) {
+				channelID := getChannelArgument(ctx)
+				if channelID.IsNil() {
+					return leaderboardResponse("Please provide a `channel` or `voice-channel`.", welcomer.EmbedColourError), nil
+				}
+
+				guildSettingsLeaderboard, err := getLeaderboardGuildSettings(ctx, *interaction.GuildID)
+				if err != nil {
+					return nil, err
+				}
+
+				if !slices.Contains(guildSettingsLeaderboard.ExcludedChannels, int64(channelID)) {
+					return leaderboardResponse(fmt.Sprintf("<#%d> is not excluded from the leaderboard.", channelID), welcomer.EmbedColourInfo), nil
+				}
+
+				guildSettingsLeaderboard.ExcludedChannels = slices.DeleteFunc(guildSettingsLeaderboard.ExcludedChannels, func(excludedChannelID int64) bool {
+					return excludedChannelID == int64(channelID)
+				})
+
+				err = updateLeaderboardGuildSettings(ctx, interaction, guildSettingsLeaderboard)
+				if err != nil {
+					return nil, err
+				}
+
+				return leaderboardResponse(fmt.Sprintf("Activity in <#%d> is counted on the leaderboard again.", channelID), welcomer.EmbedColourSuccess), nil
+			})
+		},
+	})
+
+	sub.RegisterComponentListener("leaderboard:*", handleLeaderboardComponent)
+
+	r.InteractionCommands.MustAddInteractionCommand(leaderboardGroup)
+
+	return nil
+}
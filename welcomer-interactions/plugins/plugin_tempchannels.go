@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"strings"
 
 	"github.com/WelcomerTeam/Discord/discord"
 	sandwich "github.com/WelcomerTeam/Sandwich-Daemon/proto"
@@ -77,6 +78,7 @@ func (w *TempChannelsCog) RegisterCog(sub *subway.Subway) error {
 							ChannelLobby:     welcomer.DefaultTempChannels.ChannelLobby,
 							ChannelCategory:  welcomer.DefaultTempChannels.ChannelCategory,
 							DefaultUserCount: welcomer.DefaultTempChannels.DefaultUserCount,
+							Lobbies:          welcomer.DefaultTempChannels.Lobbies,
 						}
 					} else {
 						welcomer.Logger.Error().Err(err).
@@ -87,7 +89,9 @@ func (w *TempChannelsCog) RegisterCog(sub *subway.Subway) error {
 					}
 				}
 
-				if !guildSettingsTempChannels.ToggleEnabled || guildSettingsTempChannels.ChannelCategory == 0 {
+				lobbies := welcomer.GetTempChannelLobbies(guildSettingsTempChannels)
+
+				if !guildSettingsTempChannels.ToggleEnabled || len(lobbies) == 0 || lobbies[0].CategoryID.IsNil() {
 					return &discord.InteractionResponse{
 						Type: discord.InteractionCallbackTypeChannelMessageSource,
 						Data: &discord.InteractionCallbackData{
@@ -198,6 +202,7 @@ func (w *TempChannelsCog) RegisterCog(sub *subway.Subway) error {
 							ChannelLobby:     welcomer.DefaultTempChannels.ChannelLobby,
 							ChannelCategory:  welcomer.DefaultTempChannels.ChannelCategory,
 							DefaultUserCount: welcomer.DefaultTempChannels.DefaultUserCount,
+							Lobbies:          welcomer.DefaultTempChannels.Lobbies,
 						}
 					} else {
 						welcomer.Logger.Error().Err(err).
@@ -233,6 +238,7 @@ func (w *TempChannelsCog) RegisterCog(sub *subway.Subway) error {
 							ChannelLobby:     guildSettingsTempChannels.ChannelLobby,
 							ChannelCategory:  guildSettingsTempChannels.ChannelCategory,
 							DefaultUserCount: guildSettingsTempChannels.DefaultUserCount,
+							Lobbies:          guildSettingsTempChannels.Lobbies,
 						}, interaction.GetUser().ID)
 
 						return err
@@ -309,6 +315,7 @@ func (w *TempChannelsCog) RegisterCog(sub *subway.Subway) error {
 							ChannelLobby:     welcomer.DefaultTempChannels.ChannelLobby,
 							ChannelCategory:  welcomer.DefaultTempChannels.ChannelCategory,
 							DefaultUserCount: welcomer.DefaultTempChannels.DefaultUserCount,
+							Lobbies:          welcomer.DefaultTempChannels.Lobbies,
 						}
 					} else {
 						welcomer.Logger.Error().Err(err).
@@ -345,6 +352,7 @@ func (w *TempChannelsCog) RegisterCog(sub *subway.Subway) error {
 							ChannelLobby:     guildSettingsTempChannels.ChannelLobby,
 							ChannelCategory:  guildSettingsTempChannels.ChannelCategory,
 							DefaultUserCount: guildSettingsTempChannels.DefaultUserCount,
+							Lobbies:          guildSettingsTempChannels.Lobbies,
 						}, interaction.GetUser().ID)
 
 						return err
@@ -416,6 +424,7 @@ func (w *TempChannelsCog) RegisterCog(sub *subway.Subway) error {
 							ChannelLobby:     welcomer.DefaultTempChannels.ChannelLobby,
 							ChannelCategory:  welcomer.DefaultTempChannels.ChannelCategory,
 							DefaultUserCount: welcomer.DefaultTempChannels.DefaultUserCount,
+							Lobbies:          welcomer.DefaultTempChannels.Lobbies,
 						}
 					} else {
 						welcomer.Logger.Error().Err(err).
@@ -426,7 +435,13 @@ func (w *TempChannelsCog) RegisterCog(sub *subway.Subway) error {
 					}
 				}
 
-				guildSettingsTempChannels.ChannelCategory = int64(channel.ID)
+				// The category is set on the first lobby, which is the one these commands manage.
+				if lobbies := welcomer.GetTempChannelLobbies(guildSettingsTempChannels); len(lobbies) > 0 {
+					lobbies[0].CategoryID = channel.ID
+					welcomer.SetTempChannelLobbies(guildSettingsTempChannels, lobbies)
+				} else {
+					guildSettingsTempChannels.ChannelCategory = int64(channel.ID)
+				}
 
 				err = welcomer.RetryWithFallback(
 					func() error {
@@ -437,6 +452,7 @@ func (w *TempChannelsCog) RegisterCog(sub *subway.Subway) error {
 							ChannelLobby:     guildSettingsTempChannels.ChannelLobby,
 							ChannelCategory:  guildSettingsTempChannels.ChannelCategory,
 							DefaultUserCount: guildSettingsTempChannels.DefaultUserCount,
+							Lobbies:          guildSettingsTempChannels.Lobbies,
 						}, interaction.GetUser().ID)
 
 						return err
@@ -496,6 +512,7 @@ func (w *TempChannelsCog) RegisterCog(sub *subway.Subway) error {
 							ChannelLobby:     welcomer.DefaultTempChannels.ChannelLobby,
 							ChannelCategory:  welcomer.DefaultTempChannels.ChannelCategory,
 							DefaultUserCount: welcomer.DefaultTempChannels.DefaultUserCount,
+							Lobbies:          welcomer.DefaultTempChannels.Lobbies,
 						}
 					} else {
 						welcomer.Logger.Error().Err(err).
@@ -506,9 +523,25 @@ func (w *TempChannelsCog) RegisterCog(sub *subway.Subway) error {
 					}
 				}
 
-				if !channel.ID.IsNil() {
-					guildSettingsTempChannels.ChannelLobby = int64(channel.ID)
-				} else {
+				// The lobby replaces the first lobby, which is the one these commands manage.
+				lobbies := welcomer.GetTempChannelLobbies(guildSettingsTempChannels)
+
+				switch {
+				case len(lobbies) > 0 && !channel.ID.IsNil():
+					lobbies[0].ChannelID = channel.ID
+					welcomer.SetTempChannelLobbies(guildSettingsTempChannels, lobbies)
+				case len(lobbies) > 0:
+					welcomer.SetTempChannelLobbies(guildSettingsTempChannels, lobbies[1:])
+				case !channel.ID.IsNil():
+					welcomer.SetTempChannelLobbies(guildSettingsTempChannels, []welcomer.TempChannelLobby{
+						{
+							ChannelID:    channel.ID,
+							CategoryID:   discord.Snowflake(guildSettingsTempChannels.ChannelCategory),
+							NameTemplate: welcomer.DefaultTempChannelNameTemplate,
+							UserLimit:    guildSettingsTempChannels.DefaultUserCount,
+						},
+					})
+				default:
 					guildSettingsTempChannels.ChannelLobby = 0
 				}
 
@@ -521,6 +554,7 @@ func (w *TempChannelsCog) RegisterCog(sub *subway.Subway) error {
 							ChannelLobby:     guildSettingsTempChannels.ChannelLobby,
 							ChannelCategory:  guildSettingsTempChannels.ChannelCategory,
 							DefaultUserCount: guildSettingsTempChannels.DefaultUserCount,
+							Lobbies:          guildSettingsTempChannels.Lobbies,
 						}, interaction.GetUser().ID)
 
 						return err
@@ -562,5 +596,445 @@ func (w *TempChannelsCog) RegisterCog(sub *subway.Subway) error {
 
 	w.InteractionCommands.MustAddInteractionCommand(tempchannelsGroup)
 
+	tempchannelGroup := subway.NewSubcommandGroup(
+		"tempchannel",
+		"Manage the tempchannel you own.",
+	)
+
+	// Disable the tempchannel module for DM channels.
+	tempchannelGroup.DMPermission = new(false)
+
+	tempchannelGroup.MustAddInteractionCommand(&subway.InteractionCommandable{
+		Name:        "lock",
+		Description: "Stops anyone else from joining your tempchannel.",
+
+		Type: subway.InteractionCommandableTypeSubcommand,
+
+		DMPermission: new(false),
+
+		Handler: func(ctx context.Context, sub *subway.Subway, interaction discord.Interaction) (*discord.InteractionResponse, error) {
+			return w.withOwnedTempChannel(ctx, interaction, func(session *discord.Session, tempChannel *database.GuildTempChannels, channel *discord.Channel) (*discord.InteractionResponse, error) {
+				err := welcomer.EditChannelOverwrite(ctx, session, channel, *interaction.GuildID, discord.ChannelOverrideTypeRole, 0, discord.PermissionConnect, 0, "Tempchannel locked by owner")
+				if err != nil {
+					welcomer.Logger.Error().Err(err).
+						Int64("guild_id", tempChannel.GuildID).
+						Int64("channel_id", tempChannel.ChannelID).
+						Msg("Failed to edit tempchannel permissions")
+
+					return nil, err
+				}
+
+				err = welcomer.EditChannelOverwrite(ctx, session, channel, interaction.GetUser().ID, discord.ChannelOverrideTypeMember, discord.PermissionConnect, 0, 0, "Tempchannel owner")
+				if err != nil {
+					welcomer.Logger.Error().Err(err).
+						Int64("guild_id", tempChannel.GuildID).
+						Int64("channel_id", tempChannel.ChannelID).
+						Msg("Failed to edit tempchannel permissions")
+
+					return nil, err
+				}
+
+				return tempChannelResponse("Your tempchannel has been locked.", welcomer.EmbedColourSuccess), nil
+			})
+		},
+	})
+
+	tempchannelGroup.MustAddInteractionCommand(&subway.InteractionCommandable{
+		Name:        "unlock",
+		Description: "Lets anyone join your tempchannel again.",
+
+		Type: subway.InteractionCommandableTypeSubcommand,
+
+		DMPermission: new(false),
+
+		Handler: func(ctx context.Context, sub *subway.Subway, interaction discord.Interaction) (*discord.InteractionResponse, error) {
+			return w.withOwnedTempChannel(ctx, interaction, func(session *discord.Session, tempChannel *database.GuildTempChannels, channel *discord.Channel) (*discord.InteractionResponse, error) {
+				err := welcomer.EditChannelOverwrite(ctx, session, channel, *interaction.GuildID, discord.ChannelOverrideTypeRole, 0, 0, discord.PermissionConnect, "Tempchannel unlocked by owner")
+				if err != nil {
+					welcomer.Logger.Error().Err(err).
+						Int64("guild_id", tempChannel.GuildID).
+						Int64("channel_id", tempChannel.ChannelID).
+						Msg("Failed to edit tempchannel permissions")
+
+					return nil, err
+				}
+
+				return tempChannelResponse("Your tempchannel has been unlocked.", welcomer.EmbedColourSuccess), nil
+			})
+		},
+	})
+
+	tempchannelGroup.MustAddInteractionCommand(&subway.InteractionCommandable{
+		Name:        "hide",
+		Description: "Hides your tempchannel from everyone else.",
+
+		Type: subway.InteractionCommandableTypeSubcommand,
+
+		DMPermission: new(false),
+
+		Handler: func(ctx context.Context, sub *subway.Subway, interaction discord.Interaction) (*discord.InteractionResponse, error) {
+			return w.withOwnedTempChannel(ctx, interaction, func(session *discord.Session, tempChannel *database.GuildTempChannels, channel *discord.Channel) (*discord.InteractionResponse, error) {
+				err := welcomer.EditChannelOverwrite(ctx, session, channel, *interaction.GuildID, discord.ChannelOverrideTypeRole, 0, discord.PermissionViewChannel, 0, "Tempchannel hidden by owner")
+				if err != nil {
+					welcomer.Logger.Error().Err(err).
+						Int64("guild_id", tempChannel.GuildID).
+						Int64("channel_id", tempChannel.ChannelID).
+						Msg("Failed to edit tempchannel permissions")
+
+					return nil, err
+				}
+
+				err = welcomer.EditChannelOverwrite(ctx, session, channel, interaction.GetUser().ID, discord.ChannelOverrideTypeMember, discord.PermissionViewChannel|discord.PermissionConnect, 0, 0, "Tempchannel owner")
+				if err != nil {
+					welcomer.Logger.Error().Err(err).
+						Int64("guild_id", tempChannel.GuildID).
+						Int64("channel_id", tempChannel.ChannelID).
+						Msg("Failed to edit tempchannel permissions")
+
+					return nil, err
+				}
+
+				return tempChannelResponse("Your tempchannel has been hidden.", welcomer.EmbedColourSuccess), nil
+			})
+		},
+	})
+
+	tempchannelGroup.MustAddInteractionCommand(&subway.InteractionCommandable{
+		Name:        "unhide",
+		Description: "Makes your tempchannel visible to everyone again.",
+
+		Type: subway.InteractionCommandableTypeSubcommand,
+
+		DMPermission: new(false),
+
+		Handler: func(ctx context.Context, sub *subway.Subway, interaction discord.Interaction) (*discord.InteractionResponse, error) {
+			return w.withOwnedTempChannel(ctx, interaction, func(session *discord.Session, tempChannel *database.GuildTempChannels, channel *discord.Channel) (*discord.InteractionResponse, error) {
+				err := welcomer.EditChannelOverwrite(ctx, session, channel, *interaction.GuildID, discord.ChannelOverrideTypeRole, 0, 0, discord.PermissionViewChannel, "Tempchannel unhidden by owner")
+				if err != nil {
+					welcomer.Logger.Error().Err(err).
+						Int64("guild_id", tempChannel.GuildID).
+						Int64("channel_id", tempChannel.ChannelID).
+						Msg("Failed to edit tempchannel permissions")
+
+					return nil, err
+				}
+
+				return tempChannelResponse("Your tempchannel is now visible.", welcomer.EmbedColourSuccess), nil
+			})
+		},
+	})
+
+	tempchannelGroup.MustAddInteractionCommand(&subway.InteractionCommandable{
+		Name:        "limit",
+		Description: "Sets how many users can join your tempchannel.",
+
+		Type: subway.InteractionCommandableTypeSubcommand,
+
+		ArgumentParameter: []subway.ArgumentParameter{
+			{
+				Required:     true,
+				ArgumentType: subway.ArgumentTypeInt,
+				Name:         "limit",
+				Description:  "The maximum number of users, or 0 for no limit.",
+				MinValue:     new(int32(0)),
+				MaxValue:     new(int32(99)),
+			},
+		},
+
+		DMPermission: new(false),
+
+		Handler: func(ctx context.Context, sub *subway.Subway, interaction discord.Interaction) (*discord.InteractionResponse, error) {
+			return w.withOwnedTempChannel(ctx, interaction, func(session *discord.Session, tempChannel *database.GuildTempChannels, channel *discord.Channel) (*discord.InteractionResponse, error) {
+				limit := subway.MustGetArgument(ctx, "limit").MustInt()
+
+				_, err := channel.Edit(ctx, session, discord.ChannelParams{
+					UserLimit: new(int32(limit)),
+				}, new("Tempchannel user limit changed by owner"))
+				if err != nil {
+					welcomer.Logger.Error().Err(err).
+						Int64("guild_id", tempChannel.GuildID).
+						Int64("channel_id", tempChannel.ChannelID).
+						Msg("Failed to edit tempchannel user limit")
+
+					return nil, err
+				}
+
+				if limit == 0 {
+					return tempChannelResponse("Removed the user limit from your tempchannel.", welcomer.EmbedColourSuccess), nil
+				}
+
+				return tempChannelResponse(fmt.Sprintf("Set the user limit of your tempchannel to %d.", limit), welcomer.EmbedColourSuccess), nil
+			})
+		},
+	})
+
+	tempchannelGroup.MustAddInteractionCommand(&subway.InteractionCommandable{
+		Name:        "rename",
+		Description: "Renames your tempchannel.",
+
+		Type: subway.InteractionCommandableTypeSubcommand,
+
+		ArgumentParameter: []subway.ArgumentParameter{
+			{
+				Required:     true,
+				ArgumentType: subway.ArgumentTypeString,
+				Name:         "name",
+				Description:  "The new name of your tempchannel.",
+				MaxLength:    new(int32(100)),
+			},
+		},
+
+		DMPermission: new(false),
+
+		Handler: func(ctx context.Context, sub *subway.Subway, interaction discord.Interaction) (*discord.InteractionResponse, error) {
+			return w.withOwnedTempChannel(ctx, interaction, func(session *discord.Session, tempChannel *database.GuildTempChannels, channel *discord.Channel) (*discord.InteractionResponse, error) {
+				name := strings.TrimSpace(subway.MustGetArgument(ctx, "name").MustString())
+				if name == "" {
+					return tempChannelResponse("Please provide a name for your tempchannel.", welcomer.EmbedColourError), nil
+				}
+
+				_, err := channel.Edit(ctx, session, discord.ChannelParams{
+					Name: name,
+				}, new("Tempchannel renamed by owner"))
+				if err != nil {
+					welcomer.Logger.Error().Err(err).
+						Int64("guild_id", tempChannel.GuildID).
+						Int64("channel_id", tempChannel.ChannelID).
+						Msg("Failed to rename tempchannel")
+
+					return nil, err
+				}
+
+				return tempChannelResponse("Your tempchannel has been renamed.", welcomer.EmbedColourSuccess), nil
+			})
+		},
+	})
+
+	tempchannelGroup.MustAddInteractionCommand(&subway.InteractionCommandable{
+		Name:        "kick",
+		Description: "Disconnects a user from your tempchannel.",
+
+		Type: subway.InteractionCommandableTypeSubcommand,
+
+		ArgumentParameter: []subway.ArgumentParameter{
+			{
+				Required:     true,
+				ArgumentType: subway.ArgumentTypeMember,
+				Name:         "user",
+				Description:  "The user to disconnect.",
+			},
+		},
+
+		DMPermission: new(false),
+
+		Handler: func(ctx context.Context, sub *subway.Subway, interaction discord.Interaction) (*discord.InteractionResponse, error) {
+			return w.withOwnedTempChannel(ctx, interaction, func(session *discord.Session, tempChannel *database.GuildTempChannels, channel *discord.Channel) (*discord.InteractionResponse, error) {
+				member := subway.MustGetArgument(ctx, "user").MustMember()
+				if member.User == nil || member.User.ID == interaction.GetUser().ID {
+					return tempChannelResponse("You cannot do this to yourself.", welcomer.EmbedColourError), nil
+				}
+
+				// GuildID may be missing, fill it in.
+				member.GuildID = interaction.GuildID
+
+				isInChannel, err := welcomer.IsInTempChannel(ctx, tempChannel, member.User.ID)
+				if err != nil {
+					welcomer.Logger.Error().Err(err).
+						Int64("guild_id", tempChannel.GuildID).
+						Int64("channel_id", tempChannel.ChannelID).
+						Msg("Failed to check if user is in tempchannel")
+
+					return nil, err
+				}
+
+				if !isInChannel {
+					return tempChannelResponse(fmt.Sprintf("<@%d> is not in your tempchannel.", member.User.ID), welcomer.EmbedColourError), nil
+				}
+
+				err = member.MoveTo(ctx, session, nil, new("Removed from tempchannel by owner"))
+				if err != nil {
+					welcomer.Logger.Error().Err(err).
+						Int64("guild_id", tempChannel.GuildID).
+						Int64("channel_id", tempChannel.ChannelID).
+						Int64("user_id", int64(member.User.ID)).
+						Msg("Failed to disconnect user from tempchannel")
+
+					return nil, err
+				}
+
+				return tempChannelResponse(fmt.Sprintf("Disconnected <@%d> from your tempchannel.", member.User.ID), welcomer.EmbedColourSuccess), nil
+			})
+		},
+	})
+
+	tempchannelGroup.MustAddInteractionCommand(&subway.InteractionCommandable{
+		Name:        "ban",
+		Description: "Stops a user from joining your tempchannel.",
+
+		Type: subway.InteractionCommandableTypeSubcommand,
+
+		ArgumentParameter: []subway.ArgumentParameter{
+			{
+				Required:     true,
+				ArgumentType: subway.ArgumentTypeMember,
+				Name:         "user",
+				Description:  "The user to ban.",
+			},
+		},
+
+		DMPermission: new(false),
+
+		Handler: func(ctx context.Context, sub *subway.Subway, interaction discord.Interaction) (*discord.InteractionResponse, error) {
+			return w.withOwnedTempChannel(ctx, interaction, func(session *discord.Session, tempChannel *database.GuildTempChannels, channel *discord.Channel) (*discord.InteractionResponse, error) {
+				member := subway.MustGetArgument(ctx, "user").MustMember()
+				if member.User == nil || member.User.ID == interaction.GetUser().ID {
+					return tempChannelResponse("You cannot do this to yourself.", welcomer.EmbedColourError), nil
+				}
+
+				// GuildID may be missing, fill it in.
+				member.GuildID = interaction.GuildID
+
+				err := welcomer.EditChannelOverwrite(ctx, session, channel, member.User.ID, discord.ChannelOverrideTypeMember, 0, discord.PermissionConnect, 0, "Banned from tempchannel by owner")
+				if err != nil {
+					welcomer.Logger.Error().Err(err).
+						Int64("guild_id", tempChannel.GuildID).
+						Int64("channel_id", tempChannel.ChannelID).
+						Msg("Failed to edit tempchannel permissions")
+
+					return nil, err
+				}
+
+				isInChannel, err := welcomer.IsInTempChannel(ctx, tempChannel, member.User.ID)
+				if err != nil {
+					welcomer.Logger.Error().Err(err).
+						Int64("guild_id", tempChannel.GuildID).
+						Int64("channel_id", tempChannel.ChannelID).
+						Msg("Failed to check if user is in tempchannel")
+
+					return nil, err
+				}
+
+				if isInChannel {
+					err = member.MoveTo(ctx, session, nil, new("Removed from tempchannel by owner"))
+					if err != nil {
+						welcomer.Logger.Error().Err(err).
+							Int64("guild_id", tempChannel.GuildID).
+							Int64("channel_id", tempChannel.ChannelID).
+							Int64("user_id", int64(member.User.ID)).
+							Msg("Failed to disconnect user from tempchannel")
+
+						return nil, err
+					}
+				}
+
+				return tempChannelResponse(fmt.Sprintf("<@%d> has been banned from your tempchannel.", member.User.ID), welcomer.EmbedColourSuccess), nil
+			})
+		},
+	})
+
+	tempchannelGroup.MustAddInteractionCommand(&subway.InteractionCommandable{
+		Name:        "transfer",
+		Description: "Gives your tempchannel to another user.",
+
+		Type: subway.InteractionCommandableTypeSubcommand,
+
+		ArgumentParameter: []subway.ArgumentParameter{
+			{
+				Required:     true,
+				ArgumentType: subway.ArgumentTypeMember,
+				Name:         "user",
+				Description:  "The user to give your tempchannel to.",
+			},
+		},
+
+		DMPermission: new(false),
+
+		Handler: func(ctx context.Context, sub *subway.Subway, interaction discord.Interaction) (*discord.InteractionResponse, error) {
+			return w.withOwnedTempChannel(ctx, interaction, func(session *discord.Session, tempChannel *database.GuildTempChannels, channel *discord.Channel) (*discord.InteractionResponse, error) {
+				member := subway.MustGetArgument(ctx, "user").MustMember()
+				if member.User == nil || member.User.ID == interaction.GetUser().ID {
+					return tempChannelResponse("You cannot do this to yourself.", welcomer.EmbedColourError), nil
+				}
+
+				// GuildID may be missing, fill it in.
+				member.GuildID = interaction.GuildID
+
+				err := welcomer.TransferTempChannel(ctx, session, tempChannel, member.User.ID)
+				if errors.Is(err, welcomer.ErrTempChannelOwnerIsBot) {
+					return tempChannelResponse("You cannot give your tempchannel to a bot.", welcomer.EmbedColourError), nil
+				}
+
+				if errors.Is(err, welcomer.ErrTempChannelOwnerHasOther) {
+					return tempChannelResponse(fmt.Sprintf("<@%d> already owns a tempchannel.", member.User.ID), welcomer.EmbedColourError), nil
+				}
+
+				if err != nil {
+					welcomer.Logger.Error().Err(err).
+						Int64("guild_id", tempChannel.GuildID).
+						Int64("channel_id", tempChannel.ChannelID).
+						Msg("Failed to transfer tempchannel")
+
+					return nil, err
+				}
+
+				return tempChannelResponse(fmt.Sprintf("<@%d> now owns your tempchannel.", member.User.ID), welcomer.EmbedColourSuccess), nil
+			})
+		},
+	})
+
+	w.InteractionCommands.MustAddInteractionCommand(tempchannelGroup)
+
 	return nil
 }
+
+func tempChannelResponse(message string, colour int32) *discord.InteractionResponse {
+	return &discord.InteractionResponse{
+		Type: discord.InteractionCallbackTypeChannelMessageSource,
+		Data: &discord.InteractionCallbackData{
+			Embeds: welcomer.NewEmbed(message, colour),
+			Flags:  uint32(discord.MessageFlagEphemeral),
+		},
+	}
+}
+
+// withOwnedTempChannel runs handler with the tempchannel owned by the user running the command.
+func (w *TempChannelsCog) withOwnedTempChannel(ctx context.Context, interaction discord.Interaction, handler func(session *discord.Session, tempChannel *database.GuildTempChannels, channel *discord.Channel) (*discord.InteractionResponse, error)) (*discord.InteractionResponse, error) {
+	return core.RequireGuild(interaction, func() (*discord.InteractionResponse, error) {
+		tempChannel, err := welcomer.Queries.GetTempChannelByOwner(ctx, database.GetTempChannelByOwnerParams{
+			GuildID: int64(*interaction.GuildID),
+			OwnerID: int64(interaction.GetUser().ID),
+		})
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return tempChannelResponse("You do not own a tempchannel.", welcomer.EmbedColourError), nil
+			}
+
+			welcomer.Logger.Error().Err(err).
+				Int64("guild_id", int64(*interaction.GuildID)).
+				Msg("Failed to get tempchannel for owner")
+
+			return nil, err
+		}
+
+		channel, err := welcomer.FetchGuildChannel(ctx, *interaction.GuildID, discord.Snowflake(tempChannel.ChannelID))
+		if err != nil {
+			if errors.Is(err, welcomer.ErrMissingChannel) {
+				return tempChannelResponse("Your tempchannel no longer exists.", welcomer.EmbedColourError), nil
+			}
+
+			welcomer.Logger.Error().Err(err).
+				Int64("guild_id", int64(*interaction.GuildID)).
+				Int64("channel_id", tempChannel.ChannelID).
+				Msg("Failed to fetch tempchannel")
+
+			return nil, err
+		}
+
+		session, err := welcomer.AcquireSession(ctx, welcomer.GetManagerNameFromContext(ctx))
+		if err != nil {
+			return nil, err
+		}
+
+		return handler(session, tempChannel, channel)
+	})
+}
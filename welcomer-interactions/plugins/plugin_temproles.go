@@ -0,0 +1,278 @@
+package plugins
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/WelcomerTeam/Discord/discord"
+	subway "github.com/WelcomerTeam/Subway/subway"
+	"github.com/WelcomerTeam/Welcomer/welcomer-core"
+	"github.com/WelcomerTeam/Welcomer/welcomer-core/database"
+	"github.com/gofrs/uuid"
+	"github.com/jackc/pgx/v4"
+)
+
+func NewTempRolesCog() *TempRolesCog {
+	return &TempRolesCog{
+		InteractionCommands: subway.SetupInteractionCommandable(&subway.InteractionCommandable{}),
+	}
+}
+
+type TempRolesCog struct {
+	InteractionCommands *subway.InteractionCommandable
+}
+
+// Assert types.
+
+var (
+	_ subway.Cog                        = (*TempRolesCog)(nil)
+	_ subway.CogWithInteractionCommands = (*TempRolesCog)(nil)
+)
+
+func (r *TempRolesCog) CogInfo() *subway.CogInfo {
+	return &subway.CogInfo{
+		Name:        "TempRoles",
+		Description: "Provides the cog for the 'TempRoles' feature.",
+	}
+}
+
+func (r *TempRolesCog) GetInteractionCommandable() *subway.InteractionCommandable {
+	return r.InteractionCommands
+}
+
+func (r *TempRolesCog) RegisterCog(sub *subway.Subway) error {
+	temproleGroup := subway.NewSubcommandGroup(
+		"temprole",
+		"Gives roles to users that are removed automatically after some time.",
+	)
+
+	// Disable the temprole module for DM channels.
+	temproleGroup.DMPermission = new(false)
+
+	temproleGroup.MustAddInteractionCommand(&subway.InteractionCommandable{
+		Name:        "give",
+		Description: "Gives a user a role for a period of time.",
+
+		Type: subway.InteractionCommandableTypeSubcommand,
+
+		ArgumentParameter: []subway.ArgumentParameter{
+			{
+				Required:     true,
+				ArgumentType: subway.ArgumentTypeMember,
+				Name:         "user",
+				Description:  "The user to give the role to.",
+			},
+			{
+				Required:     true,
+				ArgumentType: subway.ArgumentTypeRole,
+				Name:         "role",
+				Description:  "The role to give.",
+			},
+			{
+				Required:     true,
+				ArgumentType: subway.ArgumentTypeString,
+				Name:         "duration",
+				Description:  "How long the user keeps the role for, such as 3d or 12h.",
+			},
+		},
+
+		DMPermission:            new(false),
+		DefaultMemberPermission: new(discord.Int64(welcomer.PermissionElevated)),
+
+		Handler: func(ctx context.Context, sub *subway.Subway, interaction discord.Interaction) (*discord.InteractionResponse, error) {
+			return welcomer.RequireGuildElevation(sub, interaction, func() (*discord.InteractionResponse, error) {
+				member := subway.MustGetArgument(ctx, "user").MustMember()
+				role := subway.MustGetArgument(ctx, "role").MustRole()
+				durationString := subway.MustGetArgument(ctx, "duration").MustString()
+
+				seconds, err := welcomer.ParseDurationAsSeconds(durationString)
+				if err != nil || seconds <= 0 {
+					return &discord.InteractionResponse{
+						Type: discord.InteractionCallbackTypeChannelMessageSource,
+						Data: &discord.InteractionCallbackData{
+							Embeds: welcomer.NewEmbed("Invalid duration. Please use a duration such as `3d` or `12h`.", welcomer.EmbedColourError),
+							Flags:  uint32(discord.MessageFlagEphemeral),
+						},
+					}, nil
+				}
+
+				canAssignRoles, isRoleAssignable, isRoleElevated, err := welcomer.Accelerator_CanAssignRole(ctx, *interaction.GuildID, &role)
+				if err != nil {
+					welcomer.Logger.Error().Err(err).
+						Int64("guild_id", int64(*interaction.GuildID)).
+						Msg("Failed to check if welcomer can assign role")
+
+					return nil, err
+				}
+
+				if !canAssignRoles {
+					return &discord.InteractionResponse{
+						Type: discord.InteractionCallbackTypeChannelMessageSource,
+						Data: &discord.InteractionCallbackData{
+							Embeds: welcomer.NewEmbed("Welcomer is missing permissions to assign roles", welcomer.EmbedColourError),
+							Flags:  uint32(discord.MessageFlagEphemeral),
+						},
+					}, nil
+				}
+
+				if !isRoleAssignable {
+					return &discord.InteractionResponse{
+						Type: discord.InteractionCallbackTypeChannelMessageSource,
+						Data: &discord.InteractionCallbackData{
+							Embeds: welcomer.NewEmbed("### This role is not assignable\nWelcomer cannot assign users this role as it does not have permission to manage roles or Welcomer's highest role is below this role's position. Please rearrange your roles in the server settings to move Welcomer's role above this role.", welcomer.EmbedColourError),
+							Flags:  uint32(discord.MessageFlagEphemeral),
+						},
+					}, nil
+				}
+
+				if isRoleElevated {
+					return &discord.InteractionResponse{
+						Type: discord.InteractionCallbackTypeChannelMessageSource,
+						Data: &discord.InteractionCallbackData{
+							Embeds: welcomer.NewEmbed("This role has elevated permissions and cannot be given as a temporary role.", welcomer.EmbedColourError),
+							Flags:  uint32(discord.MessageFlagEphemeral),
+						},
+					}, nil
+				}
+
+				session, err := welcomer.AcquireSession(ctx, welcomer.GetManagerNameFromContext(ctx))
+				if err != nil {
+					return nil, err
+				}
+
+				// GuildID may be missing, fill it in.
+				member.GuildID = interaction.GuildID
+
+				err = member.AddRoles(ctx, session,
+					[]discord.Snowflake{role.ID},
+					new(fmt.Sprintf("Temporary role given by %s", interaction.GetUser().Username)),
+					true,
+				)
+				if err != nil {
+					return nil, err
+				}
+
+				tempRole, err := welcomer.RecordTempRole(ctx, *interaction.GuildID, member.User.ID, role.ID, time.Duration(seconds)*time.Second, database.TempRoleSourceCommand, uuid.Nil, interaction.GetUser().ID)
+				if err != nil {
+					welcomer.Logger.Error().Err(err).
+						Int64("guild_id", int64(*interaction.GuildID)).
+						Int64("user_id", int64(member.User.ID)).
+						Int64("role_id", int64(role.ID)).
+						Msg("Failed to record temp role")
+
+					return nil, err
+				}
+
+				return &discord.InteractionResponse{
+					Type: discord.InteractionCallbackTypeChannelMessageSource,
+					Data: &discord.InteractionCallbackData{
+						Embeds: welcomer.NewEmbed(fmt.Sprintf("Gave <@&%d> to <@%d>. It will be removed <t:%d:R>.", role.ID, member.User.ID, tempRole.ExpiresAt.Unix()), welcomer.EmbedColourSuccess),
+						Flags:  uint32(discord.MessageFlagEphemeral),
+					},
+				}, nil
+			})
+		},
+	})
+
+	temproleGroup.MustAddInteractionCommand(&subway.InteractionCommandable{
+		Name:        "remove",
+		Description: "Removes a temporary role from a user before it expires.",
+
+		Type: subway.InteractionCommandableTypeSubcommand,
+
+		ArgumentParameter: []subway.ArgumentParameter{
+			{
+				Required:     true,
+				ArgumentType: subway.ArgumentTypeMember,
+				Name:         "user",
+				Description:  "The user to remove the role from.",
+			},
+			{
+				Required:     true,
+				ArgumentType: subway.ArgumentTypeRole,
+				Name:         "role",
+				Description:  "The role to remove.",
+			},
+		},
+
+		DMPermission:            new(false),
+		DefaultMemberPermission: new(discord.Int64(welcomer.PermissionElevated)),
+
+		Handler: func(ctx context.Context, sub *subway.Subway, interaction discord.Interaction) (*discord.InteractionResponse, error) {
+			return welcomer.RequireGuildElevation(sub, interaction, func() (*discord.InteractionResponse, error) {
+				member := subway.MustGetArgument(ctx, "user").MustMember()
+				role := subway.MustGetArgument(ctx, "role").MustRole()
+
+				// Only roles in the temp-role ledger can be removed, so this cannot be used to take any role away.
+				tempRole, err := welcomer.Queries.GetTempRole(ctx, database.GetTempRoleParams{
+					GuildID: int64(*interaction.GuildID),
+					UserID:  int64(member.User.ID),
+					RoleID:  int64(role.ID),
+				})
+				if err != nil {
+					if errors.Is(err, pgx.ErrNoRows) {
+						return &discord.InteractionResponse{
+							Type: discord.InteractionCallbackTypeChannelMessageSource,
+							Data: &discord.InteractionCallbackData{
+								Embeds: welcomer.NewEmbed(fmt.Sprintf("<@&%d> is not a temporary role for <@%d>.", role.ID, member.User.ID), welcomer.EmbedColourError),
+								Flags:  uint32(discord.MessageFlagEphemeral),
+							},
+						}, nil
+					}
+
+					welcomer.Logger.Error().Err(err).
+						Int64("guild_id", int64(*interaction.GuildID)).
+						Int64("user_id", int64(member.User.ID)).
+						Int64("role_id", int64(role.ID)).
+						Msg("Failed to get temp role")
+
+					return nil, err
+				}
+
+				session, err := welcomer.AcquireSession(ctx, welcomer.GetManagerNameFromContext(ctx))
+				if err != nil {
+					return nil, err
+				}
+
+				// GuildID may be missing, fill it in.
+				member.GuildID = interaction.GuildID
+
+				err = member.RemoveRoles(ctx, session,
+					[]discord.Snowflake{role.ID},
+					new(fmt.Sprintf("Temporary role removed by %s", interaction.GetUser().Username)),
+					true,
+				)
+				if err != nil {
+					return nil, err
+				}
+
+				err = welcomer.ForgetTempRole(ctx, *interaction.GuildID, member.User.ID, role.ID)
+				if err != nil {
+					welcomer.Logger.Error().Err(err).
+						Int64("guild_id", int64(*interaction.GuildID)).
+						Int64("user_id", int64(member.User.ID)).
+						Int64("role_id", int64(role.ID)).
+						Msg("Failed to forget temp role")
+
+					return nil, err
+				}
+
+				welcomer.PushTempRoleRemoved(ctx, tempRole, interaction.GetUser().ID)
+
+				return &discord.InteractionResponse{
+					Type: discord.InteractionCallbackTypeChannelMessageSource,
+					Data: &discord.InteractionCallbackData{
+						Embeds: welcomer.NewEmbed(fmt.Sprintf("Removed <@&%d> from <@%d>.", role.ID, member.User.ID), welcomer.EmbedColourSuccess),
+						Flags:  uint32(discord.MessageFlagEphemeral),
+					},
+				}, nil
+			})
+		},
+	})
+
+	r.InteractionCommands.MustAddInteractionCommand(temproleGroup)
+
+	return nil
+}
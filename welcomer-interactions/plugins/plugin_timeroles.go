@@ -2,6 +2,7 @@ package plugins
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"slices"
@@ -9,6 +10,7 @@ import (
 	"time"
 
 	"github.com/WelcomerTeam/Discord/discord"
+	sandwich "github.com/WelcomerTeam/Sandwich-Daemon/proto"
 	subway "github.com/WelcomerTeam/Subway/subway"
 	"github.com/WelcomerTeam/Welcomer/welcomer-core"
 	"github.com/WelcomerTeam/Welcomer/welcomer-core/database"
@@ -70,6 +72,7 @@ func (r *TimeRolesCog) RegisterCog(sub *subway.Subway) error {
 							GuildID:       int64(*interaction.GuildID),
 							ToggleEnabled: welcomer.DefaultTimeRoles.ToggleEnabled,
 							Timeroles:     welcomer.DefaultTimeRoles.Timeroles,
+							ToggleLadder:  welcomer.DefaultTimeRoles.ToggleLadder,
 						}
 					} else {
 						welcomer.Logger.Error().Err(err).
@@ -80,14 +83,19 @@ func (r *TimeRolesCog) RegisterCog(sub *subway.Subway) error {
 					}
 				}
 
+				oldTimeRoles := *guildSettingsTimeRoles
+
 				guildSettingsTimeRoles.ToggleEnabled = true
 
+				var newTimeRoles *database.GuildSettingsTimeroles
+
 				err = welcomer.RetryWithFallback(
 					func() error {
-						_, err = welcomer.CreateOrUpdateTimeRolesGuildSettingsWithAudit(ctx, database.CreateOrUpdateTimeRolesGuildSettingsParams{
+						newTimeRoles, err = welcomer.CreateOrUpdateTimeRolesGuildSettingsWithAudit(ctx, database.CreateOrUpdateTimeRolesGuildSettingsParams{
 							GuildID:       int64(*interaction.GuildID),
 							ToggleEnabled: guildSettingsTimeRoles.ToggleEnabled,
 							Timeroles:     guildSettingsTimeRoles.Timeroles,
+							ToggleLadder:  guildSettingsTimeRoles.ToggleLadder,
 						}, interaction.GetUser().ID)
 
 						return err
@@ -105,6 +113,8 @@ func (r *TimeRolesCog) RegisterCog(sub *subway.Subway) error {
 					return nil, err
 				}
 
+				welcomer.SyncTimeRolesGuildSettings(ctx, &oldTimeRoles, newTimeRoles)
+
 				return &discord.InteractionResponse{
 					Type: discord.InteractionCallbackTypeChannelMessageSource,
 					Data: &discord.InteractionCallbackData{
@@ -133,6 +143,7 @@ func (r *TimeRolesCog) RegisterCog(sub *subway.Subway) error {
 							GuildID:       int64(*interaction.GuildID),
 							ToggleEnabled: welcomer.DefaultTimeRoles.ToggleEnabled,
 							Timeroles:     welcomer.DefaultTimeRoles.Timeroles,
+							ToggleLadder:  welcomer.DefaultTimeRoles.ToggleLadder,
 						}
 					} else {
 						welcomer.Logger.Error().Err(err).
@@ -150,6 +161,7 @@ func (r *TimeRolesCog) RegisterCog(sub *subway.Subway) error {
 							GuildID:       int64(*interaction.GuildID),
 							ToggleEnabled: guildSettingsTimeRoles.ToggleEnabled,
 							Timeroles:     guildSettingsTimeRoles.Timeroles,
+							ToggleLadder:  guildSettingsTimeRoles.ToggleLadder,
 						}, interaction.GetUser().ID)
 
 						return err
@@ -183,10 +195,24 @@ func (r *TimeRolesCog) RegisterCog(sub *subway.Subway) error {
 
 		Type: subway.InteractionCommandableTypeSubcommand,
 
+		ArgumentParameter: []subway.ArgumentParameter{
+			{
+				Required:     false,
+				ArgumentType: subway.ArgumentTypeMember,
+				Name:         "user",
+				Description:  "The user to check the timeroles progress of.",
+			},
+		},
+
 		DMPermission: new(false),
 
 		Handler: func(ctx context.Context, sub *subway.Subway, interaction discord.Interaction) (*discord.InteractionResponse, error) {
 			return welcomer.RequireGuild(interaction, func() (*discord.InteractionResponse, error) {
+				member := subway.MustGetArgument(ctx, "user").MustMember()
+				if member.User == nil || member.User.ID.IsNil() {
+					member = *interaction.Member
+				}
+
 				guildSettingsTimeRoles, err := welcomer.Queries.GetTimeRolesGuildSettings(ctx, int64(*interaction.GuildID))
 				if err != nil {
 					if errors.Is(err, pgx.ErrNoRows) {
@@ -194,6 +220,7 @@ func (r *TimeRolesCog) RegisterCog(sub *subway.Subway) error {
 							GuildID:       int64(*interaction.GuildID),
 							ToggleEnabled: welcomer.DefaultTimeRoles.ToggleEnabled,
 							Timeroles:     welcomer.DefaultTimeRoles.Timeroles,
+							ToggleLadder:  welcomer.DefaultTimeRoles.ToggleLadder,
 						}
 					} else {
 						welcomer.Logger.Error().Err(err).
@@ -245,47 +272,53 @@ func (r *TimeRolesCog) RegisterCog(sub *subway.Subway) error {
 
 				now := time.Now()
 
-				hasTimeRoleRemaining := false
+				subject := "You"
+				if member.User.ID != interaction.GetUser().ID {
+					subject = fmt.Sprintf("<@%d>", member.User.ID)
+				}
+
+				currentTier, nextTier := welcomer.GetTimeRoleTiers(member.JoinedAt, timeRoleList, now)
+
+				hasTimeRoleRemaining := nextTier != nil
 				var timeRoleRemainingPercent int
 
-				// Check if the user has any time roles remaining.
-				// If they do, then set the embed description to the first one.
-				for _, role := range timeRoleList {
-					roleGivenAt := interaction.Member.JoinedAt.Add(time.Second * time.Duration(role.Seconds))
+				embed.Description = fmt.Sprintf("%s joined this server <t:%d:R>!\n\n", subject, member.JoinedAt.Unix())
 
-					if roleGivenAt.After(now) {
-						embed.Description = fmt.Sprintf(
-							"You joined this server <t:%d:R>!\n\nNext role: <@&%d>\nTime until next role: <t:%d:R>\n\n",
-							interaction.Member.JoinedAt.Unix(),
-							role.Role,
-							interaction.Member.JoinedAt.Add(time.Second*time.Duration(role.Seconds)).Unix(),
-						)
+				if currentTier != nil {
+					embed.Description += fmt.Sprintf("Current tier: <@&%d>\n", currentTier.Role)
+				} else {
+					embed.Description += "Current tier: None\n"
+				}
 
-						hasTimeRoleRemaining = true
-						timeRoleRemainingPercent = int((float64(time.Since(interaction.Member.JoinedAt).Seconds()) /
-							float64(role.Seconds)) * 100)
+				// If there is a next tier, show when it will be given.
+				if hasTimeRoleRemaining {
+					nextTierAt := member.JoinedAt.Add(time.Second * time.Duration(nextTier.Seconds))
 
-						break
-					}
+					embed.Description += fmt.Sprintf("Next tier: <@&%d>\nTime until next tier: <t:%d:R>\n\n", nextTier.Role, nextTierAt.Unix())
+
+					timeRoleRemainingPercent = int((float64(now.Sub(member.JoinedAt).Seconds()) /
+						float64(nextTier.Seconds)) * 100)
+				} else {
+					embed.Description += "There are no more roles left!\n\n"
 				}
 
-				// If the user has no time roles remaining then let them know.
-				if !hasTimeRoleRemaining {
-					embed.Description = fmt.Sprintf(
-						"You joined this server <t:%d:R>!\n\nThere are no more roles left!\n\n",
-						interaction.Member.JoinedAt.Unix(),
-					)
+				if guildSettingsTimeRoles.ToggleLadder {
+					embed.Description += "Ladder mode is enabled, so only the highest tier is kept.\n\n"
 				}
 
 				// List all the time roles.
 				for _, role := range timeRoleList {
-					roleGivenAt := interaction.Member.JoinedAt.Add(time.Second * time.Duration(role.Seconds))
+					roleGivenAt := member.JoinedAt.Add(time.Second * time.Duration(role.Seconds))
 
 					var roleMessage string
 
-					if roleGivenAt.After(now) {
+					switch {
+					case roleGivenAt.After(now):
 						roleMessage = fmt.Sprintf(welcomer.EmojiNeutral+" <@&%d> <t:%d:R>\n", role.Role, roleGivenAt.Unix())
-					} else {
+					case guildSettingsTimeRoles.ToggleLadder && currentTier != nil && role.Seconds < currentTier.Seconds:
+						// Lower tiers are removed in ladder mode.
+						roleMessage = fmt.Sprintf(welcomer.EmojiCross+" ~~<@&%d>~~\n", role.Role)
+					default:
 						roleMessage = fmt.Sprintf(welcomer.EmojiCheck+" <@&%d>\n", role.Role)
 					}
 
@@ -334,6 +367,7 @@ func (r *TimeRolesCog) RegisterCog(sub *subway.Subway) error {
 							GuildID:       int64(*interaction.GuildID),
 							ToggleEnabled: welcomer.DefaultTimeRoles.ToggleEnabled,
 							Timeroles:     welcomer.DefaultTimeRoles.Timeroles,
+							ToggleLadder:  welcomer.DefaultTimeRoles.ToggleLadder,
 						}
 					} else {
 						welcomer.Logger.Error().Err(err).
@@ -509,6 +543,7 @@ func (r *TimeRolesCog) RegisterCog(sub *subway.Subway) error {
 							GuildID:       int64(*interaction.GuildID),
 							ToggleEnabled: welcomer.DefaultTimeRoles.ToggleEnabled,
 							Timeroles:     welcomer.DefaultTimeRoles.Timeroles,
+							ToggleLadder:  welcomer.DefaultTimeRoles.ToggleLadder,
 						}
 					} else {
 						welcomer.Logger.Error().Err(err).
@@ -537,13 +572,18 @@ func (r *TimeRolesCog) RegisterCog(sub *subway.Subway) error {
 					Seconds: seconds,
 				})
 
+				oldTimeRoles := *guildSettingsTempChannels
+
+				var newTimeRoles *database.GuildSettingsTimeroles
+
 				// Update the guild settings with the new timeRoles
 				err = welcomer.RetryWithFallback(
 					func() error {
-						_, err = welcomer.CreateOrUpdateTimeRolesGuildSettingsWithAudit(ctx, database.CreateOrUpdateTimeRolesGuildSettingsParams{
+						newTimeRoles, err = welcomer.CreateOrUpdateTimeRolesGuildSettingsWithAudit(ctx, database.CreateOrUpdateTimeRolesGuildSettingsParams{
 							GuildID:       int64(*interaction.GuildID),
 							ToggleEnabled: guildSettingsTempChannels.ToggleEnabled,
 							Timeroles:     welcomer.BytesToJSONB(welcomer.MarshalTimeRolesJSON(timeRoles)),
+							ToggleLadder:  guildSettingsTempChannels.ToggleLadder,
 						}, interaction.GetUser().ID)
 
 						return err
@@ -561,6 +601,8 @@ func (r *TimeRolesCog) RegisterCog(sub *subway.Subway) error {
 					return nil, err
 				}
 
+				welcomer.SyncTimeRolesGuildSettings(ctx, &oldTimeRoles, newTimeRoles)
+
 				return &discord.InteractionResponse{
 					Type: discord.InteractionCallbackTypeChannelMessageSource,
 					Data: &discord.InteractionCallbackData{
@@ -600,6 +642,7 @@ func (r *TimeRolesCog) RegisterCog(sub *subway.Subway) error {
 							GuildID:       int64(*interaction.GuildID),
 							ToggleEnabled: welcomer.DefaultTimeRoles.ToggleEnabled,
 							Timeroles:     welcomer.DefaultTimeRoles.Timeroles,
+							ToggleLadder:  welcomer.DefaultTimeRoles.ToggleLadder,
 						}
 					} else {
 						welcomer.Logger.Error().Err(err).
@@ -626,13 +669,18 @@ func (r *TimeRolesCog) RegisterCog(sub *subway.Subway) error {
 				// Remove the role from the list.
 				timeRoles = slices.DeleteFunc(timeRoles, func(tr welcomer.GuildSettingsTimeRolesRole) bool { return tr.Role == role.ID })
 
+				oldTimeRoles := *guildSettingsTimeRoles
+
+				var newTimeRoles *database.GuildSettingsTimeroles
+
 				// Update the guild settings with the new timeRoles
 				err = welcomer.RetryWithFallback(
 					func() error {
-						_, err = welcomer.CreateOrUpdateTimeRolesGuildSettingsWithAudit(ctx, database.CreateOrUpdateTimeRolesGuildSettingsParams{
+						newTimeRoles, err = welcomer.CreateOrUpdateTimeRolesGuildSettingsWithAudit(ctx, database.CreateOrUpdateTimeRolesGuildSettingsParams{
 							GuildID:       int64(*interaction.GuildID),
 							ToggleEnabled: guildSettingsTimeRoles.ToggleEnabled,
 							Timeroles:     welcomer.BytesToJSONB(welcomer.MarshalTimeRolesJSON(timeRoles)),
+							ToggleLadder:  guildSettingsTimeRoles.ToggleLadder,
 						}, interaction.GetUser().ID)
 
 						return err
@@ -650,6 +698,8 @@ func (r *TimeRolesCog) RegisterCog(sub *subway.Subway) error {
 					return nil, err
 				}
 
+				welcomer.SyncTimeRolesGuildSettings(ctx, &oldTimeRoles, newTimeRoles)
+
 				return &discord.InteractionResponse{
 					Type: discord.InteractionCallbackTypeChannelMessageSource,
 					Data: &discord.InteractionCallbackData{
@@ -660,6 +710,181 @@ func (r *TimeRolesCog) RegisterCog(sub *subway.Subway) error {
 		},
 	})
 
+	ruleGroup.MustAddInteractionCommand(&subway.InteractionCommandable{
+		Name:        "ladder",
+		Description: "Only keep the highest timerole a user has earned and remove the lower ones.",
+
+		Type: subway.InteractionCommandableTypeSubcommand,
+
+		ArgumentParameter: []subway.ArgumentParameter{
+			{
+				Required:     true,
+				ArgumentType: subway.ArgumentTypeBool,
+				Name:         "enabled",
+				Description:  "Whether ladder mode is enabled.",
+			},
+		},
+
+		DMPermission:            new(false),
+		DefaultMemberPermission: new(discord.Int64(welcomer.PermissionElevated)),
+
+		Handler: func(ctx context.Context, sub *subway.Subway, interaction discord.Interaction) (*discord.InteractionResponse, error) {
+			return welcomer.RequireGuildElevation(sub, interaction, func() (*discord.InteractionResponse, error) {
+				enabled := subway.MustGetArgument(ctx, "enabled").MustBool()
+
+				guildSettingsTimeRoles, err := welcomer.Queries.GetTimeRolesGuildSettings(ctx, int64(*interaction.GuildID))
+				if err != nil {
+					if errors.Is(err, pgx.ErrNoRows) {
+						guildSettingsTimeRoles = &database.GuildSettingsTimeroles{
+							GuildID:       int64(*interaction.GuildID),
+							ToggleEnabled: welcomer.DefaultTimeRoles.ToggleEnabled,
+							Timeroles:     welcomer.DefaultTimeRoles.Timeroles,
+							ToggleLadder:  welcomer.DefaultTimeRoles.ToggleLadder,
+						}
+					} else {
+						welcomer.Logger.Error().Err(err).
+							Int64("guild_id", int64(*interaction.GuildID)).
+							Msg("Failed to get timeroles guild settings")
+
+						return nil, err
+					}
+				}
+
+				oldTimeRoles := *guildSettingsTimeRoles
+
+				guildSettingsTimeRoles.ToggleLadder = enabled
+
+				var newTimeRoles *database.GuildSettingsTimeroles
+
+				err = welcomer.RetryWithFallback(
+					func() error {
+						newTimeRoles, err = welcomer.CreateOrUpdateTimeRolesGuildSettingsWithAudit(ctx, database.CreateOrUpdateTimeRolesGuildSettingsParams{
+							GuildID:       int64(*interaction.GuildID),
+							ToggleEnabled: guildSettingsTimeRoles.ToggleEnabled,
+							Timeroles:     guildSettingsTimeRoles.Timeroles,
+							ToggleLadder:  guildSettingsTimeRoles.ToggleLadder,
+						}, interaction.GetUser().ID)
+
+						return err
+					},
+					func() error {
+						return welcomer.EnsureGuild(ctx, discord.Snowflake(*interaction.GuildID))
+					},
+					nil,
+				)
+				if err != nil {
+					welcomer.Logger.Error().Err(err).
+						Int64("guild_id", int64(*interaction.GuildID)).
+						Msg("Failed to update timeroles guild settings")
+
+					return nil, err
+				}
+
+				welcomer.SyncTimeRolesGuildSettings(ctx, &oldTimeRoles, newTimeRoles)
+
+				var message string
+				if enabled {
+					message = "Enabled ladder mode. Users will only keep their highest timerole. Run `/timeroles backfill` to apply this to existing users."
+				} else {
+					message = "Disabled ladder mode. Users will keep every timerole they have earned."
+				}
+
+				return &discord.InteractionResponse{
+					Type: discord.InteractionCallbackTypeChannelMessageSource,
+					Data: &discord.InteractionCallbackData{
+						Embeds: welcomer.NewEmbed(message, welcomer.EmbedColourSuccess),
+					},
+				}, nil
+			})
+		},
+	})
+
+	ruleGroup.MustAddInteractionCommand(&subway.InteractionCommandable{
+		Name:        "backfill",
+		Description: "Applies timeroles to every existing user in the server.",
+
+		Type: subway.InteractionCommandableTypeSubcommand,
+
+		DMPermission:            new(false),
+		DefaultMemberPermission: new(discord.Int64(welcomer.PermissionElevated)),
+
+		Handler: func(ctx context.Context, sub *subway.Subway, interaction discord.Interaction) (*discord.InteractionResponse, error) {
+			return welcomer.RequireGuildElevation(sub, interaction, func() (*discord.InteractionResponse, error) {
+				guildSettingsTimeRoles, err := welcomer.Queries.GetTimeRolesGuildSettings(ctx, int64(*interaction.GuildID))
+				if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+					welcomer.Logger.Error().Err(err).
+						Int64("guild_id", int64(*interaction.GuildID)).
+						Msg("Failed to get timeroles guild settings")
+
+					return nil, err
+				}
+
+				if guildSettingsTimeRoles == nil || !guildSettingsTimeRoles.ToggleEnabled {
+					return &discord.InteractionResponse{
+						Type: discord.InteractionCallbackTypeChannelMessageSource,
+						Data: &discord.InteractionCallbackData{
+							Embeds: welcomer.NewEmbed("Timeroles are disabled for this server.", welcomer.EmbedColourError),
+							Flags:  uint32(discord.MessageFlagEphemeral),
+						},
+					}, nil
+				}
+
+				timeRoles := welcomer.UnmarshalTimeRolesJSON(guildSettingsTimeRoles.Timeroles.Bytes)
+				if len(timeRoles) == 0 {
+					return &discord.InteractionResponse{
+						Type: discord.InteractionCallbackTypeChannelMessageSource,
+						Data: &discord.InteractionCallbackData{
+							Embeds: welcomer.NewEmbed("There are no timeroles set for this server.", welcomer.EmbedColourInfo),
+							Flags:  uint32(discord.MessageFlagEphemeral),
+						},
+					}, nil
+				}
+
+				// Chunking large guilds can take longer than the interaction allows.
+				go func(ctx context.Context, sub *subway.Subway, interaction discord.Interaction) {
+					embeds := welcomer.NewEmbed("Failed to backfill timeroles. Please try again later.", welcomer.EmbedColourError)
+
+					backfilled, err := welcomer.BackfillGuildTimeRoles(ctx, *interaction.GuildID, timeRoles)
+					if err != nil {
+						welcomer.Logger.Error().Err(err).
+							Int64("guild_id", int64(*interaction.GuildID)).
+							Msg("Failed to backfill timeroles")
+					} else {
+						embeds = welcomer.NewEmbed(fmt.Sprintf("Queued %d users to have their timeroles checked. This may take a few minutes.", backfilled), welcomer.EmbedColourSuccess)
+
+						data, err := json.Marshal(welcomer.CustomEventInvokeTimeRolesStructure{
+							GuildID: *interaction.GuildID,
+						})
+						if err == nil {
+							_, err = sub.SandwichClient.RelayMessage(ctx, &sandwich.RelayMessageRequest{
+								Identifier: welcomer.GetManagerNameFromContext(ctx),
+								Type:       welcomer.CustomEventInvokeTimeRoles,
+								Data:       data,
+							})
+						}
+
+						if err != nil {
+							welcomer.Logger.Warn().Err(err).
+								Int64("guild_id", int64(*interaction.GuildID)).
+								Msg("Failed to relay invoke timeroles event")
+						}
+					}
+
+					_, err = interaction.EditOriginalResponse(ctx, sub.EmptySession, discord.WebhookMessageParams{
+						Embeds: embeds,
+					})
+					if err != nil {
+						welcomer.Logger.Error().Err(err).Msg("Failed to edit original response")
+					}
+				}(ctx, sub, interaction)
+
+				return &discord.InteractionResponse{
+					Type: discord.InteractionCallbackTypeDeferredChannelMessageSource,
+				}, nil
+			})
+		},
+	})
+
 	r.InteractionCommands.MustAddInteractionCommand(ruleGroup)
 
 	return nil
@@ -39,6 +39,9 @@ func NewWelcomer(ctx context.Context, options subway.SubwayOptions) *subway.Subw
 	sub.MustRegisterCog(plugins.NewLeaverCog())
 	sub.MustRegisterCog(plugins.NewFreeRolesCog())
 	sub.MustRegisterCog(plugins.NewTimeRolesCog())
+	sub.MustRegisterCog(plugins.NewActivityRolesCog())
+	sub.MustRegisterCog(plugins.NewLeaderboardCog())
+	sub.MustRegisterCog(plugins.NewInactiveCog())
 	sub.MustRegisterCog(plugins.NewTempChannelsCog())
 	sub.MustRegisterCog(plugins.NewMiscellaneousCog())
 	sub.MustRegisterCog(plugins.NewDebugCog())
@@ -46,6 +49,7 @@ func NewWelcomer(ctx context.Context, options subway.SubwayOptions) *subway.Subw
 	sub.MustRegisterCog(plugins.NewPrideCog())
 	sub.MustRegisterCog(plugins.NewReactionRolesCog())
 	sub.MustRegisterCog(plugins.NewGiveawaysCog())
+	sub.MustRegisterCog(plugins.NewTempRolesCog())
 	sub.MustRegisterCog(plugins.NewEasterCog())
 
 	sub.OnAfterInteraction = func(ctx context.Context, sub *subway.Subway, interaction discord.Interaction, resp *discord.InteractionResponse, interactionError error) error {